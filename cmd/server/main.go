@@ -3,18 +3,30 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"reflect"
 	"syscall"
 	"time"
+	_ "time/tzdata" // embed the IANA database so user timezones resolve in slim containers
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"github.com/manab-pr/evtaarpro/internal/config"
 	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
 	"github.com/manab-pr/evtaarpro/internal/httpx"
+	"github.com/manab-pr/evtaarpro/internal/jobs"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
+	"github.com/manab-pr/evtaarpro/internal/realtime"
+	"github.com/manab-pr/evtaarpro/internal/tracing"
+	meetingsModule "github.com/manab-pr/evtaarpro/modules/meetings"
+	notificationsModule "github.com/manab-pr/evtaarpro/modules/notifications"
+	webhooksModule "github.com/manab-pr/evtaarpro/modules/webhooks"
 )
 
 // @title EvtaarPro API
@@ -39,18 +51,49 @@ import (
 
 func main() {
 	// Load .env file in development
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
-	}
+	dotenvErr := godotenv.Load()
 
 	// Load configuration
-	appCfg, pgCfg, redisCfg, err := config.Load(
-		"config/app.yaml",
-		"config/postgres.yaml",
-		"config/redis.yaml",
-	)
+	configDir := os.Getenv(config.EnvPrefix + "_CONFIG_DIR")
+	if configDir == "" {
+		configDir = "config"
+	}
+	loadConfig := func() (*config.Config, *config.PostgresConfig, *config.RedisConfig, error) {
+		return config.Load(
+			filepath.Join(configDir, "app.yaml"),
+			filepath.Join(configDir, "postgres.yaml"),
+			filepath.Join(configDir, "redis.yaml"),
+		)
+	}
+	appCfg, pgCfg, redisCfg, err := loadConfig()
+	if err != nil {
+		slog.Error("failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+	live := config.NewLive(appCfg)
+
+	// Initialize logging; the standard logger writes through it too
+	logger, logCloser, err := logging.New(appCfg.Logging)
+	if err != nil {
+		slog.Error("failed to initialize logging", "error", err)
+		os.Exit(1)
+	}
+	defer logCloser.Close()
+	slog.SetDefault(logger)
+	if dotenvErr != nil {
+		logger.Info("no .env file found, using environment variables")
+	}
+	live.OnReload(func(cfg *config.Config) {
+		if err := logging.SetLevel(cfg.Logging.Level); err != nil {
+			logger.Error("failed to change log level", "error", err)
+		}
+	})
+
+	// Initialize tracing before the stores, whose calls are traced
+	shutdownTracing, err := tracing.Setup(context.Background(), appCfg.Tracing, appCfg.App)
 	if err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+		logger.Error("failed to initialize tracing", "error", err)
+		os.Exit(1)
 	}
 
 	// Set Gin mode
@@ -61,21 +104,49 @@ func main() {
 	// Initialize PostgreSQL
 	pgStore, err := datastore.NewPostgresStore(pgCfg)
 	if err != nil {
-		log.Fatalf("Failed to connect to PostgreSQL: %v", err)
+		logger.Error("failed to connect to PostgreSQL", "error", err)
+		os.Exit(1)
 	}
 	defer pgStore.Close()
-	log.Println("✓ Connected to PostgreSQL")
+	logger.Info("connected to PostgreSQL")
 
 	// Initialize Redis
 	redisStore, err := datastore.NewRedisStore(redisCfg)
 	if err != nil {
-		log.Fatalf("Failed to connect to Redis: %v", err)
+		logger.Error("failed to connect to Redis", "error", err)
+		os.Exit(1)
 	}
 	defer redisStore.Close()
-	log.Println("✓ Connected to Redis")
+	logger.Info("connected to Redis")
+
+	// Initialize realtime gateway
+	gateway := realtime.NewGateway(live, redisStore)
+	gatewayCtx, stopGateway := context.WithCancel(context.Background())
+	defer stopGateway()
+	go gateway.Run(gatewayCtx)
 
 	// Initialize router
-	router := httpx.NewRouter(appCfg, pgStore, redisStore)
+	router := httpx.NewRouter(live, logger, pgStore, redisStore, gateway)
+
+	// Initialize background jobs
+	scheduler := jobs.NewScheduler(redisStore)
+	meetingsModule.RegisterJobs(scheduler, appCfg, pgStore, redisStore)
+	notificationsModule.RegisterJobs(scheduler, appCfg, pgStore, redisStore)
+	webhooksModule.RegisterJobs(scheduler, appCfg, pgStore)
+
+	// Initialize the domain event relay; modules subscribe to the events
+	// of other modules on the bus
+	bus := eventbus.NewBus()
+	notificationsModule.RegisterSubscribers(bus, appCfg, pgStore, redisStore)
+	webhooksModule.RegisterSubscribers(bus, pgStore)
+	registerEventRelay(scheduler, appCfg, pgStore, bus)
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if appCfg.Jobs.Enabled {
+		scheduler.Start(jobsCtx)
+		logger.Info("background jobs started")
+	}
 
 	// Create HTTP server
 	server := &http.Server{
@@ -87,11 +158,38 @@ func main() {
 		MaxHeaderBytes: appCfg.Server.MaxHeaderBytes,
 	}
 
+	// Metrics are served on their own listener, kept off the public API
+	var metricsServer *http.Server
+	if appCfg.Metrics.Enabled {
+		metricsServer = newMetricsServer(appCfg, pgStore, redisStore)
+		go func() {
+			logger.Info("metrics listening", "addr", metricsServer.Addr, "path", appCfg.Metrics.Path)
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("failed to start metrics server", "error", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
+	// Close realtime connections and event streams when shutdown begins;
+	// Shutdown does not track the former and would wait on the latter
+	server.RegisterOnShutdown(stopGateway)
+
 	// Start server in a goroutine
 	go func() {
-		log.Printf("🚀 Server starting on %s", server.Addr)
+		logger.Info("server starting", "addr", server.Addr, "env", appCfg.App.Env)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Failed to start server: %v", err)
+			logger.Error("failed to start server", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	// Reload the settings that are safe to change on SIGHUP
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			reloadConfig(live, loadConfig, pgCfg, redisCfg)
 		}
 	}()
 
@@ -100,15 +198,102 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("🛑 Shutting down server...")
+	logger.Info("shutting down server")
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		logger.Error("server forced to shutdown", "error", err)
+		os.Exit(1)
 	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(ctx); err != nil {
+			logger.Error("metrics server forced to shutdown", "error", err)
+		}
+	}
+
+	// Stop background jobs
+	stopJobs()
+	scheduler.Wait()
+
+	// Flush pending spans
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("failed to flush traces", "error", err)
+	}
+
+	logger.Info("server exited gracefully")
+}
+
+// reloadConfig loads the configuration again and applies its log level,
+// CORS and rate limits. An invalid configuration is rejected as a whole.
+// Changes to other settings are reported, as they need a restart.
+func reloadConfig(live *config.Live, load func() (*config.Config, *config.PostgresConfig, *config.RedisConfig, error), pgCfg *config.PostgresConfig, redisCfg *config.RedisConfig) {
+	appCfg, newPgCfg, newRedisCfg, err := load()
+	if err != nil {
+		slog.Error("configuration reload failed, keeping the current settings", "error", err)
+		return
+	}
+
+	restart := live.Reload(appCfg)
+	if !reflect.DeepEqual(newPgCfg, pgCfg) {
+		restart = append(restart, "postgres")
+	}
+	if !reflect.DeepEqual(newRedisCfg, redisCfg) {
+		restart = append(restart, "redis")
+	}
+
+	slog.Info("configuration reloaded", "log_level", appCfg.Logging.Level)
+	if len(restart) > 0 {
+		slog.Warn("changed settings need a restart to take effect", "sections", restart)
+	}
+}
+
+// registerEventRelay registers the job dispatching outbox events to the
+// subscribers on bus
+func registerEventRelay(scheduler *jobs.Scheduler, cfg *config.Config, pgStore *datastore.PostgresStore, bus *eventbus.Bus) {
+	relay := eventbus.NewRelay(pgStore.DB, bus, eventbus.RetryPolicy{
+		MaxAttempts: cfg.Events.MaxAttempts,
+		Backoff:     cfg.Events.RetryBackoff,
+		MaxBackoff:  cfg.Events.MaxBackoff,
+	}, cfg.Events.BatchSize)
+
+	scheduler.Register(jobs.Job{
+		Name:     "events.relay",
+		Interval: cfg.Events.RelayInterval,
+		Run: func(ctx context.Context) error {
+			output, err := relay.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if output.Retrying+output.Failed > 0 {
+				logging.FromContext(ctx).Warn("event relay could not dispatch every event",
+					"dispatched", output.Dispatched, "retrying", output.Retrying, "failed", output.Failed)
+			}
+			return nil
+		},
+	})
+}
+
+// newMetricsServer creates the server exposing the Prometheus metrics,
+// including the connection pool statistics of the stores
+func newMetricsServer(cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) *http.Server {
+	metrics.Registry.MustRegister(
+		metrics.NewDBStatsCollector(pgStore.Stats),
+		metrics.NewRedisPoolCollector(redisStore.PoolStats),
+	)
 
-	log.Println("✓ Server exited gracefully")
+	path := cfg.Metrics.Path
+	if path == "" {
+		path = "/metrics"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, metrics.Handler())
+
+	return &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", cfg.Metrics.Host, cfg.Metrics.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
 }
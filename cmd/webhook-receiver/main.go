@@ -0,0 +1,83 @@
+// Command webhook-receiver is a local endpoint for trying out webhooks. It
+// verifies the signature of every delivery, prints it and answers with a
+// configurable status code.
+//
+//	go run ./cmd/webhook-receiver -secret whsec_... -status 500
+package main
+
+import (
+	"crypto/hmac"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/infra/sender"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "listen address")
+	secret := flag.String("secret", "", "signing secret of the endpoint; signatures are not checked without it")
+	status := flag.Int("status", http.StatusOK, "status code to answer with, e.g. 500 to exercise retries")
+	tolerance := flag.Duration("tolerance", 5*time.Minute, "maximum age of the signature timestamp")
+	flag.Parse()
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if *secret != "" {
+			if err := verify(r, body, *secret, *tolerance); err != "" {
+				log.Printf("rejected %s: %s", r.Header.Get("X-Webhook-ID"), err)
+				http.Error(w, err, http.StatusUnauthorized)
+				return
+			}
+		}
+
+		var payload map[string]interface{}
+		_ = json.Unmarshal(body, &payload)
+		pretty, _ := json.MarshalIndent(payload, "", "  ")
+		log.Printf("received %s (delivery %s), answering %d\n%s",
+			r.Header.Get("X-Webhook-Event"), r.Header.Get("X-Webhook-ID"), *status, pretty)
+
+		w.WriteHeader(*status)
+		_, _ = io.WriteString(w, http.StatusText(*status))
+	})
+
+	log.Printf("webhook receiver listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// verify checks the X-Webhook-Signature header and the age of its
+// timestamp, returning why the request is rejected
+func verify(r *http.Request, body []byte, secret string, tolerance time.Duration) string {
+	timestamp, err := strconv.ParseInt(r.Header.Get("X-Webhook-Timestamp"), 10, 64)
+	if err != nil {
+		return "missing or invalid timestamp"
+	}
+	if age := time.Since(time.Unix(timestamp, 0)); math.Abs(float64(age)) > float64(tolerance) {
+		return "stale timestamp"
+	}
+
+	signature, ok := strings.CutPrefix(r.Header.Get("X-Webhook-Signature"), "sha256=")
+	if !ok {
+		return "missing signature"
+	}
+	if !hmac.Equal([]byte(signature), []byte(sender.Sign(secret, timestamp, body))) {
+		return "signature mismatch"
+	}
+	return ""
+}
@@ -1,6 +1,6 @@
 import React, { useEffect, useState } from 'react';
 import { Link } from 'react-router-dom';
-import { Video, Users, Calendar, TrendingUp, Plus } from 'lucide-react';
+import { Video, Users, Calendar, TrendingUp, Plus, CheckSquare } from 'lucide-react';
 import { useAuth } from '../context/AuthContext';
 import { meetingsAPI, usersAPI } from '../services/api';
 import { format } from 'date-fns';
@@ -13,6 +13,7 @@ const Dashboard = () => {
     totalUsers: 0,
   });
   const [recentMeetings, setRecentMeetings] = useState([]);
+  const [actionItems, setActionItems] = useState([]);
   const [loading, setLoading] = useState(true);
 
   useEffect(() => {
@@ -21,12 +22,14 @@ const Dashboard = () => {
 
   const loadDashboardData = async () => {
     try {
-      const [meetingsRes, usersRes] = await Promise.all([
+      const [meetingsRes, usersRes, actionItemsRes] = await Promise.all([
         meetingsAPI.list({ page: 1, page_size: 5 }),
         usersAPI.getUsers({ page: 1, page_size: 1 }),
+        meetingsAPI.myActionItems(),
       ]);
 
       setRecentMeetings(meetingsRes.data.data || []);
+      setActionItems(actionItemsRes.data.data || []);
       setStats({
         totalMeetings: meetingsRes.data.pagination?.total_items || 0,
         upcomingMeetings: meetingsRes.data.data?.filter(m => m.status === 'scheduled').length || 0,
@@ -187,6 +190,54 @@ const Dashboard = () => {
         )}
       </div>
 
+      {/* My Action Items */}
+      <div className="card">
+        <div className="flex items-center justify-between mb-6">
+          <h2 className="text-lg font-semibold text-gray-900">My Action Items</h2>
+          <span className="text-sm text-gray-500">{actionItems.length} open</span>
+        </div>
+
+        {actionItems.length === 0 ? (
+          <div className="text-center py-8">
+            <CheckSquare className="w-12 h-12 text-gray-400 mx-auto mb-4" />
+            <p className="text-gray-600">Nothing assigned to you</p>
+          </div>
+        ) : (
+          <div className="space-y-3">
+            {actionItems.map((item) => (
+              <Link
+                key={item.id}
+                to={`/meetings/${item.meeting_id}`}
+                className="block p-4 rounded-lg border border-gray-200 hover:border-primary-300 hover:bg-primary-50 transition-all"
+              >
+                <div className="flex items-center justify-between">
+                  <div className="flex items-center space-x-3">
+                    <div className="p-2 bg-orange-100 rounded-lg">
+                      <CheckSquare className="w-5 h-5 text-orange-600" />
+                    </div>
+                    <div>
+                      <h3 className="font-medium text-gray-900">{item.title}</h3>
+                      {item.due_date && (
+                        <p className="text-sm text-gray-500">
+                          Due {format(new Date(`${item.due_date}T00:00:00`), 'MMM dd, yyyy')}
+                        </p>
+                      )}
+                    </div>
+                  </div>
+                  <span className={`
+                    px-3 py-1 rounded-full text-xs font-medium capitalize
+                    ${item.status === 'open' ? 'bg-blue-100 text-blue-700' : ''}
+                    ${item.status === 'in_progress' ? 'bg-yellow-100 text-yellow-700' : ''}
+                  `}>
+                    {item.status.replace('_', ' ')}
+                  </span>
+                </div>
+              </Link>
+            ))}
+          </div>
+        )}
+      </div>
+
       {/* Quick Actions */}
       <div className="grid grid-cols-1 md:grid-cols-3 gap-6">
         <Link
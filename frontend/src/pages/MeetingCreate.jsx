@@ -1,6 +1,6 @@
-import React, { useState } from 'react';
+import React, { useEffect, useState } from 'react';
 import { useNavigate } from 'react-router-dom';
-import { Video, Calendar, FileText, Users, ArrowLeft } from 'lucide-react';
+import { Video, Calendar, Clock, FileText, Users, ArrowLeft, LayoutTemplate, Lock } from 'lucide-react';
 import { meetingsAPI } from '../services/api';
 import toast from 'react-hot-toast';
 
@@ -10,10 +10,48 @@ const MeetingCreate = () => {
     title: '',
     description: '',
     start_time: '',
+    duration_minutes: 30,
     max_participants: 50,
+    template_id: '',
   });
+  const [settings, setSettings] = useState({
+    lobby_enabled: true,
+    start_muted: false,
+    recording_allowed: true,
+    passcode: '',
+  });
+  const [templates, setTemplates] = useState([]);
   const [loading, setLoading] = useState(false);
 
+  useEffect(() => {
+    meetingsAPI.templates()
+      .then((response) => setTemplates(response.data.data || []))
+      .catch(() => setTemplates([]));
+  }, []);
+
+  const handleTemplateChange = (templateId) => {
+    const template = templates.find((t) => t.id === templateId);
+    if (!template) {
+      setFormData({ ...formData, template_id: '' });
+      return;
+    }
+
+    setFormData({
+      ...formData,
+      template_id: template.id,
+      title: '',
+      description: template.description || '',
+      duration_minutes: template.duration_minutes,
+      max_participants: template.max_participants || formData.max_participants,
+    });
+    setSettings({
+      lobby_enabled: template.settings.lobby_enabled,
+      start_muted: template.settings.start_muted,
+      recording_allowed: template.settings.recording_allowed,
+      passcode: template.settings.passcode || '',
+    });
+  };
+
   const handleSubmit = async (e) => {
     e.preventDefault();
     setLoading(true);
@@ -22,8 +60,12 @@ const MeetingCreate = () => {
       const response = await meetingsAPI.create({
         ...formData,
         start_time: new Date(formData.start_time).toISOString(),
+        settings,
       });
 
+      if (response.data.data.conflicts?.length) {
+        toast('Some invitees already have meetings at this time', { icon: '⚠️' });
+      }
       toast.success('Meeting created successfully!');
       navigate(`/meetings/${response.data.data.id}`);
     } catch (error) {
@@ -65,18 +107,45 @@ const MeetingCreate = () => {
       {/* Form */}
       <div className="card">
         <form onSubmit={handleSubmit} className="space-y-6">
+          {/* Template */}
+          {templates.length > 0 && (
+            <div>
+              <label className="block text-sm font-medium text-gray-700 mb-2">
+                Template
+              </label>
+              <div className="relative">
+                <LayoutTemplate className="absolute left-3 top-1/2 transform -translate-y-1/2 text-gray-400 w-5 h-5" />
+                <select
+                  className="input-field pl-10"
+                  value={formData.template_id}
+                  onChange={(e) => handleTemplateChange(e.target.value)}
+                >
+                  <option value="">No template</option>
+                  {templates.map((template) => (
+                    <option key={template.id} value={template.id}>
+                      {template.name}
+                    </option>
+                  ))}
+                </select>
+              </div>
+              <p className="text-xs text-gray-500 mt-1">
+                Fills in the title, duration, invitees, agenda and room settings
+              </p>
+            </div>
+          )}
+
           {/* Meeting Title */}
           <div>
             <label className="block text-sm font-medium text-gray-700 mb-2">
-              Meeting Title *
+              Meeting Title {formData.template_id ? '' : '*'}
             </label>
             <div className="relative">
               <FileText className="absolute left-3 top-1/2 transform -translate-y-1/2 text-gray-400 w-5 h-5" />
               <input
                 type="text"
-                required
+                required={!formData.template_id}
                 className="input-field pl-10"
-                placeholder="e.g., Team Standup, Client Review, etc."
+                placeholder={formData.template_id ? 'Leave empty to use the template title' : 'e.g., Team Standup, Client Review, etc.'}
                 value={formData.title}
                 onChange={(e) => setFormData({ ...formData, title: e.target.value })}
               />
@@ -118,6 +187,29 @@ const MeetingCreate = () => {
             </p>
           </div>
 
+          {/* Duration */}
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              Duration *
+            </label>
+            <div className="relative">
+              <Clock className="absolute left-3 top-1/2 transform -translate-y-1/2 text-gray-400 w-5 h-5" />
+              <select
+                required
+                className="input-field pl-10"
+                value={formData.duration_minutes}
+                onChange={(e) => setFormData({ ...formData, duration_minutes: parseInt(e.target.value) })}
+              >
+                <option value={15}>15 minutes</option>
+                <option value={30}>30 minutes</option>
+                <option value={45}>45 minutes</option>
+                <option value={60}>1 hour</option>
+                <option value={90}>1.5 hours</option>
+                <option value={120}>2 hours</option>
+              </select>
+            </div>
+          </div>
+
           {/* Max Participants */}
           <div>
             <label className="block text-sm font-medium text-gray-700 mb-2">
@@ -139,6 +231,41 @@ const MeetingCreate = () => {
             </p>
           </div>
 
+          {/* Room Settings */}
+          <div>
+            <label className="block text-sm font-medium text-gray-700 mb-2">
+              Room Settings
+            </label>
+            <div className="space-y-2">
+              {[
+                ['lobby_enabled', 'Guests wait in the lobby until admitted'],
+                ['start_muted', 'Participants join muted'],
+                ['recording_allowed', 'Allow recording and live streaming'],
+              ].map(([key, label]) => (
+                <label key={key} className="flex items-center space-x-2 text-sm text-gray-700">
+                  <input
+                    type="checkbox"
+                    checked={settings[key]}
+                    onChange={(e) => setSettings({ ...settings, [key]: e.target.checked })}
+                  />
+                  <span>{label}</span>
+                </label>
+              ))}
+            </div>
+            <div className="relative mt-3">
+              <Lock className="absolute left-3 top-1/2 transform -translate-y-1/2 text-gray-400 w-5 h-5" />
+              <input
+                type="text"
+                minLength={4}
+                maxLength={64}
+                className="input-field pl-10"
+                placeholder="Room passcode (optional)"
+                value={settings.passcode}
+                onChange={(e) => setSettings({ ...settings, passcode: e.target.value })}
+              />
+            </div>
+          </div>
+
           {/* Actions */}
           <div className="flex items-center justify-end space-x-3 pt-6 border-t">
             <button
@@ -1,5 +1,5 @@
 import React, { useState } from 'react';
-import { User, Mail, Phone, Building, Edit2, Save } from 'lucide-react';
+import { User, Mail, Phone, Building, Globe, Edit2, Save } from 'lucide-react';
 import { useAuth } from '../context/AuthContext';
 import toast from 'react-hot-toast';
 
@@ -11,6 +11,7 @@ const Profile = () => {
     last_name: user?.last_name || '',
     phone: user?.phone || '',
     department: user?.department || '',
+    timezone: user?.timezone || 'UTC',
   });
   const [loading, setLoading] = useState(false);
 
@@ -32,6 +33,7 @@ const Profile = () => {
       last_name: user?.last_name || '',
       phone: user?.phone || '',
       department: user?.department || '',
+    timezone: user?.timezone || 'UTC',
     });
     setIsEditing(false);
   };
@@ -153,6 +155,24 @@ const Profile = () => {
               </div>
             </div>
 
+            <div>
+              <label className="block text-sm font-medium text-gray-700 mb-2">
+                Timezone
+              </label>
+              <div className="relative">
+                <Globe className="absolute left-3 top-1/2 transform -translate-y-1/2 text-gray-400 w-5 h-5" />
+                <input
+                  type="text"
+                  disabled={!isEditing}
+                  className="input-field pl-10 disabled:bg-gray-50 disabled:text-gray-500"
+                  placeholder={Intl.DateTimeFormat().resolvedOptions().timeZone}
+                  value={formData.timezone}
+                  onChange={(e) => setFormData({ ...formData, timezone: e.target.value })}
+                />
+              </div>
+              <p className="text-xs text-gray-500 mt-1">Working hours and reminders follow this timezone</p>
+            </div>
+
             <div>
               <label className="block text-sm font-medium text-gray-700 mb-2">
                 Role
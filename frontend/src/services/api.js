@@ -16,6 +16,11 @@ api.interceptors.request.use(
     if (token) {
       config.headers.Authorization = `Bearer ${token}`;
     }
+    // Render times in the browser's timezone
+    const timezone = Intl.DateTimeFormat().resolvedOptions().timeZone;
+    if (timezone) {
+      config.headers['X-Timezone'] = timezone;
+    }
     return config;
   },
   (error) => Promise.reject(error)
@@ -55,6 +60,12 @@ export const meetingsAPI = {
   list: (params) => api.get('/meetings', { params }),
   get: (id) => api.get(`/meetings/${id}`),
   join: (id) => api.post(`/meetings/${id}/join`),
+  myActionItems: (params) => api.get('/meetings/action-items', { params }),
+  updateSettings: (id, data) => api.patch(`/meetings/${id}/settings`, data),
+  templates: () => api.get('/meetings/templates'),
+  createTemplate: (data) => api.post('/meetings/templates', data),
+  updateTemplate: (id, data) => api.put(`/meetings/templates/${id}`, data),
+  deleteTemplate: (id) => api.delete(`/meetings/templates/${id}`),
 };
 
 // CRM APIs
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// genesisHash is the PrevHash of the first entry
+var genesisHash = strings.Repeat("0", 64)
+
+// computeHash returns the SHA-256 of the canonical JSON form of the entry
+// and its PrevHash. Changes are re-encoded from their decoded form, so the
+// hash survives the JSONB round trip.
+func computeHash(entry *Entry) (string, error) {
+	changes, err := canonicalChanges(entry.Changes)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal([]interface{}{
+		entry.Seq,
+		entry.ActorID,
+		entry.Action,
+		entry.ResourceType,
+		entry.ResourceID,
+		json.RawMessage(changes),
+		entry.RequestID,
+		entry.IP,
+		entry.OccurredAt.UTC().Format(time.RFC3339Nano),
+		entry.PrevHash,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalChanges encodes changes with sorted keys and the number format
+// of decoded JSON
+func canonicalChanges(changes map[string]Change) ([]byte, error) {
+	if changes == nil {
+		changes = map[string]Change{}
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return json.Marshal(decoded)
+}
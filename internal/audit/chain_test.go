@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// chain builds n linked entries the way Record does
+func chain(t *testing.T, n int) []*Entry {
+	t.Helper()
+	start := time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)
+
+	entries := make([]*Entry, 0, n)
+	prevHash := genesisHash
+	for i := 1; i <= n; i++ {
+		entry := &Entry{
+			Seq:          int64(i),
+			ActorID:      "alice",
+			Action:       "payroll.approve",
+			ResourceType: "payroll",
+			ResourceID:   "p-1",
+			Changes:      map[string]Change{"status": {Before: "pending", After: "approved"}, "amount": {Before: 1200, After: 1250.5}},
+			RequestID:    "req-1",
+			IP:           "10.0.0.1",
+			OccurredAt:   start.Add(time.Duration(i) * time.Minute),
+			PrevHash:     prevHash,
+		}
+		hash, err := computeHash(entry)
+		if err != nil {
+			t.Fatalf("computeHash: %v", err)
+		}
+		entry.Hash = hash
+		prevHash = hash
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// roundTrip returns entry as read back from the database: changes are
+// decoded from JSON and the time is in the local timezone
+func roundTrip(t *testing.T, entry *Entry) *Entry {
+	t.Helper()
+	changes, err := canonicalChanges(entry.Changes)
+	if err != nil {
+		t.Fatalf("canonicalChanges: %v", err)
+	}
+	read := *entry
+	read.Changes = nil
+	if err := json.Unmarshal(changes, &read.Changes); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	read.OccurredAt = entry.OccurredAt.In(time.FixedZone("IST", 5*3600+1800))
+	return &read
+}
+
+func TestComputeHash(t *testing.T) {
+	entry := chain(t, 1)[0]
+
+	tests := []struct {
+		name     string
+		change   func(e *Entry)
+		wantSame bool
+	}{
+		{"unchanged", func(e *Entry) {}, true},
+		{"read back from the database", func(e *Entry) { *e = *roundTrip(t, e) }, true},
+		{"no changes and empty changes", func(e *Entry) { e.Changes = map[string]Change{} }, false},
+		{"actor", func(e *Entry) { e.ActorID = "mallory" }, false},
+		{"action", func(e *Entry) { e.Action = "payroll.reject" }, false},
+		{"resource", func(e *Entry) { e.ResourceID = "p-2" }, false},
+		{"changed value", func(e *Entry) { e.Changes["amount"] = Change{Before: 1200, After: 9999} }, false},
+		{"removed change", func(e *Entry) { delete(e.Changes, "status") }, false},
+		{"request", func(e *Entry) { e.RequestID = "req-2" }, false},
+		{"ip", func(e *Entry) { e.IP = "10.0.0.2" }, false},
+		{"time", func(e *Entry) { e.OccurredAt = e.OccurredAt.Add(time.Microsecond) }, false},
+		{"sequence", func(e *Entry) { e.Seq = 2 }, false},
+		{"previous hash", func(e *Entry) { e.PrevHash = entry.Hash }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := *entry
+			changed.Changes = make(map[string]Change, len(entry.Changes))
+			for name, change := range entry.Changes {
+				changed.Changes[name] = change
+			}
+			tt.change(&changed)
+
+			hash, err := computeHash(&changed)
+			if err != nil {
+				t.Fatalf("computeHash: %v", err)
+			}
+			if (hash == entry.Hash) != tt.wantSame {
+				t.Fatalf("hash = %s, original %s, want same %v", hash, entry.Hash, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestCanonicalChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes map[string]Change
+		want    string
+	}{
+		{"nil", nil, `{}`},
+		{"keys sorted", map[string]Change{"b": {After: 1}, "a": {Before: "x", After: "y"}}, `{"a":{"after":"y","before":"x"},"b":{"after":1,"before":null}}`},
+		{"integral floats", map[string]Change{"amount": {Before: 1200.0, After: int64(1250)}}, `{"amount":{"after":1250,"before":1200}}`},
+		{"nested values", map[string]Change{"tags": {After: map[string]interface{}{"z": 1, "a": []int{2}}}}, `{"tags":{"after":{"a":[2],"z":1},"before":null}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := canonicalChanges(tt.changes)
+			if err != nil {
+				t.Fatalf("canonicalChanges: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("canonicalChanges = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
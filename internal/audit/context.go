@@ -0,0 +1,23 @@
+package audit
+
+import "context"
+
+// RequestInfo identifies the request an operation was made in
+type RequestInfo struct {
+	RequestID string
+	IP        string
+}
+
+type requestKey struct{}
+
+// ContextWithRequest returns a context carrying info, which is recorded
+// with every entry made under it
+func ContextWithRequest(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestKey{}, info)
+}
+
+// RequestFromContext returns the request info carried by ctx, if any
+func RequestFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestKey{}).(RequestInfo)
+	return info, ok
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// Entry is one recorded operation. Entries are chained: Hash covers the
+// entry and PrevHash, the hash of the entry before it, so changing or
+// removing an entry breaks every hash after it.
+type Entry struct {
+	Seq          int64
+	ActorID      string
+	Action       string // e.g. "payroll.approve"
+	ResourceType string
+	ResourceID   string
+	Changes      map[string]Change
+	RequestID    string
+	IP           string
+	OccurredAt   time.Time
+	PrevHash     string
+	Hash         string
+}
+
+// Change is the value of a field before and after an operation. Before is
+// nil for fields that did not exist yet.
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Record describes an operation to audit. Before and After are snapshots of
+// the resource, typically maps or structs of the fields that matter; only
+// fields that differ are kept. Leave secrets out of them.
+type Record struct {
+	ActorID      string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Before       interface{}
+	After        interface{}
+}
+
+// Filter selects entries. Zero fields do not filter; the time range
+// includes From and excludes To.
+type Filter struct {
+	ActorID      string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	From         *time.Time
+	To           *time.Time
+}
+
+// Diff returns the top-level fields that differ between the JSON forms of
+// before and after
+func Diff(before, after interface{}) (map[string]Change, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	for name, value := range afterFields {
+		previous, ok := beforeFields[name]
+		if !ok && value == nil {
+			continue
+		}
+		if !ok || !reflect.DeepEqual(previous, value) {
+			changes[name] = Change{Before: beforeFields[name], After: value}
+		}
+	}
+	for name, previous := range beforeFields {
+		if _, ok := afterFields[name]; !ok {
+			changes[name] = Change{Before: previous}
+		}
+	}
+	return changes, nil
+}
+
+func fields(snapshot interface{}) (map[string]interface{}, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
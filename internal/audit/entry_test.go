@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	type payroll struct {
+		Status string  `json:"status"`
+		Amount float64 `json:"amount"`
+		Note   *string `json:"note,omitempty"`
+	}
+	note := "bonus"
+
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   map[string]Change
+	}{
+		{"unchanged", payroll{Status: "pending", Amount: 10}, payroll{Status: "pending", Amount: 10}, map[string]Change{}},
+		{
+			"changed field",
+			payroll{Status: "pending", Amount: 10},
+			payroll{Status: "approved", Amount: 10},
+			map[string]Change{"status": {Before: "pending", After: "approved"}},
+		},
+		{
+			"added field",
+			payroll{Status: "pending"},
+			payroll{Status: "pending", Note: &note},
+			map[string]Change{"note": {After: "bonus"}},
+		},
+		{
+			"removed field",
+			payroll{Status: "pending", Note: &note},
+			payroll{Status: "pending"},
+			map[string]Change{"note": {Before: "bonus"}},
+		},
+		{
+			"created",
+			nil,
+			map[string]interface{}{"status": "pending", "note": nil},
+			map[string]Change{"status": {After: "pending"}},
+		},
+		{
+			"deleted",
+			map[string]interface{}{"status": "pending"},
+			nil,
+			map[string]Change{"status": {Before: "pending"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("Diff: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Diff = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Diff(nil, []string{"not", "an", "object"}); err == nil {
+		t.Fatal("Diff of a snapshot that is not an object succeeded")
+	}
+}
@@ -0,0 +1,291 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+)
+
+// ErrNoTransaction is returned when entries are recorded outside a
+// transaction
+var ErrNoTransaction = errors.New("audit entries must be recorded inside a transaction")
+
+// chainLock is the advisory lock key that serializes appends to the chain
+const chainLock = 0x61756469 // "audi"
+
+// Recorder records audited operations
+type Recorder interface {
+	Record(ctx context.Context, record Record) error
+}
+
+// Log is the append-only audit log table
+type Log struct {
+	db *sql.DB
+}
+
+// NewLog creates a new Log
+func NewLog(db *sql.DB) *Log {
+	return &Log{db: db}
+}
+
+// Record appends an entry in the transaction carried by ctx, so the
+// operation and its entry are committed together. The request ID and IP
+// are taken from ctx. Appends are serialized until the transaction ends.
+func (l *Log) Record(ctx context.Context, record Record) error {
+	tx, ok := datastore.TxFromContext(ctx)
+	if !ok {
+		return ErrNoTransaction
+	}
+
+	changes, err := Diff(record.Before, record.After)
+	if err != nil {
+		return fmt.Errorf("diff %s: %w", record.Action, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, chainLock); err != nil {
+		return err
+	}
+
+	entry := &Entry{
+		Seq:          1,
+		ActorID:      record.ActorID,
+		Action:       record.Action,
+		ResourceType: record.ResourceType,
+		ResourceID:   record.ResourceID,
+		Changes:      changes,
+		OccurredAt:   time.Now().UTC().Truncate(time.Microsecond),
+		PrevHash:     genesisHash,
+	}
+	if info, ok := RequestFromContext(ctx); ok {
+		entry.RequestID = info.RequestID
+		entry.IP = info.IP
+	}
+
+	var lastSeq int64
+	var lastHash string
+	err = tx.QueryRowContext(ctx, `SELECT seq, hash FROM audit_log ORDER BY seq DESC LIMIT 1`).Scan(&lastSeq, &lastHash)
+	switch {
+	case err == nil:
+		entry.Seq = lastSeq + 1
+		entry.PrevHash = lastHash
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+
+	if entry.Hash, err = computeHash(entry); err != nil {
+		return err
+	}
+	changesJSON, err := canonicalChanges(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_log (seq, actor_id, action, resource_type, resource_id, changes, request_id, ip,
+			occurred_at, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		entry.Seq, nullString(entry.ActorID), entry.Action, entry.ResourceType, entry.ResourceID, string(changesJSON),
+		nullString(entry.RequestID), nullString(entry.IP), entry.OccurredAt, entry.PrevHash, entry.Hash,
+	)
+	return err
+}
+
+// List retrieves the entries matching filter, newest first
+func (l *Log) List(ctx context.Context, filter Filter, limit, offset int) ([]*Entry, int, error) {
+	where, args := filterClause(filter)
+
+	var total int
+	if err := l.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_log`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM audit_log%s ORDER BY seq DESC LIMIT $%d OFFSET $%d`,
+		entryColumns, where, len(args)+1, len(args)+2)
+	entries, err := l.query(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// Each calls fn for every entry matching filter, oldest first, without
+// loading them all at once
+func (l *Log) Each(ctx context.Context, filter Filter, fn func(*Entry) error) error {
+	where, args := filterClause(filter)
+	rows, err := l.db.QueryContext(ctx, `SELECT `+entryColumns+` FROM audit_log`+where+` ORDER BY seq`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Verification is the outcome of checking the chain
+type Verification struct {
+	Entries  int64  `json:"entries"`
+	Valid    bool   `json:"valid"`
+	BrokenAt int64  `json:"broken_at,omitempty"` // sequence number of the first bad entry
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Verify walks the whole chain and reports the first entry that was
+// changed, inserted or follows a removed entry
+func (l *Log) Verify(ctx context.Context) (*Verification, error) {
+	verifier := newChainVerifier()
+	err := l.Each(ctx, Filter{}, func(entry *Entry) error {
+		ok, err := verifier.check(entry)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return nil, err
+	}
+
+	return &verifier.result, nil
+}
+
+// chainVerifier checks the entries of the chain in sequence order
+type chainVerifier struct {
+	result   Verification
+	prevHash string
+}
+
+func newChainVerifier() *chainVerifier {
+	return &chainVerifier{
+		result:   Verification{Valid: true},
+		prevHash: genesisHash,
+	}
+}
+
+// check checks the next entry and reports whether the chain still holds
+func (v *chainVerifier) check(entry *Entry) (bool, error) {
+	v.result.Entries++
+
+	reason := ""
+	switch {
+	case entry.Seq != v.result.Entries:
+		reason = fmt.Sprintf("expected sequence number %d", v.result.Entries)
+	case entry.PrevHash != v.prevHash:
+		reason = "previous hash does not match"
+	default:
+		hash, err := computeHash(entry)
+		if err != nil {
+			return false, err
+		}
+		if hash != entry.Hash {
+			reason = "hash does not match contents"
+		}
+	}
+	if reason != "" {
+		v.result.Valid = false
+		v.result.BrokenAt = entry.Seq
+		v.result.Reason = reason
+		return false, nil
+	}
+
+	v.prevHash = entry.Hash
+	return true, nil
+}
+
+var errStop = errors.New("stop")
+
+const entryColumns = `seq, actor_id, action, resource_type, resource_id, changes, request_id, ip, occurred_at,
+	prev_hash, hash`
+
+func filterClause(filter Filter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.ActorID != "" {
+		add("actor_id = $%d", filter.ActorID)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		add("resource_type = $%d", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		add("resource_id = $%d", filter.ResourceID)
+	}
+	if filter.From != nil {
+		add("occurred_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("occurred_at < $%d", *filter.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (l *Log) query(ctx context.Context, query string, args ...interface{}) ([]*Entry, error) {
+	rows, err := l.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*Entry
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func scanEntry(rows *sql.Rows) (*Entry, error) {
+	entry := &Entry{}
+	var actorID, requestID, ip sql.NullString
+	var changes []byte
+	err := rows.Scan(
+		&entry.Seq, &actorID, &entry.Action, &entry.ResourceType, &entry.ResourceID, &changes, &requestID, &ip,
+		&entry.OccurredAt, &entry.PrevHash, &entry.Hash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+		return nil, err
+	}
+	entry.ActorID = actorID.String
+	entry.RequestID = requestID.String
+	entry.IP = ip.String
+	return entry, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
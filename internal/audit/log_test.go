@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestChainVerifier(t *testing.T) {
+	tests := []struct {
+		name         string
+		tamper       func(entries []*Entry) []*Entry
+		wantEntries  int64
+		wantBrokenAt int64
+		wantReason   string
+	}{
+		{
+			name:        "intact",
+			tamper:      func(entries []*Entry) []*Entry { return entries },
+			wantEntries: 4,
+		},
+		{
+			name:        "empty",
+			tamper:      func(entries []*Entry) []*Entry { return nil },
+			wantEntries: 0,
+		},
+		{
+			name: "changed contents",
+			tamper: func(entries []*Entry) []*Entry {
+				entries[1].ActorID = "mallory"
+				return entries
+			},
+			wantEntries:  2,
+			wantBrokenAt: 2,
+			wantReason:   "hash does not match contents",
+		},
+		{
+			name: "changed contents with a recomputed hash",
+			tamper: func(entries []*Entry) []*Entry {
+				entries[1].Changes = nil
+				entries[1].Hash, _ = computeHash(entries[1])
+				return entries
+			},
+			wantEntries:  3,
+			wantBrokenAt: 3,
+			wantReason:   "previous hash does not match",
+		},
+		{
+			name: "removed entry",
+			tamper: func(entries []*Entry) []*Entry {
+				return append(entries[:1], entries[2:]...)
+			},
+			wantEntries:  2,
+			wantBrokenAt: 3,
+			wantReason:   "expected sequence number 2",
+		},
+		{
+			// The chain alone cannot tell a truncated log from a shorter one
+			name: "removed last entry",
+			tamper: func(entries []*Entry) []*Entry {
+				return entries[:3]
+			},
+			wantEntries: 3,
+		},
+		{
+			name: "removed and renumbered",
+			tamper: func(entries []*Entry) []*Entry {
+				entries = append(entries[:1], entries[2:]...)
+				for i, entry := range entries {
+					entry.Seq = int64(i + 1)
+				}
+				return entries
+			},
+			wantEntries:  2,
+			wantBrokenAt: 2,
+			wantReason:   "previous hash does not match",
+		},
+		{
+			name: "inserted entry",
+			tamper: func(entries []*Entry) []*Entry {
+				forged := *entries[0]
+				forged.Seq = 2
+				forged.PrevHash = entries[0].Hash
+				forged.Hash, _ = computeHash(&forged)
+				return []*Entry{entries[0], &forged, entries[1], entries[2], entries[3]}
+			},
+			wantEntries:  3,
+			wantBrokenAt: 2,
+			wantReason:   "expected sequence number 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := newChainVerifier()
+			for _, entry := range tt.tamper(chain(t, 4)) {
+				ok, err := verifier.check(roundTrip(t, entry))
+				if err != nil {
+					t.Fatalf("check: %v", err)
+				}
+				if !ok {
+					break
+				}
+			}
+
+			want := Verification{
+				Entries:  tt.wantEntries,
+				Valid:    tt.wantReason == "",
+				BrokenAt: tt.wantBrokenAt,
+				Reason:   tt.wantReason,
+			}
+			if verifier.result != want {
+				t.Fatalf("verification = %+v, want %+v", verifier.result, want)
+			}
+		})
+	}
+}
+
+func TestFilterClause(t *testing.T) {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name      string
+		filter    Filter
+		wantWhere string
+		wantArgs  []interface{}
+	}{
+		{"everything", Filter{}, "", nil},
+		{"actor", Filter{ActorID: "alice"}, " WHERE actor_id = $1", []interface{}{"alice"}},
+		{
+			"resource in a time range",
+			Filter{ResourceType: "payroll", ResourceID: "p-1", From: &from, To: &to},
+			" WHERE resource_type = $1 AND resource_id = $2 AND occurred_at >= $3 AND occurred_at < $4",
+			[]interface{}{"payroll", "p-1", from, to},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := filterClause(tt.filter)
+			if where != tt.wantWhere || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Fatalf("filterClause = %q %v, want %q %v", where, args, tt.wantWhere, tt.wantArgs)
+			}
+		})
+	}
+}
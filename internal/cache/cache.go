@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
+)
+
+// defaultTTL is how long entries are kept without a cache TTL in the Redis
+// config
+const defaultTTL = time.Hour
+
+// Cache is a read-through cache of JSON values in Redis, e.g. of repository
+// reads. Entries carry tags naming the data they were loaded from, and
+// invalidating a tag drops every entry carrying it, in every cache, so that
+// writers need not know which keys readers used.
+//
+// Tags are versioned rather than listing their entries: an entry records the
+// versions its tags had before the value was loaded, and invalidation bumps
+// them. An entry loaded while a write commits is thus never served after it.
+type Cache struct {
+	redis *datastore.RedisStore
+	name  string
+	ttl   time.Duration
+}
+
+// entry is what is stored under a cache key
+type entry struct {
+	Versions []int64         `json:"versions"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// New creates a Cache named name, e.g. "users", keeping entries for the
+// cache TTL of the Redis config. The name separates its keys from those of
+// other caches and labels its metrics.
+func New(redisStore *datastore.RedisStore, name string) *Cache {
+	ttl := redisStore.GetTTL("cache")
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &Cache{
+		redis: redisStore,
+		name:  name,
+		ttl:   ttl,
+	}
+}
+
+// Fetch returns the value cached under key, or loads it with load and caches
+// it with tags. Errors of load are returned and not cached. Inside a
+// transaction the cache is bypassed, so that reads see the transaction's own
+// writes and take its locks. While Redis is unavailable every value is
+// loaded.
+func Fetch[T any](ctx context.Context, c *Cache, key string, tags []string, load func(ctx context.Context) (T, error)) (T, error) {
+	if _, ok := datastore.TxFromContext(ctx); ok {
+		return load(ctx)
+	}
+
+	redisKey := c.redis.GetKey("cache", c.name+":"+key)
+	values, err := c.redis.Client.MGet(ctx, append([]string{redisKey}, c.tagKeys(tags)...)...).Result()
+	var versions []int64
+	if err == nil {
+		versions, err = parseVersions(values[1:])
+	}
+	if err != nil {
+		logging.FromContext(ctx).Warn("cache lookup failed", "cache", c.name, "error", err)
+		metrics.CacheRequests.WithLabelValues(c.name, metrics.CacheMiss).Inc()
+		return load(ctx)
+	}
+
+	if raw, ok := values[0].(string); ok {
+		var cached entry
+		var value T
+		if json.Unmarshal([]byte(raw), &cached) == nil && slices.Equal(cached.Versions, versions) && json.Unmarshal(cached.Value, &value) == nil {
+			metrics.CacheRequests.WithLabelValues(c.name, metrics.CacheHit).Inc()
+			return value, nil
+		}
+	}
+	metrics.CacheRequests.WithLabelValues(c.name, metrics.CacheMiss).Inc()
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+	c.store(ctx, redisKey, versions, value)
+	return value, nil
+}
+
+// store caches value under redisKey, logging failures
+func (c *Cache) store(ctx context.Context, redisKey string, versions []int64, value interface{}) {
+	raw, err := json.Marshal(value)
+	if err == nil {
+		raw, err = json.Marshal(entry{Versions: versions, Value: raw})
+	}
+	if err == nil {
+		err = c.redis.Client.Set(ctx, redisKey, raw, c.ttl).Err()
+	}
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to cache value", "cache", c.name, "error", err)
+	}
+}
+
+// Invalidate drops the entries carrying any of tags. Inside a transaction it
+// runs once the transaction commits, so that entries loaded before the
+// commit cannot be served after it. Failures are logged; the entries then
+// expire with the cache TTL.
+func (c *Cache) Invalidate(ctx context.Context, tags ...string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	datastore.AfterCommit(ctx, func(ctx context.Context) {
+		// The write is done, so invalidate even if the request is cancelled
+		ctx = context.WithoutCancel(ctx)
+
+		// Versions outlive every entry recording them, since an expired
+		// version restarts from zero
+		pipe := c.redis.Client.Pipeline()
+		for _, tagKey := range c.tagKeys(tags) {
+			pipe.Incr(ctx, tagKey)
+			pipe.Expire(ctx, tagKey, 2*c.ttl)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			logging.FromContext(ctx).Error("cache invalidation failed", "tags", tags, "error", err)
+		}
+	})
+}
+
+func (c *Cache) tagKeys(tags []string) []string {
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = c.redis.GetKey("cache", "tag:"+tag)
+	}
+	return keys
+}
+
+// parseVersions reads the tag versions returned by MGET, where a missing
+// tag has version zero
+func parseVersions(values []interface{}) ([]int64, error) {
+	versions := make([]int64, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		s, _ := value.(string)
+		version, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache tag version %q", s)
+		}
+		versions[i] = version
+	}
+	return versions, nil
+}
@@ -0,0 +1,278 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/redis/go-redis/v9"
+)
+
+// memoryHook keeps the keys the cache uses in a map instead of a server;
+// with down set every command fails
+type memoryHook struct {
+	mu   sync.Mutex
+	keys map[string]string
+	down bool
+}
+
+func (h *memoryHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no redis in tests")
+	}
+}
+
+func (h *memoryHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		return h.process(cmd)
+	}
+}
+
+func (h *memoryHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := h.process(cmd); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (h *memoryHook) process(cmd redis.Cmder) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.down {
+		err := errors.New("connection refused")
+		cmd.SetErr(err)
+		return err
+	}
+
+	args := cmd.Args()
+	switch cmd := cmd.(type) {
+	case *redis.SliceCmd: // MGET
+		values := make([]interface{}, 0, len(args)-1)
+		for _, key := range args[1:] {
+			if value, ok := h.keys[key.(string)]; ok {
+				values = append(values, value)
+			} else {
+				values = append(values, nil)
+			}
+		}
+		cmd.SetVal(values)
+	case *redis.StatusCmd: // SET
+		h.keys[args[1].(string)] = string(args[2].([]byte))
+		cmd.SetVal("OK")
+	case *redis.IntCmd: // INCR
+		version, _ := strconv.ParseInt(h.keys[args[1].(string)], 10, 64)
+		version++
+		h.keys[args[1].(string)] = strconv.FormatInt(version, 10)
+		cmd.SetVal(version)
+	case *redis.BoolCmd: // EXPIRE
+		cmd.SetVal(true)
+	}
+	return nil
+}
+
+// txDriver is a database/sql driver whose connections only begin, commit and
+// roll back transactions, for running code through WithinTransaction
+type txDriver struct{}
+
+func (txDriver) Open(name string) (driver.Conn, error) { return txConn{}, nil }
+
+type txConn struct{}
+
+func (txConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("no queries in tests")
+}
+func (txConn) Close() error              { return nil }
+func (txConn) Begin() (driver.Tx, error) { return txConn{}, nil }
+func (txConn) Commit() error             { return nil }
+func (txConn) Rollback() error           { return nil }
+
+func init() {
+	sql.Register("cachetest", txDriver{})
+}
+
+type cachedUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestFetch(t *testing.T) {
+	hook := &memoryHook{keys: make(map[string]string)}
+	client := redis.NewClient(&redis.Options{Addr: "redis.invalid:6379"})
+	client.AddHook(hook)
+	defer client.Close()
+	store := &datastore.RedisStore{Client: client, Prefixes: map[string]string{"cache": "cache:"}}
+	users := New(store, "users")
+
+	db, err := sql.Open("cachetest", "")
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	defer db.Close()
+	transactor := &datastore.PostgresStore{DB: db}
+	errRollback := errors.New("rolled back")
+
+	// The database: loads count the reads that missed the cache
+	names := map[string]string{"u-1": "Alice", "u-2": "Bob"}
+	loads := 0
+	errMissing := errors.New("user not found")
+	fetch := func(ctx context.Context, id string) (cachedUser, error) {
+		return Fetch(ctx, users, id, []string{"user:" + id}, func(ctx context.Context) (cachedUser, error) {
+			loads++
+			name, ok := names[id]
+			if !ok {
+				return cachedUser{}, errMissing
+			}
+			return cachedUser{ID: id, Name: name}, nil
+		})
+	}
+
+	tests := []struct {
+		name      string
+		setup     func(ctx context.Context) context.Context
+		id        string
+		want      cachedUser
+		wantErr   error
+		wantLoads int
+	}{
+		{"miss", nil, "u-1", cachedUser{"u-1", "Alice"}, nil, 1},
+		{"hit", nil, "u-1", cachedUser{"u-1", "Alice"}, nil, 0},
+		{"other key", nil, "u-2", cachedUser{"u-2", "Bob"}, nil, 1},
+		{
+			"invalidated",
+			func(ctx context.Context) context.Context {
+				names["u-1"] = "Alice Smith"
+				users.Invalidate(ctx, "user:u-1")
+				return ctx
+			},
+			"u-1", cachedUser{"u-1", "Alice Smith"}, nil, 1,
+		},
+		{"cached again", nil, "u-1", cachedUser{"u-1", "Alice Smith"}, nil, 0},
+		{"other tag untouched", nil, "u-2", cachedUser{"u-2", "Bob"}, nil, 0},
+		{
+			"invalidated by another cache",
+			func(ctx context.Context) context.Context {
+				names["u-2"] = "Robert"
+				New(store, "directory").Invalidate(ctx, "user:u-2")
+				return ctx
+			},
+			"u-2", cachedUser{"u-2", "Robert"}, nil, 1,
+		},
+		{"errors are not cached", nil, "u-3", cachedUser{}, errMissing, 1},
+		{"errors are loaded again", nil, "u-3", cachedUser{}, errMissing, 1},
+		{
+			// Invalidation waits for the commit, which never comes here
+			"invalidated inside a rolled back transaction",
+			func(ctx context.Context) context.Context {
+				err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+					users.Invalidate(ctx, "user:u-1")
+					return errRollback
+				})
+				if !errors.Is(err, errRollback) {
+					t.Fatalf("WithinTransaction: err = %v, want %v", err, errRollback)
+				}
+				return ctx
+			},
+			"u-1", cachedUser{"u-1", "Alice Smith"}, nil, 0,
+		},
+		{
+			"invalidated by a committed transaction",
+			func(ctx context.Context) context.Context {
+				err := transactor.WithinTransaction(ctx, func(txCtx context.Context) error {
+					// Reads inside the transaction bypass the cache
+					loads = 0
+					if _, err := fetch(txCtx, "u-1"); err != nil || loads != 1 {
+						t.Fatalf("Fetch inside the transaction: err = %v, loaded %d times, want 1", err, loads)
+					}
+					names["u-1"] = "Alice Jones"
+					users.Invalidate(txCtx, "user:u-1")
+					// Not invalidated before the commit
+					if got, _ := fetch(ctx, "u-1"); got.Name != "Alice Smith" {
+						t.Fatalf("Fetch before commit = %+v, want the cached value", got)
+					}
+					return nil
+				})
+				if err != nil {
+					t.Fatalf("WithinTransaction: %v", err)
+				}
+				return ctx
+			},
+			"u-1", cachedUser{"u-1", "Alice Jones"}, nil, 1,
+		},
+		{
+			"redis down",
+			func(ctx context.Context) context.Context {
+				hook.down = true
+				return ctx
+			},
+			"u-1", cachedUser{"u-1", "Alice Jones"}, nil, 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.setup != nil {
+				ctx = tt.setup(ctx)
+			}
+			loads = 0
+
+			got, err := fetch(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Fetch: err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("Fetch = %+v, want %+v", got, tt.want)
+			}
+			if loads != tt.wantLoads {
+				t.Fatalf("loaded %d times, want %d", loads, tt.wantLoads)
+			}
+		})
+	}
+}
+
+func TestParseVersions(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []interface{}
+		want    []int64
+		wantErr bool
+	}{
+		{"none", nil, []int64{}, false},
+		{"missing tags", []interface{}{nil, "3", nil}, []int64{0, 3, 0}, false},
+		{"not a number", []interface{}{"3", "x"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVersions(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseVersions: err = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseVersions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	store := &datastore.RedisStore{TTL: map[string]int{"cache": 300}}
+	if got := New(store, "users").ttl.Seconds(); got != 300 {
+		t.Fatalf("ttl = %vs, want the configured 300s", got)
+	}
+	if got := New(&datastore.RedisStore{}, "users").ttl; got != defaultTTL {
+		t.Fatalf("ttl = %v, want the default %v", got, defaultTTL)
+	}
+}
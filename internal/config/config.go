@@ -1,8 +1,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -10,16 +15,24 @@ import (
 
 // Config holds the application configuration
 type Config struct {
-	App       AppConfig       `yaml:"app"`
-	Server    ServerConfig    `yaml:"server"`
-	CORS      CORSConfig      `yaml:"cors"`
-	JWT       JWTConfig       `yaml:"jwt"`
-	OAuth     OAuthConfig     `yaml:"oauth"`
-	Jitsi     JitsiConfig     `yaml:"jitsi"`
-	AWS       AWSConfig       `yaml:"aws"`
-	Logging   LoggingConfig   `yaml:"logging"`
-	RateLimit RateLimitConfig `yaml:"rate_limiting"`
-	WebSocket WebSocketConfig `yaml:"websocket"`
+	App           AppConfig           `yaml:"app"`
+	Server        ServerConfig        `yaml:"server"`
+	CORS          CORSConfig          `yaml:"cors"`
+	JWT           JWTConfig           `yaml:"jwt"`
+	OAuth         OAuthConfig         `yaml:"oauth"`
+	Jitsi         JitsiConfig         `yaml:"jitsi"`
+	AWS           AWSConfig           `yaml:"aws"`
+	Logging       LoggingConfig       `yaml:"logging"`
+	Metrics       MetricsConfig       `yaml:"metrics"`
+	Tracing       TracingConfig       `yaml:"tracing"`
+	RateLimit     RateLimitConfig     `yaml:"rate_limiting"`
+	WebSocket     WebSocketConfig     `yaml:"websocket"`
+	Meetings      MeetingsConfig      `yaml:"meetings"`
+	Jobs          JobsConfig          `yaml:"jobs"`
+	Storage       StorageConfig       `yaml:"storage"`
+	Notifications NotificationsConfig `yaml:"notifications"`
+	Events        EventsConfig        `yaml:"events"`
+	Webhooks      WebhooksConfig      `yaml:"webhooks"`
 }
 
 type AppConfig struct {
@@ -65,52 +78,186 @@ type GoogleOAuthConfig struct {
 }
 
 type JitsiConfig struct {
-	APIURL     string `yaml:"api_url"`
-	AppID      string `yaml:"app_id"`
-	AppSecret  string `yaml:"app_secret"`
-	Domain     string `yaml:"domain"`
-	RoomPrefix string `yaml:"room_prefix"`
+	APIURL        string `yaml:"api_url"`
+	AppID         string `yaml:"app_id"`
+	AppSecret     string `yaml:"app_secret"`
+	Domain        string `yaml:"domain"`
+	RoomPrefix    string `yaml:"room_prefix"`
+	WebhookSecret string `yaml:"webhook_secret"`
 }
 
 type AWSConfig struct {
-	Region string    `yaml:"region"`
-	S3     S3Config  `yaml:"s3"`
+	Region string   `yaml:"region"`
+	S3     S3Config `yaml:"s3"`
 }
 
 type S3Config struct {
 	Bucket           string `yaml:"bucket"`
 	RecordingsPrefix string `yaml:"recordings_prefix"`
 	MaxFileSize      int64  `yaml:"max_file_size"`
+	Endpoint         string `yaml:"endpoint"`
+	AccessKeyID      string `yaml:"access_key_id"`
+	SecretAccessKey  string `yaml:"secret_access_key"`
+	UseSSL           bool   `yaml:"use_ssl"`
+}
+
+type StorageConfig struct {
+	Driver string             `yaml:"driver"`
+	Local  LocalStorageConfig `yaml:"local"`
+}
+
+type LocalStorageConfig struct {
+	Root       string `yaml:"root"`
+	BaseURL    string `yaml:"base_url"`
+	SigningKey string `yaml:"signing_key"`
 }
 
 type LoggingConfig struct {
-	Level  string `yaml:"level"`
-	Format string `yaml:"format"`
-	Output string `yaml:"output"`
+	Level      string `yaml:"level"`  // debug, info, warn or error
+	Format     string `yaml:"format"` // json or text
+	Output     string `yaml:"output"` // stdout, stderr or a file path
+	MaxSizeMB  int    `yaml:"max_size_mb"`
+	MaxBackups int    `yaml:"max_backups"` // rotated files kept next to the log file
 }
 
+// MetricsConfig configures the Prometheus listener, which is kept apart
+// from the API listener so it need not be exposed publicly
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Host    string `yaml:"host"`
+	Port    int    `yaml:"port"`
+	Path    string `yaml:"path"`
+}
+
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	Exporter    string  `yaml:"exporter"`     // otlp, stdout or file
+	Endpoint    string  `yaml:"endpoint"`     // OTLP/HTTP collector, e.g. "localhost:4318"
+	Insecure    bool    `yaml:"insecure"`     // plain http to the collector
+	FilePath    string  `yaml:"file_path"`    // written by the file exporter
+	SampleRatio float64 `yaml:"sample_ratio"` // of new traces; traces continued from a sampled caller are always kept
+}
+
+// RateLimitConfig configures the token bucket every client gets: a client
+// is an API key, else the authenticated user, else the IP address. A route
+// override adds a bucket per client for that route, which must also have a
+// token left.
 type RateLimitConfig struct {
-	Enabled            bool `yaml:"enabled"`
-	RequestsPerSecond  int  `yaml:"requests_per_second"`
-	Burst              int  `yaml:"burst"`
+	Enabled           bool                 `yaml:"enabled"`
+	RequestsPerSecond float64              `yaml:"requests_per_second"`
+	Burst             int                  `yaml:"burst"`
+	Routes            map[string]RateLimit `yaml:"routes"` // keyed by "METHOD /route/template"
+	Users             map[string]RateLimit `yaml:"users"`  // keyed by user ID
+	APIKeys           []APIKeyRateLimit    `yaml:"api_keys"`
+}
+
+type RateLimit struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+// APIKeyRateLimit is the limit of clients sending Key in X-API-Key. Unknown
+// keys are ignored, so clients cannot escape their limit by making up keys.
+type APIKeyRateLimit struct {
+	Name      string `yaml:"name"` // identifies the key in Redis and logs instead of the key itself
+	Key       string `yaml:"key"`
+	RateLimit `yaml:",inline"`
 }
 
 type WebSocketConfig struct {
-	ReadBufferSize  int           `yaml:"read_buffer_size"`
-	WriteBufferSize int           `yaml:"write_buffer_size"`
-	MaxMessageSize  int64         `yaml:"max_message_size"`
-	PingInterval    time.Duration `yaml:"ping_interval"`
-	PongTimeout     time.Duration `yaml:"pong_timeout"`
+	ReadBufferSize        int           `yaml:"read_buffer_size"`
+	WriteBufferSize       int           `yaml:"write_buffer_size"`
+	MaxMessageSize        int64         `yaml:"max_message_size"`
+	PingInterval          time.Duration `yaml:"ping_interval"`
+	PongTimeout           time.Duration `yaml:"pong_timeout"`
+	MaxConnectionsPerUser int           `yaml:"max_connections_per_user"` // across all replicas
+	SendBufferSize        int           `yaml:"send_buffer_size"`         // queued events per connection before it is dropped
+}
+
+type MeetingsConfig struct {
+	ConflictPolicy  string             `yaml:"conflict_policy"` // warn or reject
+	SlotInterval    time.Duration      `yaml:"slot_interval"`
+	MaxSuggestions  int                `yaml:"max_suggestions"`
+	WorkingHours    WorkingHoursConfig `yaml:"working_hours"`
+	ReminderBefore  time.Duration      `yaml:"reminder_before"`
+	EndGrace        time.Duration      `yaml:"end_grace"`
+	JobInterval     time.Duration      `yaml:"job_interval"`
+	RecordingURLTTL time.Duration      `yaml:"recording_url_ttl"`
+	GuestLinkURL    string             `yaml:"guest_link_url"`  // guest link tokens are appended to this URL
+	GuestTokenTTL   time.Duration      `yaml:"guest_token_ttl"` // lifetime of Jitsi tokens issued to admitted guests
+}
+
+type WorkingHoursConfig struct {
+	Start string   `yaml:"start"` // HH:MM
+	End   string   `yaml:"end"`   // HH:MM
+	Days  []string `yaml:"days"`
+}
+
+type NotificationsConfig struct {
+	DefaultChannels []string      `yaml:"default_channels"` // channels used for types without a user preference
+	MaxAttempts     int           `yaml:"max_attempts"`
+	RetryBackoff    time.Duration `yaml:"retry_backoff"` // doubles after every failed attempt
+	MaxBackoff      time.Duration `yaml:"max_backoff"`
+	JobInterval     time.Duration `yaml:"job_interval"`
+	BatchSize       int           `yaml:"batch_size"`
+	FallbackLocale  string        `yaml:"fallback_locale"` // used when a template is not translated to the recipient's language
+	DailyDigestAt   string        `yaml:"daily_digest_at"` // HH:MM in the user's timezone
+	DefaultTTL      time.Duration `yaml:"default_ttl"`     // notifications without an expiry are archived after it; 0 keeps them
+	BroadcastRoles  []string      `yaml:"broadcast_roles"` // roles allowed to broadcast to roles and departments
+	SMTP            SMTPConfig    `yaml:"smtp"`
+	Webhook         WebhookConfig `yaml:"webhook"`
+	LogSink         LogSinkConfig `yaml:"log_sink"`
+}
+
+type SMTPConfig struct {
+	Host     string        `yaml:"host"` // email delivery is disabled without a host
+	Port     int           `yaml:"port"`
+	Username string        `yaml:"username"`
+	Password string        `yaml:"password"`
+	From     string        `yaml:"from"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+type WebhookConfig struct {
+	Timeout       time.Duration `yaml:"timeout"`
+	SigningSecret string        `yaml:"signing_secret"` // signs request bodies when set
+}
+
+type LogSinkConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"` // empty writes to stdout
+}
+
+type EventsConfig struct {
+	RelayInterval time.Duration `yaml:"relay_interval"`
+	BatchSize     int           `yaml:"batch_size"`
+	MaxAttempts   int           `yaml:"max_attempts"`  // events still failing afterwards are marked failed
+	RetryBackoff  time.Duration `yaml:"retry_backoff"` // doubles after every failed attempt
+	MaxBackoff    time.Duration `yaml:"max_backoff"`
+}
+
+type WebhooksConfig struct {
+	Timeout      time.Duration `yaml:"timeout"`
+	MaxAttempts  int           `yaml:"max_attempts"`  // deliveries still failing afterwards are dead-lettered
+	RetryBackoff time.Duration `yaml:"retry_backoff"` // doubles after every failed attempt
+	MaxBackoff   time.Duration `yaml:"max_backoff"`
+	JobInterval  time.Duration `yaml:"job_interval"`
+	BatchSize    int           `yaml:"batch_size"`
+	RequireHTTPS bool          `yaml:"require_https"` // rejects plain http endpoints, e.g. in production
+}
+
+type JobsConfig struct {
+	Enabled bool `yaml:"enabled"`
 }
 
 // PostgresConfig holds PostgreSQL configuration
 type PostgresConfig struct {
-	Host     string `yaml:"host"`
-	Port     int    `yaml:"port"`
-	User     string `yaml:"user"`
-	Password string `yaml:"password"`
-	DBName   string `yaml:"dbname"`
-	SSLMode  string `yaml:"sslmode"`
+	Host     string     `yaml:"host"`
+	Port     int        `yaml:"port"`
+	User     string     `yaml:"user"`
+	Password string     `yaml:"password"`
+	DBName   string     `yaml:"dbname"`
+	SSLMode  string     `yaml:"sslmode"`
 	Pool     PoolConfig `yaml:"pool"`
 }
 
@@ -146,110 +293,108 @@ type TimeoutsConfig struct {
 	Write time.Duration `yaml:"write"`
 }
 
-// Load loads configuration from YAML files
+// EnvPrefix starts the names of the environment variables overriding
+// configuration fields, e.g. EVTAARPRO_APP_PORT for app.port
+const EnvPrefix = "EVTAARPRO"
+
+// Load loads configuration from YAML files. Next to each file an overlay
+// for the environment, e.g. app.production.yaml, may replace some of its
+// values; the environment is app.env unless EVTAARPRO_APP_ENV is set. Then
+// ${VAR} references are expanded in every string, fields are overridden by
+// EVTAARPRO_* variables and the result is validated. Every variable NAME
+// may instead be given as NAME_FILE, the path of a file holding the value.
+// All problems found are returned together in a *ValidationError.
 func Load(appConfigPath, postgresConfigPath, redisConfigPath string) (*Config, *PostgresConfig, *RedisConfig, error) {
-	// Load app config
-	appConfig, err := loadAppConfig(appConfigPath)
-	if err != nil {
+	env := &resolver{}
+
+	// Load app config; it names the environment of the overlays
+	appConfig := &Config{}
+	if err := readYAML(appConfigPath, appConfig); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load app config: %w", err)
+	}
+	environment, ok := env.get(EnvPrefix + "_APP_ENV")
+	if !ok {
+		environment = env.expandString(appConfig.App.Env)
+	}
+	if err := readOverlay(appConfigPath, environment, appConfig); err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to load app config: %w", err)
 	}
 
 	// Load postgres config
-	postgresConfig, err := loadPostgresConfig(postgresConfigPath)
-	if err != nil {
+	var postgres struct {
+		Postgres PostgresConfig `yaml:"postgres"`
+	}
+	if err := loadYAML(postgresConfigPath, environment, &postgres); err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to load postgres config: %w", err)
 	}
 
 	// Load redis config
-	redisConfig, err := loadRedisConfig(redisConfigPath)
-	if err != nil {
+	var redis struct {
+		Redis RedisConfig `yaml:"redis"`
+	}
+	if err := loadYAML(redisConfigPath, environment, &redis); err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to load redis config: %w", err)
 	}
 
-	// Expand environment variables
-	expandEnvVars(appConfig)
-	expandPostgresEnvVars(postgresConfig)
-	expandRedisEnvVars(redisConfig)
-
-	return appConfig, postgresConfig, redisConfig, nil
-}
-
-func loadAppConfig(path string) (*Config, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
+	// Apply the environment
+	for _, config := range []interface{}{appConfig, &postgres, &redis} {
+		value := reflect.ValueOf(config).Elem()
+		env.expand(value)
+		env.override(value, EnvPrefix)
 	}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, err
+	v := &validator{problems: env.problems}
+	appConfig.validate(v)
+	postgres.Postgres.validate(v)
+	redis.Redis.validate(v)
+	if err := v.err(); err != nil {
+		return nil, nil, nil, err
 	}
 
-	return &config, nil
+	return appConfig, &postgres.Postgres, &redis.Redis, nil
 }
 
-func loadPostgresConfig(path string) (*PostgresConfig, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
+// loadYAML reads the YAML file at path into out, followed by its overlay
+// for environment
+func loadYAML(path, environment string, out interface{}) error {
+	if err := readYAML(path, out); err != nil {
+		return err
 	}
-
-	var wrapper struct {
-		Postgres PostgresConfig `yaml:"postgres"`
-	}
-	if err := yaml.Unmarshal(data, &wrapper); err != nil {
-		return nil, err
-	}
-
-	return &wrapper.Postgres, nil
+	return readOverlay(path, environment, out)
 }
 
-func loadRedisConfig(path string) (*RedisConfig, error) {
+func readYAML(path string, out interface{}) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
-	}
-
-	var wrapper struct {
-		Redis RedisConfig `yaml:"redis"`
+		return err
 	}
-	if err := yaml.Unmarshal(data, &wrapper); err != nil {
-		return nil, err
-	}
-
-	return &wrapper.Redis, nil
-}
-
-func expandEnvVars(config *Config) {
-	config.JWT.Secret = os.ExpandEnv(config.JWT.Secret)
-	config.OAuth.Google.ClientID = os.ExpandEnv(config.OAuth.Google.ClientID)
-	config.OAuth.Google.ClientSecret = os.ExpandEnv(config.OAuth.Google.ClientSecret)
-	config.OAuth.Google.RedirectURL = os.ExpandEnv(config.OAuth.Google.RedirectURL)
-	config.Jitsi.APIURL = os.ExpandEnv(config.Jitsi.APIURL)
-	config.Jitsi.AppID = os.ExpandEnv(config.Jitsi.AppID)
-	config.Jitsi.AppSecret = os.ExpandEnv(config.Jitsi.AppSecret)
-	config.Jitsi.Domain = os.ExpandEnv(config.Jitsi.Domain)
-	config.AWS.Region = os.ExpandEnv(config.AWS.Region)
-	config.AWS.S3.Bucket = os.ExpandEnv(config.AWS.S3.Bucket)
+	return yaml.Unmarshal(data, out)
 }
 
-func expandPostgresEnvVars(config *PostgresConfig) {
-	config.Host = os.ExpandEnv(config.Host)
-	config.User = os.ExpandEnv(config.User)
-	config.Password = os.ExpandEnv(config.Password)
-	config.DBName = os.ExpandEnv(config.DBName)
-	config.SSLMode = os.ExpandEnv(config.SSLMode)
-}
+// readOverlay reads the overlay of the YAML file at path for environment
+// into out, if there is one. Values of the overlay replace those already
+// in out; maps are merged and lists replaced.
+func readOverlay(path, environment string, out interface{}) error {
+	if environment == "" {
+		return nil
+	}
 
-func expandRedisEnvVars(config *RedisConfig) {
-	config.Host = os.ExpandEnv(config.Host)
-	config.Password = os.ExpandEnv(config.Password)
+	ext := filepath.Ext(path)
+	overlayPath := strings.TrimSuffix(path, ext) + "." + environment + ext
+	if _, err := os.Stat(overlayPath); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err := readYAML(overlayPath, out); err != nil {
+		return fmt.Errorf("overlay %s: %w", overlayPath, err)
+	}
+	return nil
 }
 
-// GetDSN returns PostgreSQL connection string
+// GetDSN returns PostgreSQL connection string. Sessions run in UTC so
+// timestamptz values are read back as UTC.
 func (c *PostgresConfig) GetDSN() string {
 	return fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s timezone=UTC",
 		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode,
 	)
 }
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// resolver reads configuration values from the environment and collects
+// the problems it runs into. A variable NAME may also be given as
+// NAME_FILE, the path of a file holding the value, e.g. a mounted secret.
+type resolver struct {
+	problems []string
+}
+
+// get returns the value of the variable name, or of the file named by
+// name_FILE
+func (r *resolver) get(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+
+	path, ok := os.LookupEnv(name + "_FILE")
+	if !ok {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s_FILE: %v", name, err))
+		return "", false
+	}
+	// Files written by editors and secret tools often end in a newline
+	return strings.TrimRight(string(data), "\r\n"), true
+}
+
+// expandString replaces ${VAR} and $VAR in s; unset variables expand to ""
+func (r *resolver) expandString(s string) string {
+	return os.Expand(s, func(name string) string {
+		value, _ := r.get(name)
+		return value
+	})
+}
+
+// expand replaces ${VAR} and $VAR references in every string of v
+func (r *resolver) expand(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(r.expandString(v.String()))
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				r.expand(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			r.expand(v.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			r.expand(elem)
+			v.SetMapIndex(key, elem)
+		}
+	}
+}
+
+// override sets the fields of v from variables named after their YAML path
+// below name, e.g. EVTAARPRO_POSTGRES_POOL_MAX_OPEN_CONNS. Lists of strings
+// are comma separated. Maps can only override the keys they already have,
+// and lists of objects cannot be overridden.
+func (r *resolver) override(v reflect.Value, name string) {
+	switch {
+	case v.Kind() == reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			key, inline := yamlKey(field)
+			switch {
+			case key == "-":
+			case inline:
+				r.override(v.Field(i), name)
+			default:
+				r.override(v.Field(i), name+"_"+envName(key))
+			}
+		}
+
+	case v.Kind() == reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		for _, key := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			r.override(elem, name+"_"+envName(key.String()))
+			v.SetMapIndex(key, elem)
+		}
+
+	case v.Kind() == reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return
+		}
+		value, ok := r.get(name)
+		if !ok {
+			return
+		}
+		list := reflect.MakeSlice(v.Type(), 0, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = reflect.Append(list, reflect.ValueOf(item).Convert(v.Type().Elem()))
+			}
+		}
+		v.Set(list)
+
+	default:
+		value, ok := r.get(name)
+		if !ok {
+			return
+		}
+		if err := setScalar(v, value); err != nil {
+			r.problems = append(r.problems, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+}
+
+// setScalar parses value into v
+func setScalar(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", value)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", value)
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot be set from the environment")
+	}
+	return nil
+}
+
+// yamlKey returns the key of field in YAML and whether it is inlined
+func yamlKey(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("yaml")
+	key, options, _ := strings.Cut(tag, ",")
+	if options == "inline" {
+		return "", true
+	}
+	if key == "" {
+		key = strings.ToLower(field.Name)
+	}
+	return key, false
+}
+
+// envName turns a YAML key into a variable name: upper case, with every
+// character other than a letter or digit replaced by an underscore
+func envName(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, key)
+}
@@ -0,0 +1,255 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolverOverride(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "jwt_secret")
+	if err := os.WriteFile(secretFile, []byte("from-a-file\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		env          map[string]string
+		want         func(cfg *Config)
+		wantProblems []string
+	}{
+		{
+			name: "scalars",
+			env: map[string]string{
+				"EVTAARPRO_APP_PORT":                     "9000",
+				"EVTAARPRO_JOBS_ENABLED":                 "true",
+				"EVTAARPRO_TRACING_SAMPLE_RATIO":         "0.25",
+				"EVTAARPRO_AWS_S3_MAX_FILE_SIZE":         "1048576",
+				"EVTAARPRO_JWT_ACCESS_TOKEN_EXPIRY":      "30m",
+				"EVTAARPRO_WEBSOCKET_MAX_MESSAGE_SIZE":   "4096",
+				"EVTAARPRO_MEETINGS_WORKING_HOURS_START": "08:30",
+			},
+			want: func(cfg *Config) {
+				cfg.App.Port = 9000
+				cfg.Jobs.Enabled = true
+				cfg.Tracing.SampleRatio = 0.25
+				cfg.AWS.S3.MaxFileSize = 1048576
+				cfg.JWT.AccessTokenExpiry = 30 * time.Minute
+				cfg.WebSocket.MaxMessageSize = 4096
+				cfg.Meetings.WorkingHours.Start = "08:30"
+			},
+		},
+		{
+			name: "lists",
+			env:  map[string]string{"EVTAARPRO_CORS_ALLOWED_ORIGINS": "https://a.example.com, https://b.example.com,"},
+			want: func(cfg *Config) {
+				cfg.CORS.AllowedOrigins = []string{"https://a.example.com", "https://b.example.com"}
+			},
+		},
+		{
+			name: "keys of maps",
+			env: map[string]string{
+				"EVTAARPRO_RATE_LIMITING_ROUTES_POST__AUTH_LOGIN_BURST": "5",
+				"EVTAARPRO_RATE_LIMITING_ROUTES_GET__UNKNOWN_BURST":     "5",
+			},
+			want: func(cfg *Config) {
+				cfg.RateLimit.Routes["POST /auth/login"] = RateLimit{RequestsPerSecond: 1, Burst: 5}
+			},
+		},
+		{
+			name: "from a file",
+			env:  map[string]string{"EVTAARPRO_JWT_SECRET_FILE": secretFile},
+			want: func(cfg *Config) { cfg.JWT.Secret = "from-a-file" },
+		},
+		{
+			name: "variable before its file",
+			env:  map[string]string{"EVTAARPRO_JWT_SECRET": "from-the-variable", "EVTAARPRO_JWT_SECRET_FILE": secretFile},
+			want: func(cfg *Config) { cfg.JWT.Secret = "from-the-variable" },
+		},
+		{
+			name: "invalid values",
+			env: map[string]string{
+				"EVTAARPRO_APP_PORT":                "eighty",
+				"EVTAARPRO_JOBS_ENABLED":            "sometimes",
+				"EVTAARPRO_JWT_ACCESS_TOKEN_EXPIRY": "15",
+				"EVTAARPRO_JWT_SECRET_FILE":         filepath.Join(t.TempDir(), "missing"),
+			},
+			want: func(cfg *Config) {},
+			wantProblems: []string{
+				`EVTAARPRO_APP_PORT: invalid integer "eighty"`,
+				"EVTAARPRO_JWT_SECRET_FILE: open",
+				`EVTAARPRO_JWT_ACCESS_TOKEN_EXPIRY: invalid duration "15"`,
+				`EVTAARPRO_JOBS_ENABLED: invalid boolean "sometimes"`,
+			},
+		},
+	}
+
+	base := func() *Config {
+		return &Config{
+			App:       AppConfig{Port: 8080},
+			JWT:       JWTConfig{Secret: "from-yaml", AccessTokenExpiry: 15 * time.Minute},
+			RateLimit: RateLimitConfig{Routes: map[string]RateLimit{"POST /auth/login": {RequestsPerSecond: 1, Burst: 3}}},
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			cfg := base()
+			r := &resolver{}
+			r.override(reflect.ValueOf(cfg).Elem(), EnvPrefix)
+
+			want := base()
+			tt.want(want)
+			if !reflect.DeepEqual(cfg, want) {
+				t.Fatalf("config = %+v, want %+v", cfg, want)
+			}
+			if len(r.problems) != len(tt.wantProblems) {
+				t.Fatalf("problems = %q, want %q", r.problems, tt.wantProblems)
+			}
+			for i, problem := range tt.wantProblems {
+				if !strings.HasPrefix(r.problems[i], problem) {
+					t.Fatalf("problem %d = %q, want %q", i, r.problems[i], problem)
+				}
+			}
+		})
+	}
+}
+
+func TestResolverExpand(t *testing.T) {
+	t.Setenv("JWT_SECRET", "expanded-secret")
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("PARTNER_API_KEY", "partner-key")
+
+	cfg := &Config{
+		JWT: JWTConfig{Secret: "${JWT_SECRET}"},
+		Notifications: NotificationsConfig{
+			SMTP:            SMTPConfig{Host: "$SMTP_HOST", Username: "${SMTP_USERNAME_UNSET}"},
+			DefaultChannels: []string{"in_app", "${SMTP_HOST}"},
+		},
+		RateLimit: RateLimitConfig{APIKeys: []APIKeyRateLimit{{Name: "partner", Key: "${PARTNER_API_KEY}"}}},
+	}
+	(&resolver{}).expand(reflect.ValueOf(cfg).Elem())
+
+	want := &Config{
+		JWT: JWTConfig{Secret: "expanded-secret"},
+		Notifications: NotificationsConfig{
+			SMTP:            SMTPConfig{Host: "smtp.example.com"},
+			DefaultChannels: []string{"in_app", "smtp.example.com"},
+		},
+		RateLimit: RateLimitConfig{APIKeys: []APIKeyRateLimit{{Name: "partner", Key: "partner-key"}}},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Fatalf("expanded = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"max_open_conns", "MAX_OPEN_CONNS"},
+		{"POST /auth/login", "POST__AUTH_LOGIN"},
+		{"alice@example.com", "ALICE_EXAMPLE_COM"},
+		{"größe", "GR__E"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := envName(tt.key); got != tt.want {
+				t.Fatalf("envName(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("JWT_SECRET", strings.Repeat("s", minSecretLength))
+
+	// The shipped configuration is valid once the secret is set
+	cfg, postgres, redis, err := Load("../../config/app.yaml", "../../config/postgres.yaml", "../../config/redis.yaml")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.JWT.Secret != strings.Repeat("s", minSecretLength) || postgres.Host == "" || redis.TTL["cache"] <= 0 {
+		t.Fatalf("loaded %+v, %+v, %+v", cfg.JWT, postgres, redis)
+	}
+
+	// An overlay for the environment replaces values and merges maps
+	dir := t.TempDir()
+	files := map[string]string{
+		"app.yaml":           "app:\n  env: staging\n  port: 8080\njwt:\n  secret: ${JWT_SECRET}\n  access_token_expiry: 15m\n  refresh_token_expiry: 24h\n",
+		"app.staging.yaml":   "app:\n  port: 8081\n",
+		"app.qa.yaml":        "app:\n  port: 8082\n",
+		"postgres.yaml":      "postgres:\n  host: localhost\n  port: 5432\n  user: app\n  dbname: app\n  sslmode: disable\n  pool:\n    max_open_conns: 5\n",
+		"redis.yaml":         "redis:\n  host: localhost\n  port: 6379\n  pool:\n    max_active: 10\n  ttl:\n    cache: 60\n    session: 600\n",
+		"redis.staging.yaml": "redis:\n  ttl:\n    cache: 120\n",
+		"broken.yaml":        "app: [",
+		"app-invalid.yaml":   "app:\n  port: 0\n",
+		"postgres.qa.yaml":   "postgres:\n  host: qa-db\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []struct {
+		name         string
+		env          map[string]string
+		app          string
+		wantPort     int
+		wantCacheTTL time.Duration
+		wantDBHost   string
+		wantErr      string
+	}{
+		{"overlay of app.env", nil, "app.yaml", 8081, 120 * time.Second, "localhost", ""},
+		{"environment from the variable", map[string]string{"EVTAARPRO_APP_ENV": "qa"}, "app.yaml", 8082, 60 * time.Second, "qa-db", ""},
+		{"variable over the overlay", map[string]string{"EVTAARPRO_APP_PORT": "9000"}, "app.yaml", 9000, 120 * time.Second, "localhost", ""},
+		{"unparsable file", nil, "broken.yaml", 0, 0, "", "failed to load app config"},
+		{"missing file", nil, "absent.yaml", 0, 0, "", "failed to load app config"},
+		{"invalid", nil, "app-invalid.yaml", 0, 0, "", "app.port: must be a port"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			cfg, postgres, redis, err := Load(path(tt.app), path("postgres.yaml"), path("redis.yaml"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load: err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if cfg.App.Port != tt.wantPort {
+				t.Fatalf("app.port = %d, want %d", cfg.App.Port, tt.wantPort)
+			}
+			if ttl := time.Duration(redis.TTL["cache"]) * time.Second; ttl != tt.wantCacheTTL || redis.TTL["session"] != 600 {
+				t.Fatalf("redis.ttl = %v, want cache %v and the session TTL kept", redis.TTL, tt.wantCacheTTL)
+			}
+			if postgres.Host != tt.wantDBHost {
+				t.Fatalf("postgres.host = %q, want %q", postgres.Host, tt.wantDBHost)
+			}
+		})
+	}
+
+	var validationErr *ValidationError
+	if _, _, _, err := Load(path("app-invalid.yaml"), path("postgres.yaml"), path("redis.yaml")); !errors.As(err, &validationErr) {
+		t.Fatalf("Load: err = %v, want a *ValidationError", err)
+	}
+}
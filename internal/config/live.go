@@ -0,0 +1,71 @@
+package config
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Live holds the configuration of the running server. Reloading it applies
+// the settings that are safe to change at runtime, the log level, CORS and
+// rate limits, while everything else keeps the value the server started
+// with.
+type Live struct {
+	current atomic.Pointer[Config]
+
+	mu        sync.Mutex
+	listeners []func(cfg *Config)
+}
+
+// NewLive creates a Live holding the startup configuration cfg
+func NewLive(cfg *Config) *Live {
+	live := &Live{}
+	live.current.Store(cfg)
+	return live
+}
+
+// Current returns the configuration in effect. It must not be modified.
+func (l *Live) Current() *Config {
+	return l.current.Load()
+}
+
+// OnReload registers fn to be called with the configuration after every
+// reload
+func (l *Live) OnReload(fn func(cfg *Config)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.listeners = append(l.listeners, fn)
+}
+
+// Reload applies the reloadable settings of next. It returns the sections
+// of next with other changes, which need a restart to take effect.
+func (l *Live) Reload(next *Config) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	updated := *l.current.Load()
+	updated.Logging.Level = next.Logging.Level
+	updated.CORS = next.CORS
+	updated.RateLimit = next.RateLimit
+	l.current.Store(&updated)
+
+	for _, fn := range l.listeners {
+		fn(&updated)
+	}
+
+	return changedSections(&updated, next)
+}
+
+// changedSections returns the YAML keys of the sections that differ
+// between a and b
+func changedSections(a, b *Config) []string {
+	var sections []string
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for i := 0; i < va.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			key, _ := yamlKey(va.Type().Field(i))
+			sections = append(sections, key)
+		}
+	}
+	return sections
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLiveReload(t *testing.T) {
+	startup := func() *Config {
+		return &Config{
+			App:       AppConfig{Name: "evtaarpro", Port: 8080},
+			Logging:   LoggingConfig{Level: "info", Format: "json"},
+			CORS:      CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+			RateLimit: RateLimitConfig{Enabled: true, RequestsPerSecond: 10},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		change       func(cfg *Config)
+		want         func(cfg *Config) // applied to the startup config
+		wantSections []string
+	}{
+		{
+			name:   "unchanged",
+			change: func(cfg *Config) {},
+			want:   func(cfg *Config) {},
+		},
+		{
+			name: "reloadable settings",
+			change: func(cfg *Config) {
+				cfg.Logging.Level = "debug"
+				cfg.CORS.AllowedOrigins = append(cfg.CORS.AllowedOrigins, "https://admin.example.com")
+				cfg.RateLimit.RequestsPerSecond = 50
+			},
+			want: func(cfg *Config) {
+				cfg.Logging.Level = "debug"
+				cfg.CORS.AllowedOrigins = []string{"https://app.example.com", "https://admin.example.com"}
+				cfg.RateLimit.RequestsPerSecond = 50
+			},
+		},
+		{
+			// Only the level of the logging section is reloaded
+			name:         "log format",
+			change:       func(cfg *Config) { cfg.Logging.Format = "text" },
+			want:         func(cfg *Config) {},
+			wantSections: []string{"logging"},
+		},
+		{
+			name: "settings needing a restart",
+			change: func(cfg *Config) {
+				cfg.App.Port = 9090
+				cfg.RateLimit.Burst = 20
+				cfg.Webhooks.RequireHTTPS = true
+			},
+			want:         func(cfg *Config) { cfg.RateLimit.Burst = 20 },
+			wantSections: []string{"app", "webhooks"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := NewLive(startup())
+			var notified *Config
+			live.OnReload(func(cfg *Config) { notified = cfg })
+
+			next := startup()
+			tt.change(next)
+			sections := live.Reload(next)
+
+			want := startup()
+			tt.want(want)
+			if !reflect.DeepEqual(live.Current(), want) {
+				t.Fatalf("current = %+v, want %+v", live.Current(), want)
+			}
+			if notified != live.Current() {
+				t.Fatal("listener was not called with the current config")
+			}
+			if !reflect.DeepEqual(sections, tt.wantSections) {
+				t.Fatalf("sections needing a restart = %v, want %v", sections, tt.wantSections)
+			}
+		})
+	}
+}
+
+func TestChangedSections(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(cfg *Config)
+		want   []string
+	}{
+		{"none", func(cfg *Config) {}, nil},
+		{"one", func(cfg *Config) { cfg.JWT.Issuer = "other" }, []string{"jwt"}},
+		{"yaml keys", func(cfg *Config) { cfg.RateLimit.Burst = 5; cfg.OAuth.Google.Scopes = []string{"email"} }, []string{"oauth", "rate_limiting"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := &Config{}, &Config{}
+			tt.change(b)
+			if got := changedSections(a, b); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("changedSections = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
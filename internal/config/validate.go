@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+// minSecretLength is the shortest accepted signing secret, 256 bits for
+// HMAC-SHA256
+const minSecretLength = 32
+
+// ValidationError lists every problem found in the configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// validator collects the problems of a configuration
+type validator struct {
+	problems []string
+}
+
+// check records problem for field unless ok
+func (v *validator) check(ok bool, field, problem string, args ...interface{}) {
+	if !ok {
+		v.problems = append(v.problems, field+": "+fmt.Sprintf(problem, args...))
+	}
+}
+
+func (v *validator) checkPort(port int, field string) {
+	v.check(port > 0 && port <= 65535, field, "must be a port between 1 and 65535, got %d", port)
+}
+
+func (v *validator) checkNotNegative(d time.Duration, field string) {
+	v.check(d >= 0, field, "must not be negative")
+}
+
+func (v *validator) checkOneOf(value, field string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.check(false, field, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+// Validate checks the application configuration and returns a
+// *ValidationError listing every problem
+func (c *Config) Validate() error {
+	v := &validator{}
+	c.validate(v)
+	return v.err()
+}
+
+func (c *Config) validate(v *validator) {
+	v.checkPort(c.App.Port, "app.port")
+
+	v.checkNotNegative(c.Server.ReadTimeout, "server.read_timeout")
+	v.checkNotNegative(c.Server.WriteTimeout, "server.write_timeout")
+	v.checkNotNegative(c.Server.IdleTimeout, "server.idle_timeout")
+	v.check(c.Server.MaxHeaderBytes >= 0, "server.max_header_bytes", "must not be negative")
+
+	v.check(len(c.JWT.Secret) >= minSecretLength, "jwt.secret", "must be at least %d characters, got %d; set JWT_SECRET", minSecretLength, len(c.JWT.Secret))
+	v.check(c.JWT.AccessTokenExpiry > 0, "jwt.access_token_expiry", "must be positive")
+	v.check(c.JWT.RefreshTokenExpiry >= c.JWT.AccessTokenExpiry, "jwt.refresh_token_expiry", "must not be shorter than the access token expiry")
+
+	if c.Storage.Local.SigningKey != "" {
+		v.check(len(c.Storage.Local.SigningKey) >= minSecretLength, "storage.local.signing_key", "must be at least %d characters when set", minSecretLength)
+	}
+	v.checkOneOf(c.Storage.Driver, "storage.driver", "", "local", "s3")
+
+	if c.Logging.Level != "" {
+		var level slog.Level
+		v.check(level.UnmarshalText([]byte(c.Logging.Level)) == nil, "logging.level", "must be debug, info, warn or error, got %q", c.Logging.Level)
+	}
+	v.checkOneOf(strings.ToLower(c.Logging.Format), "logging.format", "", "json", "text")
+	v.check(c.Logging.MaxSizeMB >= 0, "logging.max_size_mb", "must not be negative")
+	v.check(c.Logging.MaxBackups >= 0, "logging.max_backups", "must not be negative")
+
+	if c.Metrics.Enabled {
+		v.checkPort(c.Metrics.Port, "metrics.port")
+		v.check(c.Metrics.Port != c.App.Port, "metrics.port", "must differ from app.port")
+	}
+
+	if c.Tracing.Enabled {
+		v.checkOneOf(strings.ToLower(c.Tracing.Exporter), "tracing.exporter", "", "otlp", "stdout", "file")
+		if strings.EqualFold(c.Tracing.Exporter, "file") {
+			v.check(c.Tracing.FilePath != "", "tracing.file_path", "is required by the file exporter")
+		}
+		v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be between 0 and 1")
+	}
+
+	c.RateLimit.validate(v)
+
+	v.checkOneOf(c.Meetings.ConflictPolicy, "meetings.conflict_policy", "", "warn", "reject")
+
+	v.check(c.Notifications.MaxAttempts >= 0, "notifications.max_attempts", "must not be negative")
+	v.check(c.Notifications.BatchSize >= 0, "notifications.batch_size", "must not be negative")
+	v.check(c.Events.MaxAttempts >= 0, "events.max_attempts", "must not be negative")
+	v.check(c.Events.BatchSize >= 0, "events.batch_size", "must not be negative")
+	v.check(c.Webhooks.MaxAttempts >= 0, "webhooks.max_attempts", "must not be negative")
+	v.check(c.Webhooks.BatchSize >= 0, "webhooks.batch_size", "must not be negative")
+}
+
+func (c *RateLimitConfig) validate(v *validator) {
+	checkLimit := func(limit RateLimit, field string) {
+		v.check(limit.RequestsPerSecond >= 0, field+".requests_per_second", "must not be negative")
+		v.check(limit.Burst >= 0, field+".burst", "must not be negative")
+	}
+
+	checkLimit(RateLimit{RequestsPerSecond: c.RequestsPerSecond, Burst: c.Burst}, "rate_limiting")
+	for _, route := range sortedKeys(c.Routes) {
+		checkLimit(c.Routes[route], fmt.Sprintf("rate_limiting.routes[%q]", route))
+	}
+	for _, userID := range sortedKeys(c.Users) {
+		checkLimit(c.Users[userID], fmt.Sprintf("rate_limiting.users[%q]", userID))
+	}
+	names := make(map[string]bool, len(c.APIKeys))
+	for i, key := range c.APIKeys {
+		field := fmt.Sprintf("rate_limiting.api_keys[%d]", i)
+		checkLimit(key.RateLimit, field)
+		v.check(key.Name != "", field+".name", "is required")
+		v.check(!names[key.Name], field+".name", "%q is used by another key", key.Name)
+		names[key.Name] = true
+	}
+}
+
+// Validate checks the PostgreSQL configuration and returns a
+// *ValidationError listing every problem
+func (c *PostgresConfig) Validate() error {
+	v := &validator{}
+	c.validate(v)
+	return v.err()
+}
+
+func (c *PostgresConfig) validate(v *validator) {
+	v.check(c.Host != "", "postgres.host", "is required")
+	v.checkPort(c.Port, "postgres.port")
+	v.check(c.User != "", "postgres.user", "is required")
+	v.check(c.DBName != "", "postgres.dbname", "is required")
+	v.checkOneOf(c.SSLMode, "postgres.sslmode", "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+
+	v.check(c.Pool.MaxOpenConns > 0, "postgres.pool.max_open_conns", "must be positive")
+	v.check(c.Pool.MaxIdleConns >= 0, "postgres.pool.max_idle_conns", "must not be negative")
+	v.check(c.Pool.MaxIdleConns <= c.Pool.MaxOpenConns, "postgres.pool.max_idle_conns", "must not exceed max_open_conns")
+	v.checkNotNegative(c.Pool.ConnMaxLifetime, "postgres.pool.conn_max_lifetime")
+	v.checkNotNegative(c.Pool.ConnMaxIdleTime, "postgres.pool.conn_max_idle_time")
+}
+
+// Validate checks the Redis configuration and returns a *ValidationError
+// listing every problem
+func (c *RedisConfig) Validate() error {
+	v := &validator{}
+	c.validate(v)
+	return v.err()
+}
+
+func (c *RedisConfig) validate(v *validator) {
+	v.check(c.Host != "", "redis.host", "is required")
+	v.checkPort(c.Port, "redis.port")
+	v.check(c.DB >= 0, "redis.db", "must not be negative")
+
+	v.check(c.Pool.MaxActive > 0, "redis.pool.max_active", "must be positive")
+	v.check(c.Pool.MaxIdle >= 0, "redis.pool.max_idle", "must not be negative")
+	v.check(c.Pool.MaxIdle <= c.Pool.MaxActive, "redis.pool.max_idle", "must not exceed max_active")
+	v.checkNotNegative(c.Pool.IdleTimeout, "redis.pool.idle_timeout")
+	v.checkNotNegative(c.Timeouts.Dial, "redis.timeouts.dial")
+	v.checkNotNegative(c.Timeouts.Read, "redis.timeouts.read")
+	v.checkNotNegative(c.Timeouts.Write, "redis.timeouts.write")
+
+	for _, prefix := range sortedKeys(c.TTL) {
+		v.check(c.TTL[prefix] >= 0, "redis.ttl."+prefix, "must not be negative")
+	}
+}
+
+// sortedKeys returns the keys of m in order, so that problems are reported
+// in the same order every time
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
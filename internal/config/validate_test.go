@@ -0,0 +1,150 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func validConfig() *Config {
+	return &Config{
+		App:     AppConfig{Port: 8080},
+		JWT:     JWTConfig{Secret: strings.Repeat("s", minSecretLength), AccessTokenExpiry: 15 * time.Minute, RefreshTokenExpiry: 7 * 24 * time.Hour},
+		Logging: LoggingConfig{Level: "info", Format: "json"},
+		Metrics: MetricsConfig{Enabled: true, Port: 9090},
+		Tracing: TracingConfig{Enabled: true, Exporter: "otlp", SampleRatio: 0.5},
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(cfg *Config)
+		want   []string
+	}{
+		{"valid", func(cfg *Config) {}, nil},
+		{"port", func(cfg *Config) { cfg.App.Port = 70000 }, []string{"app.port: must be a port between 1 and 65535, got 70000"}},
+		{"negative timeout", func(cfg *Config) { cfg.Server.ReadTimeout = -time.Second }, []string{"server.read_timeout: must not be negative"}},
+		{"short secret", func(cfg *Config) { cfg.JWT.Secret = "secret" }, []string{"jwt.secret: must be at least 32 characters, got 6; set JWT_SECRET"}},
+		{
+			"token expiries",
+			func(cfg *Config) { cfg.JWT.AccessTokenExpiry = 0; cfg.JWT.RefreshTokenExpiry = -time.Minute },
+			[]string{"jwt.access_token_expiry: must be positive", "jwt.refresh_token_expiry: must not be shorter than the access token expiry"},
+		},
+		{"short signing key", func(cfg *Config) { cfg.Storage.Local.SigningKey = "key" }, []string{"storage.local.signing_key: must be at least 32 characters when set"}},
+		{"storage driver", func(cfg *Config) { cfg.Storage.Driver = "gcs" }, []string{`storage.driver: must be one of , local, s3, got "gcs"`}},
+		{"log level", func(cfg *Config) { cfg.Logging.Level = "verbose" }, []string{`logging.level: must be debug, info, warn or error, got "verbose"`}},
+		{"log format in any case", func(cfg *Config) { cfg.Logging.Format = "TEXT" }, nil},
+		{"metrics on the app port", func(cfg *Config) { cfg.Metrics.Port = 8080 }, []string{"metrics.port: must differ from app.port"}},
+		{"metrics disabled", func(cfg *Config) { cfg.Metrics = MetricsConfig{Port: 8080} }, nil},
+		{
+			"file exporter without a path",
+			func(cfg *Config) { cfg.Tracing.Exporter = "File"; cfg.Tracing.SampleRatio = 2 },
+			[]string{"tracing.file_path: is required by the file exporter", "tracing.sample_ratio: must be between 0 and 1"},
+		},
+		{"tracing disabled", func(cfg *Config) { cfg.Tracing = TracingConfig{Exporter: "jaeger"} }, nil},
+		{
+			"rate limits",
+			func(cfg *Config) {
+				cfg.RateLimit = RateLimitConfig{
+					RequestsPerSecond: -1,
+					Routes:            map[string]RateLimit{"POST /b": {Burst: -1}, "POST /a": {RequestsPerSecond: -1}},
+					APIKeys:           []APIKeyRateLimit{{Name: "partner"}, {Name: "partner"}, {}},
+				}
+			},
+			[]string{
+				"rate_limiting.requests_per_second: must not be negative",
+				`rate_limiting.routes["POST /a"].requests_per_second: must not be negative`,
+				`rate_limiting.routes["POST /b"].burst: must not be negative`,
+				`rate_limiting.api_keys[1].name: "partner" is used by another key`,
+				"rate_limiting.api_keys[2].name: is required",
+			},
+		},
+		{"conflict policy", func(cfg *Config) { cfg.Meetings.ConflictPolicy = "ignore" }, []string{`meetings.conflict_policy: must be one of , warn, reject, got "ignore"`}},
+		{"batch size", func(cfg *Config) { cfg.Webhooks.BatchSize = -1 }, []string{"webhooks.batch_size: must not be negative"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.change(cfg)
+
+			err := cfg.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate: err = %v, want a *ValidationError", err)
+			}
+			if !reflect.DeepEqual(validationErr.Problems, tt.want) {
+				t.Fatalf("problems = %q, want %q", validationErr.Problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreConfigValidate(t *testing.T) {
+	postgres := func() *PostgresConfig {
+		return &PostgresConfig{Host: "localhost", Port: 5432, User: "evtaarpro", DBName: "evtaarpro", SSLMode: "disable", Pool: PoolConfig{MaxOpenConns: 25, MaxIdleConns: 5}}
+	}
+	redis := func() *RedisConfig {
+		return &RedisConfig{Host: "localhost", Port: 6379, Pool: RedisPoolConfig{MaxActive: 100, MaxIdle: 20}, TTL: map[string]int{"cache": 3600}}
+	}
+
+	tests := []struct {
+		name   string
+		config interface{ Validate() error }
+		want   []string
+	}{
+		{"postgres", postgres(), nil},
+		{"redis", redis(), nil},
+		{
+			"postgres pool",
+			func() *PostgresConfig { c := postgres(); c.SSLMode = "on"; c.Pool.MaxIdleConns = 30; return c }(),
+			[]string{
+				`postgres.sslmode: must be one of disable, allow, prefer, require, verify-ca, verify-full, got "on"`,
+				"postgres.pool.max_idle_conns: must not exceed max_open_conns",
+			},
+		},
+		{
+			"postgres missing",
+			&PostgresConfig{SSLMode: "disable", Pool: PoolConfig{MaxOpenConns: 1}},
+			[]string{"postgres.host: is required", "postgres.port: must be a port between 1 and 65535, got 0", "postgres.user: is required", "postgres.dbname: is required"},
+		},
+		{
+			"redis ttl",
+			func() *RedisConfig { c := redis(); c.TTL["session"] = -1; c.TTL["otp"] = -1; return c }(),
+			[]string{"redis.ttl.otp: must not be negative", "redis.ttl.session: must not be negative"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || !reflect.DeepEqual(validationErr.Problems, tt.want) {
+				t.Fatalf("Validate: err = %v, want problems %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := &ValidationError{Problems: []string{"app.port: must be a port", "jwt.secret: is required"}}
+	want := "invalid configuration:\n  - app.port: must be a port\n  - jwt.secret: is required"
+	if err.Error() != want {
+		t.Fatalf("Error = %q, want %q", err.Error(), want)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	_ "github.com/lib/pq"
 	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/tracing"
 )
 
 // PostgresStore wraps a PostgreSQL database connection
@@ -19,7 +20,7 @@ type PostgresStore struct {
 func NewPostgresStore(cfg *config.PostgresConfig) (*PostgresStore, error) {
 	dsn := cfg.GetDSN()
 
-	db, err := sql.Open("postgres", dsn)
+	db, err := tracing.OpenPostgres(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -80,17 +81,38 @@ func (s *PostgresStore) WithTransaction(ctx context.Context, fn func(*sql.Tx) er
 
 	defer func() {
 		if p := recover(); p != nil {
-			_ = tx.Rollback()
+			Rollback(ctx, tx)
 			panic(p)
 		}
 	}()
 
 	if err := fn(tx); err != nil {
-		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("tx error: %v, rollback error: %v", err, rbErr)
-		}
+		Rollback(ctx, tx)
 		return err
 	}
 
 	return tx.Commit()
 }
+
+// WithinTransaction runs fn with a context carrying a transaction, which is
+// committed when fn succeeds. Inside a transaction already, fn joins it.
+// Functions registered with AfterCommit run after the commit.
+func (s *PostgresStore) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	state := &txState{}
+	err := s.WithTransaction(ctx, func(tx *sql.Tx) error {
+		state.tx = tx
+		return fn(context.WithValue(ctx, txKey{}, state))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range state.afterCommit {
+		hook(ctx)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/tracing"
 )
 
 // RedisStore wraps a Redis client
@@ -30,6 +31,8 @@ func NewRedisStore(cfg *config.RedisConfig) (*RedisStore, error) {
 		PoolTimeout:  cfg.Pool.IdleTimeout,
 	})
 
+	client.AddHook(tracing.RedisHook{})
+
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -65,6 +68,11 @@ func (s *RedisStore) Health(ctx context.Context) error {
 	return nil
 }
 
+// PoolStats returns connection pool statistics
+func (s *RedisStore) PoolStats() *redis.PoolStats {
+	return s.Client.PoolStats()
+}
+
 // GetKey returns a prefixed key
 func (s *RedisStore) GetKey(prefix, key string) string {
 	if p, ok := s.Prefixes[prefix]; ok {
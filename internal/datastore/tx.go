@@ -0,0 +1,69 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+)
+
+// Executor runs queries on the connection pool or inside a transaction
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Transactor runs functions in a database transaction. Repositories that
+// query through Conn take part in it.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+// txState is the transaction carried by a context and the functions to run
+// once it commits
+type txState struct {
+	tx          *sql.Tx
+	afterCommit []func(ctx context.Context)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return nil, false
+	}
+	return state.tx, true
+}
+
+// AfterCommit runs fn once the transaction carried by ctx commits, or right
+// away outside a transaction. Functions of a rolled back transaction never
+// run. Use it for side effects that must not be seen before the data they
+// describe, e.g. cache invalidation.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn(ctx)
+		return
+	}
+	state.afterCommit = append(state.afterCommit, fn)
+}
+
+// Conn returns the transaction carried by ctx, or db outside a transaction
+func Conn(ctx context.Context, db *sql.DB) Executor {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return db
+}
+
+// Rollback rolls back tx unless it has been committed, logging failures
+// through the context logger; defer it right after beginning tx
+func Rollback(ctx context.Context, tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		logging.FromContext(ctx).Error("failed to roll back transaction", "error", err)
+	}
+}
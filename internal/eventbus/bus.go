@@ -0,0 +1,55 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Handler handles an event. Events are delivered at least once, so handlers
+// must be idempotent, e.g. by deriving what they create from the event ID.
+type Handler func(ctx context.Context, envelope *Envelope) error
+
+type subscription struct {
+	subscriber string
+	handler    Handler
+}
+
+// Bus routes events to the handlers subscribed to their name
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions map[string][]subscription
+}
+
+// NewBus creates a new Bus
+func NewBus() *Bus {
+	return &Bus{
+		subscriptions: make(map[string][]subscription),
+	}
+}
+
+// Subscribe registers handler for events named name. subscriber names the
+// handler in the record of handled events, so it must be unique per event
+// and stable across releases; renaming it makes pending events reach the
+// handler again.
+func (b *Bus) Subscribe(name, subscriber string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, existing := range b.subscriptions[name] {
+		if existing.subscriber == subscriber {
+			panic(fmt.Sprintf("eventbus: %s is already subscribed to %s", subscriber, name))
+		}
+	}
+	b.subscriptions[name] = append(b.subscriptions[name], subscription{
+		subscriber: subscriber,
+		handler:    handler,
+	})
+}
+
+func (b *Bus) subscribers(name string) []subscription {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return append([]subscription(nil), b.subscriptions[name]...)
+}
@@ -0,0 +1,26 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event is a domain event. EventName identifies its type, e.g.
+// meetings.meeting_cancelled; the event itself is stored as JSON.
+type Event interface {
+	EventName() string
+}
+
+// Envelope is an event as stored in the outbox
+type Envelope struct {
+	ID         string
+	Name       string
+	Payload    json.RawMessage
+	OccurredAt time.Time
+	Attempts   int // failed dispatch attempts so far
+}
+
+// Decode unmarshals the payload into event
+func (e *Envelope) Decode(event interface{}) error {
+	return json.Unmarshal(e.Payload, event)
+}
@@ -0,0 +1,56 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+)
+
+// ErrNoTransaction is returned when events are published outside a
+// transaction
+var ErrNoTransaction = errors.New("events must be published inside a transaction")
+
+// Publisher records domain events for dispatch
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+}
+
+// Outbox records events in the outbox table
+type Outbox struct{}
+
+// NewOutbox creates a new Outbox
+func NewOutbox() *Outbox {
+	return &Outbox{}
+}
+
+// Publish records events in the transaction carried by ctx, so they are
+// stored exactly when the change they describe is committed. The relay
+// dispatches them afterwards.
+func (o *Outbox) Publish(ctx context.Context, events ...Event) error {
+	tx, ok := datastore.TxFromContext(ctx)
+	if !ok {
+		return ErrNoTransaction
+	}
+
+	query := `
+		INSERT INTO event_outbox (id, name, payload, occurred_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`
+	now := time.Now()
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", event.EventName(), err)
+		}
+		if _, err := tx.ExecContext(ctx, query, uuid.New().String(), event.EventName(), string(payload), now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
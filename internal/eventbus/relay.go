@@ -0,0 +1,243 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+)
+
+// claimLease is how long claimed events stay hidden from other relays. A
+// relay that dies mid-batch leaves its events to be retried after it.
+const claimLease = 5 * time.Minute
+
+// RetryPolicy controls how failed events are retried
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Delay returns the wait after the given number of failed attempts; it
+// doubles with every attempt up to MaxBackoff, if set
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempts && delay > 0 && delay <= math.MaxInt64/2; i++ {
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+// Relay dispatches the events of the outbox to their subscribers
+type Relay struct {
+	db        *sql.DB
+	bus       *Bus
+	retry     RetryPolicy
+	batchSize int
+}
+
+// NewRelay creates a new Relay
+func NewRelay(db *sql.DB, bus *Bus, retry RetryPolicy, batchSize int) *Relay {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = 1
+	}
+
+	return &Relay{
+		db:        db,
+		bus:       bus,
+		retry:     retry,
+		batchSize: batchSize,
+	}
+}
+
+// RelayOutput represents the outcome of a relay run
+type RelayOutput struct {
+	Dispatched int
+	Retrying   int
+	Failed     int
+}
+
+// Execute dispatches due events in the order they occurred. Each subscriber
+// that handles an event is recorded, so a retry only reaches the
+// subscribers that failed. Events that keep failing are marked failed after
+// MaxAttempts.
+func (r *Relay) Execute(ctx context.Context) (*RelayOutput, error) {
+	envelopes, err := r.claim(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	output := &RelayOutput{}
+	for _, envelope := range envelopes {
+		if ctx.Err() != nil {
+			break
+		}
+
+		dispatchErr := r.dispatch(ctx, envelope)
+		status, err := r.finish(ctx, envelope, dispatchErr, time.Now())
+		if err != nil {
+			logging.FromContext(ctx).Error("failed to update outbox event", "event_id", envelope.ID, "error", err)
+			continue
+		}
+
+		switch status {
+		case "dispatched":
+			output.Dispatched++
+		case "failed":
+			output.Failed++
+			logging.FromContext(ctx).Warn("giving up on outbox event", "event_id", envelope.ID, "event", envelope.Name, "error", dispatchErr)
+		default:
+			output.Retrying++
+		}
+	}
+
+	return output, nil
+}
+
+// claim takes due events for the lease, skipping events other relays are
+// claiming at the same time
+func (r *Relay) claim(ctx context.Context, now time.Time) ([]*Envelope, error) {
+	query := `
+		UPDATE event_outbox
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM event_outbox
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY occurred_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, name, payload, occurred_at, attempts
+	`
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(claimLease), r.batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var envelopes []*Envelope
+	for rows.Next() {
+		envelope := &Envelope{}
+		var payload []byte
+		if err := rows.Scan(&envelope.ID, &envelope.Name, &payload, &envelope.OccurredAt, &envelope.Attempts); err != nil {
+			return nil, err
+		}
+		envelope.Payload = payload
+		envelopes = append(envelopes, envelope)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(envelopes, func(i, j int) bool {
+		return envelopes[i].OccurredAt.Before(envelopes[j].OccurredAt)
+	})
+	return envelopes, nil
+}
+
+// dispatch hands the event to every subscriber that has not handled it yet.
+// A failing subscriber does not keep the others from handling it.
+func (r *Relay) dispatch(ctx context.Context, envelope *Envelope) error {
+	subscriptions := r.bus.subscribers(envelope.Name)
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	handled, err := r.handledBy(ctx, envelope.ID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sub := range subscriptions {
+		if handled[sub.subscriber] {
+			continue
+		}
+		if err := handle(ctx, sub, envelope); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.subscriber, err))
+			continue
+		}
+
+		query := `
+			INSERT INTO event_consumptions (event_id, subscriber, handled_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (event_id, subscriber) DO NOTHING
+		`
+		if _, err := r.db.ExecContext(ctx, query, envelope.ID, sub.subscriber, time.Now()); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.subscriber, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// handle runs a handler, turning a panic into an error so one subscriber
+// cannot stop the relay
+func handle(ctx context.Context, sub subscription, envelope *Envelope) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return sub.handler(ctx, envelope)
+}
+
+func (r *Relay) handledBy(ctx context.Context, eventID string) (map[string]bool, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT subscriber FROM event_consumptions WHERE event_id = $1`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	handled := make(map[string]bool)
+	for rows.Next() {
+		var subscriber string
+		if err := rows.Scan(&subscriber); err != nil {
+			return nil, err
+		}
+		handled[subscriber] = true
+	}
+	return handled, rows.Err()
+}
+
+// finish records the outcome of dispatching the event and returns its new
+// status
+func (r *Relay) finish(ctx context.Context, envelope *Envelope, dispatchErr error, now time.Time) (string, error) {
+	if dispatchErr == nil {
+		query := `
+			UPDATE event_outbox
+			SET status = 'dispatched', dispatched_at = $2, last_error = NULL
+			WHERE id = $1
+		`
+		_, err := r.db.ExecContext(ctx, query, envelope.ID, now)
+		return "dispatched", err
+	}
+
+	attempts := envelope.Attempts + 1
+	status := "pending"
+	if attempts >= r.retry.MaxAttempts {
+		status = "failed"
+	}
+
+	query := `
+		UPDATE event_outbox
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5
+		WHERE id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query, envelope.ID, status, attempts, now.Add(r.retry.Delay(attempts)), dispatchErr.Error())
+	return status, err
+}
@@ -0,0 +1,99 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		attempts int
+		want     time.Duration
+	}{
+		{"first failure", policy, 1, time.Second},
+		{"second failure", policy, 2, 2 * time.Second},
+		{"fourth failure", policy, 4, 8 * time.Second},
+		{"capped", policy, 5, 10 * time.Second},
+		{"long after the cap", policy, 60, 10 * time.Second},
+		{"uncapped does not overflow", RetryPolicy{Backoff: time.Second}, 100, time.Second << 33},
+		{"no attempts yet", policy, 0, time.Second},
+		{"no cap", RetryPolicy{Backoff: time.Second}, 4, 8 * time.Second},
+		{"backoff above the cap", RetryPolicy{Backoff: time.Minute, MaxBackoff: 10 * time.Second}, 1, 10 * time.Second},
+		{"no backoff", RetryPolicy{MaxBackoff: time.Minute}, 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Delay(tt.attempts); got != tt.want {
+				t.Fatalf("Delay(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRelayDefaults(t *testing.T) {
+	relay := NewRelay(nil, NewBus(), RetryPolicy{}, 0)
+	if relay.batchSize != 100 || relay.retry.MaxAttempts != 1 {
+		t.Fatalf("batch size %d, max attempts %d, want 100 and 1", relay.batchSize, relay.retry.MaxAttempts)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	errHandler := errors.New("handler failed")
+
+	tests := []struct {
+		name    string
+		handler Handler
+		wantErr string
+	}{
+		{"handled", func(ctx context.Context, envelope *Envelope) error { return nil }, ""},
+		{"failed", func(ctx context.Context, envelope *Envelope) error { return errHandler }, "handler failed"},
+		{"panicked", func(ctx context.Context, envelope *Envelope) error { panic("nil map") }, "panic: nil map"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handle(context.Background(), subscription{subscriber: "test", handler: tt.handler}, &Envelope{ID: "e-1"})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("handle: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("handle: err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBusSubscribe(t *testing.T) {
+	bus := NewBus()
+	noop := func(ctx context.Context, envelope *Envelope) error { return nil }
+	bus.Subscribe("meetings.meeting_cancelled", "notifications", noop)
+	bus.Subscribe("meetings.meeting_cancelled", "webhooks", noop)
+	bus.Subscribe("meetings.meeting_created", "notifications", noop)
+
+	if got := len(bus.subscribers("meetings.meeting_cancelled")); got != 2 {
+		t.Fatalf("%d subscribers, want 2", got)
+	}
+	if got := len(bus.subscribers("payroll.generated")); got != 0 {
+		t.Fatalf("%d subscribers of an unknown event, want 0", got)
+	}
+
+	// A subscriber name identifies the handler in the record of handled
+	// events, so it cannot be reused for the same event
+	defer func() {
+		if recover() == nil {
+			t.Fatal("duplicate subscriber did not panic")
+		}
+	}()
+	bus.Subscribe("meetings.meeting_cancelled", "webhooks", noop)
+}
@@ -1,6 +1,7 @@
 package httpx
 
 import (
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -9,6 +10,7 @@ import (
 	"github.com/manab-pr/evtaarpro/internal/config"
 	"github.com/manab-pr/evtaarpro/internal/datastore"
 	"github.com/manab-pr/evtaarpro/internal/middleware"
+	"github.com/manab-pr/evtaarpro/internal/realtime"
 	"github.com/manab-pr/evtaarpro/internal/response"
 
 	// Import modules
@@ -18,16 +20,28 @@ import (
 	crmModule "github.com/manab-pr/evtaarpro/modules/crm"
 	payrollModule "github.com/manab-pr/evtaarpro/modules/payroll"
 	notificationsModule "github.com/manab-pr/evtaarpro/modules/notifications"
+	webhooksModule "github.com/manab-pr/evtaarpro/modules/webhooks"
+	auditModule "github.com/manab-pr/evtaarpro/modules/audit"
 )
 
-// NewRouter creates and configures the application router
-func NewRouter(cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) *gin.Engine {
+// NewRouter creates and configures the application router with the
+// startup configuration of live; CORS and rate limits follow reloads.
+func NewRouter(live *config.Live, logger *slog.Logger, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore, gateway *realtime.Gateway) *gin.Engine {
+	cfg := live.Current()
 	router := gin.New()
 
 	// Global middleware
+	// Tracing comes first so the request logger can use the trace ID, and
+	// the request logger before recovery so panics are logged with the
+	// request
+	router.Use(middleware.Tracing())
+	router.Use(middleware.RequestLogger(logger))
+	// Metrics come before recovery so recovered panics count as 500s
+	router.Use(middleware.Metrics())
 	router.Use(middleware.Recovery())
-	router.Use(middleware.RequestLogger())
-	router.Use(middleware.CORS(cfg.CORS))
+	router.Use(middleware.CORS(live))
+	router.Use(middleware.Timezone())
+	router.Use(middleware.RateLimit(live, cfg.JWT.Secret, redisStore))
 
 	// Health check endpoint
 	router.GET("/health", healthCheckHandler(pgStore, redisStore))
@@ -36,6 +50,9 @@ func NewRouter(cfg *config.Config, pgStore *datastore.PostgresStore, redisStore
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Realtime updates
+	router.GET("/ws", middleware.StreamAuth(cfg.JWT.Secret), gateway.ServeWS)
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
@@ -45,7 +62,9 @@ func NewRouter(cfg *config.Config, pgStore *datastore.PostgresStore, redisStore
 		registerMeetingRoutes(v1, cfg, pgStore, redisStore)
 		registerCRMRoutes(v1, cfg, pgStore, redisStore)
 		registerPayrollRoutes(v1, cfg, pgStore, redisStore)
-		registerNotificationRoutes(v1, cfg, pgStore, redisStore)
+		registerNotificationRoutes(v1, cfg, pgStore, redisStore, gateway)
+		registerWebhookRoutes(v1, cfg, pgStore)
+		registerAuditRoutes(v1, cfg, pgStore)
 	}
 
 	// 404 handler
@@ -110,6 +129,14 @@ func registerPayrollRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *dat
 	payrollModule.RegisterRoutes(rg, cfg, pgStore, redisStore)
 }
 
-func registerNotificationRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
-	notificationsModule.RegisterRoutes(rg, cfg, pgStore, redisStore)
+func registerNotificationRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore, gateway *realtime.Gateway) {
+	notificationsModule.RegisterRoutes(rg, cfg, pgStore, redisStore, gateway)
+}
+
+func registerWebhookRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore) {
+	webhooksModule.RegisterRoutes(rg, cfg, pgStore)
+}
+
+func registerAuditRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore) {
+	auditModule.RegisterRoutes(rg, cfg, pgStore)
 }
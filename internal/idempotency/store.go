@@ -0,0 +1,190 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrInProgress is returned when the first request with a key has not
+	// completed yet
+	ErrInProgress = errors.New("a request with this idempotency key is in progress")
+	// ErrFingerprintMismatch is returned when a key is reused for a
+	// different request
+	ErrFingerprintMismatch = errors.New("idempotency key was used for a different request")
+	// ErrClaimLost is returned by Complete when the key is no longer held
+	// by the claim, e.g. after the lock timed out and a retry claimed it
+	ErrClaimLost = errors.New("idempotency key is no longer held by this request")
+)
+
+// Record is what is stored under an idempotency key: the fingerprint of
+// the first request and, once it completed, its response
+type Record struct {
+	Fingerprint string      `json:"fingerprint"`
+	Completed   bool        `json:"completed"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+
+	// Lock identifies the request holding the key while it runs
+	Lock string `json:"lock,omitempty"`
+}
+
+const (
+	// defaultTTL is how long responses are kept without an idempotency TTL
+	// in the Redis config
+	defaultTTL = 24 * time.Hour
+	// lockTimeout bounds how long a request holds its key, after which a
+	// retry may run it again. It outlasts the server write timeout.
+	lockTimeout = time.Minute
+	// claimAttempts bounds how often Begin tries to claim a key that other
+	// requests release between its reads
+	claimAttempts = 3
+)
+
+// Store keeps idempotency records in Redis
+type Store struct {
+	redis *datastore.RedisStore
+	ttl   time.Duration
+}
+
+// NewStore creates a Store keeping responses for the idempotency TTL of the
+// Redis config
+func NewStore(redisStore *datastore.RedisStore) *Store {
+	ttl := redisStore.GetTTL("idempotency")
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &Store{
+		redis: redisStore,
+		ttl:   ttl,
+	}
+}
+
+// Begin claims key for a request with fingerprint. It returns the claim,
+// to be passed to Complete or Release, or, if the key was used before, the
+// stored response. The latter fails with ErrInProgress while the first
+// request runs, and with ErrFingerprintMismatch for a different request.
+func (s *Store) Begin(ctx context.Context, key, fingerprint string) (claim *Record, stored *Record, err error) {
+	claim = &Record{
+		Fingerprint: fingerprint,
+		CreatedAt:   time.Now().UTC(),
+		Lock:        uuid.New().String(),
+	}
+	data, err := json.Marshal(claim)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	redisKey := s.redis.GetKey("idempotency", key)
+	for attempt := 0; attempt < claimAttempts; attempt++ {
+		acquired, err := s.redis.Client.SetNX(ctx, redisKey, data, lockTimeout).Result()
+		if err != nil {
+			return nil, nil, err
+		}
+		if acquired {
+			return claim, nil, nil
+		}
+
+		raw, err := s.redis.Client.Get(ctx, redisKey).Bytes()
+		if errors.Is(err, redis.Nil) {
+			// The first request released the key meanwhile; claim it again
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		record, err := decodeStored(raw, fingerprint)
+		return nil, record, err
+	}
+
+	// Other requests keep claiming and releasing the key
+	return nil, nil, ErrInProgress
+}
+
+// decodeStored decodes the record of a key claimed before by a request
+// with fingerprint
+func decodeStored(raw []byte, fingerprint string) (*Record, error) {
+	stored := &Record{}
+	if err := json.Unmarshal(raw, stored); err != nil {
+		return nil, err
+	}
+	switch {
+	case stored.Fingerprint != fingerprint:
+		return nil, ErrFingerprintMismatch
+	case !stored.Completed:
+		return nil, ErrInProgress
+	}
+	return stored, nil
+}
+
+// Complete stores the response of the request holding claim. It fails with
+// ErrClaimLost, leaving the key alone, when the claim no longer holds it.
+func (s *Store) Complete(ctx context.Context, key string, claim *Record, status int, header http.Header, body []byte) error {
+	record := &Record{
+		Fingerprint: claim.Fingerprint,
+		Completed:   true,
+		Status:      status,
+		Header:      header,
+		Body:        body,
+		CreatedAt:   claim.CreatedAt,
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	stored, err := completeScript.Run(ctx, s.redis.Client, []string{s.redis.GetKey("idempotency", key)},
+		claim.Lock, claim.Fingerprint, claim.CreatedAt.Format(time.RFC3339Nano), data, s.ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if stored == 0 {
+		return ErrClaimLost
+	}
+	return nil
+}
+
+// completeScript replaces KEYS[1] with ARGV[4], kept for ARGV[5]
+// milliseconds, if it is still the claim locked by ARGV[1] with fingerprint
+// ARGV[2] and creation time ARGV[3]
+var completeScript = redis.NewScript(`
+local raw = redis.call('GET', KEYS[1])
+if not raw then
+	return 0
+end
+local record = cjson.decode(raw)
+if record.completed or record.lock ~= ARGV[1] or record.fingerprint ~= ARGV[2] or record.created_at ~= ARGV[3] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[4], 'PX', ARGV[5])
+return 1
+`)
+
+// Release frees the key of a request that did not complete, e.g. one that
+// failed with a server error, so that a retry can run it again. A key
+// claimed by another request meanwhile is left alone.
+func (s *Store) Release(ctx context.Context, key string, claim *Record) error {
+	return releaseScript.Run(ctx, s.redis.Client, []string{s.redis.GetKey("idempotency", key)}, claim.Lock).Err()
+}
+
+// releaseScript deletes KEYS[1] if it is still locked by ARGV[1]
+var releaseScript = redis.NewScript(`
+local raw = redis.call('GET', KEYS[1])
+if not raw then
+	return 0
+end
+local record = cjson.decode(raw)
+if record.completed or record.lock ~= ARGV[1] then
+	return 0
+end
+return redis.call('DEL', KEYS[1])
+`)
@@ -0,0 +1,221 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/redis/go-redis/v9"
+)
+
+// scriptedRedis answers SETNX and GET from queues instead of a server
+type scriptedRedis struct {
+	setnx []bool
+	get   []func(cmd *redis.StringCmd)
+	calls []string
+}
+
+func (r *scriptedRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no redis in tests")
+	}
+}
+
+func (r *scriptedRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		r.calls = append(r.calls, cmd.Name())
+		switch cmd := cmd.(type) {
+		case *redis.BoolCmd:
+			acquired := false
+			if len(r.setnx) > 0 {
+				acquired, r.setnx = r.setnx[0], r.setnx[1:]
+			}
+			cmd.SetVal(acquired)
+		case *redis.StringCmd:
+			if len(r.get) == 0 {
+				cmd.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			answer := r.get[0]
+			r.get = r.get[1:]
+			answer(cmd)
+			return cmd.Err()
+		}
+		return nil
+	}
+}
+
+func (r *scriptedRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func newScriptedStore(script *scriptedRedis) *Store {
+	client := redis.NewClient(&redis.Options{Addr: "redis.invalid:6379"})
+	client.AddHook(script)
+	return &Store{redis: &datastore.RedisStore{Client: client}, ttl: defaultTTL}
+}
+
+func storedRecord(t *testing.T, record *Record) func(cmd *redis.StringCmd) {
+	t.Helper()
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("marshal record: %v", err)
+	}
+	return func(cmd *redis.StringCmd) { cmd.SetVal(string(data)) }
+}
+
+func TestStoreBegin(t *testing.T) {
+	completed := &Record{Fingerprint: "body", Completed: true, Status: 201, Body: []byte(`{"id":"1"}`)}
+
+	tests := []struct {
+		name       string
+		script     *scriptedRedis
+		wantClaim  bool
+		wantStored bool
+		wantErr    error
+		wantCalls  int
+	}{
+		{
+			name:      "free key",
+			script:    &scriptedRedis{setnx: []bool{true}},
+			wantClaim: true,
+			wantCalls: 1,
+		},
+		{
+			name:      "released between the reads",
+			script:    &scriptedRedis{setnx: []bool{false, true}},
+			wantClaim: true,
+			wantCalls: 3,
+		},
+		{
+			name:      "released on every read",
+			script:    &scriptedRedis{},
+			wantErr:   ErrInProgress,
+			wantCalls: 2 * claimAttempts,
+		},
+		{
+			name:       "completed",
+			script:     &scriptedRedis{get: []func(*redis.StringCmd){storedRecord(t, completed)}},
+			wantStored: true,
+			wantCalls:  2,
+		},
+		{
+			name:      "running",
+			script:    &scriptedRedis{get: []func(*redis.StringCmd){storedRecord(t, &Record{Fingerprint: "body"})}},
+			wantErr:   ErrInProgress,
+			wantCalls: 2,
+		},
+		{
+			name:      "other body",
+			script:    &scriptedRedis{get: []func(*redis.StringCmd){storedRecord(t, &Record{Fingerprint: "other", Completed: true})}},
+			wantErr:   ErrFingerprintMismatch,
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim, stored, err := newScriptedStore(tt.script).Begin(context.Background(), "user:POST /customers:key", "body")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Begin: err = %v, want %v", err, tt.wantErr)
+			}
+			if (claim != nil) != tt.wantClaim || (stored != nil) != tt.wantStored {
+				t.Fatalf("Begin = (%+v, %+v), want claim %v, stored %v", claim, stored, tt.wantClaim, tt.wantStored)
+			}
+			if claim != nil && (claim.Lock == "" || claim.Fingerprint != "body") {
+				t.Fatalf("claim = %+v", claim)
+			}
+			if stored != nil && (stored.Status != completed.Status || string(stored.Body) != string(completed.Body)) {
+				t.Fatalf("stored = %+v, want %+v", stored, completed)
+			}
+			if len(tt.script.calls) != tt.wantCalls {
+				t.Fatalf("redis calls = %v, want %d", tt.script.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestStoreComplete(t *testing.T) {
+	const key = "user:POST /customers:key"
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	store := NewStore(&datastore.RedisStore{Client: client})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// steal takes the key from the claim before it completes
+		steal   func(t *testing.T, claim *Record)
+		wantErr error
+	}{
+		{"held", nil, nil},
+		{
+			"released",
+			func(t *testing.T, claim *Record) {
+				if err := store.Release(ctx, key, claim); err != nil {
+					t.Fatalf("Release: %v", err)
+				}
+			},
+			ErrClaimLost,
+		},
+		{
+			"claimed by a retry after the lock timed out",
+			func(t *testing.T, claim *Record) {
+				server.FastForward(lockTimeout)
+				if _, _, err := store.Begin(ctx, key, "body"); err != nil {
+					t.Fatalf("Begin retry: %v", err)
+				}
+			},
+			ErrClaimLost,
+		},
+		{
+			"claimed with another fingerprint",
+			func(t *testing.T, claim *Record) {
+				other := *claim
+				other.Fingerprint = "other"
+				data, _ := json.Marshal(other)
+				server.Set(key, string(data))
+			},
+			ErrClaimLost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.FlushAll()
+			claim, _, err := store.Begin(ctx, key, "body")
+			if err != nil || claim == nil {
+				t.Fatalf("Begin = %+v, %v", claim, err)
+			}
+			if tt.steal != nil {
+				tt.steal(t, claim)
+			}
+			before, _ := server.Get(key)
+
+			err = store.Complete(ctx, key, claim, http.StatusCreated, nil, []byte(`{"id":"1"}`))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Complete: err = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if after, _ := server.Get(key); after != before {
+					t.Fatalf("Complete overwrote the key: %s, want %s", after, before)
+				}
+				return
+			}
+			_, stored, err := store.Begin(ctx, key, "body")
+			if err != nil || stored == nil || stored.Status != http.StatusCreated || !stored.CreatedAt.Equal(claim.CreatedAt) {
+				t.Fatalf("Begin after Complete = %+v, %v", stored, err)
+			}
+			if ttl := server.TTL(key); ttl != defaultTTL {
+				t.Fatalf("TTL = %v, want %v", ttl, defaultTTL)
+			}
+		})
+	}
+}
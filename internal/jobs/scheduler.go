@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+)
+
+// Job is a unit of periodic background work
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs periodically. Each run is guarded by a
+// Redis lock held for the job interval, so with several server replicas a
+// job runs at most once per interval across the cluster.
+type Scheduler struct {
+	redis      *datastore.RedisStore
+	instanceID string
+	jobs       []Job
+	wg         sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler(redis *datastore.RedisStore) *Scheduler {
+	return &Scheduler{
+		redis:      redis,
+		instanceID: uuid.New().String(),
+	}
+}
+
+// Register adds a job to the scheduler. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) {
+	if job.Interval <= 0 {
+		job.Interval = time.Minute
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every registered job in its own goroutine until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+// Wait blocks until all job loops have exited
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	logger := slog.Default().With("job", job.Name)
+
+	key := s.redis.GetKey("job_lock", job.Name)
+	acquired, err := s.redis.Client.SetNX(ctx, key, s.instanceID, job.Interval).Result()
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("failed to acquire job lock", "error", err)
+		}
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, job.Interval)
+	defer cancel()
+	runCtx = logging.WithContext(runCtx, logger)
+
+	defer func() {
+		if p := recover(); p != nil {
+			logger.Error("job panicked", "panic", p)
+		}
+	}()
+
+	if err := job.Run(runCtx); err != nil {
+		logger.Error("job failed", "error", err)
+	}
+}
@@ -0,0 +1,113 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/redis/go-redis/v9"
+)
+
+// lockHook answers SETNX with acquired, or fails it with err, instead of
+// calling a server
+type lockHook struct {
+	acquired bool
+	err      error
+	ttl      time.Duration
+}
+
+func (h *lockHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no redis in tests")
+	}
+}
+
+func (h *lockHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		setnx, ok := cmd.(*redis.BoolCmd)
+		if !ok {
+			return next(ctx, cmd)
+		}
+		if h.err != nil {
+			setnx.SetErr(h.err)
+			return h.err
+		}
+		// SET key value EX seconds NX
+		if args := cmd.Args(); len(args) >= 5 {
+			if seconds, ok := args[4].(int64); ok {
+				h.ttl = time.Duration(seconds) * time.Second
+			}
+		}
+		setnx.SetVal(h.acquired)
+		return nil
+	}
+}
+
+func (h *lockHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestSchedulerRunOnce(t *testing.T) {
+	tests := []struct {
+		name     string
+		hook     *lockHook
+		run      func(ctx context.Context) error
+		wantRuns int
+	}{
+		{"lock acquired", &lockHook{acquired: true}, nil, 1},
+		{"held by another replica", &lockHook{acquired: false}, nil, 0},
+		{"redis down", &lockHook{err: errors.New("connection refused")}, nil, 0},
+		{"job fails", &lockHook{acquired: true}, func(ctx context.Context) error { return errors.New("boom") }, 1},
+		{"job panics", &lockHook{acquired: true}, func(ctx context.Context) error { panic("boom") }, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := redis.NewClient(&redis.Options{Addr: "redis.invalid:6379"})
+			client.AddHook(tt.hook)
+			scheduler := NewScheduler(&datastore.RedisStore{Client: client})
+
+			runs := 0
+			job := Job{
+				Name:     "meetings.reminders",
+				Interval: 2 * time.Minute,
+				Run: func(ctx context.Context) error {
+					runs++
+					if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > 2*time.Minute {
+						t.Errorf("job context deadline = %v, want within the interval", deadline)
+					}
+					if tt.run != nil {
+						return tt.run(ctx)
+					}
+					return nil
+				},
+			}
+
+			// A panic in the job must not escape runOnce
+			scheduler.runOnce(context.Background(), job)
+
+			if runs != tt.wantRuns {
+				t.Fatalf("job ran %d times, want %d", runs, tt.wantRuns)
+			}
+			if tt.hook.err == nil && tt.hook.ttl != job.Interval {
+				t.Fatalf("lock TTL = %v, want the job interval", tt.hook.ttl)
+			}
+		})
+	}
+}
+
+func TestSchedulerRegisterDefaultsInterval(t *testing.T) {
+	scheduler := NewScheduler(nil)
+	scheduler.Register(Job{Name: "a"})
+	scheduler.Register(Job{Name: "b", Interval: 5 * time.Second})
+
+	if got := scheduler.jobs[0].Interval; got != time.Minute {
+		t.Fatalf("default interval = %v, want 1m", got)
+	}
+	if got := scheduler.jobs[1].Interval; got != 5*time.Second {
+		t.Fatalf("interval = %v, want 5s", got)
+	}
+}
@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/manab-pr/evtaarpro/internal/config"
+)
+
+// level is the minimum level of the loggers created by New, which SetLevel
+// changes while they run
+var level = new(slog.LevelVar)
+
+// New creates the application logger from cfg. Output is "stdout",
+// "stderr" or a file path; files are rotated by size. The returned closer
+// releases the file.
+func New(cfg config.LoggingConfig) (*slog.Logger, io.Closer, error) {
+	if err := SetLevel(cfg.Level); err != nil {
+		return nil, nil, err
+	}
+
+	var out io.Writer
+	var closer io.Closer = nopCloser{}
+	switch cfg.Output {
+	case "", "stdout":
+		out = os.Stdout
+	case "stderr":
+		out = os.Stderr
+	default:
+		file, err := NewRotatingFile(cfg.Output, cfg.MaxSizeMB, cfg.MaxBackups)
+		if err != nil {
+			return nil, nil, err
+		}
+		out, closer = file, file
+	}
+
+	opts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "", "json":
+		handler = slog.NewJSONHandler(out, opts)
+	case "text":
+		handler = slog.NewTextHandler(out, opts)
+	default:
+		closer.Close()
+		return nil, nil, fmt.Errorf("unknown log format %q: use json or text", cfg.Format)
+	}
+
+	return slog.New(handler), closer, nil
+}
+
+// SetLevel changes the minimum level of the loggers created by New, e.g.
+// on a configuration reload
+func SetLevel(name string) error {
+	parsed, err := parseLevel(name)
+	if err != nil {
+		return err
+	}
+	level.Set(parsed)
+	return nil
+}
+
+func parseLevel(name string) (slog.Level, error) {
+	if name == "" {
+		return slog.LevelInfo, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q: use debug, info, warn or error", name)
+	}
+	return level, nil
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
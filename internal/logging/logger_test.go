@@ -0,0 +1,104 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manab-pr/evtaarpro/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	defer SetLevel("info")
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		cfg      config.LoggingConfig
+		wantFile string
+		wantLine string
+		wantErr  string
+	}{
+		{"json file", config.LoggingConfig{Level: "warn", Output: filepath.Join(dir, "app.json")}, "app.json", `"level":"WARN","msg":"kept","token":"[REDACTED]"`, ""},
+		{"text file", config.LoggingConfig{Level: "WARN", Format: "Text", Output: filepath.Join(dir, "logs", "app.log")}, "logs/app.log", `level=WARN msg=kept token=[REDACTED]`, ""},
+		{"stdout", config.LoggingConfig{}, "", "", ""},
+		{"unknown level", config.LoggingConfig{Level: "trace"}, "", "", `unknown log level "trace"`},
+		{"unknown format", config.LoggingConfig{Format: "xml", Output: filepath.Join(dir, "xml.log")}, "", "", `unknown log format "xml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, closer, err := New(tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New: err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			if tt.wantFile == "" {
+				closer.Close()
+				return
+			}
+
+			logger.Info("dropped below the level")
+			logger.Warn("kept", "token", "abc")
+			closer.Close()
+
+			data, err := os.ReadFile(filepath.Join(dir, tt.wantFile))
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			if strings.Contains(string(data), "dropped") || !strings.Contains(string(data), tt.wantLine) {
+				t.Fatalf("log = %q, want only a line with %q", data, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	defer SetLevel("info")
+
+	tests := []struct {
+		name    string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"debug", slog.LevelDebug, false},
+		{"ERROR", slog.LevelError, false},
+		{"", slog.LevelInfo, false},
+		{"loud", slog.LevelInfo, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level.Set(slog.LevelInfo)
+			if err := SetLevel(tt.name); (err != nil) != tt.wantErr {
+				t.Fatalf("SetLevel(%q): err = %v, want error %v", tt.name, err, tt.wantErr)
+			}
+			if got := level.Level(); got != tt.want {
+				t.Fatalf("level = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextLogger(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Fatal("FromContext without a logger did not return the default logger")
+	}
+
+	var buf strings.Builder
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	ctx := With(WithContext(context.Background(), logger), "request_id", "req-1")
+	FromContext(ctx).Info("handled")
+
+	if !strings.Contains(buf.String(), "msg=handled request_id=req-1") {
+		t.Fatalf("log = %q, want the request ID", buf.String())
+	}
+}
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// redacted replaces the values of sensitive attributes
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are matched against attribute keys, ignoring case, "_" and
+// "-", so "access_token" and "X-Api-Key" are caught too
+var sensitiveKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"authorization",
+	"cookie",
+	"apikey",
+	"bankaccount",
+	"accountnumber",
+	"iban",
+	"routingnumber",
+	"cardnumber",
+	"cvv",
+	"ssn",
+}
+
+// redact is a slog.HandlerOptions.ReplaceAttr that hides the values of
+// sensitive attributes, at any depth of groups
+func redact(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() != slog.KindGroup && IsSensitive(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+	return attr
+}
+
+// IsSensitive reports whether values under key must not be logged
+func IsSensitive(key string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(normalized, sensitive) {
+			return true
+		}
+	}
+	return false
+}
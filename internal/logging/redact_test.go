@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+)
+
+func TestIsSensitive(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"password", true},
+		{"new_password", true},
+		{"access_token", true},
+		{"X-Api-Key", true},
+		{"Authorization", true},
+		{"jwt_secret", true},
+		{"bank_account", true},
+		{"IBAN", true},
+		{"card-number", true},
+		{"user_id", false},
+		{"route", false},
+		{"account", false},
+		{"key", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if got := IsSensitive(tt.key); got != tt.want {
+				t.Fatalf("IsSensitive(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: redact}))
+
+	logger.With("request_id", "req-1", "session_token", "abc").Info("login",
+		"user_id", "u-1",
+		"password", "hunter2",
+		slog.Group("payroll", "employee_id", "e-1", "bank_account", "DE89370400440532013000"),
+		slog.Group("secret", "note", "a group named like a secret keeps its fields"),
+	)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	delete(record, "time")
+
+	want := map[string]interface{}{
+		"level":         "INFO",
+		"msg":           "login",
+		"request_id":    "req-1",
+		"session_token": redacted,
+		"user_id":       "u-1",
+		"password":      redacted,
+		"payroll":       map[string]interface{}{"employee_id": "e-1", "bank_account": redacted},
+		"secret":        map[string]interface{}{"note": "a group named like a secret keeps its fields"},
+	}
+	if !reflect.DeepEqual(record, want) {
+		t.Fatalf("record = %v, want %v", record, want)
+	}
+}
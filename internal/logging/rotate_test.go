@@ -0,0 +1,60 @@
+package logging
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, bytes.Repeat([]byte("0"), 300<<10), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	// 1 MiB per file and two backups; the existing file counts towards the
+	// size
+	file, err := NewRotatingFile(path, 1, 2)
+	if err != nil {
+		t.Fatalf("NewRotatingFile: %v", err)
+	}
+	defer file.Close()
+
+	tests := []struct {
+		write string
+		want  map[string]string // first byte of each file
+	}{
+		{"1", map[string]string{"app.log": "0"}},
+		{"2", map[string]string{"app.log": "2", "app.log.1": "0"}},
+		{"3", map[string]string{"app.log": "3", "app.log.1": "2", "app.log.2": "0"}},
+		// The oldest backup is dropped
+		{"4", map[string]string{"app.log": "4", "app.log.1": "3", "app.log.2": "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.write, func(t *testing.T) {
+			chunk := bytes.Repeat([]byte(tt.write), 600<<10)
+			if n, err := file.Write(chunk); err != nil || n != len(chunk) {
+				t.Fatalf("Write = %d, %v", n, err)
+			}
+
+			entries, err := os.ReadDir(filepath.Dir(path))
+			if err != nil {
+				t.Fatalf("ReadDir: %v", err)
+			}
+			if len(entries) != len(tt.want) {
+				t.Fatalf("%d files, want %d", len(entries), len(tt.want))
+			}
+			for name, first := range tt.want {
+				data, err := os.ReadFile(filepath.Join(filepath.Dir(path), name))
+				if err != nil {
+					t.Fatalf("ReadFile: %v", err)
+				}
+				if string(data[:1]) != first {
+					t.Fatalf("%s starts with %q, want %q", name, data[:1], first)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "evtaarpro"
+
+// Registry holds every metric the server exposes. It is separate from the
+// global default registry so that libraries cannot add metrics behind our
+// back.
+var Registry = prometheus.NewRegistry()
+
+// HTTP RED metrics, labelled by route template rather than path so that IDs
+// do not explode the number of series
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by method and route template.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"method", "route"})
+
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "HTTP requests currently being served.",
+	})
+
+	RateLimitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "rate_limited_requests_total",
+		Help:      "HTTP requests rejected by the rate limiter, by route template.",
+	}, []string{"route"})
+)
+
+// CacheRequests counts read-through cache lookups, labelled by the name of
+// the cache, e.g. "users", and whether the entry was found
+var CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Subsystem: "cache",
+	Name:      "requests_total",
+	Help:      "Read-through cache lookups by cache and result.",
+}, []string{"cache", "result"})
+
+// Cache lookup results
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// Domain counters
+var (
+	Logins = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "logins_total",
+		Help:      "Successful logins.",
+	})
+
+	FailedLogins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "failed_logins_total",
+		Help:      "Rejected logins by reason.",
+	}, []string{"reason"})
+
+	MeetingsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "meetings",
+		Name:      "created_total",
+		Help:      "Meetings created.",
+	})
+
+	MeetingsJoined = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "meetings",
+		Name:      "joined_total",
+		Help:      "Meetings joined by participants.",
+	})
+
+	PayrollRecordsGenerated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "payroll",
+		Name:      "records_generated_total",
+		Help:      "Payroll records generated.",
+	})
+
+	NotificationsDelivered = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notifications",
+		Name:      "delivered_total",
+		Help:      "Notifications delivered by channel.",
+	}, []string{"channel"})
+)
+
+// Failed login reasons
+const (
+	LoginFailedInvalidCredentials = "invalid_credentials"
+	LoginFailedInactive           = "inactive"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		RateLimitedRequests,
+		CacheRequests,
+		Logins,
+		FailedLogins,
+		MeetingsCreated,
+		MeetingsJoined,
+		PayrollRecordsGenerated,
+		NotificationsDelivered,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	FailedLogins.WithLabelValues(LoginFailedInactive).Inc()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body, _ := io.ReadAll(rec.Body)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"domain counter", `evtaarpro_auth_failed_logins_total{reason="inactive"} 1`},
+		{"runtime metrics", "go_goroutines "},
+		{"process metrics", "process_start_time_seconds "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(string(body), tt.want) {
+				t.Fatalf("metrics do not contain %q", tt.want)
+			}
+		})
+	}
+}
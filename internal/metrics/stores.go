@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// dbStatsCollector reports connection pool statistics of a database as
+// gauges and counters, read at scrape time
+type dbStatsCollector struct {
+	stats func() sql.DBStats
+
+	maxOpen      *prometheus.Desc
+	open         *prometheus.Desc
+	inUse        *prometheus.Desc
+	idle         *prometheus.Desc
+	waitCount    *prometheus.Desc
+	waitDuration *prometheus.Desc
+	maxIdle      *prometheus.Desc
+	maxIdleTime  *prometheus.Desc
+	maxLifetime  *prometheus.Desc
+}
+
+// NewDBStatsCollector creates a collector for the pool statistics returned
+// by stats, e.g. PostgresStore.Stats
+func NewDBStatsCollector(stats func() sql.DBStats) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "postgres", name), help, nil, nil)
+	}
+	return &dbStatsCollector{
+		stats:        stats,
+		maxOpen:      desc("max_open_connections", "Maximum number of open connections."),
+		open:         desc("open_connections", "Established connections, in use and idle."),
+		inUse:        desc("in_use_connections", "Connections currently in use."),
+		idle:         desc("idle_connections", "Idle connections."),
+		waitCount:    desc("wait_count_total", "Connections waited for."),
+		waitDuration: desc("wait_duration_seconds_total", "Time spent waiting for a connection."),
+		maxIdle:      desc("max_idle_closed_total", "Connections closed due to the idle connection limit."),
+		maxIdleTime:  desc("max_idle_time_closed_total", "Connections closed due to the maximum idle time."),
+		maxLifetime:  desc("max_lifetime_closed_total", "Connections closed due to the maximum lifetime."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *dbStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.maxOpen
+	ch <- c.open
+	ch <- c.inUse
+	ch <- c.idle
+	ch <- c.waitCount
+	ch <- c.waitDuration
+	ch <- c.maxIdle
+	ch <- c.maxIdleTime
+	ch <- c.maxLifetime
+}
+
+// Collect implements prometheus.Collector
+func (c *dbStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	ch <- prometheus.MustNewConstMetric(c.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(c.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.Idle))
+	ch <- prometheus.MustNewConstMetric(c.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	ch <- prometheus.MustNewConstMetric(c.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+	ch <- prometheus.MustNewConstMetric(c.maxIdle, prometheus.CounterValue, float64(stats.MaxIdleClosed))
+	ch <- prometheus.MustNewConstMetric(c.maxIdleTime, prometheus.CounterValue, float64(stats.MaxIdleTimeClosed))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetime, prometheus.CounterValue, float64(stats.MaxLifetimeClosed))
+}
+
+// redisPoolCollector reports connection pool statistics of a Redis client,
+// read at scrape time
+type redisPoolCollector struct {
+	stats func() *redis.PoolStats
+
+	hits     *prometheus.Desc
+	misses   *prometheus.Desc
+	timeouts *prometheus.Desc
+	total    *prometheus.Desc
+	idle     *prometheus.Desc
+	stale    *prometheus.Desc
+}
+
+// NewRedisPoolCollector creates a collector for the pool statistics
+// returned by stats, e.g. RedisStore.PoolStats
+func NewRedisPoolCollector(stats func() *redis.PoolStats) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "redis_pool", name), help, nil, nil)
+	}
+	return &redisPoolCollector{
+		stats:    stats,
+		hits:     desc("hits_total", "Times a free connection was found in the pool."),
+		misses:   desc("misses_total", "Times no free connection was found in the pool."),
+		timeouts: desc("timeouts_total", "Times a wait for a connection timed out."),
+		total:    desc("connections", "Connections in the pool."),
+		idle:     desc("idle_connections", "Idle connections in the pool."),
+		stale:    desc("stale_connections_total", "Stale connections removed from the pool."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *redisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.timeouts
+	ch <- c.total
+	ch <- c.idle
+	ch <- c.stale
+}
+
+// Collect implements prometheus.Collector
+func (c *redisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.stale, prometheus.CounterValue, float64(stats.StaleConns))
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// gather collects collector into a fresh registry and returns the value of
+// every metric by name
+func gather(t *testing.T, collector prometheus.Collector) map[string]float64 {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(collector); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		metric := family.GetMetric()[0]
+		switch {
+		case metric.GetGauge() != nil:
+			values[family.GetName()] = metric.GetGauge().GetValue()
+		case metric.GetCounter() != nil:
+			values[family.GetName()] = metric.GetCounter().GetValue()
+		}
+	}
+	return values
+}
+
+func TestStoreCollectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		collector prometheus.Collector
+		want      map[string]float64
+	}{
+		{
+			name: "postgres",
+			collector: NewDBStatsCollector(func() sql.DBStats {
+				return sql.DBStats{
+					MaxOpenConnections: 25, OpenConnections: 7, InUse: 4, Idle: 3,
+					WaitCount: 12, WaitDuration: 1500 * time.Millisecond,
+					MaxIdleClosed: 1, MaxIdleTimeClosed: 2, MaxLifetimeClosed: 3,
+				}
+			}),
+			want: map[string]float64{
+				"evtaarpro_postgres_max_open_connections":        25,
+				"evtaarpro_postgres_open_connections":            7,
+				"evtaarpro_postgres_in_use_connections":          4,
+				"evtaarpro_postgres_idle_connections":            3,
+				"evtaarpro_postgres_wait_count_total":            12,
+				"evtaarpro_postgres_wait_duration_seconds_total": 1.5,
+				"evtaarpro_postgres_max_idle_closed_total":       1,
+				"evtaarpro_postgres_max_idle_time_closed_total":  2,
+				"evtaarpro_postgres_max_lifetime_closed_total":   3,
+			},
+		},
+		{
+			name: "redis",
+			collector: NewRedisPoolCollector(func() *redis.PoolStats {
+				return &redis.PoolStats{Hits: 40, Misses: 2, Timeouts: 1, TotalConns: 10, IdleConns: 6, StaleConns: 5}
+			}),
+			want: map[string]float64{
+				"evtaarpro_redis_pool_hits_total":              40,
+				"evtaarpro_redis_pool_misses_total":            2,
+				"evtaarpro_redis_pool_timeouts_total":          1,
+				"evtaarpro_redis_pool_connections":             10,
+				"evtaarpro_redis_pool_idle_connections":        6,
+				"evtaarpro_redis_pool_stale_connections_total": 5,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gather(t, tt.collector)
+			if len(got) != len(tt.want) {
+				t.Fatalf("collected %d metrics, want %d: %v", len(got), len(tt.want), got)
+			}
+			for name, want := range tt.want {
+				if value, ok := got[name]; !ok || value != want {
+					t.Fatalf("%s = %v (collected %v), want %v", name, value, ok, want)
+				}
+			}
+		})
+	}
+}
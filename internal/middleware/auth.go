@@ -4,8 +4,11 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/logging"
 	"github.com/manab-pr/evtaarpro/internal/response"
 	"github.com/manab-pr/evtaarpro/pkg/jwt"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // AuthMiddleware validates JWT tokens
@@ -41,11 +44,28 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		c.Set("email", claims.Email)
 		c.Set("role", claims.Role)
 		c.Set("claims", claims)
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "user_id", claims.UserID))
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(semconv.EnduserID(claims.UserID))
 
 		c.Next()
 	}
 }
 
+// StreamAuth is like AuthMiddleware but also accepts the token in the
+// access_token query parameter, for WebSocket and EventSource clients that
+// cannot set headers
+func StreamAuth(jwtSecret string) gin.HandlerFunc {
+	auth := AuthMiddleware(jwtSecret)
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		auth(c)
+	}
+}
+
 // OptionalAuth is like AuthMiddleware but doesn't abort on missing/invalid tokens
 func OptionalAuth(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -72,6 +92,8 @@ func OptionalAuth(jwtSecret string) gin.HandlerFunc {
 		c.Set("email", claims.Email)
 		c.Set("role", claims.Role)
 		c.Set("claims", claims)
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "user_id", claims.UserID))
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(semconv.EnduserID(claims.UserID))
 
 		c.Next()
 	}
@@ -7,9 +7,11 @@ import (
 	"github.com/manab-pr/evtaarpro/internal/config"
 )
 
-// CORS middleware
-func CORS(cfg config.CORSConfig) gin.HandlerFunc {
+// CORS middleware. It applies the CORS settings in effect, which may
+// change on reload.
+func CORS(live *config.Live) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		cfg := live.Current().CORS
 		origin := c.Request.Header.Get("Origin")
 
 		// Check if origin is allowed
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/idempotency"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/response"
+)
+
+// maxIdempotencyKeyLength bounds the keys clients may send
+const maxIdempotencyKeyLength = 255
+
+// maxIdempotentBodySize bounds the bodies of requests with a key, which are
+// read into memory to fingerprint them
+const maxIdempotentBodySize = 1 << 20 // 1 MiB
+
+// replayedHeaders are the response headers stored with the response and
+// sent again on replay; the others describe the retry itself
+var replayedHeaders = []string{"Content-Type", "Content-Language", "Location", "ETag", "Last-Modified", "Cache-Control"}
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe
+// to retry. The first response per user, route and key is stored and
+// replayed for retries with the same body, with Idempotent-Replayed set.
+// Reusing a key for a different body gets 422, and a retry while the first
+// request still runs gets 409. Bodies above 1 MiB get 413. Server errors
+// are not stored, so they can be retried. It must run after AuthMiddleware.
+func Idempotency(store *idempotency.Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			response.BadRequest(c, "Idempotency-Key must be at most 255 characters")
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodySize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				response.Error(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "Request body is too large", "")
+			} else {
+				response.BadRequest(c, "Failed to read request body")
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		storeKey := c.GetString("user_id") + ":" + c.Request.Method + " " + c.Request.URL.Path + ":" + key
+		fingerprint := sha256.Sum256(body)
+
+		claim, stored, err := store.Begin(ctx, storeKey, hex.EncodeToString(fingerprint[:]))
+		switch {
+		case errors.Is(err, idempotency.ErrFingerprintMismatch):
+			response.Error(c, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used for a different request", "")
+			c.Abort()
+			return
+		case errors.Is(err, idempotency.ErrInProgress):
+			c.Header("Retry-After", "1")
+			response.Conflict(c, "A request with this Idempotency-Key is still in progress")
+			c.Abort()
+			return
+		case err != nil:
+			// Without Redis the request runs unprotected rather than not at all
+			logging.FromContext(ctx).Warn("idempotency store unavailable", "error", err)
+			c.Next()
+			return
+		case stored != nil:
+			replay(c, stored)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		completed := false
+		defer func() {
+			if !completed {
+				// The claim must be released even if the request was cancelled
+				if err := store.Release(context.WithoutCancel(ctx), storeKey, claim); err != nil {
+					logging.FromContext(ctx).Warn("failed to release idempotency key", "error", err)
+				}
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		header := make(http.Header)
+		for _, name := range replayedHeaders {
+			if values := recorder.Header().Values(name); len(values) > 0 {
+				header[name] = values
+			}
+		}
+		if err := store.Complete(context.WithoutCancel(ctx), storeKey, claim, status, header, recorder.body.Bytes()); err != nil {
+			logging.FromContext(ctx).Warn("failed to store idempotent response", "error", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// replay sends a stored response again
+func replay(c *gin.Context, record *idempotency.Record) {
+	for name, values := range record.Header {
+		c.Writer.Header()[name] = values
+	}
+	c.Header("Idempotent-Replayed", "true")
+	c.Header("Content-Length", strconv.Itoa(len(record.Body)))
+	c.Status(record.Status)
+	c.Writer.Write(record.Body)
+	c.Abort()
+}
+
+// responseRecorder keeps a copy of the response body
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/idempotency"
+	"github.com/redis/go-redis/v9"
+)
+
+// The requests below are rejected or let through before the store is used
+func TestIdempotencyRejectsBeforeTheStore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		method     string
+		key        string
+		body       string
+		wantStatus int
+	}{
+		{"body above the limit", http.MethodPost, "key-1", strings.Repeat("x", maxIdempotentBodySize+1), http.StatusRequestEntityTooLarge},
+		{"key too long", http.MethodPost, strings.Repeat("k", maxIdempotencyKeyLength+1), "{}", http.StatusBadRequest},
+		{"no key", http.MethodPost, "", strings.Repeat("x", maxIdempotentBodySize+1), http.StatusOK},
+		{"not a POST", http.MethodPut, "key-1", strings.Repeat("x", maxIdempotentBodySize+1), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled bool
+			router := gin.New()
+			router.Use(Idempotency(nil))
+			router.Handle(tt.method, "/customers", func(c *gin.Context) {
+				handled = true
+				body, _ := io.ReadAll(c.Request.Body)
+				if len(body) != len(tt.body) {
+					t.Errorf("handler read %d bytes, want %d", len(body), len(tt.body))
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, "/customers", strings.NewReader(tt.body))
+			if tt.key != "" {
+				req.Header.Set("Idempotency-Key", tt.key)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if handled != (tt.wantStatus == http.StatusOK) {
+				t.Fatalf("handler ran = %v for status %d", handled, rec.Code)
+			}
+		})
+	}
+}
+
+// newIdempotentRouter serves POST /customers through Idempotency backed by
+// miniredis, answering with handle
+func newIdempotentRouter(t *testing.T, handle gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	router := gin.New()
+	router.Use(Idempotency(idempotency.NewStore(&datastore.RedisStore{Client: client})))
+	router.POST("/customers", handle)
+	return router
+}
+
+func postCustomer(router http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/customers", strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", key)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyReplaysResponses(t *testing.T) {
+	calls := 0
+	router := newIdempotentRouter(t, func(c *gin.Context) {
+		calls++
+		c.Header("Location", "/customers/1")
+		c.JSON(http.StatusCreated, gin.H{"id": "1", "call": calls})
+	})
+
+	first := postCustomer(router, "key-1", `{"name":"Acme"}`)
+	if first.Code != http.StatusCreated || first.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("first response = %d %v", first.Code, first.Header())
+	}
+
+	retry := postCustomer(router, "key-1", `{"name":"Acme"}`)
+	if retry.Code != http.StatusCreated {
+		t.Fatalf("replayed status = %d, want %d", retry.Code, http.StatusCreated)
+	}
+	if got := retry.Header().Get("Idempotent-Replayed"); got != "true" {
+		t.Fatalf("Idempotent-Replayed = %q, want true", got)
+	}
+	if got := retry.Header().Get("Location"); got != "/customers/1" {
+		t.Fatalf("replayed Location = %q, want /customers/1", got)
+	}
+	if retry.Body.String() != first.Body.String() {
+		t.Fatalf("replayed body = %s, want %s", retry.Body, first.Body)
+	}
+
+	if rec := postCustomer(router, "key-1", `{"name":"Other"}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("other body status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if rec := postCustomer(router, "key-2", `{"name":"Acme"}`); rec.Code != http.StatusCreated || rec.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("other key = %d %v, want a new response", rec.Code, rec.Header())
+	}
+	if calls != 2 {
+		t.Fatalf("handler ran %d times, want 2", calls)
+	}
+}
+
+func TestIdempotencyRejectsRequestsInProgress(t *testing.T) {
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	router := newIdempotentRouter(t, func(c *gin.Context) {
+		close(started)
+		<-finish
+		c.Status(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- postCustomer(router, "key-1", "{}") }()
+	<-started
+
+	rec := postCustomer(router, "key-1", "{}")
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if got := rec.Header().Get("Retry-After"); got == "" {
+		t.Fatal("Retry-After is not set")
+	}
+
+	close(finish)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Fatalf("first status = %d, want %d", first.Code, http.StatusCreated)
+	}
+}
+
+func TestIdempotencyReleasesServerErrors(t *testing.T) {
+	statuses := []int{http.StatusInternalServerError, http.StatusCreated}
+	calls := 0
+	router := newIdempotentRouter(t, func(c *gin.Context) {
+		c.Status(statuses[calls])
+		calls++
+	})
+
+	if rec := postCustomer(router, "key-1", "{}"); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("first status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	// The retry runs again instead of getting 409 or the stored error
+	rec := postCustomer(router, "key-1", "{}")
+	if rec.Code != http.StatusCreated || rec.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("retry = %d %v, want a new response", rec.Code, rec.Header())
+	}
+	if calls != 2 {
+		t.Fatalf("handler ran %d times, want 2", calls)
+	}
+}
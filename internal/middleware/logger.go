@@ -1,47 +1,74 @@
 package middleware
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/audit"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// RequestLogger logs HTTP requests
-func RequestLogger() gin.HandlerFunc {
+// RequestLogger gives every request an ID and a logger carrying it, and
+// logs the request once it completes: server errors at error level, client
+// errors at warn level. The query string is left out, as it may hold
+// tokens. Behind Tracing, the request ID is the trace ID, so a request
+// reported by a client can be looked up in the tracing backend.
+func RequestLogger(logger *slog.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 
-		// Generate request ID
-		requestID := uuid.New().String()
+		requestID := tracing.TraceID(c.Request.Context())
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
 		c.Set("request_id", requestID)
 		c.Writer.Header().Set("X-Request-ID", requestID)
 
+		// Code handling the request logs through the request logger;
+		// AuthMiddleware adds the user ID to it
+		loggerArgs := []any{
+			"request_id", requestID,
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+		}
+		if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.IsValid() {
+			loggerArgs = append(loggerArgs, "trace_id", spanContext.TraceID().String(), "span_id", spanContext.SpanID().String())
+		}
+		ctx := logging.WithContext(c.Request.Context(), logger.With(loggerArgs...))
+
+		// Audit entries recorded by the request carry its ID and IP
+		c.Request = c.Request.WithContext(audit.ContextWithRequest(ctx, audit.RequestInfo{
+			RequestID: requestID,
+			IP:        c.ClientIP(),
+		}))
+
 		// Process request
 		c.Next()
 
-		// Calculate latency
-		latency := time.Since(start)
-
-		// Log request details
 		statusCode := c.Writer.Status()
-		method := c.Request.Method
-		path := c.Request.URL.Path
-		clientIP := c.ClientIP()
-		errorMessage := c.Errors.ByType(gin.ErrorTypePrivate).String()
-
-		// TODO: Replace with proper structured logging
-		if errorMessage != "" {
-			// Log error
-			_ = errorMessage
+		level := slog.LevelInfo
+		switch {
+		case statusCode >= 500:
+			level = slog.LevelError
+		case statusCode >= 400:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", statusCode),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if errorMessage := c.Errors.ByType(gin.ErrorTypePrivate).String(); errorMessage != "" {
+			attrs = append(attrs, slog.String("error", errorMessage))
 		}
 
-		// Log info
-		_ = statusCode
-		_ = method
-		_ = path
-		_ = clientIP
-		_ = latency
-		_ = requestID
+		logging.FromContext(c.Request.Context()).LogAttrs(c.Request.Context(), level, "request completed", attrs...)
 	}
 }
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
+)
+
+// unmatchedRoute labels requests that matched no route, so that scanners
+// probing random paths do not create a series per path
+const unmatchedRoute = "unmatched"
+
+// Metrics records the rate, errors and duration of requests per route
+// template
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
+		c.Next()
+
+		route := routeLabel(c)
+		method := c.Request.Method
+
+		metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+	}
+}
+
+// routeLabel returns the route template of the request, for labels and span
+// names
+func routeLabel(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return unmatchedRoute
+}
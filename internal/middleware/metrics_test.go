@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
+)
+
+// requestCount returns the value of the request counter with the given
+// labels
+func requestCount(t *testing.T, method, route, status string) float64 {
+	t.Helper()
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+
+	want := map[string]string{"method": method, "route": route, "status": status}
+	for _, family := range families {
+		if family.GetName() != "evtaarpro_http_requests_total" {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if want[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Metrics())
+	router.GET("/api/v1/meetings/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	router.POST("/api/v1/meetings", func(c *gin.Context) {
+		c.Status(http.StatusBadRequest)
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		wantRoute string
+		status    string
+	}{
+		{"labelled by route template", http.MethodGet, "/api/v1/meetings/m-1", "/api/v1/meetings/:id", "200"},
+		{"other IDs share the series", http.MethodGet, "/api/v1/meetings/m-2", "/api/v1/meetings/:id", "200"},
+		{"errors", http.MethodPost, "/api/v1/meetings", "/api/v1/meetings", "400"},
+		{"unmatched path", http.MethodGet, "/wp-admin/setup.php", unmatchedRoute, "404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := requestCount(t, tt.method, tt.wantRoute, tt.status)
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+			if got := requestCount(t, tt.method, tt.wantRoute, tt.status); got != before+1 {
+				t.Fatalf("requests{%s %s %s} = %v, want %v", tt.method, tt.wantRoute, tt.status, got, before+1)
+			}
+		})
+	}
+
+	if got := requestCount(t, http.MethodGet, "/wp-admin/setup.php", "404"); got != 0 {
+		t.Fatalf("unmatched path got its own series with %v requests", got)
+	}
+}
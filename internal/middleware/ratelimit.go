@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
+	"github.com/manab-pr/evtaarpro/internal/ratelimit"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/pkg/jwt"
+)
+
+// unlimitedRoutes are probed by the orchestrator and must never be limited
+var unlimitedRoutes = map[string]bool{
+	"/health": true,
+	"/ready":  true,
+}
+
+// rateLimitPolicy resolves the buckets a request takes a token from
+type rateLimitPolicy struct {
+	defaultLimit ratelimit.Limit
+	routes       map[string]ratelimit.Limit
+	users        map[string]ratelimit.Limit
+	apiKeys      map[[sha256.Size]byte]apiKeyLimit
+	jwtSecret    string
+	redis        *datastore.RedisStore
+}
+
+type apiKeyLimit struct {
+	name  string
+	limit ratelimit.Limit
+}
+
+// RateLimit limits every client to the configured requests per second and
+// burst, with the overrides of the config for routes, users and API keys.
+// Limited requests get 429 with Retry-After; every response carries the
+// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers of the
+// bucket closest to its limit. The limits in effect, which may change on
+// reload, apply; while rate limiting is disabled requests pass through.
+func RateLimit(live *config.Live, jwtSecret string, redisStore *datastore.RedisStore) gin.HandlerFunc {
+	var policy atomic.Pointer[rateLimitPolicy]
+	policy.Store(newRateLimitPolicy(live.Current().RateLimit, jwtSecret, redisStore))
+	live.OnReload(func(cfg *config.Config) {
+		policy.Store(newRateLimitPolicy(cfg.RateLimit, jwtSecret, redisStore))
+	})
+
+	limiter := ratelimit.NewLimiter(redisStore.Client)
+
+	return func(c *gin.Context) {
+		policy := policy.Load()
+		if policy == nil || unlimitedRoutes[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		result := limiter.Allow(c.Request.Context(), policy.buckets(c))
+
+		header := c.Writer.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(max(result.Remaining, 0)))
+		header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			metrics.RateLimitedRequests.WithLabelValues(routeLabel(c)).Inc()
+			header.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			response.TooManyRequests(c, "Too many requests, please retry later")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// newRateLimitPolicy builds the policy of cfg, or returns nil when rate
+// limiting is disabled
+func newRateLimitPolicy(cfg config.RateLimitConfig, jwtSecret string, redisStore *datastore.RedisStore) *rateLimitPolicy {
+	if !cfg.Enabled || cfg.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	policy := &rateLimitPolicy{
+		defaultLimit: newLimit(cfg.RequestsPerSecond, cfg.Burst),
+		routes:       make(map[string]ratelimit.Limit, len(cfg.Routes)),
+		users:        make(map[string]ratelimit.Limit, len(cfg.Users)),
+		apiKeys:      make(map[[sha256.Size]byte]apiKeyLimit, len(cfg.APIKeys)),
+		jwtSecret:    jwtSecret,
+		redis:        redisStore,
+	}
+	// Overrides without a rate are left out rather than blocking everyone
+	for route, limit := range cfg.Routes {
+		if limit.RequestsPerSecond > 0 {
+			policy.routes[route] = newLimit(limit.RequestsPerSecond, limit.Burst)
+		}
+	}
+	for userID, limit := range cfg.Users {
+		if limit.RequestsPerSecond > 0 {
+			policy.users[userID] = newLimit(limit.RequestsPerSecond, limit.Burst)
+		}
+	}
+	for _, key := range cfg.APIKeys {
+		if key.Key == "" || key.RequestsPerSecond <= 0 {
+			continue
+		}
+		policy.apiKeys[sha256.Sum256([]byte(key.Key))] = apiKeyLimit{
+			name:  key.Name,
+			limit: newLimit(key.RequestsPerSecond, key.Burst),
+		}
+	}
+	return policy
+}
+
+// buckets returns the bucket of the client and, if the route has its own
+// limit, the client's bucket for the route. Both share the client as Redis
+// hash tag so the limiter can update them together.
+func (p *rateLimitPolicy) buckets(c *gin.Context) []ratelimit.Bucket {
+	client, limit := p.client(c)
+	tag := "{" + client + "}"
+
+	buckets := []ratelimit.Bucket{{Key: p.redis.GetKey("rate_limit", tag), Limit: limit}}
+
+	route := c.Request.Method + " " + c.FullPath()
+	if routeLimit, ok := p.routes[route]; ok {
+		buckets = append(buckets, ratelimit.Bucket{
+			Key:   p.redis.GetKey("rate_limit", tag+":"+route),
+			Limit: routeLimit,
+		})
+	}
+	return buckets
+}
+
+// client identifies the caller by its configured API key, else by the user
+// of a valid access token, else by IP address, and returns its limit
+func (p *rateLimitPolicy) client(c *gin.Context) (string, ratelimit.Limit) {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		if apiKey, ok := p.apiKeys[sha256.Sum256([]byte(key))]; ok {
+			return "key:" + apiKey.name, apiKey.limit
+		}
+	}
+
+	if userID := p.userID(c); userID != "" {
+		if limit, ok := p.users[userID]; ok {
+			return "user:" + userID, limit
+		}
+		return "user:" + userID, p.defaultLimit
+	}
+
+	return "ip:" + c.ClientIP(), p.defaultLimit
+}
+
+// userID returns the user of the bearer token, or "" when there is no
+// valid token. Routes check the token again; this only picks the bucket.
+func (p *rateLimitPolicy) userID(c *gin.Context) string {
+	parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return ""
+	}
+	claims, err := jwt.ValidateToken(parts[1], p.jwtSecret)
+	if err != nil {
+		return ""
+	}
+	return claims.UserID
+}
+
+// newLimit builds a limit, defaulting the burst to one second of requests
+func newLimit(requestsPerSecond float64, burst int) ratelimit.Limit {
+	if burst <= 0 {
+		burst = max(int(math.Ceil(requestsPerSecond)), 1)
+	}
+	return ratelimit.Limit{Rate: requestsPerSecond, Burst: burst}
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
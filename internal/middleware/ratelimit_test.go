@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/ratelimit"
+	"github.com/manab-pr/evtaarpro/pkg/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+const rateLimitSecret = "test-secret"
+
+// downHook fails every command, so the limiter falls back to memory
+type downHook struct{}
+
+func (downHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no redis in tests")
+	}
+}
+
+func (downHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := errors.New("connection refused")
+		cmd.SetErr(err)
+		return err
+	}
+}
+
+func (downHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func newRateLimitStore() *datastore.RedisStore {
+	client := redis.NewClient(&redis.Options{Addr: "redis.invalid:6379"})
+	client.AddHook(downHook{})
+	return &datastore.RedisStore{Client: client, Prefixes: map[string]string{"rate_limit": "rl:"}}
+}
+
+func bearer(t *testing.T, userID string) string {
+	t.Helper()
+	token, err := jwt.GenerateToken(userID, userID+"@example.com", "employee", "evtaarpro", rateLimitSecret, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	return "Bearer " + token
+}
+
+func TestRateLimitPolicyBuckets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	policy := newRateLimitPolicy(config.RateLimitConfig{
+		Enabled:           true,
+		RequestsPerSecond: 10,
+		Routes: map[string]config.RateLimit{
+			"POST /auth/login":  {RequestsPerSecond: 0.5, Burst: 3},
+			"GET /api/v1/users": {Burst: 3}, // no rate, left out
+		},
+		Users:   map[string]config.RateLimit{"alice": {RequestsPerSecond: 50, Burst: 100}},
+		APIKeys: []config.APIKeyRateLimit{{Name: "reporting", Key: "secret-key", RateLimit: config.RateLimit{RequestsPerSecond: 5}}},
+	}, rateLimitSecret, newRateLimitStore())
+
+	defaultLimit := ratelimit.Limit{Rate: 10, Burst: 10}
+
+	tests := []struct {
+		name          string
+		method, route string
+		authorization string
+		apiKey        string
+		want          []ratelimit.Bucket
+	}{
+		{
+			name: "by IP", method: http.MethodGet, route: "/api/v1/users",
+			want: []ratelimit.Bucket{{Key: "rl:{ip:192.0.2.1}", Limit: defaultLimit}},
+		},
+		{
+			name: "by user", method: http.MethodGet, route: "/api/v1/users", authorization: bearer(t, "bob"),
+			want: []ratelimit.Bucket{{Key: "rl:{user:bob}", Limit: defaultLimit}},
+		},
+		{
+			name: "user override", method: http.MethodGet, route: "/api/v1/users", authorization: bearer(t, "alice"),
+			want: []ratelimit.Bucket{{Key: "rl:{user:alice}", Limit: ratelimit.Limit{Rate: 50, Burst: 100}}},
+		},
+		{
+			name: "invalid token", method: http.MethodGet, route: "/api/v1/users", authorization: "Bearer forged",
+			want: []ratelimit.Bucket{{Key: "rl:{ip:192.0.2.1}", Limit: defaultLimit}},
+		},
+		{
+			name: "API key", method: http.MethodGet, route: "/api/v1/users", apiKey: "secret-key", authorization: bearer(t, "alice"),
+			want: []ratelimit.Bucket{{Key: "rl:{key:reporting}", Limit: ratelimit.Limit{Rate: 5, Burst: 5}}},
+		},
+		{
+			name: "unknown API key", method: http.MethodGet, route: "/api/v1/users", apiKey: "made-up",
+			want: []ratelimit.Bucket{{Key: "rl:{ip:192.0.2.1}", Limit: defaultLimit}},
+		},
+		{
+			name: "route override", method: http.MethodPost, route: "/auth/login",
+			want: []ratelimit.Bucket{
+				{Key: "rl:{ip:192.0.2.1}", Limit: defaultLimit},
+				{Key: "rl:{ip:192.0.2.1}:POST /auth/login", Limit: ratelimit.Limit{Rate: 0.5, Burst: 3}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []ratelimit.Bucket
+			router := gin.New()
+			router.Handle(tt.method, tt.route, func(c *gin.Context) {
+				got = policy.buckets(c)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.route, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("buckets = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLimit(t *testing.T) {
+	tests := []struct {
+		rate  float64
+		burst int
+		want  ratelimit.Limit
+	}{
+		{10, 20, ratelimit.Limit{Rate: 10, Burst: 20}},
+		{10, 0, ratelimit.Limit{Rate: 10, Burst: 10}},
+		{2.5, 0, ratelimit.Limit{Rate: 2.5, Burst: 3}},
+		{0.1, 0, ratelimit.Limit{Rate: 0.1, Burst: 1}},
+	}
+
+	for _, tt := range tests {
+		if got := newLimit(tt.rate, tt.burst); got != tt.want {
+			t.Fatalf("newLimit(%v, %d) = %+v, want %+v", tt.rate, tt.burst, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	live := config.NewLive(&config.Config{
+		RateLimit: config.RateLimitConfig{Enabled: true, RequestsPerSecond: 0.1, Burst: 2},
+	})
+
+	router := gin.New()
+	router.Use(RateLimit(live, rateLimitSecret, newRateLimitStore()))
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/api/v1/meetings", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		wantStatus    int
+		wantRemaining string
+		wantRetry     string
+	}{
+		{"first", "/api/v1/meetings", http.StatusOK, "1", ""},
+		{"second", "/api/v1/meetings", http.StatusOK, "0", ""},
+		{"limited", "/api/v1/meetings", http.StatusTooManyRequests, "0", "10"},
+		{"health is never limited", "/health", http.StatusOK, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(tt.path)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("RateLimit-Remaining"); got != tt.wantRemaining {
+				t.Fatalf("RateLimit-Remaining = %q, want %q", got, tt.wantRemaining)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.wantRetry {
+				t.Fatalf("Retry-After = %q, want %q", got, tt.wantRetry)
+			}
+		})
+	}
+
+	// Disabling the limits on reload lets requests through
+	next := *live.Current()
+	next.RateLimit.Enabled = false
+	live.Reload(&next)
+	if rec := get("/api/v1/meetings"); rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+		t.Fatalf("status %d with limit %q after disabling, want 200 without headers", rec.Code, rec.Header().Get("RateLimit-Limit"))
+	}
+}
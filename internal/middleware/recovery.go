@@ -1,10 +1,10 @@
 package middleware
 
 import (
-	"fmt"
 	"runtime/debug"
 
 	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/logging"
 	"github.com/manab-pr/evtaarpro/internal/response"
 )
 
@@ -13,9 +13,11 @@ func Recovery() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				// Log the panic
-				stack := debug.Stack()
-				fmt.Printf("PANIC: %v\n%s\n", err, stack)
+				// Log the panic with the request it happened in
+				logging.FromContext(c.Request.Context()).Error("panic recovered",
+					"panic", err,
+					"stack", string(debug.Stack()),
+				)
 
 				// Return error response
 				response.InternalServerError(c, "An unexpected error occurred")
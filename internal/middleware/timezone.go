@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+)
+
+// Timezone renders response times in the viewer's timezone, taken from the
+// tz query parameter or the X-Timezone header as an IANA name (e.g.
+// "Asia/Kolkata"). Without either, times are rendered as stored, in UTC.
+func Timezone() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("tz")
+		if name == "" {
+			name = c.GetHeader("X-Timezone")
+		}
+		if name == "" {
+			c.Next()
+			return
+		}
+
+		loc, err := time.LoadLocation(name)
+		if err != nil || name == "Local" {
+			response.BadRequest(c, "Invalid timezone: use an IANA name such as Europe/Berlin")
+			c.Abort()
+			return
+		}
+
+		response.SetLocation(c, loc)
+		c.Next()
+	}
+}
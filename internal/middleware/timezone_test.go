@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+)
+
+func TestTimezone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	at := time.Date(2024, time.March, 11, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		query      string
+		header     string
+		wantStatus int
+		wantTime   string
+	}{
+		{"none", "", "", http.StatusOK, "2024-03-11T20:00:00Z"},
+		{"query", "?tz=Asia/Kolkata", "", http.StatusOK, "2024-03-12T01:30:00+05:30"},
+		{"header", "", "America/New_York", http.StatusOK, "2024-03-11T16:00:00-04:00"},
+		{"query wins", "?tz=UTC", "Asia/Kolkata", http.StatusOK, "2024-03-11T20:00:00Z"},
+		{"unknown", "?tz=Mars/Olympus_Mons", "", http.StatusBadRequest, ""},
+		{"server local time", "?tz=Local", "", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Timezone())
+			router.GET("/meetings", func(c *gin.Context) {
+				response.Success(c, http.StatusOK, "ok", gin.H{"start_time": at})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/meetings"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("X-Timezone", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data struct {
+					StartTime string `json:"start_time"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if body.Data.StartTime != tt.wantTime {
+				t.Fatalf("start_time = %s, want %s", body.Data.StartTime, tt.wantTime)
+			}
+		})
+	}
+}
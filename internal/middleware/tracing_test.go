@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	router := gin.New()
+	router.Use(Tracing(), RequestLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	router.GET("/api/v1/meetings/:id", func(c *gin.Context) {
+		if !trace.SpanContextFromContext(c.Request.Context()).IsValid() {
+			t.Error("handler context carries no span")
+		}
+		c.Status(http.StatusOK)
+	})
+	router.POST("/api/v1/meetings", func(c *gin.Context) {
+		_ = c.Error(http.ErrAbortHandler)
+		c.Status(http.StatusInternalServerError)
+	})
+
+	const parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		traceparent string
+		wantName    string
+		wantStatus  codes.Code
+		wantTraceID string
+	}{
+		{"route template", http.MethodGet, "/api/v1/meetings/m-1", "", "GET /api/v1/meetings/:id", codes.Unset, ""},
+		{"continues the caller's trace", http.MethodGet, "/api/v1/meetings/m-1", "00-" + parentTraceID + "-00f067aa0ba902b7-01", "GET /api/v1/meetings/:id", codes.Unset, parentTraceID},
+		{"server error", http.MethodPost, "/api/v1/meetings", "", "POST /api/v1/meetings", codes.Error, ""},
+		{"unmatched path", http.MethodGet, "/wp-admin/setup.php", "", "GET " + unmatchedRoute, codes.Unset, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			rec := httptest.NewRecorder()
+			before := len(recorder.Ended())
+			router.ServeHTTP(rec, req)
+
+			spans := recorder.Ended()[before:]
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != tt.wantName || span.SpanKind() != trace.SpanKindServer {
+				t.Fatalf("span %q of kind %v, want server span %q", span.Name(), span.SpanKind(), tt.wantName)
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+			traceID := span.SpanContext().TraceID().String()
+			if tt.wantTraceID != "" && traceID != tt.wantTraceID {
+				t.Fatalf("trace ID = %s, want the caller's %s", traceID, tt.wantTraceID)
+			}
+			// The request ID is the trace ID
+			if got := rec.Header().Get("X-Request-ID"); got != traceID {
+				t.Fatalf("request ID = %s, want trace ID %s", got, traceID)
+			}
+
+			attrs := attribute.NewSet(span.Attributes()...)
+			if status, _ := attrs.Value("http.response.status_code"); status.AsInt64() != int64(rec.Code) {
+				t.Fatalf("http.response.status_code = %d, want %d", status.AsInt64(), rec.Code)
+			}
+		})
+	}
+}
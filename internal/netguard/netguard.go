@@ -0,0 +1,89 @@
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned when a URL chosen by a user points at, or
+// resolves to, an address inside the server's network
+var ErrForbiddenAddress = errors.New("address is not publicly routable")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which
+// netip does not count as private
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublic reports whether ip is neither loopback, private, link-local,
+// shared, multicast nor unspecified
+func IsPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip))
+}
+
+// CheckHost rejects the host of a URL when it is localhost or an IP literal
+// that is not public. Other names are only known to be safe once resolved,
+// which the dialer of NewTransport checks.
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+
+	ip, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"))
+	if err != nil {
+		return nil
+	}
+	if !IsPublic(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip.Unmap())
+	}
+	return nil
+}
+
+// CheckAddress refuses to connect to a resolved "ip:port" address that is
+// not public
+func CheckAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	if !IsPublic(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip.Unmap())
+	}
+	return nil
+}
+
+// Control is a net.Dialer Control function applying CheckAddress. It runs
+// after DNS resolution, right before connecting, which also defeats DNS
+// rebinding.
+func Control(network, address string, _ syscall.RawConn) error {
+	return CheckAddress(address)
+}
+
+// NewTransport creates an HTTP transport that only dials public addresses,
+// for requests to URLs chosen by users
+func NewTransport(timeout time.Duration) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: Control,
+	}
+
+	return &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: timeout,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
@@ -0,0 +1,94 @@
+package netguard
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		allowed bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:4700::1111]:443", true},
+		{"127.0.0.1:443", false},
+		{"[::1]:443", false},
+		{"10.1.2.3:443", false},
+		{"172.16.0.1:443", false},
+		{"192.168.1.1:443", false},
+		{"169.254.169.254:80", false},
+		{"100.64.0.1:443", false},
+		{"0.0.0.0:443", false},
+		{"[::]:443", false},
+		{"[fe80::1]:443", false},
+		{"[fd00::1]:443", false},
+		{"[::ffff:127.0.0.1]:443", false},
+		{"224.0.0.1:443", false},
+		{"localhost:443", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := CheckAddress(tt.address)
+			if tt.allowed && err != nil {
+				t.Fatalf("CheckAddress(%q): %v", tt.address, err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrForbiddenAddress) {
+				t.Fatalf("CheckAddress(%q): err = %v, want ErrForbiddenAddress", tt.address, err)
+			}
+		})
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		allowed bool
+	}{
+		{"hooks.example.com", true},
+		{"93.184.216.34", true},
+		{"[2606:4700::1111]", true},
+		// Names are checked once resolved
+		{"internal.corp", true},
+		{"localhost", false},
+		{"LocalHost.", false},
+		{"api.localhost", false},
+		{"127.0.0.1", false},
+		{"169.254.169.254", false},
+		{"10.0.0.5", false},
+		{"[::1]", false},
+		{"[::ffff:192.168.0.1]", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			err := CheckHost(tt.host)
+			if tt.allowed && err != nil {
+				t.Fatalf("CheckHost(%q): %v", tt.host, err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrForbiddenAddress) {
+				t.Fatalf("CheckHost(%q): err = %v, want ErrForbiddenAddress", tt.host, err)
+			}
+		})
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(time.Second)}
+	if _, err := client.Get(server.URL); !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("Get loopback server: err = %v, want ErrForbiddenAddress", err)
+	}
+	if called {
+		t.Fatal("the loopback server was reached")
+	}
+}
@@ -0,0 +1,179 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/redis/go-redis/v9"
+)
+
+// fallbackLogInterval limits how often a Redis failure is logged while the
+// limiter falls back to memory
+const fallbackLogInterval = time.Minute
+
+// Limit is a token bucket holding up to Burst tokens, refilled at Rate
+// tokens per second. A request takes one token.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Bucket is a token bucket identified by Key
+type Bucket struct {
+	Key   string
+	Limit Limit
+}
+
+// Result is the outcome of taking a token from one or more buckets
+type Result struct {
+	Allowed bool
+
+	// Limit, Remaining and Reset describe the bucket with the fewest tokens
+	// left: its burst, its tokens left and the time until it is full again
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+
+	// RetryAfter is, for a denied request, the time until every bucket has
+	// a token again
+	RetryAfter time.Duration
+}
+
+// bucketState is what is left in one bucket after a request
+type bucketState struct {
+	remaining int
+	reset     time.Duration
+	retry     time.Duration
+}
+
+// Limiter takes tokens from buckets kept in Redis, so the limits hold
+// across replicas. While Redis is unavailable it falls back to buckets in
+// memory, which each replica enforces on its own.
+type Limiter struct {
+	client   *redis.Client
+	fallback *MemoryLimiter
+
+	lastFallbackLog atomic.Int64
+}
+
+// NewLimiter creates a Limiter on client
+func NewLimiter(client *redis.Client) *Limiter {
+	return &Limiter{
+		client:   client,
+		fallback: NewMemoryLimiter(),
+	}
+}
+
+// Allow takes a token from every bucket if each of them has one left, and
+// none otherwise. Buckets that must be updated together should share a
+// Redis hash tag, e.g. "{client}".
+func (l *Limiter) Allow(ctx context.Context, buckets []Bucket) *Result {
+	result, err := l.allowRedis(ctx, buckets)
+	if err == nil {
+		return result
+	}
+
+	now := time.Now()
+	if last := l.lastFallbackLog.Load(); now.UnixNano()-last >= int64(fallbackLogInterval) && l.lastFallbackLog.CompareAndSwap(last, now.UnixNano()) {
+		logging.FromContext(ctx).Warn("rate limiter falling back to memory", "error", err)
+	}
+	return l.fallback.Allow(buckets, now)
+}
+
+func (l *Limiter) allowRedis(ctx context.Context, buckets []Bucket) (*Result, error) {
+	keys := make([]string, len(buckets))
+	args := make([]interface{}, 0, 2*len(buckets))
+	for i, bucket := range buckets {
+		keys[i] = bucket.Key
+		args = append(args, bucket.Limit.Rate, bucket.Limit.Burst)
+	}
+
+	reply, err := allowScript.Run(ctx, l.client, keys, args...).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != 1+3*len(buckets) {
+		return nil, fmt.Errorf("unexpected rate limit reply of %d values", len(reply))
+	}
+
+	states := make([]bucketState, len(buckets))
+	for i := range buckets {
+		states[i] = bucketState{
+			remaining: int(reply[1+3*i]),
+			reset:     time.Duration(reply[2+3*i]) * time.Millisecond,
+			retry:     time.Duration(reply[3+3*i]) * time.Millisecond,
+		}
+	}
+	return newResult(reply[0] == 1, buckets, states), nil
+}
+
+// newResult summarizes the states of buckets
+func newResult(allowed bool, buckets []Bucket, states []bucketState) *Result {
+	result := &Result{Allowed: allowed, Remaining: math.MaxInt}
+	for i, state := range states {
+		if state.remaining < result.Remaining {
+			result.Limit = buckets[i].Limit.Burst
+			result.Remaining = state.remaining
+			result.Reset = state.reset
+		}
+		if !allowed && state.retry > result.RetryAfter {
+			result.RetryAfter = state.retry
+		}
+	}
+	return result
+}
+
+// allowScript refills and takes from the buckets in KEYS atomically, using
+// the Redis clock so that replicas agree. ARGV holds the rate and burst of
+// each bucket. It replies with 1 if the request is allowed, then the
+// remaining tokens, milliseconds until full and milliseconds until a token
+// is back of each bucket.
+var allowScript = redis.NewScript(`
+local now = redis.call('TIME')
+local now_ms = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+
+local tokens = {}
+local allowed = 1
+for i = 1, #KEYS do
+	local rate = tonumber(ARGV[2 * i - 1])
+	local burst = tonumber(ARGV[2 * i])
+	local state = redis.call('HMGET', KEYS[i], 'tokens', 'ts')
+	local t = tonumber(state[1])
+	local ts = tonumber(state[2])
+	if t == nil or ts == nil then
+		t = burst
+		ts = now_ms
+	end
+	t = math.min(burst, t + math.max(0, now_ms - ts) * rate / 1000)
+	tokens[i] = t
+	if t < 1 then
+		allowed = 0
+	end
+end
+
+local reply = {allowed}
+for i = 1, #KEYS do
+	local rate = tonumber(ARGV[2 * i - 1])
+	local burst = tonumber(ARGV[2 * i])
+	local t = tokens[i]
+	if allowed == 1 then
+		t = t - 1
+	end
+	local full_ms = math.ceil((burst - t) * 1000 / rate)
+	redis.call('HSET', KEYS[i], 'tokens', tostring(t), 'ts', now_ms)
+	redis.call('PEXPIRE', KEYS[i], full_ms + 1000)
+
+	local retry_ms = 0
+	if t < 1 then
+		retry_ms = math.ceil((1 - t) * 1000 / rate)
+	end
+	table.insert(reply, math.floor(t))
+	table.insert(reply, full_ms)
+	table.insert(reply, retry_ms)
+end
+return reply
+`)
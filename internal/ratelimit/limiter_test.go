@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// scriptHook answers the limiter's script with reply, or fails with err,
+// instead of a server
+type scriptHook struct {
+	reply []interface{}
+	err   error
+	calls int
+}
+
+func (h *scriptHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no redis in tests")
+	}
+}
+
+func (h *scriptHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.calls++
+		if h.err != nil {
+			cmd.SetErr(h.err)
+			return h.err
+		}
+		cmd.(*redis.Cmd).SetVal(h.reply)
+		return nil
+	}
+}
+
+func (h *scriptHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestLimiterAllow(t *testing.T) {
+	buckets := []Bucket{
+		{Key: "{user:alice}", Limit: Limit{Rate: 10, Burst: 20}},
+		{Key: "{user:alice}:POST /auth/login", Limit: Limit{Rate: 1, Burst: 5}},
+	}
+
+	tests := []struct {
+		name string
+		hook *scriptHook
+		want Result
+	}{
+		{
+			name: "allowed",
+			hook: &scriptHook{reply: []interface{}{int64(1), int64(12), int64(800), int64(0), int64(3), int64(2000), int64(0)}},
+			want: Result{Allowed: true, Limit: 5, Remaining: 3, Reset: 2 * time.Second},
+		},
+		{
+			name: "denied",
+			hook: &scriptHook{reply: []interface{}{int64(0), int64(0), int64(2000), int64(100), int64(4), int64(1000), int64(0)}},
+			want: Result{Allowed: false, Limit: 20, Remaining: 0, Reset: 2 * time.Second, RetryAfter: 100 * time.Millisecond},
+		},
+		// The memory fallback starts with full buckets
+		{
+			name: "redis down",
+			hook: &scriptHook{err: errors.New("connection refused")},
+			want: Result{Allowed: true, Limit: 5, Remaining: 4, Reset: time.Second},
+		},
+		{
+			name: "malformed reply",
+			hook: &scriptHook{reply: []interface{}{int64(1)}},
+			want: Result{Allowed: true, Limit: 5, Remaining: 4, Reset: time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := redis.NewClient(&redis.Options{Addr: "redis.invalid:6379"})
+			client.AddHook(tt.hook)
+			defer client.Close()
+
+			if got := NewLimiter(client).Allow(context.Background(), buckets); *got != tt.want {
+				t.Fatalf("Allow = %+v, want %+v", *got, tt.want)
+			}
+			if tt.hook.calls == 0 {
+				t.Fatal("limiter did not run the script")
+			}
+		})
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	buckets := []Bucket{{Limit: Limit{Burst: 10}}, {Limit: Limit{Burst: 2}}}
+
+	tests := []struct {
+		name    string
+		allowed bool
+		states  []bucketState
+		want    Result
+	}{
+		{
+			name:    "fewest tokens left",
+			allowed: true,
+			states:  []bucketState{{remaining: 6, reset: 400 * time.Millisecond}, {remaining: 1, reset: time.Second}},
+			want:    Result{Allowed: true, Limit: 2, Remaining: 1, Reset: time.Second},
+		},
+		{
+			name:    "retry after the slowest bucket",
+			allowed: false,
+			states:  []bucketState{{remaining: 0, reset: 3 * time.Second, retry: 300 * time.Millisecond}, {remaining: 0, reset: 2 * time.Second, retry: time.Second}},
+			want:    Result{Allowed: false, Limit: 10, Remaining: 0, Reset: 3 * time.Second, RetryAfter: time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newResult(tt.allowed, buckets, tt.states); *got != tt.want {
+				t.Fatalf("newResult = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often full buckets are dropped from memory
+const sweepInterval = time.Minute
+
+// MemoryLimiter keeps token buckets in memory, for a single replica
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	tokens float64
+	at     time.Time
+	full   time.Time // when the bucket is full again and can be dropped
+}
+
+// NewMemoryLimiter creates an empty MemoryLimiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: make(map[string]*memoryBucket)}
+}
+
+// Allow is like Limiter.Allow, at time now
+func (l *MemoryLimiter) Allow(buckets []Bucket, now time.Time) *Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	tokens := make([]float64, len(buckets))
+	allowed := true
+	for i, bucket := range buckets {
+		burst := float64(bucket.Limit.Burst)
+		tokens[i] = burst
+		if state, ok := l.buckets[bucket.Key]; ok {
+			tokens[i] = math.Min(burst, state.tokens+now.Sub(state.at).Seconds()*bucket.Limit.Rate)
+		}
+		if tokens[i] < 1 {
+			allowed = false
+		}
+	}
+
+	states := make([]bucketState, len(buckets))
+	for i, bucket := range buckets {
+		t := tokens[i]
+		if allowed {
+			t--
+		}
+		reset := secondsToDuration((float64(bucket.Limit.Burst) - t) / bucket.Limit.Rate)
+		l.buckets[bucket.Key] = &memoryBucket{tokens: t, at: now, full: now.Add(reset)}
+
+		states[i] = bucketState{remaining: int(math.Floor(t)), reset: reset}
+		if t < 1 {
+			states[i].retry = secondsToDuration((1 - t) / bucket.Limit.Rate)
+		}
+	}
+	return newResult(allowed, buckets, states)
+}
+
+// sweep drops the buckets that are full again, which are the same as
+// buckets never used
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, bucket := range l.buckets {
+		if !now.Before(bucket.full) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// secondsToDuration rounds up to the millisecond, like the Redis script
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds*1000)) * time.Millisecond
+}
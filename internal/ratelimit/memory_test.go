@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiterAllow(t *testing.T) {
+	start := time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)
+	client := Bucket{Key: "{ip:10.0.0.1}", Limit: Limit{Rate: 2, Burst: 3}}
+	route := Bucket{Key: "{ip:10.0.0.1}:POST /auth/login", Limit: Limit{Rate: 0.5, Burst: 1}}
+
+	// Each step takes from buckets after elapsed
+	type step struct {
+		elapsed time.Duration
+		buckets []Bucket
+		want    Result
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "burst then refill",
+			steps: []step{
+				{0, []Bucket{client}, Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond}},
+				{0, []Bucket{client}, Result{Allowed: true, Limit: 3, Remaining: 1, Reset: time.Second}},
+				{0, []Bucket{client}, Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond}},
+				{0, []Bucket{client}, Result{Allowed: false, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond, RetryAfter: 500 * time.Millisecond}},
+				{500 * time.Millisecond, []Bucket{client}, Result{Allowed: true, Limit: 3, Remaining: 0, Reset: 1500 * time.Millisecond}},
+			},
+		},
+		{
+			name: "full again after idling",
+			steps: []step{
+				{0, []Bucket{client}, Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond}},
+				{time.Hour, []Bucket{client}, Result{Allowed: true, Limit: 3, Remaining: 2, Reset: 500 * time.Millisecond}},
+			},
+		},
+		{
+			name: "route bucket is the tighter one",
+			steps: []step{
+				{0, []Bucket{client, route}, Result{Allowed: true, Limit: 1, Remaining: 0, Reset: 2 * time.Second}},
+				{0, []Bucket{client, route}, Result{Allowed: false, Limit: 1, Remaining: 0, Reset: 2 * time.Second, RetryAfter: 2 * time.Second}},
+				// The denied request took no token from the client bucket
+				{0, []Bucket{client}, Result{Allowed: true, Limit: 3, Remaining: 1, Reset: time.Second}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limiter := NewMemoryLimiter()
+			now := start
+			for i, step := range tt.steps {
+				now = now.Add(step.elapsed)
+				if got := limiter.Allow(step.buckets, now); *got != step.want {
+					t.Fatalf("step %d: Allow = %+v, want %+v", i+1, *got, step.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryLimiterSweep(t *testing.T) {
+	start := time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)
+	limiter := NewMemoryLimiter()
+	limiter.Allow([]Bucket{{Key: "slow", Limit: Limit{Rate: 0.001, Burst: 1}}}, start)
+	limiter.Allow([]Bucket{{Key: "fast", Limit: Limit{Rate: 10, Burst: 1}}}, start)
+
+	// Buckets are swept at most once per interval
+	limiter.Allow(nil, start.Add(sweepInterval/2))
+	if len(limiter.buckets) != 2 {
+		t.Fatalf("%d buckets before the sweep, want 2", len(limiter.buckets))
+	}
+	limiter.Allow(nil, start.Add(sweepInterval))
+	if _, ok := limiter.buckets["fast"]; ok || len(limiter.buckets) != 1 {
+		t.Fatalf("buckets after the sweep = %v, want only the slow one", limiter.buckets)
+	}
+}
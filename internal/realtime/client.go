@@ -0,0 +1,93 @@
+package realtime
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// client is a single WebSocket connection of a user
+type client struct {
+	id        string
+	userID    string
+	conn      *websocket.Conn
+	send      chan []byte
+	gateway   *Gateway
+	done      chan struct{}
+	closeOnce sync.Once
+	// logger is the logger of the upgrade request, so connection logs
+	// carry its request and trace IDs
+	logger *slog.Logger
+}
+
+func (c *client) owner() string      { return c.userID }
+func (c *client) queue() chan []byte { return c.send }
+
+// close stops the connection; it is safe to call more than once
+func (c *client) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// readPump consumes client frames so control frames (pongs, close) are
+// processed. Clients only listen, so data frames are discarded.
+func (c *client) readPump() {
+	defer c.close()
+
+	cfg := c.gateway.cfg
+	c.conn.SetReadLimit(cfg.MaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(cfg.PingInterval + cfg.PongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(cfg.PingInterval + cfg.PongTimeout))
+	})
+
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// writePump writes queued events and heartbeat pings. It owns the
+// connection and tears it down when the client is closed.
+func (c *client) writePump() {
+	cfg := c.gateway.cfg
+	ticker := time.NewTicker(cfg.PingInterval)
+
+	defer func() {
+		ticker.Stop()
+		c.gateway.hub.unregister(c)
+		if err := c.gateway.registry.release(context.Background(), c.userID, c.id); err != nil {
+			c.logger.Error("failed to release realtime connection", "error", err)
+		}
+		_ = c.conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(cfg.PongTimeout))
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			return
+
+		case message := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(cfg.PongTimeout))
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(cfg.PongTimeout))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+			if err := c.gateway.registry.refresh(context.Background(), c.userID, c.id); err != nil {
+				c.logger.Warn("failed to refresh realtime connection", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event types pushed to connected clients
+const (
+	EventConnected            = "connected"
+	EventNotificationCreated  = "notification.created"
+	EventUnreadCountChanged   = "notification.unread_count"
+	EventMeetingStatusChanged = "meeting.status_changed"
+	EventCustomerAssigned     = "crm.customer_assigned"
+)
+
+// Event is a message pushed to connected clients
+type Event struct {
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	SentAt time.Time       `json:"sent_at"`
+}
+
+// envelope is an event addressed to users, as published on Redis
+type envelope struct {
+	UserIDs []string        `json:"user_ids"`
+	Event   json.RawMessage `json:"event"`
+}
+
+// encodeEvent marshals an event with the given payload
+func encodeEvent(eventType string, data interface{}) ([]byte, error) {
+	event := Event{Type: eventType, SentAt: time.Now().UTC()}
+	if data != nil {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		event.Data = payload
+	}
+	return json.Marshal(event)
+}
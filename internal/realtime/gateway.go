@@ -0,0 +1,148 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/response"
+)
+
+// Gateway serves authenticated WebSocket connections and pushes them the
+// events published through a Publisher on any server replica
+type Gateway struct {
+	cfg      config.WebSocketConfig
+	redis    *datastore.RedisStore
+	hub      *hub
+	registry *connectionRegistry
+	upgrader websocket.Upgrader
+}
+
+// NewGateway creates a new Gateway. Browser connections are accepted from
+// the CORS origins in effect, which may change on reload.
+func NewGateway(live *config.Live, redis *datastore.RedisStore) *Gateway {
+	wsCfg := live.Current().WebSocket
+	if wsCfg.PingInterval <= 0 {
+		wsCfg.PingInterval = 30 * time.Second
+	}
+	if wsCfg.PongTimeout <= 0 {
+		wsCfg.PongTimeout = 10 * time.Second
+	}
+	if wsCfg.MaxMessageSize <= 0 {
+		wsCfg.MaxMessageSize = 512
+	}
+	if wsCfg.SendBufferSize <= 0 {
+		wsCfg.SendBufferSize = 64
+	}
+
+	return &Gateway{
+		cfg:      wsCfg,
+		redis:    redis,
+		hub:      newHub(),
+		registry: newConnectionRegistry(redis, wsCfg.MaxConnectionsPerUser, 2*wsCfg.PingInterval+wsCfg.PongTimeout),
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  wsCfg.ReadBufferSize,
+			WriteBufferSize: wsCfg.WriteBufferSize,
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				// Non-browser clients send no origin
+				if origin == "" {
+					return true
+				}
+				for _, allowed := range live.Current().CORS.AllowedOrigins {
+					if allowed == "*" || allowed == origin {
+						return true
+					}
+				}
+				return false
+			},
+		},
+	}
+}
+
+// Run relays published events to local connections until ctx is
+// cancelled, then closes every connection
+func (g *Gateway) Run(ctx context.Context) {
+	pubsub := g.redis.Client.Subscribe(ctx, eventsChannel(g.redis))
+	defer pubsub.Close()
+	defer g.hub.closeAll()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var env envelope
+			if err := json.Unmarshal([]byte(message.Payload), &env); err != nil {
+				logging.FromContext(ctx).Warn("dropping malformed realtime event", "error", err)
+				continue
+			}
+			g.hub.deliver(env.UserIDs, env.Event)
+		}
+	}
+}
+
+// ServeWS upgrades an authenticated request to a WebSocket connection
+// @Summary Realtime updates
+// @Description Open a WebSocket that receives notification, meeting status and CRM assignment events. Browsers pass the access token as the access_token query parameter.
+// @Tags realtime
+// @Security BearerAuth
+// @Param access_token query string false "Access token for clients that cannot set headers"
+// @Success 101
+// @Failure 401 {object} response.Response
+// @Failure 429 {object} response.Response
+// @Router /ws [get]
+func (g *Gateway) ServeWS(c *gin.Context) {
+	userID := c.GetString("user_id")
+	connectionID := uuid.New().String()
+	logger := logging.FromContext(c.Request.Context()).With("connection_id", connectionID)
+
+	acquired, err := g.registry.acquire(c.Request.Context(), userID, connectionID)
+	if err != nil {
+		response.InternalServerError(c, "Failed to open realtime connection")
+		return
+	}
+	if !acquired {
+		response.Error(c, http.StatusTooManyRequests, "TOO_MANY_CONNECTIONS", "Too many realtime connections for this user", "")
+		return
+	}
+
+	conn, err := g.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written the error response
+		if err := g.registry.release(context.Background(), userID, connectionID); err != nil {
+			logger.Error("failed to release realtime connection", "error", err)
+		}
+		return
+	}
+
+	cl := &client{
+		id:      connectionID,
+		userID:  userID,
+		conn:    conn,
+		send:    make(chan []byte, g.cfg.SendBufferSize),
+		gateway: g,
+		done:    make(chan struct{}),
+		logger:  logger,
+	}
+	g.hub.register(cl)
+
+	if welcome, err := encodeEvent(EventConnected, gin.H{"user_id": userID}); err == nil {
+		cl.send <- welcome
+	}
+
+	go cl.writePump()
+	go cl.readPump()
+}
@@ -0,0 +1,246 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/redis/go-redis/v9"
+)
+
+// registryHook answers the connection registry's commands instead of a
+// server: the acquire script returns added, or fails with err
+type registryHook struct {
+	mu       sync.Mutex
+	added    bool
+	err      error
+	released []string
+}
+
+func (h *registryHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no redis in tests")
+	}
+}
+
+func (h *registryHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		switch cmd := cmd.(type) {
+		case *redis.Cmd: // EVALSHA of the acquire script
+			if h.err != nil {
+				cmd.SetErr(h.err)
+				return h.err
+			}
+			if h.added {
+				cmd.SetVal(int64(1))
+			} else {
+				cmd.SetVal(int64(0))
+			}
+		case *redis.IntCmd: // ZREM on release
+			h.released = append(h.released, cmd.Args()[2].(string))
+			cmd.SetVal(1)
+		}
+		return nil
+	}
+}
+
+func (h *registryHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		return nil
+	}
+}
+
+func (h *registryHook) releasedCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.released)
+}
+
+func newTestGateway(hook *registryHook, maxPerUser, sendBuffer int) *Gateway {
+	client := redis.NewClient(&redis.Options{Addr: "redis.invalid:6379"})
+	client.AddHook(hook)
+
+	live := config.NewLive(&config.Config{
+		CORS: config.CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+		WebSocket: config.WebSocketConfig{
+			MaxConnectionsPerUser: maxPerUser,
+			SendBufferSize:        sendBuffer,
+		},
+	})
+	return NewGateway(live, &datastore.RedisStore{Client: client})
+}
+
+func TestSubscriptionDelivery(t *testing.T) {
+	g := newTestGateway(&registryHook{}, 0, 1)
+
+	alice1, alice2, bob := g.Subscribe("alice"), g.Subscribe("alice"), g.Subscribe("bob")
+	defer bob.Close()
+
+	g.hub.deliver([]string{"alice", "carol"}, []byte("first"))
+	for i, sub := range []*Subscription{alice1, alice2} {
+		select {
+		case message := <-sub.Events():
+			if string(message) != "first" {
+				t.Fatalf("subscription %d got %q, want first", i+1, message)
+			}
+		default:
+			t.Fatalf("subscription %d got nothing", i+1)
+		}
+	}
+	if len(bob.Events()) != 0 {
+		t.Fatal("bob got alice's event")
+	}
+
+	// A subscription that falls behind is closed instead of blocking others
+	g.hub.deliver([]string{"alice"}, []byte("second"))
+	<-alice2.Events()
+	g.hub.deliver([]string{"alice"}, []byte("third"))
+	select {
+	case <-alice1.Done():
+	default:
+		t.Fatal("full subscription was not closed")
+	}
+	select {
+	case <-alice2.Done():
+		t.Fatal("subscription keeping up was closed")
+	default:
+	}
+
+	alice1.Close()
+	alice2.Close()
+	if _, ok := g.hub.receivers["alice"]; ok {
+		t.Fatal("closed subscriptions are still registered")
+	}
+
+	g.hub.closeAll()
+	select {
+	case <-bob.Done():
+	default:
+		t.Fatal("closeAll left a subscription open")
+	}
+}
+
+func TestEncodeEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     interface{}
+		wantData string
+		wantErr  bool
+	}{
+		{"no data", nil, "", false},
+		{"data", map[string]string{"meeting_id": "m-1"}, `{"meeting_id":"m-1"}`, false},
+		{"unencodable", make(chan int), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := encodeEvent(EventMeetingStatusChanged, tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encodeEvent: err = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			event, err := DecodeEvent(message)
+			if err != nil {
+				t.Fatalf("DecodeEvent: %v", err)
+			}
+			if event.Type != EventMeetingStatusChanged || string(event.Data) != tt.wantData || event.SentAt.IsZero() {
+				t.Fatalf("event = %+v", event)
+			}
+		})
+	}
+}
+
+func TestServeWS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		hook         *registryHook
+		origin       string
+		wantStatus   int
+		wantReleased int
+	}{
+		{"connected", &registryHook{added: true}, "", http.StatusSwitchingProtocols, 1},
+		{"allowed origin", &registryHook{added: true}, "https://app.example.com", http.StatusSwitchingProtocols, 1},
+		{"foreign origin", &registryHook{added: true}, "https://evil.example.com", http.StatusForbidden, 1},
+		{"at the connection limit", &registryHook{added: false}, "", http.StatusTooManyRequests, 0},
+		{"registry down", &registryHook{err: errors.New("connection refused")}, "", http.StatusInternalServerError, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGateway(tt.hook, 3, 8)
+			router := gin.New()
+			router.GET("/ws", func(c *gin.Context) {
+				c.Set("user_id", "alice")
+				g.ServeWS(c)
+			})
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			header := http.Header{}
+			if tt.origin != "" {
+				header.Set("Origin", tt.origin)
+			}
+			conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", header)
+			if resp == nil || resp.StatusCode != tt.wantStatus {
+				t.Fatalf("Dial: response %v, err %v, want status %d", resp, err, tt.wantStatus)
+			}
+			if err != nil {
+				if released := tt.hook.releasedCount(); released != tt.wantReleased {
+					t.Fatalf("released %d connections, want %d", released, tt.wantReleased)
+				}
+				return
+			}
+
+			// The welcome event is queued after the connection is registered
+			_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			if event := readEvent(t, conn); event.Type != EventConnected || string(event.Data) != `{"user_id":"alice"}` {
+				t.Fatalf("first event = %+v, want the welcome", event)
+			}
+			notification, _ := encodeEvent(EventNotificationCreated, map[string]string{"id": "n-1"})
+			g.hub.deliver([]string{"alice"}, notification)
+			if event := readEvent(t, conn); event.Type != EventNotificationCreated {
+				t.Fatalf("second event = %+v, want the notification", event)
+			}
+
+			conn.Close()
+			deadline := time.Now().Add(5 * time.Second)
+			for tt.hook.releasedCount() != tt.wantReleased {
+				if time.Now().After(deadline) {
+					t.Fatal("closed connection was not released")
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn) *Event {
+	t.Helper()
+	_, message, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	event, err := DecodeEvent(message)
+	if err != nil {
+		t.Fatalf("DecodeEvent: %v", err)
+	}
+	return event
+}
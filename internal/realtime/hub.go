@@ -0,0 +1,73 @@
+package realtime
+
+import "sync"
+
+// receiver is a local consumer of a user's events: a WebSocket connection
+// or a Subscription
+type receiver interface {
+	owner() string
+	queue() chan []byte
+	close()
+}
+
+// hub tracks the receivers held by this server replica
+type hub struct {
+	mu        sync.RWMutex
+	receivers map[string]map[receiver]struct{}
+}
+
+func newHub() *hub {
+	return &hub{receivers: make(map[string]map[receiver]struct{})}
+}
+
+func (h *hub) register(r receiver) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	userID := r.owner()
+	if h.receivers[userID] == nil {
+		h.receivers[userID] = make(map[receiver]struct{})
+	}
+	h.receivers[userID][r] = struct{}{}
+}
+
+func (h *hub) unregister(r receiver) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	userID := r.owner()
+	delete(h.receivers[userID], r)
+	if len(h.receivers[userID]) == 0 {
+		delete(h.receivers, userID)
+	}
+}
+
+// deliver queues a message on every local receiver of the given users.
+// Receivers whose queue is full are closed rather than blocking delivery
+// to everyone else; their clients reconnect and refetch.
+func (h *hub) deliver(userIDs []string, message []byte) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, userID := range userIDs {
+		for r := range h.receivers[userID] {
+			select {
+			case r.queue() <- message:
+			default:
+				r.close()
+			}
+		}
+	}
+}
+
+// closeAll closes every local receiver
+func (h *hub) closeAll() {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, receivers := range h.receivers {
+		for r := range receivers {
+			r.close()
+		}
+	}
+}
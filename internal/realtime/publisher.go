@@ -0,0 +1,51 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+)
+
+// Publisher pushes events to the connected clients of users
+type Publisher interface {
+	Publish(ctx context.Context, userIDs []string, eventType string, data interface{}) error
+}
+
+// RedisPublisher publishes events on the Redis channel that the gateway of
+// every server replica subscribes to, so an event reaches the user no matter
+// which replica holds the connection
+type RedisPublisher struct {
+	redis   *datastore.RedisStore
+	channel string
+}
+
+// NewRedisPublisher creates a new RedisPublisher
+func NewRedisPublisher(redis *datastore.RedisStore) *RedisPublisher {
+	return &RedisPublisher{
+		redis:   redis,
+		channel: eventsChannel(redis),
+	}
+}
+
+// Publish sends an event to every connection of the given users
+func (p *RedisPublisher) Publish(ctx context.Context, userIDs []string, eventType string, data interface{}) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	event, err := encodeEvent(eventType, data)
+	if err != nil {
+		return err
+	}
+	message, err := json.Marshal(envelope{UserIDs: userIDs, Event: event})
+	if err != nil {
+		return err
+	}
+
+	return p.redis.Client.Publish(ctx, p.channel, message).Err()
+}
+
+func eventsChannel(redis *datastore.RedisStore) string {
+	return redis.GetKey("realtime", "events")
+}
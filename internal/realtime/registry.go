@@ -0,0 +1,88 @@
+package realtime
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireScript prunes stale connections of a user, then adds the new one
+// unless the user is at the limit. Returns 1 when the connection was added.
+var acquireScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[4])
+redis.call('PEXPIRE', KEYS[1], ARGV[5])
+return 1
+`)
+
+// connectionRegistry counts the connections of each user across all server
+// replicas. Every connection is a member of a per-user sorted set scored by
+// when it was last seen alive, so connections of a crashed replica stop
+// counting once they miss their heartbeats.
+type connectionRegistry struct {
+	redis      *datastore.RedisStore
+	maxPerUser int
+	ttl        time.Duration
+}
+
+func newConnectionRegistry(redis *datastore.RedisStore, maxPerUser int, ttl time.Duration) *connectionRegistry {
+	return &connectionRegistry{
+		redis:      redis,
+		maxPerUser: maxPerUser,
+		ttl:        ttl,
+	}
+}
+
+// acquire registers a connection and reports whether the user was below
+// the limit
+func (r *connectionRegistry) acquire(ctx context.Context, userID, connectionID string) (bool, error) {
+	if r.maxPerUser <= 0 {
+		return true, nil
+	}
+
+	now := time.Now()
+	added, err := acquireScript.Run(ctx, r.redis.Client, []string{r.key(userID)},
+		now.Add(-r.ttl).UnixMilli(),
+		now.UnixMilli(),
+		r.maxPerUser,
+		connectionID,
+		r.ttl.Milliseconds(),
+	).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return added == 1, nil
+}
+
+// refresh marks a connection as alive
+func (r *connectionRegistry) refresh(ctx context.Context, userID, connectionID string) error {
+	if r.maxPerUser <= 0 {
+		return nil
+	}
+
+	key := r.key(userID)
+	pipe := r.redis.Client.TxPipeline()
+	pipe.ZAddXX(ctx, key, redis.Z{Score: float64(time.Now().UnixMilli()), Member: connectionID})
+	pipe.PExpire(ctx, key, r.ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// release removes a connection
+func (r *connectionRegistry) release(ctx context.Context, userID, connectionID string) error {
+	if r.maxPerUser <= 0 {
+		return nil
+	}
+
+	return r.redis.Client.ZRem(ctx, r.key(userID), connectionID).Err()
+}
+
+func (r *connectionRegistry) key(userID string) string {
+	return r.redis.GetKey("realtime", "connections:"+userID)
+}
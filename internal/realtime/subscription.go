@@ -0,0 +1,64 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Subscription receives the events published to a user while it is open,
+// for streaming transports other than the WebSocket gateway. It is closed
+// when its consumer falls behind or the gateway stops; consumers then end
+// the stream so the client reconnects and catches up.
+type Subscription struct {
+	userID    string
+	events    chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+	hub       *hub
+}
+
+// Subscribe opens a subscription to the events of a user
+func (g *Gateway) Subscribe(userID string) *Subscription {
+	s := &Subscription{
+		userID: userID,
+		events: make(chan []byte, g.cfg.SendBufferSize),
+		done:   make(chan struct{}),
+		hub:    g.hub,
+	}
+	g.hub.register(s)
+	return s
+}
+
+// Events returns the encoded events delivered to the subscription
+func (s *Subscription) Events() <-chan []byte {
+	return s.events
+}
+
+// Done is closed when the subscription stops receiving events
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	s.hub.unregister(s)
+	s.close()
+}
+
+func (s *Subscription) owner() string      { return s.userID }
+func (s *Subscription) queue() chan []byte { return s.events }
+
+func (s *Subscription) close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// DecodeEvent decodes an event received from a subscription
+func DecodeEvent(message []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(message, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
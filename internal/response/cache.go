@@ -0,0 +1,75 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// revalidateCacheControl lets clients keep successful reads, but makes
+	// them check with If-None-Match before each use, since the data may
+	// change at any time and is specific to the user
+	revalidateCacheControl = "private, no-cache"
+	// noStoreCacheControl keeps errors and the results of writes out of
+	// every cache
+	noStoreCacheControl = "no-store"
+)
+
+// render sends body as JSON with a Cache-Control header, unless the handler
+// set one. Successful reads carry an ETag of the body, and a request whose
+// If-None-Match lists it gets 304 Not Modified without the body.
+func render(c *gin.Context, statusCode int, body interface{}) {
+	method := c.Request.Method
+	if statusCode != http.StatusOK || (method != http.MethodGet && method != http.MethodHead) {
+		setCacheControl(c, noStoreCacheControl)
+		c.JSON(statusCode, body)
+		return
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.JSON(statusCode, body)
+		return
+	}
+
+	etag := etagOf(data)
+	setCacheControl(c, revalidateCacheControl)
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(statusCode, "application/json; charset=utf-8", data)
+}
+
+func setCacheControl(c *gin.Context, value string) {
+	if c.Writer.Header().Get("Cache-Control") == "" {
+		c.Header("Cache-Control", value)
+	}
+}
+
+// etagOf returns a strong entity tag for a response body
+func etagOf(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, using the
+// weak comparison the header calls for
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestEtagMatches(t *testing.T) {
+	const etag = `"0123abcd"`
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{"no header", "", false},
+		{"same", `"0123abcd"`, true},
+		{"weak", `W/"0123abcd"`, true},
+		{"in a list", `"ffff", W/"0123abcd" , "eeee"`, true},
+		{"any", "*", true},
+		{"other", `"ffff"`, false},
+		{"unquoted", "0123abcd", false},
+		{"prefix", `"0123"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := etagMatches(tt.ifNoneMatch, etag); got != tt.want {
+				t.Fatalf("etagMatches(%q) = %v, want %v", tt.ifNoneMatch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body := map[string]string{"id": "m-1"}
+	etag := etagOf([]byte(`{"id":"m-1"}`))
+
+	tests := []struct {
+		name             string
+		method           string
+		status           int
+		ifNoneMatch      string
+		cacheControl     string // set by the handler
+		wantStatus       int
+		wantCacheControl string
+		wantETag         string
+		wantBody         string
+	}{
+		{"read", http.MethodGet, http.StatusOK, "", "", http.StatusOK, "private, no-cache", etag, `{"id":"m-1"}`},
+		{"head", http.MethodHead, http.StatusOK, "", "", http.StatusOK, "private, no-cache", etag, ""},
+		{"not modified", http.MethodGet, http.StatusOK, etag, "", http.StatusNotModified, "private, no-cache", etag, ""},
+		{"changed", http.MethodGet, http.StatusOK, `"stale"`, "", http.StatusOK, "private, no-cache", etag, `{"id":"m-1"}`},
+		{"handler's cache control", http.MethodGet, http.StatusOK, "", "public, max-age=60", http.StatusOK, "public, max-age=60", etag, `{"id":"m-1"}`},
+		{"write", http.MethodPost, http.StatusOK, etag, "", http.StatusOK, "no-store", "", `{"id":"m-1"}`},
+		{"created", http.MethodPost, http.StatusCreated, "", "", http.StatusCreated, "no-store", "", `{"id":"m-1"}`},
+		{"error", http.MethodGet, http.StatusNotFound, etag, "", http.StatusNotFound, "no-store", "", `{"id":"m-1"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Handle(tt.method, "/meetings/m-1", func(c *gin.Context) {
+				if tt.cacheControl != "" {
+					c.Header("Cache-Control", tt.cacheControl)
+				}
+				render(c, tt.status, body)
+			})
+
+			req := httptest.NewRequest(tt.method, "/meetings/m-1", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != tt.wantCacheControl {
+				t.Fatalf("Cache-Control = %q, want %q", got, tt.wantCacheControl)
+			}
+			if got := rec.Header().Get("ETag"); got != tt.wantETag {
+				t.Fatalf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			if got := rec.Body.String(); tt.method != http.MethodHead && got != tt.wantBody {
+				t.Fatalf("body = %q, want %q", got, tt.wantBody)
+			}
+		})
+	}
+}
@@ -39,16 +39,16 @@ type Pagination struct {
 
 // Success sends a successful response
 func Success(c *gin.Context, statusCode int, message string, data interface{}) {
-	c.JSON(statusCode, Response{
+	render(c, statusCode, Response{
 		Success: true,
 		Message: message,
-		Data:    data,
+		Data:    localize(c, data),
 	})
 }
 
 // Error sends an error response
 func Error(c *gin.Context, statusCode int, code, message, details string) {
-	c.JSON(statusCode, Response{
+	render(c, statusCode, Response{
 		Success: false,
 		Error: &ErrorInfo{
 			Code:    code,
@@ -58,13 +58,26 @@ func Error(c *gin.Context, statusCode int, code, message, details string) {
 	})
 }
 
+// ErrorWithData sends an error response that also carries data,
+// e.g. the records that caused a conflict
+func ErrorWithData(c *gin.Context, statusCode int, code, message string, data interface{}) {
+	render(c, statusCode, Response{
+		Success: false,
+		Data:    localize(c, data),
+		Error: &ErrorInfo{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
 // Paginated sends a paginated response
 func Paginated(c *gin.Context, data interface{}, page, pageSize int, totalItems int64) {
 	totalPages := int((totalItems + int64(pageSize) - 1) / int64(pageSize))
 
-	c.JSON(http.StatusOK, PaginatedResponse{
+	render(c, http.StatusOK, PaginatedResponse{
 		Success: true,
-		Data:    data,
+		Data:    localize(c, data),
 		Pagination: Pagination{
 			Page:       page,
 			PageSize:   pageSize,
@@ -99,6 +112,11 @@ func Conflict(c *gin.Context, message string) {
 	Error(c, http.StatusConflict, "CONFLICT", message, "")
 }
 
+// TooManyRequests sends a 429 Too Many Requests response
+func TooManyRequests(c *gin.Context, message string) {
+	Error(c, http.StatusTooManyRequests, "TOO_MANY_REQUESTS", message, "")
+}
+
 // InternalServerError sends a 500 Internal Server Error response
 func InternalServerError(c *gin.Context, message string) {
 	Error(c, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", message, "")
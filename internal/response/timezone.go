@@ -0,0 +1,151 @@
+package response
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// locationKey is the context key holding the viewer's timezone
+const locationKey = "response_location"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// hasTimeCache remembers which types may contain a time.Time
+var hasTimeCache sync.Map
+
+// SetLocation renders the times in responses to this request in loc
+func SetLocation(c *gin.Context, loc *time.Location) {
+	c.Set(locationKey, loc)
+}
+
+// localize returns a copy of data with every time.Time converted to the
+// viewer's timezone. Data is returned unchanged when no timezone is set.
+// The original value is never modified, so cached or shared values can be
+// passed safely. Fields of unexported embedded structs cannot be set through
+// reflection and keep their UTC rendering.
+func localize(c *gin.Context, data interface{}) interface{} {
+	value, ok := c.Get(locationKey)
+	if !ok || data == nil {
+		return data
+	}
+	loc, ok := value.(*time.Location)
+	if !ok {
+		return data
+	}
+
+	return localizeValue(reflect.ValueOf(data), loc).Interface()
+}
+
+func localizeValue(v reflect.Value, loc *time.Location) reflect.Value {
+	if !hasTime(v.Type()) {
+		return v
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if v.Type() == timeType {
+			t := v.Interface().(time.Time)
+			// Zero times keep their UTC rendering
+			if t.IsZero() {
+				return v
+			}
+			return reflect.ValueOf(t.In(loc))
+		}
+
+		localized := reflect.New(v.Type()).Elem()
+		localized.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				localized.Field(i).Set(localizeValue(v.Field(i), loc))
+			}
+		}
+		return localized
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		localized := reflect.New(v.Type().Elem())
+		localized.Elem().Set(localizeValue(v.Elem(), loc))
+		return localized
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		localized := reflect.New(v.Type()).Elem()
+		localized.Set(localizeValue(v.Elem(), loc))
+		return localized
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		localized := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			localized.Index(i).Set(localizeValue(v.Index(i), loc))
+		}
+		return localized
+
+	case reflect.Array:
+		localized := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			localized.Index(i).Set(localizeValue(v.Index(i), loc))
+		}
+		return localized
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		localized := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			localized.SetMapIndex(iter.Key(), localizeValue(iter.Value(), loc))
+		}
+		return localized
+	}
+
+	return v
+}
+
+// hasTime reports whether values of t may hold a time.Time. Interfaces
+// may hold anything, so they always count.
+func hasTime(t reflect.Type) bool {
+	if cached, ok := hasTimeCache.Load(t); ok {
+		return cached.(bool)
+	}
+
+	result := typeHasTime(t, make(map[reflect.Type]bool))
+	hasTimeCache.Store(t, result)
+	return result
+}
+
+func typeHasTime(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if t == timeType {
+		return true
+	}
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return typeHasTime(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.IsExported() && typeHasTime(field.Type, visiting) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package response
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type localizedEvent struct {
+	Name     string
+	At       time.Time
+	EndsAt   *time.Time
+	Created  time.Time
+	Extra    interface{}
+	internal time.Time
+}
+
+func TestLocalize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	at := time.Date(2024, time.March, 11, 20, 0, 0, 0, time.UTC)
+	endsAt := at.Add(time.Hour)
+
+	event := localizedEvent{Name: "Sync", At: at, EndsAt: &endsAt, Extra: map[string]interface{}{"moved": at}, internal: at}
+
+	tests := []struct {
+		name string
+		data interface{}
+		want interface{}
+	}{
+		{"nil", nil, nil},
+		{"no times", map[string]int{"a": 1}, map[string]int{"a": 1}},
+		{"time", at, at.In(kolkata)},
+		{
+			"struct",
+			event,
+			localizedEvent{Name: "Sync", At: at.In(kolkata), EndsAt: ptrTime(endsAt.In(kolkata)), Extra: map[string]interface{}{"moved": at.In(kolkata)}, internal: at},
+		},
+		{"slice of pointers", []*time.Time{&endsAt, nil}, []*time.Time{ptrTime(endsAt.In(kolkata)), nil}},
+		{"array", [1]time.Time{at}, [1]time.Time{at.In(kolkata)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			SetLocation(c, kolkata)
+
+			got := localize(c, tt.data)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("localize = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	if event.At.Location() != time.UTC || event.EndsAt.Location() != time.UTC {
+		t.Fatal("localize modified the original value")
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if got := localize(c, event); !reflect.DeepEqual(got, event) {
+		t.Fatalf("without a location: localize = %#v, want the data unchanged", got)
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/config"
+)
+
+// MinPartSize is the smallest allowed multipart part except the last one.
+// It matches the S3 limit so uploads behave the same on every driver.
+const MinPartSize = 5 << 20 // 5 MiB
+
+// MaxParts is the largest allowed multipart part number
+const MaxParts = 10000
+
+var (
+	ErrNotFound      = errors.New("object not found")
+	ErrInvalidKey    = errors.New("invalid object key")
+	ErrInvalidPart   = errors.New("invalid upload part")
+	ErrUploadMissing = errors.New("multipart upload not found")
+)
+
+// Part describes an uploaded part of a multipart upload
+type Part struct {
+	Number int
+	Size   int64
+	ETag   string
+}
+
+// BlobStore stores binary objects such as meeting recordings
+type BlobStore interface {
+	// Put stores an object in a single request
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Delete removes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// CreateMultipartUpload starts a multipart upload and returns its ID
+	CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error)
+
+	// UploadPart stores one part of a multipart upload, replacing any
+	// earlier upload of the same part number
+	UploadPart(ctx context.Context, key, uploadID string, partNumber int, r io.Reader, size int64) (Part, error)
+
+	// ListParts returns the parts uploaded so far, ordered by number
+	ListParts(ctx context.Context, key, uploadID string) ([]Part, error)
+
+	// CompleteMultipartUpload assembles the given parts into the object
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []Part) error
+
+	// AbortMultipartUpload discards a multipart upload and its parts
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+
+	// SignedURL returns a URL that allows downloading the object until it expires
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// New creates the BlobStore selected by the storage driver
+func New(cfg *config.Config) (BlobStore, error) {
+	switch cfg.Storage.Driver {
+	case "", "local":
+		return NewLocalStore(cfg.Storage.Local.Root, cfg.Storage.Local.BaseURL, signingKey(cfg))
+	case "s3":
+		return NewS3Store(cfg.AWS.S3.Endpoint, cfg.AWS.Region, cfg.AWS.S3.Bucket,
+			cfg.AWS.S3.AccessKeyID, cfg.AWS.S3.SecretAccessKey, cfg.AWS.S3.UseSSL)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
+
+// signingKey returns the local URL signing key, falling back to the JWT secret
+func signingKey(cfg *config.Config) string {
+	if cfg.Storage.Local.SigningKey != "" {
+		return cfg.Storage.Local.SigningKey
+	}
+	return cfg.JWT.Secret
+}
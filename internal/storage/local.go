@@ -0,0 +1,338 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// uploadsDir holds in-progress multipart uploads below the store root
+const uploadsDir = ".uploads"
+
+// LocalStore implements BlobStore on the local filesystem. Signed URLs point
+// at Handler, which must be mounted at baseURL.
+type LocalStore struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+}
+
+// NewLocalStore creates a new LocalStore
+func NewLocalStore(root, baseURL, signingKey string) (*LocalStore, error) {
+	if root == "" {
+		return nil, errors.New("local storage root is required")
+	}
+	if signingKey == "" {
+		return nil, errors.New("local storage signing key is required")
+	}
+	if err := os.MkdirAll(filepath.Join(root, uploadsDir), 0o750); err != nil {
+		return nil, err
+	}
+
+	return &LocalStore{
+		root:       root,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		signingKey: []byte(signingKey),
+	}, nil
+}
+
+// Put stores an object in a single request
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	target, err := s.objectPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+
+	return writeAtomic(target, func(f *os.File) error {
+		written, err := io.Copy(f, r)
+		if err != nil {
+			return err
+		}
+		if size >= 0 && written != size {
+			return fmt.Errorf("expected %d bytes, got %d", size, written)
+		}
+		return nil
+	})
+}
+
+// Delete removes an object
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	target, err := s.objectPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// CreateMultipartUpload starts a multipart upload
+func (s *LocalStore) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	if _, err := s.objectPath(key); err != nil {
+		return "", err
+	}
+
+	uploadID := uuid.New().String()
+	if err := os.MkdirAll(s.uploadPath(uploadID), 0o750); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(s.uploadPath(uploadID), "key"), []byte(key), 0o640); err != nil {
+		return "", err
+	}
+
+	return uploadID, nil
+}
+
+// UploadPart stores one part of a multipart upload
+func (s *LocalStore) UploadPart(ctx context.Context, key, uploadID string, partNumber int, r io.Reader, size int64) (Part, error) {
+	if partNumber < 1 || partNumber > MaxParts {
+		return Part{}, ErrInvalidPart
+	}
+	dir, err := s.checkUpload(key, uploadID)
+	if err != nil {
+		return Part{}, err
+	}
+
+	hash := md5.New()
+	var written int64
+	err = writeAtomic(filepath.Join(dir, strconv.Itoa(partNumber)), func(f *os.File) error {
+		n, err := io.Copy(io.MultiWriter(f, hash), r)
+		if err != nil {
+			return err
+		}
+		written = n
+		if size >= 0 && written != size {
+			return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidPart, size, written)
+		}
+		return nil
+	})
+	if err != nil {
+		return Part{}, err
+	}
+
+	return Part{Number: partNumber, Size: written, ETag: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// ListParts returns the parts uploaded so far
+func (s *LocalStore) ListParts(ctx context.Context, key, uploadID string) ([]Part, error) {
+	dir, err := s.checkUpload(key, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]Part, 0, len(entries))
+	for _, entry := range entries {
+		number, err := strconv.Atoi(entry.Name())
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, Part{Number: number, Size: info.Size()})
+	}
+
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].Number < parts[j].Number
+	})
+
+	return parts, nil
+}
+
+// CompleteMultipartUpload assembles the given parts into the object
+func (s *LocalStore) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []Part) error {
+	dir, err := s.checkUpload(key, uploadID)
+	if err != nil {
+		return err
+	}
+	target, err := s.objectPath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+		return err
+	}
+
+	err = writeAtomic(target, func(f *os.File) error {
+		for i, part := range parts {
+			if i > 0 && part.Number <= parts[i-1].Number {
+				return ErrInvalidPart
+			}
+			if i < len(parts)-1 && part.Size < MinPartSize {
+				return fmt.Errorf("%w: part %d is smaller than %d bytes", ErrInvalidPart, part.Number, MinPartSize)
+			}
+			if err := appendFile(f, filepath.Join(dir, strconv.Itoa(part.Number))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(dir)
+}
+
+// AbortMultipartUpload discards a multipart upload
+func (s *LocalStore) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	dir, err := s.checkUpload(key, uploadID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// SignedURL returns an HMAC-signed download URL for the object
+func (s *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	target, err := s.objectPath(key)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(target); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+
+	return s.baseURL + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+// Handler returns a handler serving objects requested with a valid,
+// unexpired signed URL. The request path is the object key, so it must be
+// mounted at baseURL with that prefix stripped.
+func (s *LocalStore) Handler() http.Handler {
+	return http.HandlerFunc(s.serveSigned)
+}
+
+func (s *LocalStore) serveSigned(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	query := r.URL.Query()
+	expires := query.Get("expires")
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt ||
+		!hmac.Equal([]byte(s.sign(key, expires)), []byte(query.Get("signature"))) {
+		http.Error(w, "Invalid or expired link", http.StatusForbidden)
+		return
+	}
+
+	target, err := s.objectPath(key)
+	if err != nil {
+		http.Error(w, "Object not found", http.StatusNotFound)
+		return
+	}
+	if _, err := os.Stat(target); err != nil {
+		http.Error(w, "Object not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(key)))
+	w.Header().Set("Cache-Control", "private, no-store")
+	http.ServeFile(w, r, target)
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// objectPath maps a key to a path below the root, rejecting traversal
+func (s *LocalStore) objectPath(key string) (string, error) {
+	clean := path.Clean("/" + key)[1:]
+	if key == "" || clean != key || strings.HasPrefix(clean, uploadsDir) {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
+
+func (s *LocalStore) uploadPath(uploadID string) string {
+	return filepath.Join(s.root, uploadsDir, uploadID)
+}
+
+// checkUpload verifies that the upload exists and belongs to key
+func (s *LocalStore) checkUpload(key, uploadID string) (string, error) {
+	if _, err := uuid.Parse(uploadID); err != nil {
+		return "", ErrUploadMissing
+	}
+
+	dir := s.uploadPath(uploadID)
+	stored, err := os.ReadFile(filepath.Join(dir, "key"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrUploadMissing
+		}
+		return "", err
+	}
+	if string(stored) != key {
+		return "", ErrUploadMissing
+	}
+
+	return dir, nil
+}
+
+// writeAtomic writes to a temporary file and renames it over target on success
+func writeAtomic(target string, write func(f *os.File) error) error {
+	f, err := os.CreateTemp(filepath.Dir(target), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), target)
+}
+
+func appendFile(dst *os.File, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrInvalidPart
+		}
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(dst, f)
+	return err
+}
@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocalStore(t *testing.T) *LocalStore {
+	t.Helper()
+	store, err := NewLocalStore(t.TempDir(), "http://localhost/files", "test-signing-key")
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	return store
+}
+
+func TestLocalStoreMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+	key := "recordings/meeting/recording.webm"
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/webm")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload: %v", err)
+	}
+
+	first := bytes.Repeat([]byte("a"), MinPartSize)
+	second := []byte("tail")
+
+	// Parts may arrive out of order and be uploaded again
+	if _, err := store.UploadPart(ctx, key, uploadID, 2, bytes.NewReader([]byte("stale")), 5); err != nil {
+		t.Fatalf("UploadPart 2: %v", err)
+	}
+	if _, err := store.UploadPart(ctx, key, uploadID, 1, bytes.NewReader(first), int64(len(first))); err != nil {
+		t.Fatalf("UploadPart 1: %v", err)
+	}
+	if _, err := store.UploadPart(ctx, key, uploadID, 2, bytes.NewReader(second), int64(len(second))); err != nil {
+		t.Fatalf("UploadPart 2 again: %v", err)
+	}
+
+	parts, err := store.ListParts(ctx, key, uploadID)
+	if err != nil {
+		t.Fatalf("ListParts: %v", err)
+	}
+	if len(parts) != 2 || parts[0].Number != 1 || parts[0].Size != int64(len(first)) || parts[1].Number != 2 || parts[1].Size != int64(len(second)) {
+		t.Fatalf("ListParts = %+v", parts)
+	}
+
+	if err := store.CompleteMultipartUpload(ctx, key, uploadID, parts); err != nil {
+		t.Fatalf("CompleteMultipartUpload: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(store.root, filepath.FromSlash(key)))
+	if err != nil {
+		t.Fatalf("reading object: %v", err)
+	}
+	if !bytes.Equal(data, append(append([]byte(nil), first...), second...)) {
+		t.Fatalf("object has %d bytes, want %d", len(data), len(first)+len(second))
+	}
+
+	if _, err := store.ListParts(ctx, key, uploadID); !errors.Is(err, ErrUploadMissing) {
+		t.Fatalf("ListParts after completion: err = %v, want ErrUploadMissing", err)
+	}
+}
+
+func TestLocalStoreCompleteRejectsSmallParts(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+	key := "recordings/small.webm"
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/webm")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload: %v", err)
+	}
+	for number := 1; number <= 2; number++ {
+		if _, err := store.UploadPart(ctx, key, uploadID, number, bytes.NewReader([]byte("part")), 4); err != nil {
+			t.Fatalf("UploadPart %d: %v", number, err)
+		}
+	}
+	parts, err := store.ListParts(ctx, key, uploadID)
+	if err != nil {
+		t.Fatalf("ListParts: %v", err)
+	}
+
+	if err := store.CompleteMultipartUpload(ctx, key, uploadID, parts); !errors.Is(err, ErrInvalidPart) {
+		t.Fatalf("CompleteMultipartUpload: err = %v, want ErrInvalidPart", err)
+	}
+}
+
+func TestLocalStoreAbortMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+	key := "recordings/aborted.webm"
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/webm")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload: %v", err)
+	}
+	if _, err := store.UploadPart(ctx, key, uploadID, 1, bytes.NewReader([]byte("part")), 4); err != nil {
+		t.Fatalf("UploadPart: %v", err)
+	}
+
+	if err := store.AbortMultipartUpload(ctx, key, uploadID); err != nil {
+		t.Fatalf("AbortMultipartUpload: %v", err)
+	}
+	if _, err := os.Stat(store.uploadPath(uploadID)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("upload directory still exists: %v", err)
+	}
+	if err := store.AbortMultipartUpload(ctx, key, uploadID); !errors.Is(err, ErrUploadMissing) {
+		t.Fatalf("second AbortMultipartUpload: err = %v, want ErrUploadMissing", err)
+	}
+}
+
+func TestLocalStoreUploadPartValidation(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+	key := "recordings/validation.webm"
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/webm")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		uploadID   string
+		partNumber int
+		body       string
+		size       int64
+		want       error
+	}{
+		{"part number zero", key, uploadID, 0, "data", 4, ErrInvalidPart},
+		{"part number too large", key, uploadID, MaxParts + 1, "data", 4, ErrInvalidPart},
+		{"size mismatch", key, uploadID, 1, "data", 10, ErrInvalidPart},
+		{"other key", "recordings/other.webm", uploadID, 1, "data", 4, ErrUploadMissing},
+		{"unknown upload", key, "5d1c8f5e-8a49-4a39-a2a4-8d1d3c1f0a77", 1, "data", 4, ErrUploadMissing},
+		{"malformed upload ID", key, "../../etc", 1, "data", 4, ErrUploadMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := store.UploadPart(ctx, tt.key, tt.uploadID, tt.partNumber, bytes.NewReader([]byte(tt.body)), tt.size)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("UploadPart: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalStoreObjectPath(t *testing.T) {
+	store := newTestLocalStore(t)
+
+	tests := []struct {
+		key   string
+		valid bool
+	}{
+		{"recordings/a.webm", true},
+		{"a", true},
+		{"", false},
+		{"../escape", false},
+		{"recordings/../../escape", false},
+		{"/absolute", false},
+		{"recordings//double", false},
+		{".uploads/id/key", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			_, err := store.objectPath(tt.key)
+			if tt.valid && err != nil {
+				t.Fatalf("objectPath(%q): %v", tt.key, err)
+			}
+			if !tt.valid && !errors.Is(err, ErrInvalidKey) {
+				t.Fatalf("objectPath(%q): err = %v, want ErrInvalidKey", tt.key, err)
+			}
+		})
+	}
+}
+
+func TestLocalStoreHandler(t *testing.T) {
+	ctx := context.Background()
+	store := newTestLocalStore(t)
+	key := "recordings/meeting/recording.webm"
+	if err := store.Put(ctx, key, strings.NewReader("video"), 5, "video/webm"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	signed, err := store.SignedURL(ctx, key, time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	link, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("signed URL %q: %v", signed, err)
+	}
+	expired := link.Query()
+	expired.Set("expires", strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10))
+	expired.Set("signature", store.sign(key, expired.Get("expires")))
+	tampered := link.Query()
+	tampered.Set("expires", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+
+	handler := http.StripPrefix("/files", store.Handler())
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+	}{
+		{"signed", link.RequestURI(), http.StatusOK},
+		{"expired", link.Path + "?" + expired.Encode(), http.StatusForbidden},
+		{"extended expiry", link.Path + "?" + tampered.Encode(), http.StatusForbidden},
+		{"other key", "/files/recordings/other.webm?" + link.RawQuery, http.StatusForbidden},
+		{"unsigned", link.Path, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && (rec.Body.String() != "video" || rec.Header().Get("Content-Disposition") != `attachment; filename="recording.webm"`) {
+				t.Fatalf("served %q with headers %v", rec.Body.String(), rec.Header())
+			}
+		})
+	}
+
+	// A link stops working once the object is deleted
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, link.RequestURI(), nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status after delete = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
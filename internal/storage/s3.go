@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Store implements BlobStore on Amazon S3 or any S3-compatible service
+// such as MinIO
+type S3Store struct {
+	core   *minio.Core
+	bucket string
+}
+
+// NewS3Store creates a new S3Store. An empty endpoint selects Amazon S3.
+// Without static credentials the standard AWS environment variables and
+// instance metadata are used.
+func NewS3Store(endpoint, region, bucket, accessKeyID, secretAccessKey string, useSSL bool) (*S3Store, error) {
+	if bucket == "" {
+		return nil, errors.New("s3 bucket is required")
+	}
+	if endpoint == "" {
+		endpoint = "s3.amazonaws.com"
+		useSSL = true
+	}
+
+	creds := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.IAM{},
+	})
+	if accessKeyID != "" {
+		creds = credentials.NewStaticV4(accessKeyID, secretAccessKey, "")
+	}
+
+	core, err := minio.NewCore(strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://"), &minio.Options{
+		Creds:  creds,
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Store{core: core, bucket: bucket}, nil
+}
+
+// Put stores an object in a single request
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.core.Client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// Delete removes an object
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	return s.core.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// CreateMultipartUpload starts a multipart upload
+func (s *S3Store) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	return s.core.NewMultipartUpload(ctx, s.bucket, key, minio.PutObjectOptions{ContentType: contentType})
+}
+
+// UploadPart stores one part of a multipart upload
+func (s *S3Store) UploadPart(ctx context.Context, key, uploadID string, partNumber int, r io.Reader, size int64) (Part, error) {
+	if partNumber < 1 || partNumber > MaxParts {
+		return Part{}, ErrInvalidPart
+	}
+
+	part, err := s.core.PutObjectPart(ctx, s.bucket, key, uploadID, partNumber, r, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		return Part{}, mapS3Error(err)
+	}
+
+	return Part{Number: part.PartNumber, Size: part.Size, ETag: part.ETag}, nil
+}
+
+// ListParts returns the parts uploaded so far
+func (s *S3Store) ListParts(ctx context.Context, key, uploadID string) ([]Part, error) {
+	parts := make([]Part, 0)
+	marker := 0
+
+	for {
+		result, err := s.core.ListObjectParts(ctx, s.bucket, key, uploadID, marker, 1000)
+		if err != nil {
+			return nil, mapS3Error(err)
+		}
+		for _, part := range result.ObjectParts {
+			// Listed ETags are quoted, unlike those UploadPart returns
+			parts = append(parts, Part{Number: part.PartNumber, Size: part.Size, ETag: strings.Trim(part.ETag, `"`)})
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+// CompleteMultipartUpload assembles the given parts into the object
+func (s *S3Store) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []Part) error {
+	completeParts := make([]minio.CompletePart, len(parts))
+	for i, part := range parts {
+		completeParts[i] = minio.CompletePart{PartNumber: part.Number, ETag: part.ETag}
+	}
+
+	_, err := s.core.CompleteMultipartUpload(ctx, s.bucket, key, uploadID, completeParts, minio.PutObjectOptions{})
+	return mapS3Error(err)
+}
+
+// AbortMultipartUpload discards a multipart upload
+func (s *S3Store) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	return mapS3Error(s.core.AbortMultipartUpload(ctx, s.bucket, key, uploadID))
+}
+
+// SignedURL returns a presigned GET URL for the object
+func (s *S3Store) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if _, err := s.core.StatObject(ctx, s.bucket, key, minio.StatObjectOptions{}); err != nil {
+		return "", mapS3Error(err)
+	}
+
+	u, err := s.core.PresignedGetObject(ctx, s.bucket, key, ttl, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return u.String(), nil
+}
+
+func mapS3Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	resp := minio.ToErrorResponse(err)
+	switch {
+	case resp.Code == "NoSuchUpload":
+		return ErrUploadMissing
+	case resp.Code == "NoSuchKey" || resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.Code == "InvalidPart" || resp.Code == "InvalidPartOrder" || resp.Code == "EntityTooSmall":
+		return ErrInvalidPart
+	}
+
+	return err
+}
@@ -0,0 +1,496 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is an in-memory, path-style S3 endpoint for one bucket, serving
+// the object and multipart upload calls S3Store makes. ListParts pages
+// hold at most pageSize parts.
+type fakeS3 struct {
+	t        *testing.T
+	bucket   string
+	pageSize int
+
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]*fakeUpload
+	nextID  int
+	aborted []string
+}
+
+type fakeUpload struct {
+	key   string
+	parts map[int][]byte
+}
+
+type s3Part struct {
+	PartNumber int
+	ETag       string
+	Size       int64 `xml:",omitempty"`
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *S3Store) {
+	t.Helper()
+	fake := &fakeS3{
+		t:        t,
+		bucket:   "recordings",
+		pageSize: 2,
+		objects:  make(map[string][]byte),
+		uploads:  make(map[string]*fakeUpload),
+	}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Store(server.URL, "us-east-1", fake.bucket, "AKIATEST", "secret", false)
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+	return fake, store
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket || key == "" {
+		f.fail(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	query := r.URL.Query()
+	_, initiate := query["uploads"]
+	uploadID := query.Get("uploadId")
+
+	switch {
+	case r.Method == http.MethodPost && initiate:
+		f.nextID++
+		uploadID = fmt.Sprintf("upload-%d", f.nextID)
+		f.uploads[uploadID] = &fakeUpload{key: key, parts: make(map[int][]byte)}
+		f.reply(w, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			Bucket   string
+			Key      string
+			UploadID string `xml:"UploadId"`
+		}{Bucket: bucket, Key: key, UploadID: uploadID})
+
+	case uploadID != "":
+		upload, ok := f.uploads[uploadID]
+		if !ok || upload.key != key {
+			f.fail(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		f.serveUpload(w, r, uploadID, upload)
+
+	case r.Method == http.MethodPut:
+		data := f.body(r)
+		f.objects[key] = data
+		w.Header().Set("ETag", strconv.Quote(etag(data)))
+
+	case r.Method == http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", strconv.Quote(etag(data)))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		f.fail(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *fakeS3) serveUpload(w http.ResponseWriter, r *http.Request, uploadID string, upload *fakeUpload) {
+	switch r.Method {
+	case http.MethodPut:
+		number, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+		if err != nil || number < 1 || number > MaxParts {
+			f.fail(w, http.StatusBadRequest, "InvalidArgument")
+			return
+		}
+		data := f.body(r)
+		upload.parts[number] = data
+		w.Header().Set("ETag", strconv.Quote(etag(data)))
+
+	case http.MethodGet:
+		marker, _ := strconv.Atoi(r.URL.Query().Get("part-number-marker"))
+		numbers := make([]int, 0, len(upload.parts))
+		for number := range upload.parts {
+			if number > marker {
+				numbers = append(numbers, number)
+			}
+		}
+		sort.Ints(numbers)
+
+		result := struct {
+			XMLName              xml.Name `xml:"ListPartsResult"`
+			UploadID             string   `xml:"UploadId"`
+			NextPartNumberMarker int
+			IsTruncated          bool
+			Parts                []s3Part `xml:"Part"`
+		}{UploadID: uploadID}
+		for _, number := range numbers {
+			if len(result.Parts) == f.pageSize {
+				result.IsTruncated = true
+				break
+			}
+			data := upload.parts[number]
+			result.Parts = append(result.Parts, s3Part{PartNumber: number, ETag: strconv.Quote(etag(data)), Size: int64(len(data))})
+			result.NextPartNumberMarker = number
+		}
+		f.reply(w, result)
+
+	case http.MethodPost:
+		var request struct {
+			Parts []s3Part `xml:"Part"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&request); err != nil {
+			f.fail(w, http.StatusBadRequest, "MalformedXML")
+			return
+		}
+
+		var object []byte
+		for i, part := range request.Parts {
+			data, ok := upload.parts[part.PartNumber]
+			switch {
+			case i > 0 && part.PartNumber <= request.Parts[i-1].PartNumber:
+				f.fail(w, http.StatusBadRequest, "InvalidPartOrder")
+				return
+			case !ok || strings.Trim(part.ETag, `"`) != etag(data):
+				f.fail(w, http.StatusBadRequest, "InvalidPart")
+				return
+			case i < len(request.Parts)-1 && len(data) < MinPartSize:
+				f.fail(w, http.StatusBadRequest, "EntityTooSmall")
+				return
+			}
+			object = append(object, data...)
+		}
+		f.objects[upload.key] = object
+		delete(f.uploads, uploadID)
+		f.reply(w, struct {
+			XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+			Bucket  string
+			Key     string
+			ETag    string
+		}{Bucket: f.bucket, Key: upload.key, ETag: strconv.Quote(etag(object))})
+
+	case http.MethodDelete:
+		delete(f.uploads, uploadID)
+		f.aborted = append(f.aborted, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// body reads a request body, decoding the aws-chunked encoding the client
+// uses to sign payloads sent without TLS
+func (f *fakeS3) body(r *http.Request) []byte {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		data, _ := io.ReadAll(r.Body)
+		return data
+	}
+
+	var data []byte
+	reader := bufio.NewReader(r.Body)
+	for {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			f.t.Errorf("read chunk header: %v", err)
+			return data
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(header), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			f.t.Errorf("chunk header %q: %v", header, err)
+			return data
+		}
+		if size == 0 {
+			return data
+		}
+		chunk := make([]byte, size+2) // data and CRLF
+		if _, err := io.ReadFull(reader, chunk); err != nil {
+			f.t.Errorf("read chunk: %v", err)
+			return data
+		}
+		data = append(data, chunk[:size]...)
+	}
+}
+
+func (f *fakeS3) reply(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	if err := xml.NewEncoder(w).Encode(result); err != nil {
+		f.t.Errorf("encode reply: %v", err)
+	}
+}
+
+func (f *fakeS3) fail(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_ = xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: code})
+}
+
+func (f *fakeS3) object(key string) ([]byte, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, ok := f.objects[key]
+	return data, ok
+}
+
+func TestS3StoreMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	fake, store := newFakeS3(t)
+	key := "recordings/meeting/recording.webm"
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/webm")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload: %v", err)
+	}
+
+	data := [][]byte{
+		bytes.Repeat([]byte("a"), MinPartSize),
+		bytes.Repeat([]byte("b"), MinPartSize),
+		[]byte("tail"),
+	}
+
+	// Parts may arrive out of order and be uploaded again
+	if _, err := store.UploadPart(ctx, key, uploadID, 3, bytes.NewReader([]byte("stale")), 5); err != nil {
+		t.Fatalf("UploadPart 3: %v", err)
+	}
+	uploaded := make([]Part, len(data))
+	for i := len(data) - 1; i >= 0; i-- {
+		part, err := store.UploadPart(ctx, key, uploadID, i+1, bytes.NewReader(data[i]), int64(len(data[i])))
+		if err != nil {
+			t.Fatalf("UploadPart %d: %v", i+1, err)
+		}
+		if part.Number != i+1 || part.ETag != etag(data[i]) {
+			t.Fatalf("UploadPart %d = %+v, want the unquoted MD5 ETag %s", i+1, part, etag(data[i]))
+		}
+		uploaded[i] = part
+	}
+
+	// Listed across pages, ordered by number, with the same ETags
+	parts, err := store.ListParts(ctx, key, uploadID)
+	if err != nil {
+		t.Fatalf("ListParts: %v", err)
+	}
+	if len(parts) != len(data) {
+		t.Fatalf("ListParts returned %d parts, want %d", len(parts), len(data))
+	}
+	for i, part := range parts {
+		if part.Number != i+1 || part.Size != int64(len(data[i])) || part.ETag != uploaded[i].ETag {
+			t.Fatalf("ListParts[%d] = %+v, want %+v", i, part, uploaded[i])
+		}
+	}
+
+	if err := store.CompleteMultipartUpload(ctx, key, uploadID, parts); err != nil {
+		t.Fatalf("CompleteMultipartUpload: %v", err)
+	}
+	object, ok := fake.object(key)
+	if !ok || !bytes.Equal(object, bytes.Join(data, nil)) {
+		t.Fatalf("object has %d bytes, want %d", len(object), len(bytes.Join(data, nil)))
+	}
+	if _, err := store.ListParts(ctx, key, uploadID); !errors.Is(err, ErrUploadMissing) {
+		t.Fatalf("ListParts after completion: err = %v, want ErrUploadMissing", err)
+	}
+}
+
+func TestS3StoreCompleteMultipartUploadErrors(t *testing.T) {
+	ctx := context.Background()
+	_, store := newFakeS3(t)
+	key := "recordings/errors.webm"
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/webm")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload: %v", err)
+	}
+	big := bytes.Repeat([]byte("a"), MinPartSize)
+	first, err := store.UploadPart(ctx, key, uploadID, 1, bytes.NewReader(big), int64(len(big)))
+	if err != nil {
+		t.Fatalf("UploadPart 1: %v", err)
+	}
+	second, err := store.UploadPart(ctx, key, uploadID, 2, bytes.NewReader([]byte("small")), 5)
+	if err != nil {
+		t.Fatalf("UploadPart 2: %v", err)
+	}
+	third, err := store.UploadPart(ctx, key, uploadID, 3, bytes.NewReader([]byte("tail")), 4)
+	if err != nil {
+		t.Fatalf("UploadPart 3: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		uploadID string
+		parts    []Part
+		want     error
+	}{
+		{"stale ETag", key, uploadID, []Part{first, {Number: 3, ETag: etag([]byte("other"))}}, ErrInvalidPart},
+		{"missing part", key, uploadID, []Part{first, {Number: 4, ETag: third.ETag}}, ErrInvalidPart},
+		{"out of order", key, uploadID, []Part{third, first}, ErrInvalidPart},
+		{"small part before the last", key, uploadID, []Part{first, second, third}, ErrInvalidPart},
+		{"unknown upload", key, "upload-404", []Part{first}, ErrUploadMissing},
+		{"other key", "recordings/other.webm", uploadID, []Part{first}, ErrUploadMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := store.CompleteMultipartUpload(ctx, tt.key, tt.uploadID, tt.parts); !errors.Is(err, tt.want) {
+				t.Fatalf("CompleteMultipartUpload: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// The rejected attempts left the upload intact
+	if err := store.CompleteMultipartUpload(ctx, key, uploadID, []Part{first, third}); err != nil {
+		t.Fatalf("CompleteMultipartUpload: %v", err)
+	}
+}
+
+func TestS3StoreUploadPartValidation(t *testing.T) {
+	ctx := context.Background()
+	_, store := newFakeS3(t)
+	key := "recordings/validation.webm"
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/webm")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		uploadID   string
+		partNumber int
+		want       error
+	}{
+		{"part number zero", key, uploadID, 0, ErrInvalidPart},
+		{"part number too large", key, uploadID, MaxParts + 1, ErrInvalidPart},
+		{"other key", "recordings/other.webm", uploadID, 1, ErrUploadMissing},
+		{"unknown upload", key, "upload-404", 1, ErrUploadMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := store.UploadPart(ctx, tt.key, tt.uploadID, tt.partNumber, strings.NewReader("data"), 4)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("UploadPart: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestS3StoreAbortMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	fake, store := newFakeS3(t)
+	key := "recordings/aborted.webm"
+
+	uploadID, err := store.CreateMultipartUpload(ctx, key, "video/webm")
+	if err != nil {
+		t.Fatalf("CreateMultipartUpload: %v", err)
+	}
+	if _, err := store.UploadPart(ctx, key, uploadID, 1, strings.NewReader("part"), 4); err != nil {
+		t.Fatalf("UploadPart: %v", err)
+	}
+
+	if err := store.AbortMultipartUpload(ctx, key, uploadID); err != nil {
+		t.Fatalf("AbortMultipartUpload: %v", err)
+	}
+	if len(fake.aborted) != 1 || fake.aborted[0] != uploadID {
+		t.Fatalf("aborted uploads = %v, want %s", fake.aborted, uploadID)
+	}
+
+	// Later calls see the upload gone
+	if _, err := store.UploadPart(ctx, key, uploadID, 2, strings.NewReader("part"), 4); !errors.Is(err, ErrUploadMissing) {
+		t.Fatalf("UploadPart after abort: err = %v, want ErrUploadMissing", err)
+	}
+	if err := store.CompleteMultipartUpload(ctx, key, uploadID, nil); !errors.Is(err, ErrUploadMissing) {
+		t.Fatalf("CompleteMultipartUpload after abort: err = %v, want ErrUploadMissing", err)
+	}
+	if err := store.AbortMultipartUpload(ctx, key, uploadID); !errors.Is(err, ErrUploadMissing) {
+		t.Fatalf("second AbortMultipartUpload: err = %v, want ErrUploadMissing", err)
+	}
+}
+
+func TestS3StoreObjects(t *testing.T) {
+	ctx := context.Background()
+	fake, store := newFakeS3(t)
+	key := "recordings/meeting/notes.txt"
+
+	if _, err := store.SignedURL(ctx, key, time.Minute); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("SignedURL of a missing object: err = %v, want ErrNotFound", err)
+	}
+
+	if err := store.Put(ctx, key, strings.NewReader("notes"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if data, ok := fake.object(key); !ok || string(data) != "notes" {
+		t.Fatalf("stored %q, want notes", data)
+	}
+
+	signed, err := store.SignedURL(ctx, key, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL: %v", err)
+	}
+	link, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("signed URL %q: %v", signed, err)
+	}
+	query := link.Query()
+	if link.Path != "/"+fake.bucket+"/"+key || query.Get("X-Amz-Expires") != "900" ||
+		!strings.HasPrefix(query.Get("X-Amz-Credential"), "AKIATEST/") || query.Get("X-Amz-Signature") == "" {
+		t.Fatalf("signed URL = %s, want a presigned GET of the object valid for 15 minutes", signed)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := fake.object(key); ok {
+		t.Fatal("object still stored after Delete")
+	}
+}
+
+func TestNewS3Store(t *testing.T) {
+	if _, err := NewS3Store("", "us-east-1", "", "", "", false); err == nil {
+		t.Fatal("NewS3Store without a bucket succeeded")
+	}
+
+	store, err := NewS3Store("https://minio.internal:9000", "us-east-1", "recordings", "key", "secret", false)
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+	if endpoint := store.core.EndpointURL(); endpoint.Host != "minio.internal:9000" || endpoint.Scheme != "http" {
+		t.Fatalf("endpoint = %s, want the scheme stripped and useSSL honoured", endpoint)
+	}
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"runtime"
+	"strings"
+)
+
+const modulePrefix = "github.com/manab-pr/evtaarpro/"
+
+// wrapperPrefixes are application packages that only wrap the clients; the
+// repository or use case calling them names the span instead
+var wrapperPrefixes = []string{
+	modulePrefix + "internal/tracing.",
+	modulePrefix + "internal/datastore.",
+}
+
+// caller returns the application function that issued the current query or
+// command, e.g. "postgresql.PayrollRepository.ListPayrollRecords", so that
+// database and Redis spans name the repository call rather than the driver
+// method. It returns "" when no application frame is on the stack.
+func caller() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, modulePrefix) && !isWrapper(frame.Function) {
+			return shortFunctionName(frame.Function)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func isWrapper(function string) bool {
+	for _, prefix := range wrapperPrefixes {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// shortFunctionName strips the package path and pointer receiver markers:
+// ".../infra/postgresql.(*PayrollRepository).Get" becomes
+// "postgresql.PayrollRepository.Get"
+func shortFunctionName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.NewReplacer("(*", "", ")", "").Replace(name)
+}
@@ -0,0 +1,52 @@
+package tracing
+
+import "testing"
+
+func TestShortFunctionName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{modulePrefix + "modules/payroll/infra/postgresql.(*PayrollRepository).Get", "postgresql.PayrollRepository.Get"},
+		{modulePrefix + "modules/meetings/domain/usecases.(*CreateMeetingUseCase).Execute.func1", "usecases.CreateMeetingUseCase.Execute.func1"},
+		{modulePrefix + "internal/cache.Get[...]", "cache.Get[...]"},
+		{"main.main", "main.main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := shortFunctionName(tt.name); got != tt.want {
+				t.Fatalf("shortFunctionName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsWrapper(t *testing.T) {
+	tests := []struct {
+		function string
+		want     bool
+	}{
+		{modulePrefix + "internal/datastore.(*PostgresStore).WithTx", true},
+		{modulePrefix + "internal/tracing.RedisHook.ProcessHook.func1", true},
+		{modulePrefix + "internal/tracing_test.TestRedisHook", false},
+		{modulePrefix + "internal/datastorex.Helper", false},
+		{modulePrefix + "modules/users/infra/redis.(*SessionStore).Get", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			if got := isWrapper(tt.function); got != tt.want {
+				t.Fatalf("isWrapper(%q) = %v, want %v", tt.function, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallerSkipsWrappers(t *testing.T) {
+	// Every function of this package is a wrapper, and the test runner is
+	// not part of the application
+	if got := caller(); got != "" {
+		t.Fatalf("caller = %q, want none", got)
+	}
+}
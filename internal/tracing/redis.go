@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisHook traces Redis commands and pipelines. Command arguments are left
+// out, as they hold session tokens. Like database queries, commands outside
+// a traced operation are not traced.
+type RedisHook struct{}
+
+var _ redis.Hook = RedisHook{}
+
+// DialHook implements redis.Hook
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook implements redis.Hook
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			return next(ctx, cmd)
+		}
+
+		ctx, span := startRedisSpan(ctx, "redis "+cmd.Name(), cmd.Name())
+		defer span.End()
+
+		err := next(ctx, cmd)
+		recordRedisError(span, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook implements redis.Hook
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			return next(ctx, cmds)
+		}
+
+		ctx, span := startRedisSpan(ctx, "redis pipeline", "pipeline")
+		defer span.End()
+		span.SetAttributes(attribute.Int("db.redis.num_cmd", len(cmds)))
+
+		err := next(ctx, cmds)
+		recordRedisError(span, err)
+		return err
+	}
+}
+
+func startRedisSpan(ctx context.Context, name, operation string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemRedis,
+		semconv.DBOperationName(operation),
+	}
+	if function := caller(); function != "" {
+		attrs = append(attrs, semconv.CodeFunction(function))
+	}
+	return Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// recordRedisError marks the span failed; a missing key is not a failure
+func recordRedisError(span trace.Span, err error) {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,149 @@
+// The hook is tested from outside the package: functions of the tracing
+// package are skipped when naming the caller
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/manab-pr/evtaarpro/internal/tracing"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// replyHook answers every command with err instead of a server
+type replyHook struct {
+	err error
+}
+
+func (h replyHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no redis in tests")
+	}
+}
+
+func (h replyHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		cmd.SetErr(h.err)
+		return h.err
+	}
+}
+
+func (h replyHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		return h.err
+	}
+}
+
+func TestRedisHook(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	tests := []struct {
+		name       string
+		traced     bool
+		err        error
+		run        func(ctx context.Context, client *redis.Client)
+		wantSpan   string
+		wantStatus codes.Code
+	}{
+		{
+			name: "outside a trace",
+			run:  func(ctx context.Context, client *redis.Client) { client.Get(ctx, "session:abc") },
+		},
+		{
+			name:     "command",
+			traced:   true,
+			run:      func(ctx context.Context, client *redis.Client) { client.Set(ctx, "session:abc", "alice", 0) },
+			wantSpan: "redis set",
+		},
+		{
+			name:     "missing key",
+			traced:   true,
+			err:      redis.Nil,
+			run:      func(ctx context.Context, client *redis.Client) { client.Get(ctx, "session:abc") },
+			wantSpan: "redis get",
+		},
+		{
+			name:       "failed command",
+			traced:     true,
+			err:        errors.New("READONLY"),
+			run:        func(ctx context.Context, client *redis.Client) { client.Del(ctx, "session:abc") },
+			wantSpan:   "redis del",
+			wantStatus: codes.Error,
+		},
+		{
+			name:   "pipeline",
+			traced: true,
+			run: func(ctx context.Context, client *redis.Client) {
+				pipe := client.Pipeline()
+				pipe.Incr(ctx, "counter")
+				pipe.Expire(ctx, "counter", 0)
+				_, _ = pipe.Exec(ctx)
+			},
+			wantSpan: "redis pipeline",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := redis.NewClient(&redis.Options{Addr: "redis.invalid:6379"})
+			client.AddHook(tracing.RedisHook{})
+			client.AddHook(replyHook{err: tt.err})
+			defer client.Close()
+
+			ctx := context.Background()
+			var parent trace.Span
+			if tt.traced {
+				ctx, parent = tracing.Start(ctx, "request")
+			}
+			before := len(recorder.Ended())
+			tt.run(ctx, client)
+			spans := recorder.Ended()[before:]
+			if parent != nil {
+				parent.End()
+			}
+
+			if tt.wantSpan == "" {
+				if len(spans) != 0 {
+					t.Fatalf("recorded %d spans, want none", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("recorded %d spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if span.Name() != tt.wantSpan || span.SpanKind() != trace.SpanKindClient {
+				t.Fatalf("span %q of kind %v, want client span %q", span.Name(), span.SpanKind(), tt.wantSpan)
+			}
+			if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+				t.Fatal("span is not a child of the request")
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+
+			attrs := attribute.NewSet(span.Attributes()...)
+			if system, _ := attrs.Value("db.system"); system.AsString() != "redis" {
+				t.Fatalf("db.system = %q, want redis", system.AsString())
+			}
+			if function, _ := attrs.Value("code.function"); !strings.HasPrefix(function.AsString(), "tracing_test.TestRedisHook") {
+				t.Fatalf("code.function = %q, want the calling test", function.AsString())
+			}
+			for _, attr := range span.Attributes() {
+				if strings.Contains(attr.Value.Emit(), "session:abc") {
+					t.Fatalf("attribute %s holds the command arguments", attr.Key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/manab-pr/evtaarpro/internal/config"
+)
+
+func TestSetup(t *testing.T) {
+	app := config.AppConfig{Name: "evtaarpro", Version: "test", Env: "test"}
+
+	tests := []struct {
+		name    string
+		cfg     config.TracingConfig
+		wantErr string
+	}{
+		{"disabled", config.TracingConfig{Exporter: "jaeger"}, ""},
+		{"stdout", config.TracingConfig{Enabled: true, Exporter: "STDOUT"}, ""},
+		{"file", config.TracingConfig{Enabled: true, Exporter: "file", FilePath: filepath.Join(t.TempDir(), "traces.json")}, ""},
+		{"file without a path", config.TracingConfig{Enabled: true, Exporter: "file"}, "needs a file_path"},
+		{"unknown exporter", config.TracingConfig{Enabled: true, Exporter: "jaeger"}, `unknown tracing exporter "jaeger"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), tt.cfg, app)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Setup: err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Setup: %v", err)
+			}
+			if err := shutdown(context.Background()); err != nil {
+				t.Fatalf("shutdown: %v", err)
+			}
+		})
+	}
+}
+
+func TestTraceID(t *testing.T) {
+	if got := TraceID(context.Background()); got != "" {
+		t.Fatalf("TraceID without a span = %q, want none", got)
+	}
+}
@@ -0,0 +1,6 @@
+-- Add scheduled duration to meetings for conflict detection and free/busy lookup
+
+ALTER TABLE meetings ADD COLUMN IF NOT EXISTS duration_minutes INT NOT NULL DEFAULT 60 CHECK (duration_minutes > 0);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_meeting_participants_meeting_id ON meeting_participants(meeting_id);
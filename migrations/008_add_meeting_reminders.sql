@@ -0,0 +1,11 @@
+-- Track reminder delivery and allow meetings that never started to be marked as missed
+
+ALTER TABLE meetings ADD COLUMN IF NOT EXISTS reminder_sent_at TIMESTAMP;
+
+ALTER TABLE meetings DROP CONSTRAINT IF EXISTS meetings_status_check;
+ALTER TABLE meetings ADD CONSTRAINT meetings_status_check
+    CHECK (status IN ('scheduled', 'ongoing', 'completed', 'cancelled', 'missed'));
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_meetings_reminder_pending ON meetings(start_time)
+    WHERE status = 'scheduled' AND reminder_sent_at IS NULL;
@@ -0,0 +1,18 @@
+-- Create meeting_attendance_sessions table
+CREATE TABLE IF NOT EXISTS meeting_attendance_sessions (
+    id VARCHAR(36) PRIMARY KEY,
+    meeting_id VARCHAR(36) NOT NULL REFERENCES meetings(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL CHECK (source IN ('app', 'jitsi')),
+    joined_at TIMESTAMP NOT NULL,
+    left_at TIMESTAMP,
+    CHECK (left_at IS NULL OR left_at >= joined_at)
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_meeting_attendance_meeting_id ON meeting_attendance_sessions(meeting_id);
+
+-- At most one open session per user and source
+CREATE UNIQUE INDEX IF NOT EXISTS idx_meeting_attendance_open
+    ON meeting_attendance_sessions(meeting_id, user_id, source)
+    WHERE left_at IS NULL;
@@ -0,0 +1,45 @@
+-- Create meeting_agenda_items table
+CREATE TABLE IF NOT EXISTS meeting_agenda_items (
+    id VARCHAR(36) PRIMARY KEY,
+    meeting_id VARCHAR(36) NOT NULL REFERENCES meetings(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    duration_minutes INT CHECK (duration_minutes IS NULL OR duration_minutes > 0),
+    presenter_id VARCHAR(36) REFERENCES users(id) ON DELETE SET NULL,
+    UNIQUE (meeting_id, position)
+);
+
+-- Create meeting_note_revisions table (the latest revision is the current notes)
+CREATE TABLE IF NOT EXISTS meeting_note_revisions (
+    meeting_id VARCHAR(36) NOT NULL REFERENCES meetings(id) ON DELETE CASCADE,
+    revision INT NOT NULL CHECK (revision > 0),
+    content TEXT NOT NULL,
+    edited_by VARCHAR(36) REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (meeting_id, revision)
+);
+
+-- Create meeting_action_items table
+CREATE TABLE IF NOT EXISTS meeting_action_items (
+    id VARCHAR(36) PRIMARY KEY,
+    meeting_id VARCHAR(36) NOT NULL REFERENCES meetings(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    assignee_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    due_date DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'in_progress', 'done', 'cancelled')),
+    created_by VARCHAR(36) REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_meeting_action_items_meeting_id ON meeting_action_items(meeting_id);
+CREATE INDEX IF NOT EXISTS idx_meeting_action_items_assignee_open ON meeting_action_items(assignee_id, due_date)
+    WHERE status IN ('open', 'in_progress');
+
+-- Create trigger for meeting_action_items table
+CREATE TRIGGER update_meeting_action_items_updated_at BEFORE UPDATE ON meeting_action_items
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,32 @@
+-- Create meeting_guest_links table
+-- Only hashes of link tokens and passcodes are stored
+CREATE TABLE IF NOT EXISTS meeting_guest_links (
+    id VARCHAR(36) PRIMARY KEY,
+    meeting_id VARCHAR(36) NOT NULL REFERENCES meetings(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    passcode_hash VARCHAR(255),
+    expires_at TIMESTAMP NOT NULL,
+    max_uses INTEGER NOT NULL DEFAULT 0 CHECK (max_uses >= 0),
+    use_count INTEGER NOT NULL DEFAULT 0,
+    created_by VARCHAR(36) REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+-- Create meeting_guest_lobby table
+CREATE TABLE IF NOT EXISTS meeting_guest_lobby (
+    id VARCHAR(36) PRIMARY KEY,
+    meeting_id VARCHAR(36) NOT NULL REFERENCES meetings(id) ON DELETE CASCADE,
+    link_id VARCHAR(36) NOT NULL REFERENCES meeting_guest_links(id) ON DELETE CASCADE,
+    display_name VARCHAR(100) NOT NULL,
+    email VARCHAR(255),
+    secret_hash VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting' CHECK (status IN ('waiting', 'admitted', 'denied')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_at TIMESTAMP,
+    decided_by VARCHAR(36) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_meeting_guest_links_meeting_id ON meeting_guest_links(meeting_id);
+CREATE INDEX IF NOT EXISTS idx_meeting_guest_lobby_meeting_status ON meeting_guest_lobby(meeting_id, status);
@@ -0,0 +1,36 @@
+-- Create meeting_transcripts table
+-- A meeting has at most one transcript; ingesting again replaces it
+CREATE TABLE IF NOT EXISTS meeting_transcripts (
+    meeting_id VARCHAR(36) PRIMARY KEY REFERENCES meetings(id) ON DELETE CASCADE,
+    format VARCHAR(10) NOT NULL CHECK (format IN ('vtt', 'srt', 'json')),
+    segment_count INTEGER NOT NULL DEFAULT 0,
+    uploaded_by VARCHAR(36) REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create meeting_transcript_segments table
+CREATE TABLE IF NOT EXISTS meeting_transcript_segments (
+    meeting_id VARCHAR(36) NOT NULL REFERENCES meeting_transcripts(meeting_id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+    start_ms BIGINT NOT NULL CHECK (start_ms >= 0),
+    end_ms BIGINT NOT NULL CHECK (end_ms >= start_ms),
+    speaker VARCHAR(100),
+    text TEXT NOT NULL,
+    search TSVECTOR GENERATED ALWAYS AS (
+        to_tsvector('english', COALESCE(speaker, '') || ' ' || text)
+    ) STORED,
+    PRIMARY KEY (meeting_id, seq)
+);
+
+-- Create meeting_summaries table
+CREATE TABLE IF NOT EXISTS meeting_summaries (
+    meeting_id VARCHAR(36) PRIMARY KEY REFERENCES meetings(id) ON DELETE CASCADE,
+    summary TEXT NOT NULL,
+    key_points JSONB NOT NULL DEFAULT '[]',
+    action_items JSONB NOT NULL DEFAULT '[]',
+    generator VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_meeting_transcript_segments_search ON meeting_transcript_segments USING GIN (search);
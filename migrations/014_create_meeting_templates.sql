@@ -0,0 +1,35 @@
+-- Add room settings to meetings. The passcode is kept in plain text because
+-- it is handed to Jitsi clients, which lock the room with it.
+ALTER TABLE meetings
+    ADD COLUMN IF NOT EXISTS lobby_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS start_muted BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS recording_allowed BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS room_passcode VARCHAR(64);
+
+-- Create meeting_templates table
+CREATE TABLE IF NOT EXISTS meeting_templates (
+    id VARCHAR(36) PRIMARY KEY,
+    owner_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    title_pattern VARCHAR(255) NOT NULL,
+    description TEXT,
+    duration_minutes INT NOT NULL CHECK (duration_minutes > 0),
+    max_participants INT NOT NULL DEFAULT 0 CHECK (max_participants >= 0),
+    invitee_ids TEXT[] NOT NULL DEFAULT '{}',
+    agenda JSONB NOT NULL DEFAULT '[]',
+    lobby_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    start_muted BOOLEAN NOT NULL DEFAULT FALSE,
+    recording_allowed BOOLEAN NOT NULL DEFAULT TRUE,
+    room_passcode VARCHAR(64),
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_meeting_templates_owner_id ON meeting_templates(owner_id);
+CREATE INDEX idx_meeting_templates_shared ON meeting_templates(shared) WHERE shared;
+
+-- Create trigger
+CREATE TRIGGER update_meeting_templates_updated_at BEFORE UPDATE ON meeting_templates
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
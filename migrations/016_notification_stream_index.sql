@@ -0,0 +1,2 @@
+-- Support resuming a user's notification stream after a given notification
+CREATE INDEX IF NOT EXISTS idx_notifications_user_stream ON notifications(user_id, created_at, id);
@@ -0,0 +1,39 @@
+-- Per-user notification delivery settings
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    quiet_hours_start TIME,
+    quiet_hours_end TIME,
+    webhook_url TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL)),
+    CHECK (quiet_hours_start IS NULL OR quiet_hours_start <> quiet_hours_end)
+);
+
+-- Channel preferences per notification type; '*' applies to every type
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('in_app', 'email', 'webhook', 'log')),
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, type, channel)
+);
+
+-- Delivery status of every notification per channel
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    notification_id VARCHAR(36) NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('in_app', 'email', 'webhook', 'log')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'sent', 'failed', 'skipped')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (notification_id, channel)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_due
+    ON notification_deliveries(next_attempt_at) WHERE status = 'pending';
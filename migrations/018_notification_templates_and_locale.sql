@@ -0,0 +1,7 @@
+-- Preferred language of a user, as a BCP 47 tag
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(35) NOT NULL DEFAULT 'en';
+
+-- Template a notification was rendered from and the locale it was stored in;
+-- its variables are kept in data so it can be re-rendered at send time
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS template VARCHAR(100);
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS locale VARCHAR(35);
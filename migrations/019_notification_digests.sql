@@ -0,0 +1,11 @@
+-- How often a user receives batched low and medium priority notifications
+ALTER TABLE notification_settings ADD COLUMN IF NOT EXISTS digest VARCHAR(10) NOT NULL DEFAULT 'immediate'
+    CHECK (digest IN ('immediate', 'hourly', 'daily'));
+
+-- Batched deliveries wait for the next digest instead of being sent on their own;
+-- digest_id points at the digest notification they were sent in
+ALTER TABLE notification_deliveries ADD COLUMN IF NOT EXISTS batched BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE notification_deliveries ADD COLUMN IF NOT EXISTS digest_id VARCHAR(36) REFERENCES notifications(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_digest_due
+    ON notification_deliveries(user_id, next_attempt_at) WHERE status = 'pending' AND batched;
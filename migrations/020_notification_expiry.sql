@@ -0,0 +1,7 @@
+-- Notifications expire and are archived by the notifications.archive job;
+-- archived notifications no longer show in lists, streams or unread counts
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_expiring
+    ON notifications(expires_at) WHERE archived_at IS NULL AND expires_at IS NOT NULL;
@@ -0,0 +1,24 @@
+-- Domain events, written in the transaction of the change they describe.
+-- The relay dispatches them to subscribers at least once.
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'dispatched', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    dispatched_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_due
+    ON event_outbox(next_attempt_at) WHERE status = 'pending';
+
+-- Subscribers that handled an event; retries of the event skip them
+CREATE TABLE IF NOT EXISTS event_consumptions (
+    event_id VARCHAR(36) NOT NULL REFERENCES event_outbox(id) ON DELETE CASCADE,
+    subscriber VARCHAR(100) NOT NULL,
+    handled_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, subscriber)
+);
@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/audit"
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/audit/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/audit/presentation/http/handlers"
+	"github.com/manab-pr/evtaarpro/modules/audit/presentation/http/routes"
+)
+
+// RegisterRoutes registers audit module routes
+func RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore) {
+	// Infrastructure
+	entryRepo := audit.NewLog(pgStore.DB)
+
+	// Use cases
+	listEntriesUC := usecases.NewListEntriesUseCase(entryRepo)
+	exportEntriesUC := usecases.NewExportEntriesUseCase(entryRepo)
+	verifyChainUC := usecases.NewVerifyChainUseCase(entryRepo)
+
+	// Handlers
+	auditHandlers := handlers.NewAuditHandlers(listEntriesUC, exportEntriesUC, verifyChainUC)
+
+	// Register routes
+	routes.RegisterRoutes(rg, auditHandlers, cfg.JWT.Secret)
+}
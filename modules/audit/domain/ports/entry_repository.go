@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/audit"
+)
+
+// EntryRepository defines audit log read operations. Entries are only ever
+// appended, by the modules that perform the audited operations.
+type EntryRepository interface {
+	// List retrieves the entries matching filter, newest first
+	List(ctx context.Context, filter audit.Filter, limit, offset int) ([]*audit.Entry, int, error)
+	// Each calls fn for every entry matching filter, oldest first
+	Each(ctx context.Context, filter audit.Filter, fn func(*audit.Entry) error) error
+	// Verify checks the hash chain of the whole log
+	Verify(ctx context.Context) (*audit.Verification, error)
+}
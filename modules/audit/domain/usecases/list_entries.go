@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manab-pr/evtaarpro/internal/audit"
+	"github.com/manab-pr/evtaarpro/modules/audit/domain/ports"
+)
+
+var ErrInvalidTimeRange = errors.New("from must be before to")
+
+// ListEntriesInput represents the filter and page of entries to list
+type ListEntriesInput struct {
+	Filter   audit.Filter
+	Page     int
+	PageSize int
+}
+
+// ListEntriesOutput represents a page of entries
+type ListEntriesOutput struct {
+	Entries  []*audit.Entry
+	Total    int
+	Page     int
+	PageSize int
+}
+
+// ListEntriesUseCase handles querying the audit log
+type ListEntriesUseCase struct {
+	entryRepo ports.EntryRepository
+}
+
+// NewListEntriesUseCase creates a new ListEntriesUseCase
+func NewListEntriesUseCase(entryRepo ports.EntryRepository) *ListEntriesUseCase {
+	return &ListEntriesUseCase{
+		entryRepo: entryRepo,
+	}
+}
+
+// Execute lists the entries matching the filter, newest first
+func (uc *ListEntriesUseCase) Execute(ctx context.Context, input ListEntriesInput) (*ListEntriesOutput, error) {
+	if err := validateFilter(input.Filter); err != nil {
+		return nil, err
+	}
+
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 50
+	}
+
+	offset := (input.Page - 1) * input.PageSize
+	entries, total, err := uc.entryRepo.List(ctx, input.Filter, input.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListEntriesOutput{
+		Entries:  entries,
+		Total:    total,
+		Page:     input.Page,
+		PageSize: input.PageSize,
+	}, nil
+}
+
+func validateFilter(filter audit.Filter) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/audit"
+	"github.com/manab-pr/evtaarpro/modules/audit/domain/ports"
+)
+
+// VerifyChainUseCase handles checking the audit log for tampering
+type VerifyChainUseCase struct {
+	entryRepo ports.EntryRepository
+}
+
+// NewVerifyChainUseCase creates a new VerifyChainUseCase
+func NewVerifyChainUseCase(entryRepo ports.EntryRepository) *VerifyChainUseCase {
+	return &VerifyChainUseCase{
+		entryRepo: entryRepo,
+	}
+}
+
+// Execute recomputes every hash of the chain and reports the first entry
+// that was changed, inserted or follows a removed entry
+func (uc *VerifyChainUseCase) Execute(ctx context.Context) (*audit.Verification, error) {
+	return uc.entryRepo.Verify(ctx)
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/audit"
+)
+
+// EntryResponse represents an audit log entry
+type EntryResponse struct {
+	Seq          int64                   `json:"seq"`
+	ActorID      string                  `json:"actor_id,omitempty"`
+	Action       string                  `json:"action"`
+	ResourceType string                  `json:"resource_type"`
+	ResourceID   string                  `json:"resource_id"`
+	Changes      map[string]audit.Change `json:"changes"` // field -> {before, after}
+	RequestID    string                  `json:"request_id,omitempty"`
+	IP           string                  `json:"ip,omitempty"`
+	OccurredAt   time.Time               `json:"occurred_at"`
+	PrevHash     string                  `json:"prev_hash"`
+	Hash         string                  `json:"hash"`
+}
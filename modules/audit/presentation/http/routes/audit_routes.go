@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/middleware"
+	"github.com/manab-pr/evtaarpro/modules/audit/presentation/http/handlers"
+)
+
+// RegisterRoutes registers audit log routes
+func RegisterRoutes(rg *gin.RouterGroup, auditHandlers *handlers.AuditHandlers, jwtSecret string) {
+	auditLog := rg.Group("/audit")
+	auditLog.Use(middleware.AuthMiddleware(jwtSecret), middleware.RequireRole("admin"))
+	{
+		auditLog.GET("/logs", auditHandlers.ListEntries)
+		auditLog.GET("/logs/export", auditHandlers.ExportEntries)
+		auditLog.GET("/verify", auditHandlers.VerifyChain)
+	}
+}
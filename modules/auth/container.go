@@ -2,9 +2,11 @@ package auth
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/cache"
 	"github.com/manab-pr/evtaarpro/internal/config"
 	"github.com/manab-pr/evtaarpro/internal/datastore"
 	"github.com/manab-pr/evtaarpro/modules/auth/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/auth/infra/cached"
 	"github.com/manab-pr/evtaarpro/modules/auth/infra/postgresql"
 	"github.com/manab-pr/evtaarpro/modules/auth/infra/redis"
 	"github.com/manab-pr/evtaarpro/modules/auth/infra/security"
@@ -15,7 +17,8 @@ import (
 // RegisterRoutes registers auth module routes
 func RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
 	// Infrastructure
-	userRepo := postgresql.NewUserRepository(pgStore.DB)
+	// Users are cached by the users module, which must see registrations
+	userRepo := cached.NewUserRepository(postgresql.NewUserRepository(pgStore.DB), cache.New(redisStore, "users"))
 	passwordHasher := security.NewBcryptHasher()
 	tokenGenerator := security.NewJWTGenerator(
 		cfg.JWT.Secret,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/manab-pr/evtaarpro/internal/metrics"
 	"github.com/manab-pr/evtaarpro/modules/auth/domain/ports"
 )
 
@@ -55,16 +56,19 @@ func (uc *LoginUseCase) Execute(ctx context.Context, input LoginInput) (*LoginOu
 	// Get user by email
 	user, err := uc.userRepo.GetByEmail(ctx, input.Email)
 	if err != nil {
+		metrics.FailedLogins.WithLabelValues(metrics.LoginFailedInvalidCredentials).Inc()
 		return nil, ErrInvalidCredentials
 	}
 
 	// Check if user is active
 	if !user.IsActive {
+		metrics.FailedLogins.WithLabelValues(metrics.LoginFailedInactive).Inc()
 		return nil, ErrUserNotActive
 	}
 
 	// Verify password
 	if err := uc.passwordHasher.Compare(user.PasswordHash, input.Password); err != nil {
+		metrics.FailedLogins.WithLabelValues(metrics.LoginFailedInvalidCredentials).Inc()
 		return nil, ErrInvalidCredentials
 	}
 
@@ -83,6 +87,7 @@ func (uc *LoginUseCase) Execute(ctx context.Context, input LoginInput) (*LoginOu
 	if err := uc.sessionStore.Create(ctx, user.ID, refreshToken, 0); err != nil {
 		return nil, err
 	}
+	metrics.Logins.Inc()
 
 	return &LoginOutput{
 		UserID:       user.ID,
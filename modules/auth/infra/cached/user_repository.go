@@ -0,0 +1,50 @@
+package cached
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/cache"
+	"github.com/manab-pr/evtaarpro/modules/auth/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/auth/domain/ports"
+	userscached "github.com/manab-pr/evtaarpro/modules/users/data/cached"
+)
+
+// UserRepository invalidates the users module's cached user reads when a
+// ports.UserRepository writes users. Reads go straight to the repository.
+type UserRepository struct {
+	ports.UserRepository
+	cache *cache.Cache
+}
+
+// NewUserRepository creates a UserRepository invalidating cache on the
+// writes of repo
+func NewUserRepository(repo ports.UserRepository, cache *cache.Cache) *UserRepository {
+	return &UserRepository{UserRepository: repo, cache: cache}
+}
+
+// Create creates a new user
+func (r *UserRepository) Create(ctx context.Context, user *entities.User) error {
+	if err := r.UserRepository.Create(ctx, user); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, userscached.ListTag)
+	return nil
+}
+
+// Update updates a user
+func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, userscached.UserTag(user.ID), userscached.ListTag)
+	return nil
+}
+
+// Delete deletes a user
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	if err := r.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, userscached.UserTag(id), userscached.ListTag)
+	return nil
+}
@@ -2,32 +2,44 @@ package crm
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/audit"
 	"github.com/manab-pr/evtaarpro/internal/config"
 	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/internal/idempotency"
+	"github.com/manab-pr/evtaarpro/internal/middleware"
+	"github.com/manab-pr/evtaarpro/internal/realtime"
 	"github.com/manab-pr/evtaarpro/modules/crm/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/crm/infra/notifications"
 	"github.com/manab-pr/evtaarpro/modules/crm/infra/postgresql"
 	"github.com/manab-pr/evtaarpro/modules/crm/presentation/http/handlers"
 	"github.com/manab-pr/evtaarpro/modules/crm/presentation/http/routes"
+	notificationsModule "github.com/manab-pr/evtaarpro/modules/notifications"
 )
 
 // RegisterRoutes registers CRM module routes
 func RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
 	// Infrastructure
 	customerRepo := postgresql.NewCustomerRepository(pgStore.DB)
+	publisher := realtime.NewRedisPublisher(redisStore)
+	notifier := notifications.NewNotifier(notificationsModule.NewDispatchNotificationUseCase(cfg, pgStore, redisStore))
+	outbox := eventbus.NewOutbox()
 
 	// Use cases
-	createCustomerUC := usecases.NewCreateCustomerUseCase(customerRepo)
+	createCustomerUC := usecases.NewCreateCustomerUseCase(customerRepo, pgStore, outbox, publisher)
+	updateCustomerUC := usecases.NewUpdateCustomerUseCase(customerRepo, pgStore, outbox, publisher, audit.NewLog(pgStore.DB))
 	listCustomersUC := usecases.NewListCustomersUseCase(customerRepo)
-	addInteractionUC := usecases.NewAddInteractionUseCase(customerRepo)
+	addInteractionUC := usecases.NewAddInteractionUseCase(customerRepo, notifier)
 
 	// Handlers
 	customerHandlers := handlers.NewCustomerHandlers(
 		createCustomerUC,
+		updateCustomerUC,
 		listCustomersUC,
 		addInteractionUC,
 		customerRepo,
 	)
 
 	// Register routes
-	routes.RegisterRoutes(rg, customerHandlers, cfg.JWT.Secret)
+	routes.RegisterRoutes(rg, customerHandlers, cfg.JWT.Secret, middleware.Idempotency(idempotency.NewStore(redisStore)))
 }
@@ -1,6 +1,12 @@
 package entities
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrCustomerNotFound is returned for customers that do not exist
+var ErrCustomerNotFound = errors.New("customer not found")
 
 // CustomerStatus represents the status of a customer
 type CustomerStatus string
@@ -0,0 +1,22 @@
+package events
+
+import "time"
+
+// CustomerAssignedEvent is the name of CustomerAssigned events
+const CustomerAssignedEvent = "crm.customer_assigned"
+
+// CustomerAssigned is published when a customer is created with an assignee
+// or assigned to someone else
+type CustomerAssigned struct {
+	CustomerID   string    `json:"customer_id"`
+	CustomerName string    `json:"customer_name"`
+	Company      string    `json:"company"`
+	AssignedTo   string    `json:"assigned_to"`
+	AssignedBy   string    `json:"assigned_by"`
+	AssignedAt   time.Time `json:"assigned_at"`
+}
+
+// EventName implements eventbus.Event
+func (CustomerAssigned) EventName() string {
+	return CustomerAssignedEvent
+}
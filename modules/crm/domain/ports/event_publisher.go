@@ -0,0 +1,8 @@
+package ports
+
+import "context"
+
+// EventPublisher pushes live events to the connected clients of users
+type EventPublisher interface {
+	Publish(ctx context.Context, userIDs []string, eventType string, data interface{}) error
+}
@@ -0,0 +1,8 @@
+package ports
+
+import "context"
+
+// Notifier sends notifications rendered from a template to users
+type Notifier interface {
+	Notify(ctx context.Context, userID, template string, variables map[string]interface{}) error
+}
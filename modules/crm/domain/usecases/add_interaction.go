@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/logging"
 	"github.com/manab-pr/evtaarpro/modules/crm/domain/entities"
 	"github.com/manab-pr/evtaarpro/modules/crm/domain/ports"
 )
@@ -22,12 +23,14 @@ type AddInteractionInput struct {
 // AddInteractionUseCase handles adding customer interactions
 type AddInteractionUseCase struct {
 	customerRepo ports.CustomerRepository
+	notifier     ports.Notifier
 }
 
 // NewAddInteractionUseCase creates a new use case
-func NewAddInteractionUseCase(customerRepo ports.CustomerRepository) *AddInteractionUseCase {
+func NewAddInteractionUseCase(customerRepo ports.CustomerRepository, notifier ports.Notifier) *AddInteractionUseCase {
 	return &AddInteractionUseCase{
 		customerRepo: customerRepo,
+		notifier:     notifier,
 	}
 }
 
@@ -48,5 +51,30 @@ func (uc *AddInteractionUseCase) Execute(ctx context.Context, input AddInteracti
 		return nil, err
 	}
 
+	uc.notifyAssignee(ctx, interaction)
+
 	return interaction, nil
 }
+
+// notifyAssignee tells the user a customer is assigned to about interactions
+// others logged. Failures are logged because the interaction is stored.
+func (uc *AddInteractionUseCase) notifyAssignee(ctx context.Context, interaction *entities.CustomerInteraction) {
+	customer, err := uc.customerRepo.GetByID(ctx, interaction.CustomerID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to load customer to notify about interaction", "customer_id", interaction.CustomerID, "interaction_id", interaction.ID, "error", err)
+		return
+	}
+	if customer.AssignedTo == nil || *customer.AssignedTo == "" || *customer.AssignedTo == interaction.UserID {
+		return
+	}
+
+	if err := uc.notifier.Notify(ctx, *customer.AssignedTo, "crm.interaction_logged", map[string]interface{}{
+		"customer_id":      customer.ID,
+		"customer_name":    customer.Name,
+		"interaction_id":   interaction.ID,
+		"interaction_type": interaction.Type,
+		"subject":          interaction.Subject,
+	}); err != nil {
+		logging.FromContext(ctx).Warn("failed to notify assignee about interaction", "assignee_id", *customer.AssignedTo, "interaction_id", interaction.ID, "error", err)
+	}
+}
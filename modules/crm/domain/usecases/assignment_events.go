@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/crm/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/crm/domain/events"
+	"github.com/manab-pr/evtaarpro/modules/crm/domain/ports"
+)
+
+// eventCustomerAssigned matches realtime.EventCustomerAssigned
+const eventCustomerAssigned = "crm.customer_assigned"
+
+// PublishAssignment tells the assignee of a customer about the assignment,
+// unless they assigned it to themselves. Failures are logged because the
+// assignment is already stored.
+func PublishAssignment(ctx context.Context, publisher ports.EventPublisher, customer *entities.Customer, assignedBy string) {
+	if customer.AssignedTo == nil || *customer.AssignedTo == "" || *customer.AssignedTo == assignedBy {
+		return
+	}
+
+	data := map[string]interface{}{
+		"customer_id":   customer.ID,
+		"customer_name": customer.Name,
+		"company":       customer.Company,
+		"assigned_by":   assignedBy,
+	}
+	if err := publisher.Publish(ctx, []string{*customer.AssignedTo}, eventCustomerAssigned, data); err != nil {
+		logging.FromContext(ctx).Warn("failed to publish customer assignment", "customer_id", customer.ID, "error", err)
+	}
+}
+
+// recordAssignment adds a CustomerAssigned event to the transaction of ctx
+// when the customer has an assignee
+func recordAssignment(ctx context.Context, outbox eventbus.Publisher, customer *entities.Customer, assignedBy string, at time.Time) error {
+	if customer.AssignedTo == nil || *customer.AssignedTo == "" {
+		return nil
+	}
+
+	return outbox.Publish(ctx, events.CustomerAssigned{
+		CustomerID:   customer.ID,
+		CustomerName: customer.Name,
+		Company:      customer.Company,
+		AssignedTo:   *customer.AssignedTo,
+		AssignedBy:   assignedBy,
+		AssignedAt:   at,
+	})
+}
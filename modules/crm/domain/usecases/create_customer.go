@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
 	"github.com/manab-pr/evtaarpro/modules/crm/domain/entities"
 	"github.com/manab-pr/evtaarpro/modules/crm/domain/ports"
 )
@@ -26,16 +28,28 @@ type CreateCustomerInput struct {
 // CreateCustomerUseCase handles customer creation
 type CreateCustomerUseCase struct {
 	customerRepo ports.CustomerRepository
+	transactor   datastore.Transactor
+	outbox       eventbus.Publisher
+	publisher    ports.EventPublisher
 }
 
 // NewCreateCustomerUseCase creates a new use case
-func NewCreateCustomerUseCase(customerRepo ports.CustomerRepository) *CreateCustomerUseCase {
+func NewCreateCustomerUseCase(
+	customerRepo ports.CustomerRepository,
+	transactor datastore.Transactor,
+	outbox eventbus.Publisher,
+	publisher ports.EventPublisher,
+) *CreateCustomerUseCase {
 	return &CreateCustomerUseCase{
 		customerRepo: customerRepo,
+		transactor:   transactor,
+		outbox:       outbox,
+		publisher:    publisher,
 	}
 }
 
-// Execute creates a new customer
+// Execute creates a new customer. A customer created with an assignee is
+// stored together with its CustomerAssigned event.
 func (uc *CreateCustomerUseCase) Execute(ctx context.Context, input CreateCustomerInput) (*entities.Customer, error) {
 	customer := &entities.Customer{
 		ID:         uuid.New().String(),
@@ -53,9 +67,17 @@ func (uc *CreateCustomerUseCase) Execute(ctx context.Context, input CreateCustom
 		UpdatedAt:  time.Now(),
 	}
 
-	if err := uc.customerRepo.Create(ctx, customer); err != nil {
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.customerRepo.Create(ctx, customer); err != nil {
+			return err
+		}
+		return recordAssignment(ctx, uc.outbox, customer, input.CreatedBy, customer.CreatedAt)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	PublishAssignment(ctx, uc.publisher, customer, input.CreatedBy)
+
 	return customer, nil
 }
@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/audit"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/modules/crm/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/crm/domain/ports"
+)
+
+// UpdateCustomerInput represents changes to a customer. Empty fields and a
+// nil AssignedTo are left unchanged.
+type UpdateCustomerInput struct {
+	CustomerID string
+	UpdatedBy  string
+	Name       string
+	Email      string
+	Phone      string
+	Company    string
+	Status     entities.CustomerStatus
+	Source     string
+	AssignedTo *string
+	Notes      string
+}
+
+// UpdateCustomerUseCase handles customer updates
+type UpdateCustomerUseCase struct {
+	customerRepo ports.CustomerRepository
+	transactor   datastore.Transactor
+	outbox       eventbus.Publisher
+	publisher    ports.EventPublisher
+	auditLog     audit.Recorder
+}
+
+// NewUpdateCustomerUseCase creates a new use case
+func NewUpdateCustomerUseCase(
+	customerRepo ports.CustomerRepository,
+	transactor datastore.Transactor,
+	outbox eventbus.Publisher,
+	publisher ports.EventPublisher,
+	auditLog audit.Recorder,
+) *UpdateCustomerUseCase {
+	return &UpdateCustomerUseCase{
+		customerRepo: customerRepo,
+		transactor:   transactor,
+		outbox:       outbox,
+		publisher:    publisher,
+		auditLog:     auditLog,
+	}
+}
+
+// Execute updates a customer. Assigning it to someone else is stored
+// together with its CustomerAssigned event and audit entry.
+func (uc *UpdateCustomerUseCase) Execute(ctx context.Context, input UpdateCustomerInput) (*entities.Customer, error) {
+	customer, err := uc.customerRepo.GetByID(ctx, input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != "" {
+		customer.Name = input.Name
+	}
+	if input.Email != "" {
+		customer.Email = input.Email
+	}
+	if input.Phone != "" {
+		customer.Phone = input.Phone
+	}
+	if input.Company != "" {
+		customer.Company = input.Company
+	}
+	if input.Status != "" {
+		customer.Status = input.Status
+	}
+	if input.Source != "" {
+		customer.Source = input.Source
+	}
+	reassigned := false
+	previousAssignee := customer.AssignedTo
+	if input.AssignedTo != nil {
+		reassigned = customer.AssignedTo == nil || *customer.AssignedTo != *input.AssignedTo
+		customer.AssignedTo = input.AssignedTo
+	}
+	if input.Notes != "" {
+		customer.Notes = input.Notes
+	}
+	customer.UpdatedAt = time.Now()
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.customerRepo.Update(ctx, customer); err != nil {
+			return err
+		}
+		if !reassigned {
+			return nil
+		}
+
+		err := uc.auditLog.Record(ctx, audit.Record{
+			ActorID:      input.UpdatedBy,
+			Action:       "crm.customer_reassign",
+			ResourceType: "customer",
+			ResourceID:   customer.ID,
+			Before:       map[string]interface{}{"assigned_to": previousAssignee},
+			After:        map[string]interface{}{"assigned_to": customer.AssignedTo},
+		})
+		if err != nil {
+			return err
+		}
+		return recordAssignment(ctx, uc.outbox, customer, input.UpdatedBy, customer.UpdatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reassigned {
+		PublishAssignment(ctx, uc.publisher, customer, input.UpdatedBy)
+	}
+
+	return customer, nil
+}
@@ -3,7 +3,9 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 
+	"github.com/manab-pr/evtaarpro/internal/datastore"
 	"github.com/manab-pr/evtaarpro/modules/crm/domain/entities"
 )
 
@@ -17,13 +19,14 @@ func NewCustomerRepository(db *sql.DB) *CustomerRepository {
 	return &CustomerRepository{db: db}
 }
 
-// Create creates a new customer
+// Create creates a new customer, inside the transaction of ctx if there is
+// one
 func (r *CustomerRepository) Create(ctx context.Context, customer *entities.Customer) error {
 	query := `
 		INSERT INTO customers (id, company_id, name, email, phone, company, status, source, assigned_to, notes, created_by, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := datastore.Conn(ctx, r.db).ExecContext(ctx, query,
 		customer.ID, customer.CompanyID, customer.Name, customer.Email, customer.Phone,
 		customer.Company, customer.Status, customer.Source, customer.AssignedTo, customer.Notes,
 		customer.CreatedBy, customer.CreatedAt, customer.UpdatedAt,
@@ -44,6 +47,9 @@ func (r *CustomerRepository) GetByID(ctx context.Context, id string) (*entities.
 		&customer.CreatedBy, &customer.CreatedAt, &customer.UpdatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrCustomerNotFound
+		}
 		return nil, err
 	}
 	return customer, nil
@@ -88,7 +94,7 @@ func (r *CustomerRepository) List(ctx context.Context, companyID string, limit,
 	return customers, total, nil
 }
 
-// Update updates a customer
+// Update updates a customer, inside the transaction of ctx if there is one
 func (r *CustomerRepository) Update(ctx context.Context, customer *entities.Customer) error {
 	query := `
 		UPDATE customers
@@ -96,7 +102,7 @@ func (r *CustomerRepository) Update(ctx context.Context, customer *entities.Cust
 		    assigned_to = $8, notes = $9, updated_at = $10
 		WHERE id = $1
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := datastore.Conn(ctx, r.db).ExecContext(ctx, query,
 		customer.ID, customer.Name, customer.Email, customer.Phone, customer.Company,
 		customer.Status, customer.Source, customer.AssignedTo, customer.Notes, customer.UpdatedAt,
 	)
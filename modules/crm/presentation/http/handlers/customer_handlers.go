@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -14,6 +15,7 @@ import (
 // CustomerHandlers contains CRM-related HTTP handlers
 type CustomerHandlers struct {
 	createCustomerUC    *usecases.CreateCustomerUseCase
+	updateCustomerUC    *usecases.UpdateCustomerUseCase
 	listCustomersUC     *usecases.ListCustomersUseCase
 	addInteractionUC    *usecases.AddInteractionUseCase
 	customerRepo        ports.CustomerRepository
@@ -22,12 +24,14 @@ type CustomerHandlers struct {
 // NewCustomerHandlers creates new CustomerHandlers
 func NewCustomerHandlers(
 	createCustomerUC *usecases.CreateCustomerUseCase,
+	updateCustomerUC *usecases.UpdateCustomerUseCase,
 	listCustomersUC *usecases.ListCustomersUseCase,
 	addInteractionUC *usecases.AddInteractionUseCase,
 	customerRepo ports.CustomerRepository,
 ) *CustomerHandlers {
 	return &CustomerHandlers{
 		createCustomerUC: createCustomerUC,
+		updateCustomerUC: updateCustomerUC,
 		listCustomersUC:  listCustomersUC,
 		addInteractionUC: addInteractionUC,
 		customerRepo:     customerRepo,
@@ -99,6 +103,7 @@ func (h *CustomerHandlers) GetCustomer(c *gin.Context) {
 
 // UpdateCustomer updates a customer
 func (h *CustomerHandlers) UpdateCustomer(c *gin.Context) {
+	userID, _ := c.Get("user_id")
 	customerID := c.Param("id")
 
 	var req dto.UpdateCustomerRequest
@@ -107,39 +112,23 @@ func (h *CustomerHandlers) UpdateCustomer(c *gin.Context) {
 		return
 	}
 
-	customer, err := h.customerRepo.GetByID(c.Request.Context(), customerID)
+	customer, err := h.updateCustomerUC.Execute(c.Request.Context(), usecases.UpdateCustomerInput{
+		CustomerID: customerID,
+		UpdatedBy:  userID.(string),
+		Name:       req.Name,
+		Email:      req.Email,
+		Phone:      req.Phone,
+		Company:    req.Company,
+		Status:     entities.CustomerStatus(req.Status),
+		Source:     req.Source,
+		AssignedTo: req.AssignedTo,
+		Notes:      req.Notes,
+	})
 	if err != nil {
-		response.NotFound(c, "Customer not found")
-		return
-	}
-
-	// Update fields
-	if req.Name != "" {
-		customer.Name = req.Name
-	}
-	if req.Email != "" {
-		customer.Email = req.Email
-	}
-	if req.Phone != "" {
-		customer.Phone = req.Phone
-	}
-	if req.Company != "" {
-		customer.Company = req.Company
-	}
-	if req.Status != "" {
-		customer.Status = entities.CustomerStatus(req.Status)
-	}
-	if req.Source != "" {
-		customer.Source = req.Source
-	}
-	if req.AssignedTo != nil {
-		customer.AssignedTo = req.AssignedTo
-	}
-	if req.Notes != "" {
-		customer.Notes = req.Notes
-	}
-
-	if err := h.customerRepo.Update(c.Request.Context(), customer); err != nil {
+		if errors.Is(err, entities.ErrCustomerNotFound) {
+			response.NotFound(c, "Customer not found")
+			return
+		}
 		response.InternalServerError(c, "Failed to update customer")
 		return
 	}
@@ -7,9 +7,9 @@ import (
 )
 
 // RegisterRoutes registers CRM routes
-func RegisterRoutes(rg *gin.RouterGroup, customerHandlers *handlers.CustomerHandlers, jwtSecret string) {
+func RegisterRoutes(rg *gin.RouterGroup, customerHandlers *handlers.CustomerHandlers, jwtSecret string, idempotent gin.HandlerFunc) {
 	crm := rg.Group("/crm")
-	crm.Use(middleware.AuthMiddleware(jwtSecret))
+	crm.Use(middleware.AuthMiddleware(jwtSecret), idempotent)
 	{
 		// Customer routes
 		crm.POST("/customers", customerHandlers.CreateCustomer)
@@ -2,43 +2,225 @@ package meetings
 
 import (
 	"context"
+	"log/slog"
+	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/cache"
 	"github.com/manab-pr/evtaarpro/internal/config"
 	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/internal/jobs"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/realtime"
+	"github.com/manab-pr/evtaarpro/internal/storage"
+	"github.com/manab-pr/evtaarpro/modules/auth/infra/security"
 	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
 	"github.com/manab-pr/evtaarpro/modules/meetings/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/meetings/infra/cached"
 	"github.com/manab-pr/evtaarpro/modules/meetings/infra/jitsi"
+	"github.com/manab-pr/evtaarpro/modules/meetings/infra/notifications"
 	"github.com/manab-pr/evtaarpro/modules/meetings/infra/postgresql"
+	"github.com/manab-pr/evtaarpro/modules/meetings/infra/summarizer"
 	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/handlers"
 	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/routes"
+	notificationsModule "github.com/manab-pr/evtaarpro/modules/notifications"
 )
 
 // RegisterRoutes registers meetings module routes
 func RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
 	// Infrastructure
-	meetingRepo := postgresql.NewMeetingRepository(pgStore.DB)
+	meetingRepo := cached.NewMeetingRepository(postgresql.NewMeetingRepository(pgStore.DB), cache.New(redisStore, "meetings"))
+	attendanceRepo := postgresql.NewAttendanceRepository(pgStore.DB)
+	recordingRepo := postgresql.NewRecordingRepository(pgStore.DB)
+	minutesRepo := postgresql.NewMinutesRepository(pgStore.DB)
+	guestRepo := postgresql.NewGuestRepository(pgStore.DB)
+	transcriptRepo := postgresql.NewTranscriptRepository(pgStore.DB)
+	templateRepo := postgresql.NewTemplateRepository(pgStore.DB)
+	scheduleRepo := postgresql.NewUserScheduleRepository(pgStore.DB)
+	localSummarizer := summarizer.NewLocalSummarizer()
+	passcodeHasher := security.NewBcryptHasher()
+	notifier := notifications.NewNotifier(notificationsModule.NewDispatchNotificationUseCase(cfg, pgStore, redisStore))
 	jitsiAdapter := jitsi.NewJitsiAdapter(cfg.Jitsi.Domain, cfg.Jitsi.AppID, cfg.Jitsi.AppSecret)
+	publisher := realtime.NewRedisPublisher(redisStore)
+
+	blobStore, err := storage.New(cfg)
+	if err != nil {
+		slog.Error("failed to initialize blob storage", "error", err)
+		os.Exit(1)
+	}
 
 	// Create a wrapper repository for join use case
 	joinRepo := &joinMeetingRepoAdapter{repo: meetingRepo}
 
+	workingHours := defaultWorkingHours(cfg)
+
 	// Use cases
-	createMeetingUC := usecases.NewCreateMeetingUseCase(meetingRepo)
+	createMeetingUC := usecases.NewCreateMeetingUseCase(meetingRepo, templateRepo, minutesRepo, scheduleRepo, pgStore, workingHours, usecases.ConflictPolicy(cfg.Meetings.ConflictPolicy))
 	getMeetingUC := usecases.NewGetMeetingUseCase(meetingRepo)
 	listMeetingsUC := usecases.NewListMeetingsUseCase(meetingRepo)
-	joinMeetingUC := usecases.NewJoinMeetingUseCase(joinRepo, jitsiAdapter)
+	joinMeetingUC := usecases.NewJoinMeetingUseCase(joinRepo, attendanceRepo, jitsiAdapter, meetingRepo, publisher)
+	updateSettingsUC := usecases.NewUpdateRoomSettingsUseCase(meetingRepo)
+	cancelMeetingUC := usecases.NewCancelMeetingUseCase(meetingRepo, pgStore, eventbus.NewOutbox(), publisher)
+	createTemplateUC := usecases.NewCreateTemplateUseCase(templateRepo)
+	listTemplatesUC := usecases.NewListTemplatesUseCase(templateRepo)
+	getTemplateUC := usecases.NewGetTemplateUseCase(templateRepo)
+	updateTemplateUC := usecases.NewUpdateTemplateUseCase(templateRepo)
+	deleteTemplateUC := usecases.NewDeleteTemplateUseCase(templateRepo)
+	leaveMeetingUC := usecases.NewLeaveMeetingUseCase(attendanceRepo)
+	getAttendanceReportUC := usecases.NewGetAttendanceReportUseCase(meetingRepo, attendanceRepo)
+	handleJitsiEventUC := usecases.NewHandleJitsiEventUseCase(meetingRepo, attendanceRepo)
+	startUploadUC := usecases.NewStartRecordingUploadUseCase(
+		meetingRepo,
+		recordingRepo,
+		blobStore,
+		cfg.AWS.S3.RecordingsPrefix,
+		cfg.AWS.S3.MaxFileSize,
+	)
+	uploadPartUC := usecases.NewUploadRecordingPartUseCase(meetingRepo, recordingRepo, blobStore)
+	getUploadUC := usecases.NewGetRecordingUploadUseCase(meetingRepo, recordingRepo, blobStore)
+	completeUploadUC := usecases.NewCompleteRecordingUploadUseCase(meetingRepo, recordingRepo, blobStore)
+	abortUploadUC := usecases.NewAbortRecordingUploadUseCase(meetingRepo, recordingRepo, blobStore)
+	listRecordingsUC := usecases.NewListRecordingsUseCase(meetingRepo, recordingRepo)
+	downloadURLUC := usecases.NewGetRecordingDownloadURLUseCase(meetingRepo, recordingRepo, blobStore, cfg.Meetings.RecordingURLTTL)
+	setAgendaUC := usecases.NewSetAgendaUseCase(meetingRepo, minutesRepo)
+	getAgendaUC := usecases.NewGetAgendaUseCase(meetingRepo, minutesRepo)
+	getNotesUC := usecases.NewGetNotesUseCase(meetingRepo, minutesRepo)
+	updateNotesUC := usecases.NewUpdateNotesUseCase(meetingRepo, minutesRepo)
+	listRevisionsUC := usecases.NewListNoteRevisionsUseCase(meetingRepo, minutesRepo)
+	getRevisionUC := usecases.NewGetNoteRevisionUseCase(meetingRepo, minutesRepo)
+	createActionItemUC := usecases.NewCreateActionItemUseCase(meetingRepo, minutesRepo, notifier)
+	updateActionItemUC := usecases.NewUpdateActionItemUseCase(meetingRepo, minutesRepo, notifier)
+	listActionItemsUC := usecases.NewListActionItemsUseCase(meetingRepo, minutesRepo)
+	listMyActionItemsUC := usecases.NewListMyActionItemsUseCase(minutesRepo)
+	createGuestLinkUC := usecases.NewCreateGuestLinkUseCase(meetingRepo, guestRepo, passcodeHasher, cfg.Meetings.GuestLinkURL)
+	listGuestLinksUC := usecases.NewListGuestLinksUseCase(meetingRepo, guestRepo)
+	revokeGuestLinkUC := usecases.NewRevokeGuestLinkUseCase(meetingRepo, guestRepo)
+	getGuestLinkUC := usecases.NewGetGuestLinkUseCase(meetingRepo, guestRepo)
+	requestGuestAccessUC := usecases.NewRequestGuestAccessUseCase(meetingRepo, guestRepo, passcodeHasher, notifier)
+	getLobbyStatusUC := usecases.NewGetLobbyStatusUseCase(meetingRepo, guestRepo, jitsiAdapter, cfg.Meetings.GuestTokenTTL)
+	listLobbyUC := usecases.NewListLobbyUseCase(meetingRepo, guestRepo)
+	decideLobbyEntryUC := usecases.NewDecideLobbyEntryUseCase(meetingRepo, guestRepo)
+	ingestTranscriptUC := usecases.NewIngestTranscriptUseCase(meetingRepo, transcriptRepo, localSummarizer)
+	getTranscriptUC := usecases.NewGetTranscriptUseCase(meetingRepo, transcriptRepo)
+	getSummaryUC := usecases.NewGetSummaryUseCase(meetingRepo, transcriptRepo)
+	searchTranscriptsUC := usecases.NewSearchTranscriptsUseCase(transcriptRepo)
+	getFreeBusyUC := usecases.NewGetFreeBusyUseCase(meetingRepo, scheduleRepo, workingHours)
+	suggestSlotsUC := usecases.NewSuggestSlotsUseCase(
+		meetingRepo,
+		scheduleRepo,
+		workingHours,
+		cfg.Meetings.SlotInterval,
+		cfg.Meetings.MaxSuggestions,
+	)
 
 	// Handlers
-	meetingHandlers := handlers.NewMeetingHandlers(createMeetingUC, getMeetingUC, listMeetingsUC, joinMeetingUC)
+	meetingHandlers := handlers.NewMeetingHandlers(createMeetingUC, getMeetingUC, listMeetingsUC, joinMeetingUC, updateSettingsUC, cancelMeetingUC)
+	scheduleHandlers := handlers.NewScheduleHandlers(getFreeBusyUC, suggestSlotsUC)
+	attendanceHandlers := handlers.NewAttendanceHandlers(leaveMeetingUC, getAttendanceReportUC, handleJitsiEventUC, cfg.Jitsi.WebhookSecret)
+	recordingHandlers := handlers.NewRecordingHandlers(
+		startUploadUC,
+		uploadPartUC,
+		getUploadUC,
+		completeUploadUC,
+		abortUploadUC,
+		listRecordingsUC,
+		downloadURLUC,
+		cfg.AWS.S3.MaxFileSize,
+	)
+	minutesHandlers := handlers.NewMinutesHandlers(
+		setAgendaUC,
+		getAgendaUC,
+		getNotesUC,
+		updateNotesUC,
+		listRevisionsUC,
+		getRevisionUC,
+		createActionItemUC,
+		updateActionItemUC,
+		listActionItemsUC,
+		listMyActionItemsUC,
+	)
+	guestHandlers := handlers.NewGuestHandlers(
+		createGuestLinkUC,
+		listGuestLinksUC,
+		revokeGuestLinkUC,
+		getGuestLinkUC,
+		requestGuestAccessUC,
+		getLobbyStatusUC,
+		listLobbyUC,
+		decideLobbyEntryUC,
+	)
+	transcriptHandlers := handlers.NewTranscriptHandlers(ingestTranscriptUC, getTranscriptUC, getSummaryUC, searchTranscriptsUC)
+	templateHandlers := handlers.NewTemplateHandlers(createTemplateUC, listTemplatesUC, getTemplateUC, updateTemplateUC, deleteTemplateUC)
+
+	var blobHandler http.Handler
+	if localStore, ok := blobStore.(*storage.LocalStore); ok {
+		blobHandler = localStore.Handler()
+	}
 
 	// Register routes
-	routes.RegisterRoutes(rg, meetingHandlers, cfg.JWT.Secret)
+	routes.RegisterRoutes(rg, meetingHandlers, scheduleHandlers, attendanceHandlers, recordingHandlers, minutesHandlers, guestHandlers, transcriptHandlers, templateHandlers, blobHandler, cfg.JWT.Secret)
+}
+
+// RegisterJobs registers meetings module background jobs
+func RegisterJobs(scheduler *jobs.Scheduler, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
+	// Infrastructure
+	meetingRepo := cached.NewMeetingRepository(postgresql.NewMeetingRepository(pgStore.DB), cache.New(redisStore, "meetings"))
+	scheduleRepo := postgresql.NewUserScheduleRepository(pgStore.DB)
+	notifier := notifications.NewNotifier(notificationsModule.NewDispatchNotificationUseCase(cfg, pgStore, redisStore))
+
+	// Use cases
+	sendRemindersUC := usecases.NewSendRemindersUseCase(meetingRepo, scheduleRepo, notifier, defaultWorkingHours(cfg), cfg.Meetings.ReminderBefore)
+	closeOverdueUC := usecases.NewCloseOverdueMeetingsUseCase(meetingRepo, realtime.NewRedisPublisher(redisStore), cfg.Meetings.EndGrace)
+
+	// Jobs
+	scheduler.Register(jobs.Job{
+		Name:     "meetings.send_reminders",
+		Interval: cfg.Meetings.JobInterval,
+		Run: func(ctx context.Context) error {
+			count, err := sendRemindersUC.Execute(ctx)
+			if count > 0 {
+				logging.FromContext(ctx).Info("sent meeting reminders", "meetings", count)
+			}
+			return err
+		},
+	})
+	scheduler.Register(jobs.Job{
+		Name:     "meetings.close_overdue",
+		Interval: cfg.Meetings.JobInterval,
+		Run: func(ctx context.Context) error {
+			output, err := closeOverdueUC.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if output.Missed > 0 || output.Completed > 0 {
+				logging.FromContext(ctx).Info("closed overdue meetings", "missed", output.Missed, "completed", output.Completed)
+			}
+			return nil
+		},
+	})
+}
+
+// defaultWorkingHours parses the configured working hours, which apply in
+// each user's own timezone unless the user has set personal hours
+func defaultWorkingHours(cfg *config.Config) entities.WorkingHours {
+	workingHours, err := entities.NewWorkingHours(
+		cfg.Meetings.WorkingHours.Start,
+		cfg.Meetings.WorkingHours.End,
+		cfg.Meetings.WorkingHours.Days,
+	)
+	if err != nil {
+		slog.Error("invalid meetings working hours", "error", err)
+		os.Exit(1)
+	}
+	return workingHours
 }
 
 // Adapter to bridge the meeting repository with join use case interface
 type joinMeetingRepoAdapter struct {
-	repo *postgresql.MeetingRepository
+	repo repository.MeetingRepository
 }
 
 func (a *joinMeetingRepoAdapter) GetByID(ctx context.Context, id string) (*usecases.MeetingEntity, error) {
@@ -53,6 +235,7 @@ func (a *joinMeetingRepoAdapter) GetByID(ctx context.Context, id string) (*useca
 		OrganizerID:  meeting.OrganizerID,
 		Status:       string(meeting.Status),
 		JitsiRoomURL: meeting.JitsiRoomURL,
+		Settings:     meeting.Settings,
 	}, nil
 }
 
@@ -0,0 +1,136 @@
+package entities
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttendanceSource identifies where a join or leave event came from
+type AttendanceSource string
+
+const (
+	AttendanceSourceApp   AttendanceSource = "app"
+	AttendanceSourceJitsi AttendanceSource = "jitsi"
+)
+
+// AttendanceSession represents a continuous period a user was present in a meeting
+type AttendanceSession struct {
+	ID        string
+	MeetingID string
+	UserID    string
+	Source    AttendanceSource
+	JoinedAt  time.Time
+	LeftAt    *time.Time
+}
+
+// NewAttendanceSession creates a new open attendance session
+func NewAttendanceSession(meetingID, userID string, source AttendanceSource, joinedAt time.Time) *AttendanceSession {
+	return &AttendanceSession{
+		ID:        uuid.New().String(),
+		MeetingID: meetingID,
+		UserID:    userID,
+		Source:    source,
+		JoinedAt:  joinedAt,
+	}
+}
+
+// AttendanceRecord summarises the attendance of one participant
+type AttendanceRecord struct {
+	UserID      string
+	Role        ParticipantRole
+	FirstJoin   *time.Time
+	LastLeave   *time.Time
+	TimePresent time.Duration
+	Sessions    int
+	NoShow      bool
+}
+
+// AttendanceReport summarises the attendance of a meeting
+type AttendanceReport struct {
+	MeetingID string
+	Records   []*AttendanceRecord
+}
+
+// NewAttendanceReport builds an attendance report from participants and their
+// sessions. Sessions without a leave event are counted until the meeting
+// end, or until now if the meeting has not ended. Overlapping sessions of a user (e.g. the
+// app and Jitsi both reporting a join) are counted once.
+func NewAttendanceReport(meeting *Meeting, participants []*Participant, sessions []*AttendanceSession, now time.Time) *AttendanceReport {
+	until := now
+	if meeting.EndTime != nil {
+		until = *meeting.EndTime
+	}
+
+	records := make(map[string]*AttendanceRecord, len(participants))
+	order := make([]string, 0, len(participants))
+	record := func(userID string, role ParticipantRole) *AttendanceRecord {
+		if r, ok := records[userID]; ok {
+			return r
+		}
+		r := &AttendanceRecord{UserID: userID, Role: role}
+		records[userID] = r
+		order = append(order, userID)
+		return r
+	}
+
+	for _, participant := range participants {
+		record(participant.UserID, participant.Role)
+	}
+
+	slots := make(map[string][]TimeSlot)
+	present := make(map[string]bool)
+	for _, session := range sessions {
+		r := record(session.UserID, ParticipantRoleParticipant)
+		r.Sessions++
+
+		joinedAt := session.JoinedAt
+		if r.FirstJoin == nil || joinedAt.Before(*r.FirstJoin) {
+			r.FirstJoin = &joinedAt
+		}
+
+		leftAt := session.LeftAt
+		if leftAt == nil && meeting.EndTime != nil {
+			// The meeting ended without a leave event for this session
+			leftAt = &until
+		}
+
+		end := until
+		if leftAt != nil {
+			leftAt := *leftAt
+			if r.LastLeave == nil || leftAt.After(*r.LastLeave) {
+				r.LastLeave = &leftAt
+			}
+			end = leftAt
+		} else {
+			present[session.UserID] = true
+		}
+		if end.After(joinedAt) {
+			slots[session.UserID] = append(slots[session.UserID], TimeSlot{Start: joinedAt, End: end})
+		}
+	}
+
+	report := &AttendanceReport{
+		MeetingID: meeting.ID,
+		Records:   make([]*AttendanceRecord, 0, len(order)),
+	}
+	for _, userID := range order {
+		r := records[userID]
+		for _, slot := range MergeSlots(slots[userID]) {
+			r.TimePresent += slot.Duration()
+		}
+		if present[userID] {
+			// Still in the meeting, so there is no last leave yet
+			r.LastLeave = nil
+		}
+		r.NoShow = r.Sessions == 0 && !now.Before(meeting.StartTime)
+		report.Records = append(report.Records, r)
+	}
+
+	sort.SliceStable(report.Records, func(i, j int) bool {
+		return report.Records[i].TimePresent > report.Records[j].TimePresent
+	})
+
+	return report
+}
@@ -0,0 +1,115 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func session(userID string, source AttendanceSource, joinedAt time.Time, leftAt *time.Time) *AttendanceSession {
+	s := NewAttendanceSession("meeting-1", userID, source, joinedAt)
+	s.LeftAt = leftAt
+	return s
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestNewAttendanceReport(t *testing.T) {
+	start := at(11, 10, 0)
+	participants := []*Participant{
+		NewParticipant("meeting-1", "alice", ParticipantRoleHost),
+		NewParticipant("meeting-1", "bob", ParticipantRoleParticipant),
+		NewParticipant("meeting-1", "carol", ParticipantRoleParticipant),
+	}
+
+	type want struct {
+		userID    string
+		role      ParticipantRole
+		present   time.Duration
+		sessions  int
+		firstJoin *time.Time
+		lastLeave *time.Time
+		noShow    bool
+	}
+
+	tests := []struct {
+		name     string
+		endTime  *time.Time
+		now      time.Time
+		sessions []*AttendanceSession
+		want     []want // ordered by time present
+	}{
+		{
+			name:    "ended",
+			endTime: ptr(at(11, 11, 0)),
+			now:     at(11, 12, 0),
+			sessions: []*AttendanceSession{
+				// The app and Jitsi both report alice; the overlap counts once
+				session("alice", AttendanceSourceApp, at(11, 10, 0), ptr(at(11, 10, 30))),
+				session("alice", AttendanceSourceJitsi, at(11, 10, 15), ptr(at(11, 10, 45))),
+				// bob never left, so the session counts until the end
+				session("bob", AttendanceSourceApp, at(11, 10, 10), nil),
+				// dave is not a participant but was there
+				session("dave", AttendanceSourceJitsi, at(11, 10, 0), ptr(at(11, 10, 5))),
+			},
+			want: []want{
+				{"bob", ParticipantRoleParticipant, 50 * time.Minute, 1, ptr(at(11, 10, 10)), ptr(at(11, 11, 0)), false},
+				{"alice", ParticipantRoleHost, 45 * time.Minute, 2, ptr(at(11, 10, 0)), ptr(at(11, 10, 45)), false},
+				{"dave", ParticipantRoleParticipant, 5 * time.Minute, 1, ptr(at(11, 10, 0)), ptr(at(11, 10, 5)), false},
+				{"carol", ParticipantRoleParticipant, 0, 0, nil, nil, true},
+			},
+		},
+		{
+			name: "ongoing",
+			now:  at(11, 10, 30),
+			sessions: []*AttendanceSession{
+				session("alice", AttendanceSourceApp, at(11, 10, 0), ptr(at(11, 10, 20))),
+				session("alice", AttendanceSourceApp, at(11, 10, 25), nil),
+				session("bob", AttendanceSourceApp, at(11, 10, 5), ptr(at(11, 10, 15))),
+			},
+			want: []want{
+				// alice is back in the meeting, so there is no last leave
+				{"alice", ParticipantRoleHost, 25 * time.Minute, 2, ptr(at(11, 10, 0)), nil, false},
+				{"bob", ParticipantRoleParticipant, 10 * time.Minute, 1, ptr(at(11, 10, 5)), ptr(at(11, 10, 15)), false},
+				{"carol", ParticipantRoleParticipant, 0, 0, nil, nil, true},
+			},
+		},
+		{
+			name: "not started",
+			now:  at(11, 9, 0),
+			want: []want{
+				{"alice", ParticipantRoleHost, 0, 0, nil, nil, false},
+				{"bob", ParticipantRoleParticipant, 0, 0, nil, nil, false},
+				{"carol", ParticipantRoleParticipant, 0, 0, nil, nil, false},
+			},
+		},
+	}
+
+	sameTime := func(a, b *time.Time) bool {
+		if a == nil || b == nil {
+			return a == nil && b == nil
+		}
+		return a.Equal(*b)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meeting := &Meeting{ID: "meeting-1", StartTime: start, Duration: time.Hour, EndTime: tt.endTime}
+			report := NewAttendanceReport(meeting, participants, tt.sessions, tt.now)
+
+			if report.MeetingID != meeting.ID || len(report.Records) != len(tt.want) {
+				t.Fatalf("report has %d records, want %d", len(report.Records), len(tt.want))
+			}
+			for i, w := range tt.want {
+				r := report.Records[i]
+				if r.UserID != w.userID || r.Role != w.role || r.TimePresent != w.present || r.Sessions != w.sessions || r.NoShow != w.noShow {
+					t.Fatalf("record %d = %+v, want %+v", i, r, w)
+				}
+				if !sameTime(r.FirstJoin, w.firstJoin) || !sameTime(r.LastLeave, w.lastLeave) {
+					t.Fatalf("record %d of %s: first join %v, last leave %v, want %v, %v", i, r.UserID, r.FirstJoin, r.LastLeave, w.firstJoin, w.lastLeave)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTimeRange    = errors.New("invalid time range")
+	ErrInvalidWorkingHours = errors.New("invalid working hours")
+)
+
+// TimeSlot represents a half-open time interval [Start, End)
+type TimeSlot struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the slot
+func (s TimeSlot) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Overlaps checks if two slots share any instant
+func (s TimeSlot) Overlaps(other TimeSlot) bool {
+	return s.Start.Before(other.End) && other.Start.Before(s.End)
+}
+
+// BusyInterval represents a period in which a user is booked in a meeting
+type BusyInterval struct {
+	UserID    string
+	MeetingID string
+	Title     string
+	Start     time.Time
+	End       time.Time
+}
+
+// Slot returns the interval as a TimeSlot
+func (b *BusyInterval) Slot() TimeSlot {
+	return TimeSlot{Start: b.Start, End: b.End}
+}
+
+// Conflict represents an invitee's existing meeting overlapping a new one
+type Conflict struct {
+	UserID    string
+	MeetingID string
+	Title     string
+	Start     time.Time
+	End       time.Time
+}
+
+// MergeSlots sorts slots and merges the ones that overlap or touch
+func MergeSlots(slots []TimeSlot) []TimeSlot {
+	if len(slots) == 0 {
+		return nil
+	}
+
+	sorted := make([]TimeSlot, len(slots))
+	copy(sorted, slots)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	merged := []TimeSlot{sorted[0]}
+	for _, slot := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if !slot.Start.After(last.End) {
+			if slot.End.After(last.End) {
+				last.End = slot.End
+			}
+			continue
+		}
+		merged = append(merged, slot)
+	}
+
+	return merged
+}
+
+// SubtractSlots returns the parts of window not covered by busy.
+// busy must be sorted and merged (see MergeSlots).
+func SubtractSlots(window TimeSlot, busy []TimeSlot) []TimeSlot {
+	free := make([]TimeSlot, 0)
+	cursor := window.Start
+
+	for _, slot := range busy {
+		if !slot.End.After(cursor) {
+			continue
+		}
+		if !slot.Start.Before(window.End) {
+			break
+		}
+		if slot.Start.After(cursor) {
+			free = append(free, TimeSlot{Start: cursor, End: slot.Start})
+		}
+		cursor = slot.End
+	}
+
+	if cursor.Before(window.End) {
+		free = append(free, TimeSlot{Start: cursor, End: window.End})
+	}
+
+	return free
+}
+
+// WorkingHours describes the daily window in which meetings may be scheduled
+type WorkingHours struct {
+	StartMinute int // minutes after local midnight
+	EndMinute   int
+	Days        map[time.Weekday]bool
+	Location    *time.Location
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// NewWorkingHours creates working hours from "HH:MM" bounds and weekday names
+func NewWorkingHours(start, end string, days []string) (WorkingHours, error) {
+	startMinute, err := parseClock(start)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	endMinute, err := parseClock(end)
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	if endMinute <= startMinute {
+		return WorkingHours{}, ErrInvalidWorkingHours
+	}
+
+	dayset := make(map[time.Weekday]bool, len(days))
+	for _, day := range days {
+		weekday, ok := weekdays[strings.ToLower(day)]
+		if !ok {
+			return WorkingHours{}, fmt.Errorf("%w: unknown day %q", ErrInvalidWorkingHours, day)
+		}
+		dayset[weekday] = true
+	}
+	if len(dayset) == 0 {
+		return WorkingHours{}, ErrInvalidWorkingHours
+	}
+
+	return WorkingHours{
+		StartMinute: startMinute,
+		EndMinute:   endMinute,
+		Days:        dayset,
+		Location:    time.UTC,
+	}, nil
+}
+
+// Windows returns the working-hour windows intersecting [from, to)
+func (w WorkingHours) Windows(from, to time.Time) []TimeSlot {
+	loc := w.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	windows := make([]TimeSlot, 0)
+	localFrom := from.In(loc)
+	day := time.Date(localFrom.Year(), localFrom.Month(), localFrom.Day(), 0, 0, 0, 0, loc)
+
+	for day.Before(to) {
+		if w.Days[day.Weekday()] {
+			// time.Date normalises minute overflow and resolves DST per day
+			window := TimeSlot{
+				Start: time.Date(day.Year(), day.Month(), day.Day(), 0, w.StartMinute, 0, 0, loc),
+				End:   time.Date(day.Year(), day.Month(), day.Day(), 0, w.EndMinute, 0, 0, loc),
+			}
+			if window.Start.Before(from) {
+				window.Start = from
+			}
+			if window.End.After(to) {
+				window.End = to
+			}
+			if window.Start.Before(window.End) {
+				windows = append(windows, window)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return windows
+}
+
+// In returns the same working hours in another timezone
+func (w WorkingHours) In(loc *time.Location) WorkingHours {
+	w.Location = loc
+	return w
+}
+
+// Contains checks if slot lies within a single working-hour window
+func (w WorkingHours) Contains(slot TimeSlot) bool {
+	windows := w.Windows(slot.Start, slot.End)
+	return len(windows) == 1 && windows[0].Start.Equal(slot.Start) && windows[0].End.Equal(slot.End)
+}
+
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not HH:MM", ErrInvalidWorkingHours, value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// IntersectSlots returns the instants covered by both a and b.
+// Both must be sorted and merged (see MergeSlots).
+func IntersectSlots(a, b []TimeSlot) []TimeSlot {
+	intersection := make([]TimeSlot, 0)
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		start := a[i].Start
+		if b[j].Start.After(start) {
+			start = b[j].Start
+		}
+		end := a[i].End
+		if b[j].End.Before(end) {
+			end = b[j].End
+		}
+		if start.Before(end) {
+			intersection = append(intersection, TimeSlot{Start: start, End: end})
+		}
+
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return intersection
+}
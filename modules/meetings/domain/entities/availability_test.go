@@ -0,0 +1,263 @@
+package entities
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// at returns 2024-03-<day> hh:mm in UTC
+func at(day, hh, mm int) time.Time {
+	return time.Date(2024, time.March, day, hh, mm, 0, 0, time.UTC)
+}
+
+func slot(day, startH, endH int) TimeSlot {
+	return TimeSlot{Start: at(day, startH, 0), End: at(day, endH, 0)}
+}
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q): %v", name, err)
+	}
+	return loc
+}
+
+func sameSlots(a, b []TimeSlot) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Start.Equal(b[i].Start) || !a[i].End.Equal(b[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestTimeSlotOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b TimeSlot
+		want bool
+	}{
+		{"same", slot(4, 9, 10), slot(4, 9, 10), true},
+		{"partial", slot(4, 9, 11), slot(4, 10, 12), true},
+		{"contained", slot(4, 9, 12), slot(4, 10, 11), true},
+		{"touching", slot(4, 9, 10), slot(4, 10, 11), false},
+		{"apart", slot(4, 9, 10), slot(4, 11, 12), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Overlaps(tt.b); got != tt.want {
+				t.Fatalf("Overlaps = %v, want %v", got, tt.want)
+			}
+			if got := tt.b.Overlaps(tt.a); got != tt.want {
+				t.Fatalf("Overlaps is not symmetric")
+			}
+		})
+	}
+}
+
+func TestMergeSlots(t *testing.T) {
+	tests := []struct {
+		name  string
+		slots []TimeSlot
+		want  []TimeSlot
+	}{
+		{"empty", nil, nil},
+		{"single", []TimeSlot{slot(4, 9, 10)}, []TimeSlot{slot(4, 9, 10)}},
+		{"unsorted apart", []TimeSlot{slot(4, 13, 14), slot(4, 9, 10)}, []TimeSlot{slot(4, 9, 10), slot(4, 13, 14)}},
+		{"overlapping", []TimeSlot{slot(4, 9, 11), slot(4, 10, 12)}, []TimeSlot{slot(4, 9, 12)}},
+		{"touching", []TimeSlot{slot(4, 10, 11), slot(4, 9, 10)}, []TimeSlot{slot(4, 9, 11)}},
+		{"contained", []TimeSlot{slot(4, 9, 17), slot(4, 10, 11), slot(4, 12, 13)}, []TimeSlot{slot(4, 9, 17)}},
+		{"chain", []TimeSlot{slot(4, 9, 10), slot(4, 15, 16), slot(4, 9, 12), slot(4, 11, 13)}, []TimeSlot{slot(4, 9, 13), slot(4, 15, 16)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append([]TimeSlot(nil), tt.slots...)
+			if got := MergeSlots(tt.slots); !sameSlots(got, tt.want) {
+				t.Fatalf("MergeSlots = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(input, tt.slots) {
+				t.Fatalf("MergeSlots modified its input")
+			}
+		})
+	}
+}
+
+func TestSubtractSlots(t *testing.T) {
+	window := slot(4, 9, 17)
+
+	tests := []struct {
+		name string
+		busy []TimeSlot
+		want []TimeSlot
+	}{
+		{"free", nil, []TimeSlot{window}},
+		{"middle", []TimeSlot{slot(4, 12, 13)}, []TimeSlot{slot(4, 9, 12), slot(4, 13, 17)}},
+		{"start", []TimeSlot{slot(4, 8, 10)}, []TimeSlot{slot(4, 10, 17)}},
+		{"end", []TimeSlot{slot(4, 16, 18)}, []TimeSlot{slot(4, 9, 16)}},
+		{"all day", []TimeSlot{slot(4, 8, 18)}, []TimeSlot{}},
+		{"before and after", []TimeSlot{slot(4, 6, 8), slot(4, 18, 19)}, []TimeSlot{window}},
+		{"several", []TimeSlot{slot(4, 9, 10), slot(4, 11, 12), slot(4, 15, 17)}, []TimeSlot{slot(4, 10, 11), slot(4, 12, 15)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SubtractSlots(window, tt.busy); !sameSlots(got, tt.want) {
+				t.Fatalf("SubtractSlots = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntersectSlots(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []TimeSlot
+		want []TimeSlot
+	}{
+		{"empty", nil, []TimeSlot{slot(4, 9, 17)}, []TimeSlot{}},
+		{"disjoint", []TimeSlot{slot(4, 9, 12)}, []TimeSlot{slot(4, 13, 17)}, []TimeSlot{}},
+		{"touching", []TimeSlot{slot(4, 9, 12)}, []TimeSlot{slot(4, 12, 17)}, []TimeSlot{}},
+		{"overlap", []TimeSlot{slot(4, 9, 17)}, []TimeSlot{slot(4, 13, 20)}, []TimeSlot{slot(4, 13, 17)}},
+		{
+			"several",
+			[]TimeSlot{slot(4, 9, 17), slot(5, 9, 17)},
+			[]TimeSlot{slot(4, 8, 10), slot(4, 16, 23), slot(5, 0, 12)},
+			[]TimeSlot{slot(4, 9, 10), slot(4, 16, 17), slot(5, 9, 12)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IntersectSlots(tt.a, tt.b); !sameSlots(got, tt.want) {
+				t.Fatalf("IntersectSlots = %v, want %v", got, tt.want)
+			}
+			if got := IntersectSlots(tt.b, tt.a); !sameSlots(got, tt.want) {
+				t.Fatalf("IntersectSlots is not symmetric: %v", got)
+			}
+		})
+	}
+}
+
+func TestNewWorkingHours(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+		days       []string
+		wantErr    bool
+	}{
+		{"office", "09:00", "17:30", []string{"Monday", "friday"}, false},
+		{"end before start", "17:00", "09:00", []string{"monday"}, true},
+		{"empty window", "09:00", "09:00", []string{"monday"}, true},
+		{"bad clock", "9am", "17:00", []string{"monday"}, true},
+		{"unknown day", "09:00", "17:00", []string{"funday"}, true},
+		{"no days", "09:00", "17:00", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hours, err := NewWorkingHours(tt.start, tt.end, tt.days)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWorkingHours) {
+					t.Fatalf("err = %v, want ErrInvalidWorkingHours", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewWorkingHours: %v", err)
+			}
+			if hours.StartMinute != 9*60 || hours.EndMinute != 17*60+30 || len(hours.Days) != 2 {
+				t.Fatalf("hours = %+v", hours)
+			}
+		})
+	}
+}
+
+func TestWorkingHoursWindows(t *testing.T) {
+	weekdays := []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+	hours, err := NewWorkingHours("09:00", "17:00", weekdays)
+	if err != nil {
+		t.Fatalf("NewWorkingHours: %v", err)
+	}
+	newYork := loadLocation(t, "America/New_York")
+	kolkata := loadLocation(t, "Asia/Kolkata")
+
+	tests := []struct {
+		name     string
+		hours    WorkingHours
+		from, to time.Time
+		want     []TimeSlot
+	}{
+		{
+			// 2024-03-08 is a Friday; the weekend is skipped
+			"weekend",
+			hours, at(8, 0, 0), at(12, 0, 0),
+			[]TimeSlot{slot(8, 9, 17), slot(11, 9, 17)},
+		},
+		{
+			"clipped to the range",
+			hours, at(11, 12, 0), at(12, 10, 0),
+			[]TimeSlot{slot(11, 12, 17), slot(12, 9, 10)},
+		},
+		{
+			"outside",
+			hours, at(11, 18, 0), at(11, 23, 0),
+			[]TimeSlot{},
+		},
+		{
+			// Clocks in New York move forward on Sunday 2024-03-10
+			"daylight saving",
+			hours.In(newYork), at(8, 0, 0), at(12, 0, 0),
+			[]TimeSlot{slot(8, 14, 22), slot(11, 13, 21)},
+		},
+		{
+			// 09:00 in Kolkata is 03:30 UTC
+			"half hour offset",
+			hours.In(kolkata), at(11, 0, 0), at(12, 0, 0),
+			[]TimeSlot{{Start: at(11, 3, 30), End: at(11, 11, 30)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hours.Windows(tt.from, tt.to); !sameSlots(got, tt.want) {
+				t.Fatalf("Windows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkingHoursContains(t *testing.T) {
+	hours, err := NewWorkingHours("09:00", "17:00", []string{"monday"})
+	if err != nil {
+		t.Fatalf("NewWorkingHours: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		slot TimeSlot
+		want bool
+	}{
+		{"inside", slot(11, 10, 11), true},
+		{"whole day", slot(11, 9, 17), true},
+		{"starts early", slot(11, 8, 10), false},
+		{"ends late", slot(11, 16, 18), false},
+		{"other day", slot(12, 10, 11), false},
+		{"spans two days", TimeSlot{Start: at(11, 16, 0), End: at(18, 10, 0)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hours.Contains(tt.slot); got != tt.want {
+				t.Fatalf("Contains(%v) = %v, want %v", tt.slot, got, tt.want)
+			}
+		})
+	}
+}
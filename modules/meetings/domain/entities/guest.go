@@ -0,0 +1,162 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxGuestLinkTTL is the longest time a guest link can stay valid
+const MaxGuestLinkTTL = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidGuestLink     = errors.New("invalid guest link")
+	ErrGuestLinkNotFound    = errors.New("guest link not found")
+	ErrGuestLinkUnavailable = errors.New("guest link has expired, was revoked or has no uses left")
+	ErrInvalidPasscode      = errors.New("invalid passcode")
+	ErrInvalidGuest         = errors.New("invalid guest details")
+	ErrLobbyEntryNotFound   = errors.New("lobby request not found")
+	ErrLobbyEntryDecided    = errors.New("lobby request was already decided")
+)
+
+// GuestLink lets people without an account request to join a meeting.
+// The link token is only known to the creator; TokenHash is stored.
+type GuestLink struct {
+	ID           string
+	MeetingID    string
+	TokenHash    string
+	PasscodeHash string
+	ExpiresAt    time.Time
+	MaxUses      int
+	UseCount     int
+	CreatedBy    string
+	CreatedAt    time.Time
+	RevokedAt    *time.Time
+}
+
+// NewGuestLink creates a new guest link entity and returns it with its token.
+// A MaxUses of 0 means unlimited.
+func NewGuestLink(meetingID, createdBy string, expiresAt time.Time, maxUses int, passcodeHash string) (*GuestLink, string, error) {
+	now := time.Now()
+	if meetingID == "" || maxUses < 0 || !expiresAt.After(now) || expiresAt.Sub(now) > MaxGuestLinkTTL {
+		return nil, "", ErrInvalidGuestLink
+	}
+
+	token, err := newGuestSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &GuestLink{
+		ID:           uuid.New().String(),
+		MeetingID:    meetingID,
+		TokenHash:    HashGuestSecret(token),
+		PasscodeHash: passcodeHash,
+		ExpiresAt:    expiresAt,
+		MaxUses:      maxUses,
+		CreatedBy:    createdBy,
+		CreatedAt:    now,
+	}, token, nil
+}
+
+// HasPasscode checks if the link is passcode protected
+func (l *GuestLink) HasPasscode() bool {
+	return l.PasscodeHash != ""
+}
+
+// IsUsable checks if the link can still be used to request access
+func (l *GuestLink) IsUsable(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt) && (l.MaxUses == 0 || l.UseCount < l.MaxUses)
+}
+
+// LobbyStatus represents the state of a guest waiting to be admitted
+type LobbyStatus string
+
+const (
+	LobbyStatusWaiting  LobbyStatus = "waiting"
+	LobbyStatusAdmitted LobbyStatus = "admitted"
+	LobbyStatusDenied   LobbyStatus = "denied"
+)
+
+// LobbyEntry represents a guest's request to join a meeting.
+// The guest proves ownership with a secret; SecretHash is stored.
+type LobbyEntry struct {
+	ID          string
+	MeetingID   string
+	LinkID      string
+	DisplayName string
+	Email       string
+	SecretHash  string
+	Status      LobbyStatus
+	CreatedAt   time.Time
+	DecidedAt   *time.Time
+	DecidedBy   string
+}
+
+// NewLobbyEntry creates a new waiting lobby entry and returns it with the
+// secret the guest uses to poll for a decision
+func NewLobbyEntry(meetingID, linkID, displayName, email string) (*LobbyEntry, string, error) {
+	displayName = strings.TrimSpace(displayName)
+	if displayName == "" || len(displayName) > 100 {
+		return nil, "", ErrInvalidGuest
+	}
+	if email != "" {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return nil, "", ErrInvalidGuest
+		}
+	}
+
+	secret, err := newGuestSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &LobbyEntry{
+		ID:          uuid.New().String(),
+		MeetingID:   meetingID,
+		LinkID:      linkID,
+		DisplayName: displayName,
+		Email:       email,
+		SecretHash:  HashGuestSecret(secret),
+		Status:      LobbyStatusWaiting,
+		CreatedAt:   time.Now(),
+	}, secret, nil
+}
+
+// Decide admits or denies a waiting guest
+func (e *LobbyEntry) Decide(admit bool, hostID string) error {
+	if e.Status != LobbyStatusWaiting {
+		return ErrLobbyEntryDecided
+	}
+
+	now := time.Now()
+	e.Status = LobbyStatusDenied
+	if admit {
+		e.Status = LobbyStatusAdmitted
+	}
+	e.DecidedAt = &now
+	e.DecidedBy = hostID
+	return nil
+}
+
+// HashGuestSecret hashes a guest link token or lobby secret for storage.
+// The secrets are random, so a plain SHA-256 is sufficient.
+func HashGuestSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func newGuestSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,132 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewGuestLink(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		meetingID string
+		expiresAt time.Time
+		maxUses   int
+		want      error
+	}{
+		{"valid", "meeting-1", now.Add(time.Hour), 5, nil},
+		{"unlimited", "meeting-1", now.Add(MaxGuestLinkTTL - time.Minute), 0, nil},
+		{"no meeting", "", now.Add(time.Hour), 0, ErrInvalidGuestLink},
+		{"negative uses", "meeting-1", now.Add(time.Hour), -1, ErrInvalidGuestLink},
+		{"expired", "meeting-1", now.Add(-time.Minute), 0, ErrInvalidGuestLink},
+		{"too long", "meeting-1", now.Add(MaxGuestLinkTTL + time.Hour), 0, ErrInvalidGuestLink},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, token, err := NewGuestLink(tt.meetingID, "alice", tt.expiresAt, tt.maxUses, "")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewGuestLink: err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if token == "" || link.TokenHash != HashGuestSecret(token) || link.TokenHash == token {
+				t.Fatalf("link stores %q for token %q, want its hash", link.TokenHash, token)
+			}
+			if link.HasPasscode() {
+				t.Fatal("link without passcode hash reports a passcode")
+			}
+		})
+	}
+}
+
+func TestGuestLinkIsUsable(t *testing.T) {
+	now := time.Now()
+	revoked := now.Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		link GuestLink
+		want bool
+	}{
+		{"fresh", GuestLink{ExpiresAt: now.Add(time.Hour)}, true},
+		{"uses left", GuestLink{ExpiresAt: now.Add(time.Hour), MaxUses: 2, UseCount: 1}, true},
+		{"used up", GuestLink{ExpiresAt: now.Add(time.Hour), MaxUses: 2, UseCount: 2}, false},
+		{"unlimited", GuestLink{ExpiresAt: now.Add(time.Hour), UseCount: 1000}, true},
+		{"expired", GuestLink{ExpiresAt: now}, false},
+		{"revoked", GuestLink{ExpiresAt: now.Add(time.Hour), RevokedAt: &revoked}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.link.IsUsable(now); got != tt.want {
+				t.Fatalf("IsUsable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLobbyEntry(t *testing.T) {
+	tests := []struct {
+		name  string
+		guest string
+		email string
+		want  error
+	}{
+		{"name only", "  Dana  ", "", nil},
+		{"with email", "Dana", "dana@example.com", nil},
+		{"blank name", "  ", "", ErrInvalidGuest},
+		{"long name", string(make([]byte, 101)), "", ErrInvalidGuest},
+		{"bad email", "Dana", "not-an-email", ErrInvalidGuest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, secret, err := NewLobbyEntry("meeting-1", "link-1", tt.guest, tt.email)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewLobbyEntry: err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if entry.DisplayName != "Dana" || entry.Status != LobbyStatusWaiting || entry.SecretHash != HashGuestSecret(secret) {
+				t.Fatalf("entry = %+v", entry)
+			}
+		})
+	}
+}
+
+func TestLobbyEntryDecide(t *testing.T) {
+	tests := []struct {
+		name  string
+		admit bool
+		want  LobbyStatus
+	}{
+		{"admit", true, LobbyStatusAdmitted},
+		{"deny", false, LobbyStatusDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, _, err := NewLobbyEntry("meeting-1", "link-1", "Dana", "")
+			if err != nil {
+				t.Fatalf("NewLobbyEntry: %v", err)
+			}
+			if err := entry.Decide(tt.admit, "alice"); err != nil {
+				t.Fatalf("Decide: %v", err)
+			}
+			if entry.Status != tt.want || entry.DecidedBy != "alice" || entry.DecidedAt == nil {
+				t.Fatalf("entry = %+v", entry)
+			}
+			if err := entry.Decide(!tt.admit, "bob"); !errors.Is(err, ErrLobbyEntryDecided) {
+				t.Fatalf("second decision: err = %v, want ErrLobbyEntryDecided", err)
+			}
+			if entry.Status != tt.want || entry.DecidedBy != "alice" {
+				t.Fatal("second decision changed the entry")
+			}
+		})
+	}
+}
@@ -2,14 +2,20 @@ package entities
 
 import (
 	"errors"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrInvalidMeetingData = errors.New("invalid meeting data")
-	ErrMeetingNotActive   = errors.New("meeting is not active")
+	ErrInvalidMeetingData  = errors.New("invalid meeting data")
+	ErrInvalidDuration     = errors.New("meeting duration must be positive")
+	ErrMeetingNotActive    = errors.New("meeting is not active")
+	ErrMeetingNotFound     = errors.New("meeting not found")
+	ErrInvalidRoomSettings = errors.New("room passcode must be 4 to 64 characters without spaces")
+	ErrRecordingNotAllowed = errors.New("recording is not allowed in this meeting")
 )
 
 // MeetingStatus represents the status of a meeting
@@ -20,48 +26,100 @@ const (
 	StatusOngoing   MeetingStatus = "ongoing"
 	StatusCompleted MeetingStatus = "completed"
 	StatusCancelled MeetingStatus = "cancelled"
+	StatusMissed    MeetingStatus = "missed"
 )
 
 // Meeting represents a meeting entity
 type Meeting struct {
-	ID             string
-	RoomID         string
-	Title          string
-	Description    string
-	OrganizerID    string
-	StartTime      time.Time
-	EndTime        *time.Time
-	Status         MeetingStatus
-	JitsiRoomURL   string
-	RecordingURL   string
+	ID              string
+	RoomID          string
+	Title           string
+	Description     string
+	OrganizerID     string
+	StartTime       time.Time
+	Duration        time.Duration
+	EndTime         *time.Time
+	Status          MeetingStatus
+	JitsiRoomURL    string
+	RecordingURL    string
 	MaxParticipants int
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	Settings        RoomSettings
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// RoomSettings controls how the Jitsi room of a meeting behaves
+type RoomSettings struct {
+	// LobbyEnabled makes guests wait for a host to admit them
+	LobbyEnabled bool
+	// StartMuted joins everyone with their microphone off
+	StartMuted bool
+	// RecordingAllowed permits recording and live streaming
+	RecordingAllowed bool
+	// Passcode locks the room; empty means no passcode
+	Passcode string
+}
+
+// DefaultRoomSettings returns the settings of meetings that do not choose any
+func DefaultRoomSettings() RoomSettings {
+	return RoomSettings{
+		LobbyEnabled:     true,
+		RecordingAllowed: true,
+	}
+}
+
+// Validate checks the room settings
+func (s RoomSettings) Validate() error {
+	if s.Passcode == "" {
+		return nil
+	}
+	if n := len([]rune(s.Passcode)); n < 4 || n > 64 || strings.ContainsFunc(s.Passcode, unicode.IsSpace) {
+		return ErrInvalidRoomSettings
+	}
+	return nil
 }
 
 // NewMeeting creates a new meeting entity
-func NewMeeting(title, description, organizerID string, startTime time.Time) (*Meeting, error) {
+func NewMeeting(title, description, organizerID string, startTime time.Time, duration time.Duration) (*Meeting, error) {
 	if title == "" || organizerID == "" {
 		return nil, ErrInvalidMeetingData
 	}
+	if duration <= 0 {
+		return nil, ErrInvalidDuration
+	}
 
 	roomID := uuid.New().String()
 	now := time.Now()
 
 	return &Meeting{
-		ID:             uuid.New().String(),
-		RoomID:         roomID,
-		Title:          title,
-		Description:    description,
-		OrganizerID:    organizerID,
-		StartTime:      startTime,
-		Status:         StatusScheduled,
+		ID:              uuid.New().String(),
+		RoomID:          roomID,
+		Title:           title,
+		Description:     description,
+		OrganizerID:     organizerID,
+		StartTime:       startTime.UTC(),
+		Duration:        duration,
+		Status:          StatusScheduled,
 		MaxParticipants: 50,
-		CreatedAt:      now,
-		UpdatedAt:      now,
+		Settings:        DefaultRoomSettings(),
+		CreatedAt:       now,
+		UpdatedAt:       now,
 	}, nil
 }
 
+// StatusChange records a meeting moving to a new status
+type StatusChange struct {
+	MeetingID   string
+	OrganizerID string
+	Status      MeetingStatus
+	ChangedAt   time.Time
+}
+
+// ScheduledEnd returns the planned end of the meeting
+func (m *Meeting) ScheduledEnd() time.Time {
+	return m.StartTime.Add(m.Duration)
+}
+
 // Start starts the meeting
 func (m *Meeting) Start(jitsiRoomURL string) error {
 	if m.Status != StatusScheduled {
@@ -85,10 +143,11 @@ func (m *Meeting) Complete(recordingURL string) {
 	m.UpdatedAt = now
 }
 
-// Cancel cancels the meeting
+// Cancel cancels the meeting. Only scheduled and ongoing meetings can be
+// cancelled.
 func (m *Meeting) Cancel() error {
-	if m.Status == StatusCompleted {
-		return errors.New("cannot cancel completed meeting")
+	if !m.IsActive() {
+		return ErrMeetingNotActive
 	}
 
 	m.Status = StatusCancelled
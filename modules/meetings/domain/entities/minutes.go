@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAgenda       = errors.New("invalid agenda item")
+	ErrNotesConflict       = errors.New("notes were changed by someone else")
+	ErrRevisionNotFound    = errors.New("notes revision not found")
+	ErrInvalidActionItem   = errors.New("invalid action item")
+	ErrActionItemNotFound  = errors.New("action item not found")
+	ErrInvalidActionStatus = errors.New("invalid action item status")
+)
+
+// AgendaItem represents a topic on a meeting agenda
+type AgendaItem struct {
+	ID              string
+	MeetingID       string
+	Position        int
+	Title           string
+	Description     string
+	DurationMinutes int
+	PresenterID     string
+}
+
+// NewAgendaItem creates a new agenda item entity
+func NewAgendaItem(meetingID string, position int, title, description string, durationMinutes int, presenterID string) (*AgendaItem, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || durationMinutes < 0 {
+		return nil, ErrInvalidAgenda
+	}
+
+	return &AgendaItem{
+		ID:              uuid.New().String(),
+		MeetingID:       meetingID,
+		Position:        position,
+		Title:           title,
+		Description:     description,
+		DurationMinutes: durationMinutes,
+		PresenterID:     presenterID,
+	}, nil
+}
+
+// NoteRevision represents one saved version of a meeting's notes.
+// The latest revision is the current notes; revision 0 means no notes yet.
+type NoteRevision struct {
+	MeetingID string
+	Revision  int
+	Content   string
+	EditedBy  string
+	CreatedAt time.Time
+}
+
+// ActionItemStatus represents the status of an action item
+type ActionItemStatus string
+
+const (
+	ActionItemOpen       ActionItemStatus = "open"
+	ActionItemInProgress ActionItemStatus = "in_progress"
+	ActionItemDone       ActionItemStatus = "done"
+	ActionItemCancelled  ActionItemStatus = "cancelled"
+)
+
+// IsValid checks if the status is known
+func (s ActionItemStatus) IsValid() bool {
+	switch s {
+	case ActionItemOpen, ActionItemInProgress, ActionItemDone, ActionItemCancelled:
+		return true
+	}
+	return false
+}
+
+// IsOpen checks if the item still needs work
+func (s ActionItemStatus) IsOpen() bool {
+	return s == ActionItemOpen || s == ActionItemInProgress
+}
+
+// ActionItem represents a follow-up task agreed in a meeting
+type ActionItem struct {
+	ID          string
+	MeetingID   string
+	Title       string
+	Description string
+	AssigneeID  string
+	DueDate     *time.Time
+	Status      ActionItemStatus
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// NewActionItem creates a new open action item entity
+func NewActionItem(meetingID, title, description, assigneeID string, dueDate *time.Time, createdBy string) (*ActionItem, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || assigneeID == "" {
+		return nil, ErrInvalidActionItem
+	}
+
+	now := time.Now()
+
+	return &ActionItem{
+		ID:          uuid.New().String(),
+		MeetingID:   meetingID,
+		Title:       title,
+		Description: description,
+		AssigneeID:  assigneeID,
+		DueDate:     dueDate,
+		Status:      ActionItemOpen,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// SetStatus changes the status and tracks completion time
+func (a *ActionItem) SetStatus(status ActionItemStatus) error {
+	if !status.IsValid() {
+		return ErrInvalidActionStatus
+	}
+
+	now := time.Now()
+	if status == ActionItemDone && a.Status != ActionItemDone {
+		a.CompletedAt = &now
+	} else if status != ActionItemDone {
+		a.CompletedAt = nil
+	}
+
+	a.Status = status
+	a.UpdatedAt = now
+	return nil
+}
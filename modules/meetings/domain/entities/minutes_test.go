@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewAgendaItem(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		duration int
+		want     error
+	}{
+		{"valid", "  Roadmap  ", 15, nil},
+		{"no duration", "Roadmap", 0, nil},
+		{"blank title", "   ", 15, ErrInvalidAgenda},
+		{"negative duration", "Roadmap", -5, ErrInvalidAgenda},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewAgendaItem("meeting-1", 2, tt.title, "", tt.duration, "alice")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewAgendaItem: err = %v, want %v", err, tt.want)
+			}
+			if err == nil && (item.Title != "Roadmap" || item.Position != 2 || item.ID == "") {
+				t.Fatalf("item = %+v", item)
+			}
+		})
+	}
+}
+
+func TestNewActionItem(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		assignee string
+		want     error
+	}{
+		{"valid", " Send notes ", "bob", nil},
+		{"blank title", " ", "bob", ErrInvalidActionItem},
+		{"no assignee", "Send notes", "", ErrInvalidActionItem},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewActionItem("meeting-1", tt.title, "", tt.assignee, nil, "alice")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewActionItem: err = %v, want %v", err, tt.want)
+			}
+			if err == nil && (item.Title != "Send notes" || item.Status != ActionItemOpen || item.CompletedAt != nil) {
+				t.Fatalf("item = %+v", item)
+			}
+		})
+	}
+}
+
+func TestActionItemSetStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		from, to      ActionItemStatus
+		want          error
+		wantCompleted bool
+		wantOpen      bool
+	}{
+		{"start", ActionItemOpen, ActionItemInProgress, nil, false, true},
+		{"finish", ActionItemInProgress, ActionItemDone, nil, true, false},
+		{"done again", ActionItemDone, ActionItemDone, nil, true, false},
+		{"reopen", ActionItemDone, ActionItemOpen, nil, false, true},
+		{"cancel", ActionItemOpen, ActionItemCancelled, nil, false, false},
+		{"unknown", ActionItemOpen, "blocked", ErrInvalidActionStatus, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item, err := NewActionItem("meeting-1", "Send notes", "", "bob", nil, "alice")
+			if err != nil {
+				t.Fatalf("NewActionItem: %v", err)
+			}
+			if tt.from != ActionItemOpen {
+				if err := item.SetStatus(tt.from); err != nil {
+					t.Fatalf("SetStatus(%s): %v", tt.from, err)
+				}
+			}
+			completedAt := item.CompletedAt
+
+			if err := item.SetStatus(tt.to); !errors.Is(err, tt.want) {
+				t.Fatalf("SetStatus(%s): err = %v, want %v", tt.to, err, tt.want)
+			}
+			if (item.CompletedAt != nil) != tt.wantCompleted {
+				t.Fatalf("CompletedAt = %v, want set %v", item.CompletedAt, tt.wantCompleted)
+			}
+			if tt.from == ActionItemDone && tt.to == ActionItemDone && item.CompletedAt != completedAt {
+				t.Fatal("marking a done item done again moved its completion time")
+			}
+			if item.Status.IsOpen() != tt.wantOpen {
+				t.Fatalf("status %s open = %v, want %v", item.Status, item.Status.IsOpen(), tt.wantOpen)
+			}
+		})
+	}
+}
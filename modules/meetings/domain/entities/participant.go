@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// ParticipantRole represents the role of a participant in a meeting
+type ParticipantRole string
+
+const (
+	ParticipantRoleHost        ParticipantRole = "host"
+	ParticipantRoleParticipant ParticipantRole = "participant"
+	ParticipantRoleGuest       ParticipantRole = "guest"
+)
+
+// Participant represents a user invited to a meeting
+type Participant struct {
+	MeetingID string
+	UserID    string
+	Role      ParticipantRole
+	JoinedAt  *time.Time
+	LeftAt    *time.Time
+}
+
+// NewParticipant creates a new participant entity
+func NewParticipant(meetingID, userID string, role ParticipantRole) *Participant {
+	return &Participant{
+		MeetingID: meetingID,
+		UserID:    userID,
+		Role:      role,
+	}
+}
@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRecording      = errors.New("invalid recording data")
+	ErrRecordingNotFound     = errors.New("recording not found")
+	ErrRecordingTooLarge     = errors.New("recording exceeds the maximum file size")
+	ErrRecordingNotUploading = errors.New("recording upload is not in progress")
+	ErrRecordingIncomplete   = errors.New("uploaded parts do not add up to the recording size")
+	ErrRecordingUnavailable  = errors.New("recording is not available yet")
+)
+
+// RecordingStatus represents the status of a recording
+type RecordingStatus string
+
+const (
+	RecordingStatusUploading RecordingStatus = "uploading"
+	RecordingStatusAvailable RecordingStatus = "available"
+)
+
+// Recording represents a meeting recording stored in the blob store
+type Recording struct {
+	ID          string
+	MeetingID   string
+	ObjectKey   string
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	UploadID    string
+	Status      RecordingStatus
+	UploadedBy  string
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// NewRecording creates a new recording entity awaiting upload
+func NewRecording(meetingID, uploadedBy, fileName, contentType string, sizeBytes, maxSize int64, keyPrefix string) (*Recording, error) {
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if meetingID == "" || fileName == "" || fileName == "." || fileName == "/" || sizeBytes <= 0 {
+		return nil, ErrInvalidRecording
+	}
+	if maxSize > 0 && sizeBytes > maxSize {
+		return nil, ErrRecordingTooLarge
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	id := uuid.New().String()
+
+	return &Recording{
+		ID:          id,
+		MeetingID:   meetingID,
+		ObjectKey:   keyPrefix + meetingID + "/" + id + path.Ext(fileName),
+		FileName:    fileName,
+		ContentType: contentType,
+		SizeBytes:   sizeBytes,
+		Status:      RecordingStatusUploading,
+		UploadedBy:  uploadedBy,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// MarkAvailable marks the upload as completed
+func (r *Recording) MarkAvailable() error {
+	if r.Status != RecordingStatusUploading {
+		return ErrRecordingNotUploading
+	}
+
+	now := time.Now()
+	r.Status = RecordingStatusAvailable
+	r.UploadID = ""
+	r.CompletedAt = &now
+	return nil
+}
@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+// UserSchedule holds a user's timezone and, when set, personal working
+// hours. WorkStart and WorkEnd are "HH:MM" in the user's timezone.
+type UserSchedule struct {
+	UserID    string
+	Location  *time.Location
+	WorkStart string
+	WorkEnd   string
+	WorkDays  []string
+}
+
+// Timezone returns the IANA name of the user's timezone
+func (s *UserSchedule) Timezone() string {
+	return s.location().String()
+}
+
+// WorkingHours returns the user's working hours in their timezone. The
+// defaults apply unless the user has set valid personal working hours.
+func (s *UserSchedule) WorkingHours(defaults WorkingHours) WorkingHours {
+	if s != nil && s.WorkStart != "" {
+		if hours, err := NewWorkingHours(s.WorkStart, s.WorkEnd, s.WorkDays); err == nil {
+			return hours.In(s.location())
+		}
+	}
+	return defaults.In(s.location())
+}
+
+// LocalTime returns t in the user's timezone
+func (s *UserSchedule) LocalTime(t time.Time) time.Time {
+	return t.In(s.location())
+}
+
+// location returns the user's timezone; users without a schedule are in UTC
+func (s *UserSchedule) location() *time.Location {
+	if s == nil || s.Location == nil {
+		return time.UTC
+	}
+	return s.Location
+}
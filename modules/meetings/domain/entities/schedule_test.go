@@ -0,0 +1,70 @@
+package entities
+
+import "testing"
+
+func TestUserScheduleWorkingHours(t *testing.T) {
+	defaults, err := NewWorkingHours("09:00", "17:00", []string{"monday", "tuesday", "wednesday", "thursday", "friday"})
+	if err != nil {
+		t.Fatalf("NewWorkingHours: %v", err)
+	}
+	kolkata := loadLocation(t, "Asia/Kolkata")
+
+	// March 11, 2024 is a Monday and March 16 a Saturday
+	tests := []struct {
+		name     string
+		schedule *UserSchedule
+		slot     TimeSlot
+		want     bool
+	}{
+		{"no schedule", nil, slot(11, 9, 10), true},
+		{"no schedule after hours", nil, slot(11, 17, 18), false},
+		{"no timezone", &UserSchedule{UserID: "u"}, slot(11, 16, 17), true},
+		// 09:30-10:30 in Kolkata
+		{"defaults in the user's timezone", &UserSchedule{Location: kolkata}, TimeSlot{Start: at(11, 4, 0), End: at(11, 5, 0)}, true},
+		// 17:30-18:30 in Kolkata
+		{"defaults after local hours", &UserSchedule{Location: kolkata}, slot(11, 12, 13), false},
+		// 06:30-07:30 in Kolkata
+		{"personal hours", &UserSchedule{Location: kolkata, WorkStart: "06:00", WorkEnd: "10:00", WorkDays: []string{"monday"}}, TimeSlot{Start: at(11, 1, 0), End: at(11, 2, 0)}, true},
+		// 09:30-10:30 in Kolkata
+		{"past personal hours", &UserSchedule{Location: kolkata, WorkStart: "06:00", WorkEnd: "10:00", WorkDays: []string{"monday"}}, TimeSlot{Start: at(11, 4, 0), End: at(11, 5, 0)}, false},
+		{"weekend worker", &UserSchedule{WorkStart: "09:00", WorkEnd: "17:00", WorkDays: []string{"saturday"}}, slot(16, 9, 10), true},
+		{"weekend worker on a weekday", &UserSchedule{WorkStart: "09:00", WorkEnd: "17:00", WorkDays: []string{"saturday"}}, slot(11, 9, 10), false},
+		{"invalid personal hours", &UserSchedule{Location: kolkata, WorkStart: "25:00", WorkEnd: "10:00", WorkDays: []string{"monday"}}, TimeSlot{Start: at(11, 4, 0), End: at(11, 5, 0)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.WorkingHours(defaults).Contains(tt.slot); got != tt.want {
+				t.Fatalf("Contains(%v-%v) = %v, want %v", tt.slot.Start, tt.slot.End, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserScheduleTimezone(t *testing.T) {
+	kolkata := loadLocation(t, "Asia/Kolkata")
+	start := at(11, 20, 0)
+
+	tests := []struct {
+		name     string
+		schedule *UserSchedule
+		wantTZ   string
+		wantTime string
+	}{
+		{"no schedule", nil, "UTC", "2024-03-11 20:00"},
+		{"no timezone", &UserSchedule{}, "UTC", "2024-03-11 20:00"},
+		{"kolkata", &UserSchedule{Location: kolkata}, "Asia/Kolkata", "2024-03-12 01:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.Timezone(); got != tt.wantTZ {
+				t.Fatalf("Timezone = %q, want %q", got, tt.wantTZ)
+			}
+			local := tt.schedule.LocalTime(start)
+			if got := local.Format("2006-01-02 15:04"); got != tt.wantTime || !local.Equal(start) {
+				t.Fatalf("LocalTime = %v, want %s", local, tt.wantTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxTemplateAgendaItems limits the agenda of a template
+	MaxTemplateAgendaItems = 50
+	// MaxTemplateInvitees limits the default invitees of a template
+	MaxTemplateInvitees = 200
+)
+
+var (
+	ErrInvalidTemplate   = errors.New("invalid meeting template")
+	ErrTemplateNotFound  = errors.New("meeting template not found")
+	ErrTemplateForbidden = errors.New("only the owner can change a meeting template")
+)
+
+// TemplateAgendaItem is an agenda item copied into meetings created from a template
+type TemplateAgendaItem struct {
+	Title           string `json:"title"`
+	Description     string `json:"description,omitempty"`
+	DurationMinutes int    `json:"duration_minutes,omitempty"`
+	PresenterID     string `json:"presenter_id,omitempty"`
+}
+
+// TemplateSpec holds the editable fields of a meeting template.
+//
+// TitlePattern may contain {date}, {time}, {weekday} and {month}, which are
+// filled in from the start time of the meeting.
+type TemplateSpec struct {
+	Name            string
+	TitlePattern    string
+	Description     string
+	Duration        time.Duration
+	MaxParticipants int
+	InviteeIDs      []string
+	Agenda          []TemplateAgendaItem
+	Settings        RoomSettings
+	Shared          bool
+}
+
+// MeetingTemplate holds reusable defaults for creating meetings. Shared
+// templates can be used, but not changed, by everyone.
+type MeetingTemplate struct {
+	ID      string
+	OwnerID string
+	TemplateSpec
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewMeetingTemplate creates a new meeting template entity
+func NewMeetingTemplate(ownerID string, spec TemplateSpec) (*MeetingTemplate, error) {
+	if ownerID == "" {
+		return nil, ErrInvalidTemplate
+	}
+
+	spec, err := spec.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &MeetingTemplate{
+		ID:           uuid.New().String(),
+		OwnerID:      ownerID,
+		TemplateSpec: spec,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Update replaces the editable fields of the template
+func (t *MeetingTemplate) Update(spec TemplateSpec) error {
+	spec, err := spec.normalize()
+	if err != nil {
+		return err
+	}
+
+	t.TemplateSpec = spec
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// RenderTitle fills in the title pattern for a meeting starting at start
+func (t *MeetingTemplate) RenderTitle(start time.Time) string {
+	return strings.NewReplacer(
+		"{date}", start.Format("2006-01-02"),
+		"{time}", start.Format("15:04"),
+		"{weekday}", start.Weekday().String(),
+		"{month}", start.Month().String(),
+	).Replace(t.TitlePattern)
+}
+
+// normalize trims the spec, drops duplicate invitees and validates it
+func (s TemplateSpec) normalize() (TemplateSpec, error) {
+	s.Name = strings.TrimSpace(s.Name)
+	s.TitlePattern = strings.TrimSpace(s.TitlePattern)
+
+	if s.Name == "" || len([]rune(s.Name)) > 100 || s.TitlePattern == "" || len([]rune(s.TitlePattern)) > 200 {
+		return s, ErrInvalidTemplate
+	}
+	if s.Duration <= 0 || s.Duration > 24*time.Hour || s.MaxParticipants < 0 {
+		return s, ErrInvalidTemplate
+	}
+	if len(s.Agenda) > MaxTemplateAgendaItems || len(s.InviteeIDs) > MaxTemplateInvitees {
+		return s, ErrInvalidTemplate
+	}
+	if err := s.Settings.Validate(); err != nil {
+		return s, err
+	}
+
+	agenda := make([]TemplateAgendaItem, len(s.Agenda))
+	for i, item := range s.Agenda {
+		item.Title = strings.TrimSpace(item.Title)
+		if item.Title == "" || item.DurationMinutes < 0 {
+			return s, ErrInvalidAgenda
+		}
+		agenda[i] = item
+	}
+	s.Agenda = agenda
+
+	invitees := make([]string, 0, len(s.InviteeIDs))
+	seen := make(map[string]bool)
+	for _, inviteeID := range s.InviteeIDs {
+		if inviteeID == "" || seen[inviteeID] {
+			continue
+		}
+		seen[inviteeID] = true
+		invitees = append(invitees, inviteeID)
+	}
+	s.InviteeIDs = invitees
+
+	return s, nil
+}
@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewMeetingTemplate(t *testing.T) {
+	valid := func() TemplateSpec {
+		return TemplateSpec{
+			Name:         "  Weekly sync  ",
+			TitlePattern: " Sync {date} ",
+			Duration:     30 * time.Minute,
+			InviteeIDs:   []string{"bob", "", "carol", "bob"},
+			Agenda:       []TemplateAgendaItem{{Title: " Updates ", DurationMinutes: 10}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ownerID string
+		change  func(*TemplateSpec)
+		want    error
+	}{
+		{"valid", "alice", func(*TemplateSpec) {}, nil},
+		{"no owner", "", func(*TemplateSpec) {}, ErrInvalidTemplate},
+		{"blank name", "alice", func(s *TemplateSpec) { s.Name = "  " }, ErrInvalidTemplate},
+		{"long name", "alice", func(s *TemplateSpec) { s.Name = strings.Repeat("n", 101) }, ErrInvalidTemplate},
+		{"no title pattern", "alice", func(s *TemplateSpec) { s.TitlePattern = "" }, ErrInvalidTemplate},
+		{"no duration", "alice", func(s *TemplateSpec) { s.Duration = 0 }, ErrInvalidTemplate},
+		{"longer than a day", "alice", func(s *TemplateSpec) { s.Duration = 25 * time.Hour }, ErrInvalidTemplate},
+		{"negative participants", "alice", func(s *TemplateSpec) { s.MaxParticipants = -1 }, ErrInvalidTemplate},
+		{"too many invitees", "alice", func(s *TemplateSpec) { s.InviteeIDs = make([]string, MaxTemplateInvitees+1) }, ErrInvalidTemplate},
+		{"blank agenda item", "alice", func(s *TemplateSpec) { s.Agenda = []TemplateAgendaItem{{Title: " "}} }, ErrInvalidAgenda},
+		{"bad passcode", "alice", func(s *TemplateSpec) { s.Settings.Passcode = "12" }, ErrInvalidRoomSettings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := valid()
+			tt.change(&spec)
+
+			template, err := NewMeetingTemplate(tt.ownerID, spec)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("NewMeetingTemplate: err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			if template.Name != "Weekly sync" || template.TitlePattern != "Sync {date}" || template.Agenda[0].Title != "Updates" {
+				t.Fatalf("template = %+v, want trimmed fields", template.TemplateSpec)
+			}
+			if !reflect.DeepEqual(template.InviteeIDs, []string{"bob", "carol"}) {
+				t.Fatalf("invitees = %v, want [bob carol]", template.InviteeIDs)
+			}
+		})
+	}
+}
+
+func TestMeetingTemplateUpdateKeepsInvalidSpecOut(t *testing.T) {
+	template, err := NewMeetingTemplate("alice", TemplateSpec{Name: "Sync", TitlePattern: "Sync", Duration: time.Hour})
+	if err != nil {
+		t.Fatalf("NewMeetingTemplate: %v", err)
+	}
+
+	if err := template.Update(TemplateSpec{Name: "Renamed", TitlePattern: "Sync"}); !errors.Is(err, ErrInvalidTemplate) {
+		t.Fatalf("Update: err = %v, want ErrInvalidTemplate", err)
+	}
+	if template.Name != "Sync" {
+		t.Fatalf("rejected update renamed the template to %q", template.Name)
+	}
+}
+
+func TestMeetingTemplateRenderTitle(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	start := time.Date(2024, time.March, 31, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		pattern string
+		start   time.Time
+		want    string
+	}{
+		{"Retro", start, "Retro"},
+		{"Sync {date} {time}", start, "Sync 2024-03-31 20:00"},
+		{"{weekday} in {month}", start, "Sunday in March"},
+		// The title follows the time zone of the start time
+		{"{weekday} {date} {time} {month}", start.In(kolkata), "Monday 2024-04-01 01:30 April"},
+		{"{unknown}", start, "{unknown}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			template := &MeetingTemplate{TemplateSpec: TemplateSpec{TitlePattern: tt.pattern}}
+			if got := template.RenderTitle(tt.start); got != tt.want {
+				t.Fatalf("RenderTitle = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoomSettingsValidate(t *testing.T) {
+	tests := []struct {
+		passcode string
+		want     error
+	}{
+		{"", nil},
+		{"1234", nil},
+		{"пароль", nil},
+		{strings.Repeat("p", 64), nil},
+		{"123", ErrInvalidRoomSettings},
+		{strings.Repeat("p", 65), ErrInvalidRoomSettings},
+		{"12 34", ErrInvalidRoomSettings},
+		{"1234\t", ErrInvalidRoomSettings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.passcode, func(t *testing.T) {
+			if err := (RoomSettings{Passcode: tt.passcode}).Validate(); !errors.Is(err, tt.want) {
+				t.Fatalf("Validate(%q): err = %v, want %v", tt.passcode, err, tt.want)
+			}
+		})
+	}
+}
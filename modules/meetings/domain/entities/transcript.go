@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxTranscriptSegments limits the size of an ingested transcript
+const MaxTranscriptSegments = 20000
+
+var (
+	ErrInvalidTranscript     = errors.New("invalid transcript")
+	ErrUnsupportedTranscript = errors.New("unsupported transcript format")
+	ErrTranscriptNotFound    = errors.New("transcript not found")
+	ErrSummaryNotFound       = errors.New("summary not found")
+)
+
+// TranscriptFormat identifies how a transcript was supplied
+type TranscriptFormat string
+
+const (
+	TranscriptFormatVTT  TranscriptFormat = "vtt"
+	TranscriptFormatSRT  TranscriptFormat = "srt"
+	TranscriptFormatJSON TranscriptFormat = "json"
+)
+
+// TranscriptSegment is one timed utterance of a transcript. Start and End
+// are offsets from the beginning of the recording.
+type TranscriptSegment struct {
+	Seq     int
+	Start   time.Duration
+	End     time.Duration
+	Speaker string
+	Text    string
+}
+
+// Transcript represents the transcript of a meeting
+type Transcript struct {
+	MeetingID  string
+	Format     TranscriptFormat
+	Segments   []TranscriptSegment
+	UploadedBy string
+	CreatedAt  time.Time
+}
+
+// NewTranscript parses data in the given format into a transcript
+func NewTranscript(meetingID, uploadedBy string, format TranscriptFormat, data []byte) (*Transcript, error) {
+	var (
+		segments []TranscriptSegment
+		err      error
+	)
+
+	switch format {
+	case TranscriptFormatVTT:
+		segments, err = parseVTT(data)
+	case TranscriptFormatSRT:
+		segments, err = parseSRT(data)
+	case TranscriptFormatJSON:
+		segments, err = parseTranscriptJSON(data)
+	default:
+		return nil, ErrUnsupportedTranscript
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 || len(segments) > MaxTranscriptSegments {
+		return nil, ErrInvalidTranscript
+	}
+
+	for i := range segments {
+		if len(segments[i].Speaker) > 100 {
+			return nil, fmt.Errorf("%w: speaker label too long", ErrInvalidTranscript)
+		}
+		segments[i].Seq = i + 1
+	}
+
+	return &Transcript{
+		MeetingID:  meetingID,
+		Format:     format,
+		Segments:   segments,
+		UploadedBy: uploadedBy,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// TranscriptSearchResult is a transcript segment matching a search
+type TranscriptSearchResult struct {
+	MeetingID    string
+	MeetingTitle string
+	Segment      TranscriptSegment
+	Snippet      string
+	Rank         float64
+}
+
+// DetectedActionItem is a possible follow-up task found in a transcript
+type DetectedActionItem struct {
+	Text    string `json:"text"`
+	Speaker string `json:"speaker,omitempty"`
+	Seq     int    `json:"seq"`
+}
+
+// Summary is a generated summary of a meeting transcript
+type Summary struct {
+	MeetingID   string
+	Text        string
+	KeyPoints   []string
+	ActionItems []DetectedActionItem
+	Generator   string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,224 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// voiceTagPattern matches a WebVTT voice span such as <v Alice> or <v.loud Alice>
+	voiceTagPattern = regexp.MustCompile(`^<v(?:\.[^\s>]+)?\s+([^>]+)>`)
+	// cueTagPattern matches any WebVTT cue markup tag
+	cueTagPattern = regexp.MustCompile(`<[^>]*>`)
+	// speakerPrefixPattern matches a "Name: text" speaker label
+	speakerPrefixPattern = regexp.MustCompile(`^(\p{Lu}[\p{L}.'\- ]{0,39}):\s+(.+)$`)
+)
+
+// parseVTT parses a WebVTT transcript
+func parseVTT(data []byte) ([]TranscriptSegment, error) {
+	blocks := splitCueBlocks(data)
+	if len(blocks) == 0 || !strings.HasPrefix(strings.TrimPrefix(blocks[0][0], "\ufeff"), "WEBVTT") {
+		return nil, fmt.Errorf("%w: missing WEBVTT header", ErrInvalidTranscript)
+	}
+
+	segments := make([]TranscriptSegment, 0, len(blocks))
+	for _, block := range blocks[1:] {
+		switch {
+		case strings.HasPrefix(block[0], "NOTE"),
+			strings.HasPrefix(block[0], "STYLE"),
+			strings.HasPrefix(block[0], "REGION"):
+			continue
+		}
+
+		segment, ok, err := parseCue(block)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments, nil
+}
+
+// parseSRT parses a SubRip transcript
+func parseSRT(data []byte) ([]TranscriptSegment, error) {
+	blocks := splitCueBlocks(data)
+
+	segments := make([]TranscriptSegment, 0, len(blocks))
+	for _, block := range blocks {
+		segment, ok, err := parseCue(block)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments, nil
+}
+
+// transcriptJSONSegment is a segment of a JSON transcript; times are seconds
+type transcriptJSONSegment struct {
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+	Speaker string  `json:"speaker"`
+	Text    string  `json:"text"`
+}
+
+// parseTranscriptJSON parses a JSON transcript, either an array of segments
+// or an object with a "segments" array
+func parseTranscriptJSON(data []byte) ([]TranscriptSegment, error) {
+	var items []transcriptJSONSegment
+
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTranscript, err)
+		}
+	} else {
+		var wrapper struct {
+			Segments []transcriptJSONSegment `json:"segments"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTranscript, err)
+		}
+		items = wrapper.Segments
+	}
+
+	segments := make([]TranscriptSegment, 0, len(items))
+	for i, item := range items {
+		text := strings.TrimSpace(item.Text)
+		if text == "" {
+			continue
+		}
+		if item.Start < 0 || item.End < item.Start || math.IsInf(item.End, 0) {
+			return nil, fmt.Errorf("%w: segment %d has invalid times", ErrInvalidTranscript, i+1)
+		}
+
+		segments = append(segments, TranscriptSegment{
+			Start:   time.Duration(item.Start * float64(time.Second)).Round(time.Millisecond),
+			End:     time.Duration(item.End * float64(time.Second)).Round(time.Millisecond),
+			Speaker: strings.TrimSpace(item.Speaker),
+			Text:    text,
+		})
+	}
+
+	return segments, nil
+}
+
+// splitCueBlocks splits a subtitle file into blocks of non-empty lines
+func splitCueBlocks(data []byte) [][]string {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	var blocks [][]string
+	var current []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if len(current) > 0 {
+				blocks = append(blocks, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		blocks = append(blocks, current)
+	}
+
+	return blocks
+}
+
+// parseCue parses a cue block: an optional identifier, a timing line and
+// text lines. Blocks without a timing line or text are skipped.
+func parseCue(block []string) (TranscriptSegment, bool, error) {
+	timing := -1
+	for i, line := range block {
+		if strings.Contains(line, "-->") {
+			timing = i
+			break
+		}
+	}
+	if timing < 0 || timing == len(block)-1 {
+		return TranscriptSegment{}, false, nil
+	}
+
+	parts := strings.SplitN(block[timing], "-->", 2)
+	start, err := parseCueTimestamp(parts[0])
+	if err != nil {
+		return TranscriptSegment{}, false, err
+	}
+	// Cue settings may follow the end timestamp
+	endFields := strings.Fields(parts[1])
+	if len(endFields) == 0 {
+		return TranscriptSegment{}, false, fmt.Errorf("%w: missing end time", ErrInvalidTranscript)
+	}
+	end, err := parseCueTimestamp(endFields[0])
+	if err != nil {
+		return TranscriptSegment{}, false, err
+	}
+	if end < start {
+		return TranscriptSegment{}, false, fmt.Errorf("%w: cue ends before it starts", ErrInvalidTranscript)
+	}
+
+	var speaker string
+	lines := make([]string, 0, len(block)-timing-1)
+	for _, line := range block[timing+1:] {
+		if match := voiceTagPattern.FindStringSubmatch(line); match != nil && speaker == "" {
+			speaker = strings.TrimSpace(match[1])
+		}
+		line = html.UnescapeString(cueTagPattern.ReplaceAllString(line, ""))
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	text := strings.Join(lines, " ")
+	if speaker == "" {
+		if match := speakerPrefixPattern.FindStringSubmatch(text); match != nil {
+			speaker, text = strings.TrimSpace(match[1]), match[2]
+		}
+	}
+	if text == "" {
+		return TranscriptSegment{}, false, nil
+	}
+
+	return TranscriptSegment{Start: start, End: end, Speaker: speaker, Text: text}, true, nil
+}
+
+// parseCueTimestamp parses [hh:]mm:ss.mmm, accepting a comma as used by SRT
+func parseCueTimestamp(value string) (time.Duration, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	fields := strings.Split(value, ":")
+	if len(fields) < 2 || len(fields) > 3 {
+		return 0, fmt.Errorf("%w: bad timestamp %q", ErrInvalidTranscript, value)
+	}
+
+	seconds, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+	if err != nil || math.IsNaN(seconds) || seconds < 0 || seconds >= 60 {
+		return 0, fmt.Errorf("%w: bad timestamp %q", ErrInvalidTranscript, value)
+	}
+
+	total := time.Duration(seconds * float64(time.Second))
+	for i, unit := range []time.Duration{time.Minute, time.Hour}[:len(fields)-1] {
+		n, err := strconv.Atoi(fields[len(fields)-2-i])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("%w: bad timestamp %q", ErrInvalidTranscript, value)
+		}
+		total += time.Duration(n) * unit
+	}
+
+	return total.Round(time.Millisecond), nil
+}
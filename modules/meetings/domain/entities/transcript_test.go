@@ -0,0 +1,155 @@
+package entities
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// offset builds a duration from minutes, seconds and milliseconds
+func offset(m, s, ms int) time.Duration {
+	return time.Duration(m)*time.Minute + time.Duration(s)*time.Second + time.Duration(ms)*time.Millisecond
+}
+
+func TestNewTranscript(t *testing.T) {
+	tests := []struct {
+		name   string
+		format TranscriptFormat
+		data   string
+		want   []TranscriptSegment
+		err    error
+	}{
+		{
+			name:   "vtt with voice tags",
+			format: TranscriptFormatVTT,
+			data: "\ufeffWEBVTT - standup\r\n\r\nNOTE recorded by Jitsi\r\n\r\n1\r\n00:01.000 --> 00:04.500 align:start\r\n" +
+				"<v.loud Alice>Hello &amp; welcome</v>\r\n\r\n00:00:05.000 --> 00:00:07.250\r\n<v Bob>Thanks,\r\n<i>everyone</i>\r\n",
+			want: []TranscriptSegment{
+				{Seq: 1, Start: offset(0, 1, 0), End: offset(0, 4, 500), Speaker: "Alice", Text: "Hello & welcome"},
+				{Seq: 2, Start: offset(0, 5, 0), End: offset(0, 7, 250), Speaker: "Bob", Text: "Thanks, everyone"},
+			},
+		},
+		{
+			name:   "vtt with speaker labels",
+			format: TranscriptFormatVTT,
+			data:   "WEBVTT\n\nSTYLE\n::cue { color: red }\n\n01:02:03.004 --> 01:02:04.000\nDr. Smith: Let's begin\n\n00:10.000 --> 00:11.000\nno speaker: lower case\n",
+			want: []TranscriptSegment{
+				{Seq: 1, Start: time.Hour + offset(2, 3, 4), End: time.Hour + offset(2, 4, 0), Speaker: "Dr. Smith", Text: "Let's begin"},
+				{Seq: 2, Start: offset(0, 10, 0), End: offset(0, 11, 0), Text: "no speaker: lower case"},
+			},
+		},
+		{
+			name:   "vtt without header",
+			format: TranscriptFormatVTT,
+			data:   "00:01.000 --> 00:02.000\nHello\n",
+			err:    ErrInvalidTranscript,
+		},
+		{
+			name:   "vtt cue ending before it starts",
+			format: TranscriptFormatVTT,
+			data:   "WEBVTT\n\n00:05.000 --> 00:02.000\nHello\n",
+			err:    ErrInvalidTranscript,
+		},
+		{
+			name:   "vtt with only empty cues",
+			format: TranscriptFormatVTT,
+			data:   "WEBVTT\n\n00:01.000 --> 00:02.000\n<b></b>\n",
+			err:    ErrInvalidTranscript,
+		},
+		{
+			name:   "srt",
+			format: TranscriptFormatSRT,
+			data:   "1\n00:00:01,000 --> 00:00:02,500\nAlice: First line\nsecond line\n\n2\n00:00:03,000 --> 00:00:04,000\n\n3\n00:00:05,000 --> 00:00:06,000\nDone\n",
+			want: []TranscriptSegment{
+				{Seq: 1, Start: offset(0, 1, 0), End: offset(0, 2, 500), Speaker: "Alice", Text: "First line second line"},
+				{Seq: 2, Start: offset(0, 5, 0), End: offset(0, 6, 0), Text: "Done"},
+			},
+		},
+		{
+			name:   "srt with bad timestamp",
+			format: TranscriptFormatSRT,
+			data:   "1\n00:00:61,000 --> 00:01:02,000\nHello\n",
+			err:    ErrInvalidTranscript,
+		},
+		{
+			name:   "json array",
+			format: TranscriptFormatJSON,
+			data:   `[{"start": 1.5, "end": 3.25, "speaker": " Alice ", "text": " Hi "}, {"start": 4, "end": 5, "text": "  "}]`,
+			want: []TranscriptSegment{
+				{Seq: 1, Start: offset(0, 1, 500), End: offset(0, 3, 250), Speaker: "Alice", Text: "Hi"},
+			},
+		},
+		{
+			name:   "json object",
+			format: TranscriptFormatJSON,
+			data:   `{"segments": [{"start": 0, "end": 61, "text": "Hello"}]}`,
+			want: []TranscriptSegment{
+				{Seq: 1, Start: 0, End: offset(1, 1, 0), Text: "Hello"},
+			},
+		},
+		{
+			name:   "json segment ending before it starts",
+			format: TranscriptFormatJSON,
+			data:   `[{"start": 5, "end": 1, "text": "Hello"}]`,
+			err:    ErrInvalidTranscript,
+		},
+		{
+			name:   "malformed json",
+			format: TranscriptFormatJSON,
+			data:   `[{"start": "soon"}]`,
+			err:    ErrInvalidTranscript,
+		},
+		{
+			name:   "unsupported format",
+			format: "docx",
+			data:   "Hello",
+			err:    ErrUnsupportedTranscript,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transcript, err := NewTranscript("meeting-1", "alice", tt.format, []byte(tt.data))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("NewTranscript: err = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(transcript.Segments, tt.want) {
+				t.Fatalf("segments = %+v, want %+v", transcript.Segments, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCueTimestamp(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		err   bool
+	}{
+		{"00:01.000", offset(0, 1, 0), false},
+		{"12:34.567", offset(12, 34, 567), false},
+		{"01:00:00,001", time.Hour + time.Millisecond, false},
+		{" 00:00:02.5 ", offset(0, 2, 500), false},
+		{"1.000", 0, true},
+		{"00:60.000", 0, true},
+		{"-1:00.000", 0, true},
+		{"00:aa.000", 0, true},
+		{"1:2:3:4.000", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseCueTimestamp(tt.value)
+			if (err != nil) != tt.err {
+				t.Fatalf("parseCueTimestamp(%q): err = %v, want error %v", tt.value, err, tt.err)
+			}
+			if err == nil && got != tt.want {
+				t.Fatalf("parseCueTimestamp(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package events
+
+import "time"
+
+// MeetingCancelledEvent is the name of MeetingCancelled events
+const MeetingCancelledEvent = "meetings.meeting_cancelled"
+
+// MeetingCancelled is published when a host cancels a meeting
+type MeetingCancelled struct {
+	MeetingID      string    `json:"meeting_id"`
+	Title          string    `json:"title"`
+	OrganizerID    string    `json:"organizer_id"`
+	StartTime      time.Time `json:"start_time"`
+	CancelledBy    string    `json:"cancelled_by"`
+	ParticipantIDs []string  `json:"participant_ids"` // organizer and participants
+	CancelledAt    time.Time `json:"cancelled_at"`
+}
+
+// EventName implements eventbus.Event
+func (MeetingCancelled) EventName() string {
+	return MeetingCancelledEvent
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// AttendanceRepository defines methods for meeting attendance data access
+type AttendanceRepository interface {
+	// OpenSession records a join. It is a no-op if the user already has an
+	// open session from the same source. It never adds the user as a
+	// participant.
+	OpenSession(ctx context.Context, session *entities.AttendanceSession) error
+
+	// CloseSessions records a leave by closing all open sessions of the user
+	// from the given source, or from any source if source is empty
+	CloseSessions(ctx context.Context, meetingID, userID string, source entities.AttendanceSource, leftAt time.Time) (int64, error)
+
+	// ListSessions retrieves all attendance sessions of a meeting
+	ListSessions(ctx context.Context, meetingID string) ([]*entities.AttendanceSession, error)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// GuestRepository defines methods for guest link and lobby data access
+type GuestRepository interface {
+	// CreateLink creates a new guest link
+	CreateLink(ctx context.Context, link *entities.GuestLink) error
+
+	// GetLinkByTokenHash retrieves a guest link by the hash of its token
+	GetLinkByTokenHash(ctx context.Context, tokenHash string) (*entities.GuestLink, error)
+
+	// ListLinks retrieves the guest links of a meeting, newest first
+	ListLinks(ctx context.Context, meetingID string) ([]*entities.GuestLink, error)
+
+	// RevokeLink revokes a guest link of a meeting
+	RevokeLink(ctx context.Context, meetingID, id string, revokedAt time.Time) error
+
+	// CreateLobbyEntry adds a guest to the lobby and counts a use of the link.
+	// It returns ErrGuestLinkUnavailable if the link can no longer be used.
+	CreateLobbyEntry(ctx context.Context, entry *entities.LobbyEntry) error
+
+	// GetLobbyEntry retrieves a lobby entry of a meeting by ID
+	GetLobbyEntry(ctx context.Context, meetingID, id string) (*entities.LobbyEntry, error)
+
+	// ListLobby retrieves the lobby entries of a meeting with the given status
+	ListLobby(ctx context.Context, meetingID string, status entities.LobbyStatus) ([]*entities.LobbyEntry, error)
+
+	// DecideLobbyEntry stores the decision for a waiting lobby entry. It
+	// returns ErrLobbyEntryDecided if another host decided first.
+	DecideLobbyEntry(ctx context.Context, entry *entities.LobbyEntry) error
+}
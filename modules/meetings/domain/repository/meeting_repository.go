@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
 )
@@ -14,6 +15,13 @@ type MeetingRepository interface {
 	// GetByID retrieves a meeting by ID
 	GetByID(ctx context.Context, id string) (*entities.Meeting, error)
 
+	// GetByIDForUpdate retrieves a meeting by ID and locks it until the
+	// transaction carried by ctx ends
+	GetByIDForUpdate(ctx context.Context, id string) (*entities.Meeting, error)
+
+	// GetByRoomID retrieves a meeting by its Jitsi room ID
+	GetByRoomID(ctx context.Context, roomID string) (*entities.Meeting, error)
+
 	// List retrieves meetings with pagination
 	List(ctx context.Context, page, pageSize int, userID string) ([]*entities.Meeting, int64, error)
 
@@ -28,4 +36,25 @@ type MeetingRepository interface {
 
 	// GetUpcoming retrieves upcoming meetings
 	GetUpcoming(ctx context.Context, userID string, limit int) ([]*entities.Meeting, error)
+
+	// AddParticipants adds participants to a meeting, ignoring existing ones
+	AddParticipants(ctx context.Context, participants []*entities.Participant) error
+
+	// ListParticipants retrieves the participants of a meeting
+	ListParticipants(ctx context.Context, meetingID string) ([]*entities.Participant, error)
+
+	// ListBusy retrieves active meetings of the given users overlapping [from, to)
+	ListBusy(ctx context.Context, userIDs []string, from, to time.Time) ([]*entities.BusyInterval, error)
+
+	// ClaimDueReminders marks scheduled meetings starting in (now, before] as
+	// reminded and returns them. Each meeting is claimed by exactly one caller.
+	ClaimDueReminders(ctx context.Context, now, before time.Time) ([]*entities.Meeting, error)
+
+	// ReleaseReminder undoes the claim on the reminder of a meeting, so that
+	// the next run sends it
+	ReleaseReminder(ctx context.Context, meetingID string) error
+
+	// CloseOverdue marks meetings whose scheduled end is before cutoff as
+	// missed (never started) or completed (ongoing) and returns the changes
+	CloseOverdue(ctx context.Context, cutoff time.Time) ([]*entities.StatusChange, error)
 }
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// MinutesRepository defines methods for meeting agenda, notes and action item data access
+type MinutesRepository interface {
+	// ReplaceAgenda replaces the agenda of a meeting
+	ReplaceAgenda(ctx context.Context, meetingID string, items []*entities.AgendaItem) error
+
+	// ListAgenda retrieves the agenda of a meeting in order
+	ListAgenda(ctx context.Context, meetingID string) ([]*entities.AgendaItem, error)
+
+	// GetNotes retrieves the latest notes revision, or nil if there are no notes
+	GetNotes(ctx context.Context, meetingID string) (*entities.NoteRevision, error)
+
+	// SaveNotes stores a new revision on top of baseRevision. It returns
+	// entities.ErrNotesConflict if baseRevision is not the latest revision.
+	SaveNotes(ctx context.Context, revision *entities.NoteRevision, baseRevision int) error
+
+	// ListNoteRevisions retrieves all notes revisions, newest first
+	ListNoteRevisions(ctx context.Context, meetingID string) ([]*entities.NoteRevision, error)
+
+	// GetNoteRevision retrieves one notes revision
+	GetNoteRevision(ctx context.Context, meetingID string, revision int) (*entities.NoteRevision, error)
+
+	// CreateActionItem creates a new action item
+	CreateActionItem(ctx context.Context, item *entities.ActionItem) error
+
+	// GetActionItem retrieves an action item of a meeting by ID
+	GetActionItem(ctx context.Context, meetingID, id string) (*entities.ActionItem, error)
+
+	// UpdateActionItem updates an action item
+	UpdateActionItem(ctx context.Context, item *entities.ActionItem) error
+
+	// ListActionItems retrieves the action items of a meeting
+	ListActionItems(ctx context.Context, meetingID string) ([]*entities.ActionItem, error)
+
+	// ListAssignedActionItems retrieves action items assigned to a user,
+	// optionally only those still open, ordered by due date
+	ListAssignedActionItems(ctx context.Context, assigneeID string, openOnly bool) ([]*entities.ActionItem, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// RecordingRepository defines methods for meeting recording data access
+type RecordingRepository interface {
+	// Create creates a new recording
+	Create(ctx context.Context, recording *entities.Recording) error
+
+	// GetByID retrieves a recording of a meeting by ID
+	GetByID(ctx context.Context, meetingID, id string) (*entities.Recording, error)
+
+	// ListByMeeting retrieves the recordings of a meeting
+	ListByMeeting(ctx context.Context, meetingID string) ([]*entities.Recording, error)
+
+	// Update updates a recording
+	Update(ctx context.Context, recording *entities.Recording) error
+
+	// Delete deletes a recording
+	Delete(ctx context.Context, id string) error
+}
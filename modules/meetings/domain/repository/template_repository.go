@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// TemplateRepository defines methods for meeting template data access
+type TemplateRepository interface {
+	// Create creates a new meeting template
+	Create(ctx context.Context, template *entities.MeetingTemplate) error
+
+	// GetByID retrieves a meeting template by ID
+	GetByID(ctx context.Context, id string) (*entities.MeetingTemplate, error)
+
+	// ListVisible retrieves the templates a user owns and the shared
+	// templates of others, ordered by name
+	ListVisible(ctx context.Context, userID string) ([]*entities.MeetingTemplate, error)
+
+	// Update updates a meeting template
+	Update(ctx context.Context, template *entities.MeetingTemplate) error
+
+	// Delete deletes a meeting template
+	Delete(ctx context.Context, id string) error
+}
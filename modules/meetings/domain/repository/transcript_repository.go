@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// TranscriptRepository defines methods for transcript and summary data access
+type TranscriptRepository interface {
+	// SaveTranscript stores a transcript, replacing any earlier one of the meeting
+	SaveTranscript(ctx context.Context, transcript *entities.Transcript) error
+
+	// GetTranscript retrieves the transcript of a meeting
+	GetTranscript(ctx context.Context, meetingID string) (*entities.Transcript, error)
+
+	// SaveSummary stores a summary, replacing any earlier one of the meeting
+	SaveSummary(ctx context.Context, summary *entities.Summary) error
+
+	// GetSummary retrieves the summary of a meeting
+	GetSummary(ctx context.Context, meetingID string) (*entities.Summary, error)
+
+	// Search finds transcript segments matching a query in meetings the user
+	// organizes or participates in, best matches first
+	Search(ctx context.Context, userID, query string, page, pageSize int) ([]*entities.TranscriptSearchResult, int64, error)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// UserScheduleRepository reads the timezones and working hours of users
+type UserScheduleRepository interface {
+	// ListSchedules retrieves the schedules of the given users keyed by
+	// user ID. Unknown users are left out.
+	ListSchedules(ctx context.Context, userIDs []string) (map[string]*entities.UserSchedule, error)
+}
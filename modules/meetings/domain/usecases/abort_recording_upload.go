@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manab-pr/evtaarpro/internal/storage"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// AbortRecordingUploadUseCase handles cancelling a recording upload
+type AbortRecordingUploadUseCase struct {
+	meetingRepo   repository.MeetingRepository
+	recordingRepo repository.RecordingRepository
+	blobStore     storage.BlobStore
+}
+
+// NewAbortRecordingUploadUseCase creates a new AbortRecordingUploadUseCase
+func NewAbortRecordingUploadUseCase(
+	meetingRepo repository.MeetingRepository,
+	recordingRepo repository.RecordingRepository,
+	blobStore storage.BlobStore,
+) *AbortRecordingUploadUseCase {
+	return &AbortRecordingUploadUseCase{
+		meetingRepo:   meetingRepo,
+		recordingRepo: recordingRepo,
+		blobStore:     blobStore,
+	}
+}
+
+// Execute discards the uploaded parts and the recording
+func (uc *AbortRecordingUploadUseCase) Execute(ctx context.Context, meetingID, recordingID, userID string) error {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true); err != nil {
+		return err
+	}
+
+	recording, err := uploadingRecording(ctx, uc.recordingRepo, meetingID, recordingID)
+	if err != nil {
+		return err
+	}
+
+	err = uc.blobStore.AbortMultipartUpload(ctx, recording.ObjectKey, recording.UploadID)
+	if err != nil && !errors.Is(err, storage.ErrUploadMissing) {
+		return err
+	}
+
+	return uc.recordingRepo.Delete(ctx, recording.ID)
+}
@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/events"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// CancelMeetingUseCase handles cancelling a meeting
+type CancelMeetingUseCase struct {
+	meetingRepo repository.MeetingRepository
+	transactor  datastore.Transactor
+	outbox      eventbus.Publisher
+	publisher   EventPublisher
+}
+
+// NewCancelMeetingUseCase creates a new CancelMeetingUseCase
+func NewCancelMeetingUseCase(
+	meetingRepo repository.MeetingRepository,
+	transactor datastore.Transactor,
+	outbox eventbus.Publisher,
+	publisher EventPublisher,
+) *CancelMeetingUseCase {
+	return &CancelMeetingUseCase{
+		meetingRepo: meetingRepo,
+		transactor:  transactor,
+		outbox:      outbox,
+		publisher:   publisher,
+	}
+}
+
+// Execute cancels a scheduled or ongoing meeting. Only hosts may cancel it.
+// The cancellation and its MeetingCancelled event are stored together, with
+// the meeting locked so that concurrent cancellations emit a single event.
+func (uc *CancelMeetingUseCase) Execute(ctx context.Context, meetingID, userID string) (*entities.Meeting, error) {
+	meeting, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := uc.meetingRepo.ListParticipants(ctx, meeting.ID)
+	if err != nil {
+		return nil, err
+	}
+	participantIDs := []string{meeting.OrganizerID}
+	for _, participant := range participants {
+		if participant.UserID != meeting.OrganizerID {
+			participantIDs = append(participantIDs, participant.UserID)
+		}
+	}
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		meeting, err = uc.meetingRepo.GetByIDForUpdate(ctx, meetingID)
+		if err != nil {
+			return err
+		}
+		if err := meeting.Cancel(); err != nil {
+			return err
+		}
+		if err := uc.meetingRepo.Update(ctx, meeting); err != nil {
+			return err
+		}
+		return uc.outbox.Publish(ctx, events.MeetingCancelled{
+			MeetingID:      meeting.ID,
+			Title:          meeting.Title,
+			OrganizerID:    meeting.OrganizerID,
+			StartTime:      meeting.StartTime,
+			CancelledBy:    userID,
+			ParticipantIDs: participantIDs,
+			CancelledAt:    meeting.UpdatedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishStatusChange(ctx, uc.meetingRepo, uc.publisher, &entities.StatusChange{
+		MeetingID:   meeting.ID,
+		OrganizerID: meeting.OrganizerID,
+		Status:      meeting.Status,
+		ChangedAt:   meeting.UpdatedAt,
+	})
+
+	return meeting, nil
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/events"
+)
+
+func TestCancelMeetingEmitsOneEvent(t *testing.T) {
+	ctx := context.Background()
+	meetings := newFakeMeetingRepo()
+	meeting := meetings.addMeeting(t, "host", "alice")
+	outbox := &fakeOutbox{}
+	publisher := &fakePublisher{}
+	uc := NewCancelMeetingUseCase(meetings, &fakeTransactor{}, outbox, publisher)
+
+	cancelled, err := uc.Execute(ctx, meeting.ID, "host")
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if cancelled.Status != entities.StatusCancelled || meetings.meetings[meeting.ID].Status != entities.StatusCancelled {
+		t.Fatalf("status = %s, want cancelled", cancelled.Status)
+	}
+
+	if len(outbox.events) != 1 {
+		t.Fatalf("%d events stored, want 1", len(outbox.events))
+	}
+	event, ok := outbox.events[0].(events.MeetingCancelled)
+	if !ok {
+		t.Fatalf("stored %T, want events.MeetingCancelled", outbox.events[0])
+	}
+	if event.CancelledBy != "host" || len(event.ParticipantIDs) != 2 {
+		t.Fatalf("event = %+v", event)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].eventType != eventMeetingStatusChanged {
+		t.Fatalf("pushed %+v, want one status change", publisher.events)
+	}
+
+	// Cancelling again finds the meeting cancelled and stores no event
+	if _, err := uc.Execute(ctx, meeting.ID, "host"); !errors.Is(err, entities.ErrMeetingNotActive) {
+		t.Fatalf("second Execute: err = %v, want ErrMeetingNotActive", err)
+	}
+	if len(outbox.events) != 1 {
+		t.Fatalf("%d events stored after cancelling twice, want 1", len(outbox.events))
+	}
+}
+
+func TestCancelMeetingRequiresHost(t *testing.T) {
+	ctx := context.Background()
+	meetings := newFakeMeetingRepo()
+	meeting := meetings.addMeeting(t, "host", "alice")
+	outbox := &fakeOutbox{}
+	uc := NewCancelMeetingUseCase(meetings, &fakeTransactor{}, outbox, &fakePublisher{})
+
+	for _, userID := range []string{"alice", "stranger"} {
+		if _, err := uc.Execute(ctx, meeting.ID, userID); !errors.Is(err, ErrMeetingForbidden) {
+			t.Fatalf("Execute by %s: err = %v, want ErrMeetingForbidden", userID, err)
+		}
+	}
+	if _, err := uc.Execute(ctx, "missing", "host"); !errors.Is(err, entities.ErrMeetingNotFound) {
+		t.Fatalf("Execute on a missing meeting: err = %v, want ErrMeetingNotFound", err)
+	}
+	if len(outbox.events) != 0 || meetings.meetings[meeting.ID].Status != entities.StatusScheduled {
+		t.Fatal("a rejected cancellation changed the meeting")
+	}
+}
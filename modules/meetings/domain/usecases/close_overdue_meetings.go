@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// CloseOverdueMeetingsUseCase transitions meetings whose window has passed
+type CloseOverdueMeetingsUseCase struct {
+	meetingRepo repository.MeetingRepository
+	publisher   EventPublisher
+	endGrace    time.Duration
+}
+
+// NewCloseOverdueMeetingsUseCase creates a new CloseOverdueMeetingsUseCase
+func NewCloseOverdueMeetingsUseCase(meetingRepo repository.MeetingRepository, publisher EventPublisher, endGrace time.Duration) *CloseOverdueMeetingsUseCase {
+	if endGrace < 0 {
+		endGrace = 0
+	}
+
+	return &CloseOverdueMeetingsUseCase{
+		meetingRepo: meetingRepo,
+		publisher:   publisher,
+		endGrace:    endGrace,
+	}
+}
+
+// CloseOutput represents the number of meetings transitioned
+type CloseOutput struct {
+	Missed    int64
+	Completed int64
+}
+
+// Execute marks scheduled meetings that never started as missed and
+// ongoing meetings past their scheduled end as completed
+func (uc *CloseOverdueMeetingsUseCase) Execute(ctx context.Context) (*CloseOutput, error) {
+	changes, err := uc.meetingRepo.CloseOverdue(ctx, time.Now().Add(-uc.endGrace))
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CloseOutput{}
+	for _, change := range changes {
+		if change.Status == entities.StatusMissed {
+			output.Missed++
+		} else {
+			output.Completed++
+		}
+		publishStatusChange(ctx, uc.meetingRepo, uc.publisher, change)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/storage"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// CompleteRecordingUploadUseCase handles finishing a recording upload
+type CompleteRecordingUploadUseCase struct {
+	meetingRepo   repository.MeetingRepository
+	recordingRepo repository.RecordingRepository
+	blobStore     storage.BlobStore
+}
+
+// NewCompleteRecordingUploadUseCase creates a new CompleteRecordingUploadUseCase
+func NewCompleteRecordingUploadUseCase(
+	meetingRepo repository.MeetingRepository,
+	recordingRepo repository.RecordingRepository,
+	blobStore storage.BlobStore,
+) *CompleteRecordingUploadUseCase {
+	return &CompleteRecordingUploadUseCase{
+		meetingRepo:   meetingRepo,
+		recordingRepo: recordingRepo,
+		blobStore:     blobStore,
+	}
+}
+
+// Execute assembles the uploaded parts and makes the recording available
+func (uc *CompleteRecordingUploadUseCase) Execute(ctx context.Context, meetingID, recordingID, userID string) (*entities.Recording, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true); err != nil {
+		return nil, err
+	}
+
+	recording, err := uploadingRecording(ctx, uc.recordingRepo, meetingID, recordingID)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := uc.blobStore.ListParts(ctx, recording.ObjectKey, recording.UploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, part := range parts {
+		total += part.Size
+	}
+	if len(parts) == 0 || total != recording.SizeBytes {
+		return nil, entities.ErrRecordingIncomplete
+	}
+
+	if err := uc.blobStore.CompleteMultipartUpload(ctx, recording.ObjectKey, recording.UploadID, parts); err != nil {
+		return nil, err
+	}
+
+	if err := recording.MarkAvailable(); err != nil {
+		return nil, err
+	}
+	if err := uc.recordingRepo.Update(ctx, recording); err != nil {
+		return nil, err
+	}
+
+	return recording, nil
+}
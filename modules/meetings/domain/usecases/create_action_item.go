@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// CreateActionItemUseCase handles creating meeting action items
+type CreateActionItemUseCase struct {
+	meetingRepo repository.MeetingRepository
+	minutesRepo repository.MinutesRepository
+	notifier    Notifier
+}
+
+// NewCreateActionItemUseCase creates a new CreateActionItemUseCase
+func NewCreateActionItemUseCase(meetingRepo repository.MeetingRepository, minutesRepo repository.MinutesRepository, notifier Notifier) *CreateActionItemUseCase {
+	return &CreateActionItemUseCase{
+		meetingRepo: meetingRepo,
+		minutesRepo: minutesRepo,
+		notifier:    notifier,
+	}
+}
+
+// CreateActionItemInput represents action item creation input
+type CreateActionItemInput struct {
+	MeetingID   string
+	UserID      string
+	Title       string
+	Description string
+	AssigneeID  string
+	DueDate     *time.Time
+}
+
+// Execute creates an action item and notifies the assignee
+func (uc *CreateActionItemUseCase) Execute(ctx context.Context, input CreateActionItemInput) (*entities.ActionItem, error) {
+	meeting, err := authorizeParticipant(ctx, uc.meetingRepo, input.MeetingID, input.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAssignee(ctx, uc.meetingRepo, meeting, input.AssigneeID); err != nil {
+		return nil, err
+	}
+
+	item, err := entities.NewActionItem(input.MeetingID, input.Title, input.Description, input.AssigneeID, input.DueDate, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.minutesRepo.CreateActionItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	notifyAssignee(ctx, uc.notifier, meeting, item, input.UserID)
+
+	return item, nil
+}
+
+// checkAssignee verifies that action items are only assigned to people in the meeting
+func checkAssignee(ctx context.Context, meetingRepo repository.MeetingRepository, meeting *entities.Meeting, assigneeID string) error {
+	if assigneeID == meeting.OrganizerID {
+		return nil
+	}
+
+	participants, err := meetingRepo.ListParticipants(ctx, meeting.ID)
+	if err != nil {
+		return err
+	}
+	if !hasParticipant(participants, assigneeID) {
+		return fmt.Errorf("%w: assignee is not a participant of the meeting", entities.ErrInvalidActionItem)
+	}
+
+	return nil
+}
+
+// notifyAssignee tells the assignee about a new assignment. Failures are
+// logged rather than failing the request.
+func notifyAssignee(ctx context.Context, notifier Notifier, meeting *entities.Meeting, item *entities.ActionItem, actorID string) {
+	if item.AssigneeID == actorID {
+		return
+	}
+
+	variables := map[string]interface{}{
+		"meeting_id":        meeting.ID,
+		"meeting_title":     meeting.Title,
+		"action_item_id":    item.ID,
+		"action_item_title": item.Title,
+	}
+	if item.DueDate != nil {
+		variables["due_date"] = *item.DueDate
+	}
+
+	if err := notifier.Notify(ctx, item.AssigneeID, "meeting.action_item_assigned", variables); err != nil {
+		logging.FromContext(ctx).Warn("failed to notify assignee about action item", "assignee_id", item.AssigneeID, "action_item_id", item.ID, "error", err)
+	}
+}
@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// DefaultGuestLinkTTL is used when no expiry is requested
+const DefaultGuestLinkTTL = 24 * time.Hour
+
+// CreateGuestLinkUseCase handles creating guest access links
+type CreateGuestLinkUseCase struct {
+	meetingRepo repository.MeetingRepository
+	guestRepo   repository.GuestRepository
+	hasher      PasscodeHasher
+	linkBaseURL string
+}
+
+// NewCreateGuestLinkUseCase creates a new CreateGuestLinkUseCase
+func NewCreateGuestLinkUseCase(meetingRepo repository.MeetingRepository, guestRepo repository.GuestRepository, hasher PasscodeHasher, linkBaseURL string) *CreateGuestLinkUseCase {
+	return &CreateGuestLinkUseCase{
+		meetingRepo: meetingRepo,
+		guestRepo:   guestRepo,
+		hasher:      hasher,
+		linkBaseURL: strings.TrimSuffix(linkBaseURL, "/"),
+	}
+}
+
+// CreateGuestLinkInput represents guest link creation input
+type CreateGuestLinkInput struct {
+	MeetingID string
+	UserID    string
+	ExpiresAt *time.Time
+	Passcode  string
+	MaxUses   int
+}
+
+// CreateGuestLinkOutput represents a new guest link. The token is only
+// returned once.
+type CreateGuestLinkOutput struct {
+	Link  *entities.GuestLink
+	Token string
+	URL   string
+}
+
+// Execute creates a guest link for an active meeting. Only hosts may
+// invite guests.
+func (uc *CreateGuestLinkUseCase) Execute(ctx context.Context, input CreateGuestLinkInput) (*CreateGuestLinkOutput, error) {
+	meeting, err := authorizeParticipant(ctx, uc.meetingRepo, input.MeetingID, input.UserID, true)
+	if err != nil {
+		return nil, err
+	}
+	if !meeting.IsActive() {
+		return nil, entities.ErrMeetingNotActive
+	}
+
+	expiresAt := time.Now().Add(DefaultGuestLinkTTL)
+	if input.ExpiresAt != nil {
+		expiresAt = *input.ExpiresAt
+	}
+
+	var passcodeHash string
+	if input.Passcode != "" {
+		passcodeHash, err = uc.hasher.Hash(input.Passcode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	link, token, err := entities.NewGuestLink(input.MeetingID, input.UserID, expiresAt, input.MaxUses, passcodeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.guestRepo.CreateLink(ctx, link); err != nil {
+		return nil, err
+	}
+
+	output := &CreateGuestLinkOutput{Link: link, Token: token}
+	if uc.linkBaseURL != "" {
+		output.URL = uc.linkBaseURL + "/" + token
+	}
+
+	return output, nil
+}
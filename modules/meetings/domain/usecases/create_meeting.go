@@ -2,38 +2,142 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
 	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
 	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
 )
 
+// ConflictPolicy decides what happens when invitees are already booked
+type ConflictPolicy string
+
+const (
+	ConflictPolicyWarn   ConflictPolicy = "warn"
+	ConflictPolicyReject ConflictPolicy = "reject"
+)
+
+var ErrSchedulingConflict = errors.New("meeting overlaps existing meetings of invitees")
+
+// ConflictError carries the conflicts that caused a meeting to be rejected
+type ConflictError struct {
+	Conflicts []*entities.Conflict
+}
+
+func (e *ConflictError) Error() string {
+	return ErrSchedulingConflict.Error()
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrSchedulingConflict
+}
+
 // CreateMeetingUseCase handles meeting creation
 type CreateMeetingUseCase struct {
-	meetingRepo repository.MeetingRepository
+	meetingRepo    repository.MeetingRepository
+	templateRepo   repository.TemplateRepository
+	minutesRepo    repository.MinutesRepository
+	scheduleRepo   repository.UserScheduleRepository
+	transactor     datastore.Transactor
+	workingHours   entities.WorkingHours
+	conflictPolicy ConflictPolicy
 }
 
 // NewCreateMeetingUseCase creates a new CreateMeetingUseCase
-func NewCreateMeetingUseCase(meetingRepo repository.MeetingRepository) *CreateMeetingUseCase {
-	return &CreateMeetingUseCase{meetingRepo: meetingRepo}
+func NewCreateMeetingUseCase(
+	meetingRepo repository.MeetingRepository,
+	templateRepo repository.TemplateRepository,
+	minutesRepo repository.MinutesRepository,
+	scheduleRepo repository.UserScheduleRepository,
+	transactor datastore.Transactor,
+	workingHours entities.WorkingHours,
+	conflictPolicy ConflictPolicy,
+) *CreateMeetingUseCase {
+	if conflictPolicy != ConflictPolicyReject {
+		conflictPolicy = ConflictPolicyWarn
+	}
+
+	return &CreateMeetingUseCase{
+		meetingRepo:    meetingRepo,
+		templateRepo:   templateRepo,
+		minutesRepo:    minutesRepo,
+		scheduleRepo:   scheduleRepo,
+		transactor:     transactor,
+		workingHours:   workingHours,
+		conflictPolicy: conflictPolicy,
+	}
 }
 
-// CreateInput represents meeting creation input
+// CreateInput represents meeting creation input. With a template, empty
+// fields are taken from the template, invitees are added to its default
+// invitees and settings override its room settings.
 type CreateInput struct {
-	Title          string
-	Description    string
-	OrganizerID    string
-	StartTime      time.Time
+	Title           string
+	Description     string
+	OrganizerID     string
+	StartTime       time.Time
+	Duration        time.Duration
 	MaxParticipants int
+	InviteeIDs      []string
+	TemplateID      string
+	Settings        *RoomSettingsInput
+}
+
+// CreateOutput represents meeting creation output. OutsideWorkingHours
+// lists the participants for whom the meeting falls outside their local
+// working hours.
+type CreateOutput struct {
+	Meeting             *entities.Meeting
+	Conflicts           []*entities.Conflict
+	OutsideWorkingHours []string
 }
 
 // Execute creates a new meeting
-func (uc *CreateMeetingUseCase) Execute(ctx context.Context, input CreateInput) (*entities.Meeting, error) {
+func (uc *CreateMeetingUseCase) Execute(ctx context.Context, input CreateInput) (*CreateOutput, error) {
+	settings := entities.DefaultRoomSettings()
+	inviteeIDs := input.InviteeIDs
+
+	var template *entities.MeetingTemplate
+	if input.TemplateID != "" {
+		var err error
+		template, err = visibleTemplate(ctx, uc.templateRepo, input.TemplateID, input.OrganizerID)
+		if err != nil {
+			return nil, err
+		}
+
+		if input.Title == "" {
+			organizer, err := uc.scheduleRepo.ListSchedules(ctx, []string{input.OrganizerID})
+			if err != nil {
+				return nil, err
+			}
+			input.Title = template.RenderTitle(organizer[input.OrganizerID].LocalTime(input.StartTime))
+		}
+		if input.Description == "" {
+			input.Description = template.Description
+		}
+		if input.Duration == 0 {
+			input.Duration = template.Duration
+		}
+		if input.MaxParticipants == 0 {
+			input.MaxParticipants = template.MaxParticipants
+		}
+		settings = template.Settings
+		inviteeIDs = append(append([]string(nil), template.InviteeIDs...), input.InviteeIDs...)
+	}
+
+	settings, err := input.Settings.apply(settings)
+	if err != nil {
+		return nil, err
+	}
+
 	meeting, err := entities.NewMeeting(
 		input.Title,
 		input.Description,
 		input.OrganizerID,
 		input.StartTime,
+		input.Duration,
 	)
 	if err != nil {
 		return nil, err
@@ -42,10 +146,88 @@ func (uc *CreateMeetingUseCase) Execute(ctx context.Context, input CreateInput)
 	if input.MaxParticipants > 0 {
 		meeting.MaxParticipants = input.MaxParticipants
 	}
+	meeting.Settings = settings
+
+	participants := []*entities.Participant{
+		entities.NewParticipant(meeting.ID, meeting.OrganizerID, entities.ParticipantRoleHost),
+	}
+	userIDs := []string{meeting.OrganizerID}
+	seen := map[string]bool{meeting.OrganizerID: true}
+	for _, inviteeID := range inviteeIDs {
+		if inviteeID == "" || seen[inviteeID] {
+			continue
+		}
+		seen[inviteeID] = true
+		participants = append(participants, entities.NewParticipant(meeting.ID, inviteeID, entities.ParticipantRoleParticipant))
+		userIDs = append(userIDs, inviteeID)
+	}
 
-	if err := uc.meetingRepo.Create(ctx, meeting); err != nil {
+	busy, err := uc.meetingRepo.ListBusy(ctx, userIDs, meeting.StartTime, meeting.ScheduledEnd())
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]*entities.Conflict, 0, len(busy))
+	for _, interval := range busy {
+		conflicts = append(conflicts, &entities.Conflict{
+			UserID:    interval.UserID,
+			MeetingID: interval.MeetingID,
+			Title:     interval.Title,
+			Start:     interval.Start,
+			End:       interval.End,
+		})
+	}
+
+	if len(conflicts) > 0 && uc.conflictPolicy == ConflictPolicyReject {
+		return nil, &ConflictError{Conflicts: conflicts}
+	}
+
+	schedules, err := uc.scheduleRepo.ListSchedules(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := entities.TimeSlot{Start: meeting.StartTime, End: meeting.ScheduledEnd()}
+	outsideWorkingHours := make([]string, 0)
+	for _, userID := range userIDs {
+		if !schedules[userID].WorkingHours(uc.workingHours).Contains(slot) {
+			outsideWorkingHours = append(outsideWorkingHours, userID)
+		}
+	}
+
+	var agenda []*entities.AgendaItem
+	if template != nil && len(template.Agenda) > 0 {
+		agenda = make([]*entities.AgendaItem, len(template.Agenda))
+		for i, item := range template.Agenda {
+			agendaItem, err := entities.NewAgendaItem(meeting.ID, i+1, item.Title, item.Description, item.DurationMinutes, item.PresenterID)
+			if err != nil {
+				return nil, err
+			}
+			agenda[i] = agendaItem
+		}
+	}
+
+	// The meeting is only visible with its participants and agenda
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.meetingRepo.Create(ctx, meeting); err != nil {
+			return err
+		}
+		if err := uc.meetingRepo.AddParticipants(ctx, participants); err != nil {
+			return err
+		}
+		if len(agenda) > 0 {
+			return uc.minutesRepo.ReplaceAgenda(ctx, meeting.ID, agenda)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
+	metrics.MeetingsCreated.Inc()
 
-	return meeting, nil
+	return &CreateOutput{
+		Meeting:             meeting,
+		Conflicts:           conflicts,
+		OutsideWorkingHours: outsideWorkingHours,
+	}, nil
 }
@@ -0,0 +1,231 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+func TestCreateMeetingConflicts(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        ConflictPolicy
+		busy          []*entities.BusyInterval
+		wantRejected  bool
+		wantConflicts int
+	}{
+		{"free", ConflictPolicyReject, nil, false, 0},
+		{"warn", ConflictPolicyWarn, []*entities.BusyInterval{busyFor("bob", monday(10, 0), monday(11, 0))}, false, 1},
+		{"unknown policy warns", "", []*entities.BusyInterval{busyFor("bob", monday(10, 0), monday(11, 0))}, false, 1},
+		{"reject", ConflictPolicyReject, []*entities.BusyInterval{busyFor("bob", monday(10, 0), monday(11, 0))}, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meetings := newFakeMeetingRepo()
+			meetings.busy = tt.busy
+			transactor := &fakeTransactor{}
+			uc := NewCreateMeetingUseCase(meetings, nil, nil, &fakeScheduleRepo{}, transactor, officeHours(t), tt.policy)
+
+			output, err := uc.Execute(context.Background(), CreateInput{
+				Title:       "Planning",
+				OrganizerID: "alice",
+				StartTime:   monday(10, 30),
+				Duration:    time.Hour,
+				InviteeIDs:  []string{"bob"},
+			})
+
+			if tt.wantRejected {
+				var conflictErr *ConflictError
+				if !errors.As(err, &conflictErr) || !errors.Is(err, ErrSchedulingConflict) {
+					t.Fatalf("Execute: err = %v, want a ConflictError", err)
+				}
+				if len(conflictErr.Conflicts) != tt.wantConflicts {
+					t.Fatalf("conflicts = %v, want %d", conflictErr.Conflicts, tt.wantConflicts)
+				}
+				if len(meetings.meetings) != 0 || transactor.calls != 0 {
+					t.Fatalf("rejected meeting was stored")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+			if len(output.Conflicts) != tt.wantConflicts {
+				t.Fatalf("conflicts = %v, want %d", output.Conflicts, tt.wantConflicts)
+			}
+			if _, ok := meetings.meetings[output.Meeting.ID]; !ok || transactor.calls != 1 {
+				t.Fatalf("meeting stored = %v in %d transactions, want 1", ok, transactor.calls)
+			}
+		})
+	}
+}
+
+func TestCreateMeetingParticipantsAndWorkingHours(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	meetings := newFakeMeetingRepo()
+	schedules := &fakeScheduleRepo{schedules: map[string]*entities.UserSchedule{
+		"bob": {UserID: "bob", Location: newYork},
+	}}
+	uc := NewCreateMeetingUseCase(meetings, nil, nil, schedules, &fakeTransactor{}, officeHours(t), ConflictPolicyWarn)
+
+	// 10:30-11:30 UTC is 06:30 in New York
+	output, err := uc.Execute(context.Background(), CreateInput{
+		Title:       "Planning",
+		OrganizerID: "alice",
+		StartTime:   monday(10, 30),
+		Duration:    time.Hour,
+		InviteeIDs:  []string{"bob", "", "alice", "bob", "carol"},
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if !reflect.DeepEqual(output.OutsideWorkingHours, []string{"bob"}) {
+		t.Fatalf("outside working hours = %v, want [bob]", output.OutsideWorkingHours)
+	}
+
+	roles := make(map[string]entities.ParticipantRole)
+	for _, participant := range meetings.participants[output.Meeting.ID] {
+		if _, ok := roles[participant.UserID]; ok {
+			t.Fatalf("%s was added twice", participant.UserID)
+		}
+		roles[participant.UserID] = participant.Role
+	}
+	want := map[string]entities.ParticipantRole{
+		"alice": entities.ParticipantRoleHost,
+		"bob":   entities.ParticipantRoleParticipant,
+		"carol": entities.ParticipantRoleParticipant,
+	}
+	if !reflect.DeepEqual(roles, want) {
+		t.Fatalf("participants = %v, want %v", roles, want)
+	}
+}
+
+func TestCreateMeetingFromTemplate(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	spec := entities.TemplateSpec{
+		Name:            "Weekly sync",
+		TitlePattern:    "Sync {weekday} {time}",
+		Description:     "Status updates",
+		Duration:        45 * time.Minute,
+		MaxParticipants: 8,
+		InviteeIDs:      []string{"bob"},
+		Agenda:          []entities.TemplateAgendaItem{{Title: "Updates", DurationMinutes: 30}, {Title: "Risks"}},
+		Settings:        entities.RoomSettings{StartMuted: true, Passcode: "1234"},
+	}
+	private, err := entities.NewMeetingTemplate("alice", spec)
+	if err != nil {
+		t.Fatalf("NewMeetingTemplate: %v", err)
+	}
+	spec.Shared = true
+	spec.Agenda = nil
+	shared, err := entities.NewMeetingTemplate("carol", spec)
+	if err != nil {
+		t.Fatalf("NewMeetingTemplate: %v", err)
+	}
+	templates := &fakeTemplateRepo{templates: map[string]*entities.MeetingTemplate{private.ID: private, shared.ID: shared}}
+
+	yes, no := true, false
+	empty, short := "", "12"
+
+	tests := []struct {
+		name         string
+		input        CreateInput
+		want         error
+		wantTitle    string
+		wantDuration time.Duration
+		wantSettings entities.RoomSettings
+		wantInvited  int
+		wantAgenda   int
+	}{
+		{
+			name:         "template defaults",
+			input:        CreateInput{OrganizerID: "alice", TemplateID: private.ID},
+			wantTitle:    "Sync Monday 16:00",
+			wantDuration: 45 * time.Minute,
+			wantSettings: entities.RoomSettings{StartMuted: true, Passcode: "1234"},
+			wantInvited:  1,
+			wantAgenda:   2,
+		},
+		{
+			name: "input overrides",
+			input: CreateInput{
+				Title: "Special sync", OrganizerID: "alice", TemplateID: private.ID, Duration: time.Hour,
+				Settings: &RoomSettingsInput{LobbyEnabled: &yes, StartMuted: &no, Passcode: &empty},
+			},
+			wantTitle:    "Special sync",
+			wantDuration: time.Hour,
+			wantSettings: entities.RoomSettings{LobbyEnabled: true},
+			wantInvited:  1,
+			wantAgenda:   2,
+		},
+		{
+			// The organizer is also the template's invitee
+			name:         "shared template",
+			input:        CreateInput{OrganizerID: "bob", TemplateID: shared.ID},
+			wantTitle:    "Sync Monday 10:30",
+			wantDuration: 45 * time.Minute,
+			wantSettings: entities.RoomSettings{StartMuted: true, Passcode: "1234"},
+		},
+		{
+			name:  "private template of another user",
+			input: CreateInput{OrganizerID: "bob", TemplateID: private.ID},
+			want:  entities.ErrTemplateNotFound,
+		},
+		{
+			name:  "invalid settings",
+			input: CreateInput{OrganizerID: "alice", TemplateID: private.ID, Settings: &RoomSettingsInput{Passcode: &short}},
+			want:  entities.ErrInvalidRoomSettings,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meetings := newFakeMeetingRepo()
+			minutes := newFakeMinutesRepo()
+			// Titles use the organizer's timezone
+			schedules := &fakeScheduleRepo{schedules: map[string]*entities.UserSchedule{
+				"alice": {UserID: "alice", Location: kolkata},
+			}}
+			uc := NewCreateMeetingUseCase(meetings, templates, minutes, schedules, &fakeTransactor{}, officeHours(t), ConflictPolicyWarn)
+
+			tt.input.StartTime = monday(10, 30)
+			output, err := uc.Execute(context.Background(), tt.input)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute: err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				if len(meetings.meetings) != 0 {
+					t.Fatal("rejected meeting was stored")
+				}
+				return
+			}
+
+			meeting := output.Meeting
+			if meeting.Title != tt.wantTitle || meeting.Duration != tt.wantDuration || meeting.Description != "Status updates" || meeting.MaxParticipants != 8 {
+				t.Fatalf("meeting = %q, %v, %q, %d", meeting.Title, meeting.Duration, meeting.Description, meeting.MaxParticipants)
+			}
+			if meeting.Settings != tt.wantSettings {
+				t.Fatalf("settings = %+v, want %+v", meeting.Settings, tt.wantSettings)
+			}
+			if invited := len(meetings.participants[meeting.ID]) - 1; invited != tt.wantInvited {
+				t.Fatalf("%d invitees, want %d", invited, tt.wantInvited)
+			}
+			if agenda := minutes.agendas[meeting.ID]; len(agenda) != tt.wantAgenda || (len(agenda) > 0 && agenda[1].Position != 2) {
+				t.Fatalf("agenda = %+v, want %d items", agenda, tt.wantAgenda)
+			}
+		})
+	}
+}
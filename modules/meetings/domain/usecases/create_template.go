@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// CreateTemplateUseCase handles creating meeting templates
+type CreateTemplateUseCase struct {
+	templateRepo repository.TemplateRepository
+}
+
+// NewCreateTemplateUseCase creates a new CreateTemplateUseCase
+func NewCreateTemplateUseCase(templateRepo repository.TemplateRepository) *CreateTemplateUseCase {
+	return &CreateTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// TemplateInput represents the fields of a meeting template. Room settings
+// not given fall back to the defaults of new meetings.
+type TemplateInput struct {
+	Name            string
+	TitlePattern    string
+	Description     string
+	Duration        time.Duration
+	MaxParticipants int
+	InviteeIDs      []string
+	Agenda          []AgendaItemInput
+	Settings        *RoomSettingsInput
+	Shared          bool
+}
+
+// spec converts the input into a template spec
+func (in TemplateInput) spec() (entities.TemplateSpec, error) {
+	settings, err := in.Settings.apply(entities.DefaultRoomSettings())
+	if err != nil {
+		return entities.TemplateSpec{}, err
+	}
+
+	agenda := make([]entities.TemplateAgendaItem, len(in.Agenda))
+	for i, item := range in.Agenda {
+		agenda[i] = entities.TemplateAgendaItem{
+			Title:           item.Title,
+			Description:     item.Description,
+			DurationMinutes: item.DurationMinutes,
+			PresenterID:     item.PresenterID,
+		}
+	}
+
+	return entities.TemplateSpec{
+		Name:            in.Name,
+		TitlePattern:    in.TitlePattern,
+		Description:     in.Description,
+		Duration:        in.Duration,
+		MaxParticipants: in.MaxParticipants,
+		InviteeIDs:      in.InviteeIDs,
+		Agenda:          agenda,
+		Settings:        settings,
+		Shared:          in.Shared,
+	}, nil
+}
+
+// Execute creates a meeting template owned by the user
+func (uc *CreateTemplateUseCase) Execute(ctx context.Context, userID string, input TemplateInput) (*entities.MeetingTemplate, error) {
+	spec, err := input.spec()
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := entities.NewMeetingTemplate(userID, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
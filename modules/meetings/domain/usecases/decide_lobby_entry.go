@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// DecideLobbyEntryUseCase handles hosts admitting or denying guests
+type DecideLobbyEntryUseCase struct {
+	meetingRepo repository.MeetingRepository
+	guestRepo   repository.GuestRepository
+}
+
+// NewDecideLobbyEntryUseCase creates a new DecideLobbyEntryUseCase
+func NewDecideLobbyEntryUseCase(meetingRepo repository.MeetingRepository, guestRepo repository.GuestRepository) *DecideLobbyEntryUseCase {
+	return &DecideLobbyEntryUseCase{
+		meetingRepo: meetingRepo,
+		guestRepo:   guestRepo,
+	}
+}
+
+// Execute admits or denies a waiting guest
+func (uc *DecideLobbyEntryUseCase) Execute(ctx context.Context, meetingID, entryID, userID string, admit bool) (*entities.LobbyEntry, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true); err != nil {
+		return nil, err
+	}
+
+	entry, err := uc.guestRepo.GetLobbyEntry(ctx, meetingID, entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := entry.Decide(admit, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.guestRepo.DecideLobbyEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// DeleteTemplateUseCase handles deleting a meeting template
+type DeleteTemplateUseCase struct {
+	templateRepo repository.TemplateRepository
+}
+
+// NewDeleteTemplateUseCase creates a new DeleteTemplateUseCase
+func NewDeleteTemplateUseCase(templateRepo repository.TemplateRepository) *DeleteTemplateUseCase {
+	return &DeleteTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute deletes a template. Only the owner may delete it.
+func (uc *DeleteTemplateUseCase) Execute(ctx context.Context, templateID, userID string) error {
+	if _, err := ownedTemplate(ctx, uc.templateRepo, templateID, userID); err != nil {
+		return err
+	}
+
+	return uc.templateRepo.Delete(ctx, templateID)
+}
@@ -0,0 +1,353 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// fakeMeetingRepo keeps meetings and participants in memory. Methods the
+// tests do not need panic through the embedded nil interface.
+type fakeMeetingRepo struct {
+	repository.MeetingRepository
+
+	mu           sync.Mutex
+	meetings     map[string]*entities.Meeting
+	participants map[string][]*entities.Participant
+	busy         []*entities.BusyInterval
+	due          []*entities.Meeting
+	released     []string
+
+	// participantsErr fails ListParticipants for the given meeting IDs
+	participantsErr map[string]error
+}
+
+func newFakeMeetingRepo() *fakeMeetingRepo {
+	return &fakeMeetingRepo{
+		meetings:        make(map[string]*entities.Meeting),
+		participants:    make(map[string][]*entities.Participant),
+		participantsErr: make(map[string]error),
+	}
+}
+
+// addMeeting stores a scheduled meeting of organizerID with the given
+// participants
+func (r *fakeMeetingRepo) addMeeting(t *testing.T, organizerID string, participants ...string) *entities.Meeting {
+	t.Helper()
+	meeting, err := entities.NewMeeting("Standup", "", organizerID, time.Now().Add(time.Hour), 30*time.Minute)
+	if err != nil {
+		t.Fatalf("NewMeeting: %v", err)
+	}
+	r.meetings[meeting.ID] = meeting
+	r.participants[meeting.ID] = []*entities.Participant{
+		entities.NewParticipant(meeting.ID, organizerID, entities.ParticipantRoleHost),
+	}
+	for _, userID := range participants {
+		r.participants[meeting.ID] = append(r.participants[meeting.ID], entities.NewParticipant(meeting.ID, userID, entities.ParticipantRoleParticipant))
+	}
+	return meeting
+}
+
+func (r *fakeMeetingRepo) Create(ctx context.Context, meeting *entities.Meeting) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *meeting
+	r.meetings[meeting.ID] = &copied
+	return nil
+}
+
+func (r *fakeMeetingRepo) GetByID(ctx context.Context, id string) (*entities.Meeting, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	meeting, ok := r.meetings[id]
+	if !ok {
+		return nil, entities.ErrMeetingNotFound
+	}
+	copied := *meeting
+	return &copied, nil
+}
+
+func (r *fakeMeetingRepo) GetByIDForUpdate(ctx context.Context, id string) (*entities.Meeting, error) {
+	return r.GetByID(ctx, id)
+}
+
+func (r *fakeMeetingRepo) GetByRoomID(ctx context.Context, roomID string) (*entities.Meeting, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, meeting := range r.meetings {
+		if meeting.RoomID == roomID {
+			copied := *meeting
+			return &copied, nil
+		}
+	}
+	return nil, entities.ErrMeetingNotFound
+}
+
+func (r *fakeMeetingRepo) Update(ctx context.Context, meeting *entities.Meeting) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.meetings[meeting.ID]; !ok {
+		return entities.ErrMeetingNotFound
+	}
+	copied := *meeting
+	r.meetings[meeting.ID] = &copied
+	return nil
+}
+
+func (r *fakeMeetingRepo) AddParticipants(ctx context.Context, participants []*entities.Participant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, participant := range participants {
+		r.participants[participant.MeetingID] = append(r.participants[participant.MeetingID], participant)
+	}
+	return nil
+}
+
+func (r *fakeMeetingRepo) ListParticipants(ctx context.Context, meetingID string) ([]*entities.Participant, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.participantsErr[meetingID]; err != nil {
+		return nil, err
+	}
+	return append([]*entities.Participant(nil), r.participants[meetingID]...), nil
+}
+
+func (r *fakeMeetingRepo) ListBusy(ctx context.Context, userIDs []string, from, to time.Time) ([]*entities.BusyInterval, error) {
+	return r.busy, nil
+}
+
+func (r *fakeMeetingRepo) ClaimDueReminders(ctx context.Context, now, before time.Time) ([]*entities.Meeting, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	due := r.due
+	r.due = nil
+	return due, nil
+}
+
+func (r *fakeMeetingRepo) ReleaseReminder(ctx context.Context, meetingID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.released = append(r.released, meetingID)
+	return nil
+}
+
+// fakeRecordingRepo keeps recordings in memory
+type fakeRecordingRepo struct {
+	recordings map[string]*entities.Recording
+}
+
+func newFakeRecordingRepo() *fakeRecordingRepo {
+	return &fakeRecordingRepo{recordings: make(map[string]*entities.Recording)}
+}
+
+func (r *fakeRecordingRepo) Create(ctx context.Context, recording *entities.Recording) error {
+	copied := *recording
+	r.recordings[recording.ID] = &copied
+	return nil
+}
+
+func (r *fakeRecordingRepo) GetByID(ctx context.Context, meetingID, id string) (*entities.Recording, error) {
+	recording, ok := r.recordings[id]
+	if !ok || recording.MeetingID != meetingID {
+		return nil, entities.ErrRecordingNotFound
+	}
+	copied := *recording
+	return &copied, nil
+}
+
+func (r *fakeRecordingRepo) ListByMeeting(ctx context.Context, meetingID string) ([]*entities.Recording, error) {
+	recordings := make([]*entities.Recording, 0)
+	for _, recording := range r.recordings {
+		if recording.MeetingID == meetingID {
+			copied := *recording
+			recordings = append(recordings, &copied)
+		}
+	}
+	return recordings, nil
+}
+
+func (r *fakeRecordingRepo) Update(ctx context.Context, recording *entities.Recording) error {
+	if _, ok := r.recordings[recording.ID]; !ok {
+		return entities.ErrRecordingNotFound
+	}
+	copied := *recording
+	r.recordings[recording.ID] = &copied
+	return nil
+}
+
+func (r *fakeRecordingRepo) Delete(ctx context.Context, id string) error {
+	delete(r.recordings, id)
+	return nil
+}
+
+// fakeTransactor runs functions without a transaction, counting the calls
+type fakeTransactor struct {
+	calls int
+}
+
+func (t *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.calls++
+	return fn(ctx)
+}
+
+// fakeOutbox records the events published to it
+type fakeOutbox struct {
+	events []eventbus.Event
+}
+
+func (o *fakeOutbox) Publish(ctx context.Context, events ...eventbus.Event) error {
+	o.events = append(o.events, events...)
+	return nil
+}
+
+// publishedEvent is an event pushed to connected clients
+type publishedEvent struct {
+	userIDs   []string
+	eventType string
+	data      interface{}
+}
+
+// fakePublisher records the events pushed to connected clients
+type fakePublisher struct {
+	events []publishedEvent
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, userIDs []string, eventType string, data interface{}) error {
+	p.events = append(p.events, publishedEvent{userIDs: userIDs, eventType: eventType, data: data})
+	return nil
+}
+
+// fakeScheduleRepo returns the schedules it holds; users without one are
+// left out, like unknown users in the repository
+type fakeScheduleRepo struct {
+	schedules map[string]*entities.UserSchedule
+}
+
+func (r *fakeScheduleRepo) ListSchedules(ctx context.Context, userIDs []string) (map[string]*entities.UserSchedule, error) {
+	schedules := make(map[string]*entities.UserSchedule, len(userIDs))
+	for _, userID := range userIDs {
+		if schedule, ok := r.schedules[userID]; ok {
+			schedules[userID] = schedule
+		}
+	}
+	return schedules, nil
+}
+
+// fakeAttendanceRepo keeps attendance sessions in memory
+type fakeAttendanceRepo struct {
+	sessions []*entities.AttendanceSession
+}
+
+func (r *fakeAttendanceRepo) OpenSession(ctx context.Context, session *entities.AttendanceSession) error {
+	for _, open := range r.sessions {
+		if open.MeetingID == session.MeetingID && open.UserID == session.UserID && open.Source == session.Source && open.LeftAt == nil {
+			return nil
+		}
+	}
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func (r *fakeAttendanceRepo) CloseSessions(ctx context.Context, meetingID, userID string, source entities.AttendanceSource, leftAt time.Time) (int64, error) {
+	var closed int64
+	for _, open := range r.sessions {
+		if open.MeetingID != meetingID || open.UserID != userID || open.LeftAt != nil || (source != "" && open.Source != source) {
+			continue
+		}
+		left := leftAt
+		open.LeftAt = &left
+		closed++
+	}
+	return closed, nil
+}
+
+func (r *fakeAttendanceRepo) ListSessions(ctx context.Context, meetingID string) ([]*entities.AttendanceSession, error) {
+	sessions := make([]*entities.AttendanceSession, 0)
+	for _, session := range r.sessions {
+		if session.MeetingID == meetingID {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+// fakeMinutesRepo keeps agendas, notes revisions and action items in memory
+type fakeMinutesRepo struct {
+	repository.MinutesRepository
+
+	agendas     map[string][]*entities.AgendaItem
+	notes       map[string][]*entities.NoteRevision
+	actionItems map[string]*entities.ActionItem
+}
+
+func newFakeMinutesRepo() *fakeMinutesRepo {
+	return &fakeMinutesRepo{
+		agendas:     make(map[string][]*entities.AgendaItem),
+		notes:       make(map[string][]*entities.NoteRevision),
+		actionItems: make(map[string]*entities.ActionItem),
+	}
+}
+
+func (r *fakeMinutesRepo) ReplaceAgenda(ctx context.Context, meetingID string, items []*entities.AgendaItem) error {
+	r.agendas[meetingID] = items
+	return nil
+}
+
+func (r *fakeMinutesRepo) GetNotes(ctx context.Context, meetingID string) (*entities.NoteRevision, error) {
+	revisions := r.notes[meetingID]
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+	return revisions[len(revisions)-1], nil
+}
+
+func (r *fakeMinutesRepo) SaveNotes(ctx context.Context, revision *entities.NoteRevision, baseRevision int) error {
+	if baseRevision != len(r.notes[revision.MeetingID]) {
+		return entities.ErrNotesConflict
+	}
+	revision.Revision = baseRevision + 1
+	r.notes[revision.MeetingID] = append(r.notes[revision.MeetingID], revision)
+	return nil
+}
+
+func (r *fakeMinutesRepo) CreateActionItem(ctx context.Context, item *entities.ActionItem) error {
+	copied := *item
+	r.actionItems[item.ID] = &copied
+	return nil
+}
+
+func (r *fakeMinutesRepo) GetActionItem(ctx context.Context, meetingID, id string) (*entities.ActionItem, error) {
+	item, ok := r.actionItems[id]
+	if !ok || item.MeetingID != meetingID {
+		return nil, entities.ErrActionItemNotFound
+	}
+	copied := *item
+	return &copied, nil
+}
+
+func (r *fakeMinutesRepo) UpdateActionItem(ctx context.Context, item *entities.ActionItem) error {
+	copied := *item
+	r.actionItems[item.ID] = &copied
+	return nil
+}
+
+// fakeTemplateRepo keeps meeting templates in memory
+type fakeTemplateRepo struct {
+	repository.TemplateRepository
+
+	templates map[string]*entities.MeetingTemplate
+}
+
+func (r *fakeTemplateRepo) GetByID(ctx context.Context, id string) (*entities.MeetingTemplate, error) {
+	template, ok := r.templates[id]
+	if !ok {
+		return nil, entities.ErrTemplateNotFound
+	}
+	copied := *template
+	return &copied, nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetAgendaUseCase handles retrieving a meeting agenda
+type GetAgendaUseCase struct {
+	meetingRepo repository.MeetingRepository
+	minutesRepo repository.MinutesRepository
+}
+
+// NewGetAgendaUseCase creates a new GetAgendaUseCase
+func NewGetAgendaUseCase(meetingRepo repository.MeetingRepository, minutesRepo repository.MinutesRepository) *GetAgendaUseCase {
+	return &GetAgendaUseCase{
+		meetingRepo: meetingRepo,
+		minutesRepo: minutesRepo,
+	}
+}
+
+// Execute retrieves the agenda of a meeting for one of its participants
+func (uc *GetAgendaUseCase) Execute(ctx context.Context, meetingID, userID string) ([]*entities.AgendaItem, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.minutesRepo.ListAgenda(ctx, meetingID)
+}
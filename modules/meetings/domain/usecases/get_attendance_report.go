@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+var ErrAttendanceForbidden = errors.New("only the organizer can view attendance")
+
+// GetAttendanceReportUseCase handles building a meeting attendance report
+type GetAttendanceReportUseCase struct {
+	meetingRepo    repository.MeetingRepository
+	attendanceRepo repository.AttendanceRepository
+}
+
+// NewGetAttendanceReportUseCase creates a new GetAttendanceReportUseCase
+func NewGetAttendanceReportUseCase(meetingRepo repository.MeetingRepository, attendanceRepo repository.AttendanceRepository) *GetAttendanceReportUseCase {
+	return &GetAttendanceReportUseCase{
+		meetingRepo:    meetingRepo,
+		attendanceRepo: attendanceRepo,
+	}
+}
+
+// Execute builds the attendance report of a meeting for its organizer or an admin
+func (uc *GetAttendanceReportUseCase) Execute(ctx context.Context, meetingID, requesterID, requesterRole string) (*entities.AttendanceReport, error) {
+	meeting, err := uc.meetingRepo.GetByID(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if meeting.OrganizerID != requesterID && requesterRole != "admin" {
+		return nil, ErrAttendanceForbidden
+	}
+
+	participants, err := uc.meetingRepo.ListParticipants(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := uc.attendanceRepo.ListSessions(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewAttendanceReport(meeting, participants, sessions, time.Now()), nil
+}
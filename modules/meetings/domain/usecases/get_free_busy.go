@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// maxAvailabilityRange bounds free/busy and slot lookups
+const maxAvailabilityRange = 31 * 24 * time.Hour
+
+// GetFreeBusyUseCase handles free/busy lookups
+type GetFreeBusyUseCase struct {
+	meetingRepo  repository.MeetingRepository
+	scheduleRepo repository.UserScheduleRepository
+	workingHours entities.WorkingHours
+}
+
+// NewGetFreeBusyUseCase creates a new GetFreeBusyUseCase
+func NewGetFreeBusyUseCase(
+	meetingRepo repository.MeetingRepository,
+	scheduleRepo repository.UserScheduleRepository,
+	workingHours entities.WorkingHours,
+) *GetFreeBusyUseCase {
+	return &GetFreeBusyUseCase{
+		meetingRepo:  meetingRepo,
+		scheduleRepo: scheduleRepo,
+		workingHours: workingHours,
+	}
+}
+
+// UserBusy represents the busy intervals of a single user together with
+// their timezone and working-hour windows over the requested range
+type UserBusy struct {
+	UserID       string
+	Timezone     string
+	WorkingHours []entities.TimeSlot
+	Busy         []*entities.BusyInterval
+}
+
+// Execute returns busy intervals for each user over [from, to)
+func (uc *GetFreeBusyUseCase) Execute(ctx context.Context, userIDs []string, from, to time.Time) ([]*UserBusy, error) {
+	if err := validateRange(userIDs, from, to); err != nil {
+		return nil, err
+	}
+
+	intervals, err := uc.meetingRepo.ListBusy(ctx, userIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := uc.scheduleRepo.ListSchedules(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[string]*UserBusy, len(userIDs))
+	result := make([]*UserBusy, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if _, ok := byUser[userID]; ok {
+			continue
+		}
+		schedule := schedules[userID]
+		entry := &UserBusy{
+			UserID:       userID,
+			Timezone:     schedule.Timezone(),
+			WorkingHours: schedule.WorkingHours(uc.workingHours).Windows(from, to),
+			Busy:         make([]*entities.BusyInterval, 0),
+		}
+		byUser[userID] = entry
+		result = append(result, entry)
+	}
+
+	for _, interval := range intervals {
+		if entry, ok := byUser[interval.UserID]; ok {
+			entry.Busy = append(entry.Busy, interval)
+		}
+	}
+
+	return result, nil
+}
+
+func validateRange(userIDs []string, from, to time.Time) error {
+	if len(userIDs) == 0 || !from.Before(to) || to.Sub(from) > maxAvailabilityRange {
+		return entities.ErrInvalidTimeRange
+	}
+	return nil
+}
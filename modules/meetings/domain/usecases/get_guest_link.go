@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetGuestLinkUseCase handles looking up a guest link before joining
+type GetGuestLinkUseCase struct {
+	meetingRepo repository.MeetingRepository
+	guestRepo   repository.GuestRepository
+}
+
+// NewGetGuestLinkUseCase creates a new GetGuestLinkUseCase
+func NewGetGuestLinkUseCase(meetingRepo repository.MeetingRepository, guestRepo repository.GuestRepository) *GetGuestLinkUseCase {
+	return &GetGuestLinkUseCase{
+		meetingRepo: meetingRepo,
+		guestRepo:   guestRepo,
+	}
+}
+
+// GuestLinkInfo is what a guest may see about the meeting behind a link
+type GuestLinkInfo struct {
+	MeetingTitle     string
+	StartTime        time.Time
+	Duration         time.Duration
+	RequiresPasscode bool
+	ExpiresAt        time.Time
+}
+
+// Execute returns the public details of a usable guest link
+func (uc *GetGuestLinkUseCase) Execute(ctx context.Context, token string) (*GuestLinkInfo, error) {
+	link, err := linkByToken(ctx, uc.guestRepo, token)
+	if err != nil {
+		return nil, err
+	}
+	if !link.IsUsable(time.Now()) {
+		return nil, entities.ErrGuestLinkUnavailable
+	}
+
+	meeting, err := uc.meetingRepo.GetByID(ctx, link.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	if !meeting.IsActive() {
+		return nil, entities.ErrMeetingNotActive
+	}
+
+	return &GuestLinkInfo{
+		MeetingTitle:     meeting.Title,
+		StartTime:        meeting.StartTime,
+		Duration:         meeting.Duration,
+		RequiresPasscode: link.HasPasscode(),
+		ExpiresAt:        link.ExpiresAt,
+	}, nil
+}
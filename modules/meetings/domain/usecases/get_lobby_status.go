@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetLobbyStatusUseCase handles guests polling for the host's decision
+type GetLobbyStatusUseCase struct {
+	meetingRepo repository.MeetingRepository
+	guestRepo   repository.GuestRepository
+	jitsi       GuestTokenIssuer
+	tokenTTL    time.Duration
+}
+
+// NewGetLobbyStatusUseCase creates a new GetLobbyStatusUseCase
+func NewGetLobbyStatusUseCase(meetingRepo repository.MeetingRepository, guestRepo repository.GuestRepository, jitsi GuestTokenIssuer, tokenTTL time.Duration) *GetLobbyStatusUseCase {
+	return &GetLobbyStatusUseCase{
+		meetingRepo: meetingRepo,
+		guestRepo:   guestRepo,
+		jitsi:       jitsi,
+		tokenTTL:    tokenTTL,
+	}
+}
+
+// LobbyStatusOutput represents a lobby entry and, once admitted, the
+// restricted credentials to enter the meeting room
+type LobbyStatusOutput struct {
+	Entry          *entities.LobbyEntry
+	RoomURL        string
+	JitsiToken     string
+	TokenExpiresAt *time.Time
+	RoomPasscode   string
+}
+
+// Execute returns the state of a lobby entry. Admitted guests get a fresh
+// restricted Jitsi token as long as the link is not revoked and the meeting
+// is active.
+func (uc *GetLobbyStatusUseCase) Execute(ctx context.Context, token, entryID, secret string) (*LobbyStatusOutput, error) {
+	link, err := linkByToken(ctx, uc.guestRepo, token)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := uc.guestRepo.GetLobbyEntry(ctx, link.MeetingID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.LinkID != link.ID ||
+		subtle.ConstantTimeCompare([]byte(entry.SecretHash), []byte(entities.HashGuestSecret(secret))) != 1 {
+		return nil, entities.ErrLobbyEntryNotFound
+	}
+
+	output := &LobbyStatusOutput{Entry: entry}
+	if entry.Status != entities.LobbyStatusAdmitted {
+		return output, nil
+	}
+	if link.RevokedAt != nil {
+		return nil, entities.ErrGuestLinkUnavailable
+	}
+
+	meeting, err := uc.meetingRepo.GetByID(ctx, link.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	if !meeting.IsActive() {
+		return nil, entities.ErrMeetingNotActive
+	}
+
+	jitsiToken, err := uc.jitsi.CreateGuestToken(meeting.RoomID, "guest-"+entry.ID, entry.DisplayName, entry.Email, uc.tokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(uc.tokenTTL)
+	output.RoomURL = uc.jitsi.GetConfiguredRoomURL(meeting.RoomID, meeting.Settings)
+	output.JitsiToken = jitsiToken
+	output.TokenExpiresAt = &expiresAt
+	output.RoomPasscode = meeting.Settings.Passcode
+
+	return output, nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetNoteRevisionUseCase handles retrieving one version of meeting notes
+type GetNoteRevisionUseCase struct {
+	meetingRepo repository.MeetingRepository
+	minutesRepo repository.MinutesRepository
+}
+
+// NewGetNoteRevisionUseCase creates a new GetNoteRevisionUseCase
+func NewGetNoteRevisionUseCase(meetingRepo repository.MeetingRepository, minutesRepo repository.MinutesRepository) *GetNoteRevisionUseCase {
+	return &GetNoteRevisionUseCase{
+		meetingRepo: meetingRepo,
+		minutesRepo: minutesRepo,
+	}
+}
+
+// Execute retrieves a notes revision
+func (uc *GetNoteRevisionUseCase) Execute(ctx context.Context, meetingID, userID string, revision int) (*entities.NoteRevision, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.minutesRepo.GetNoteRevision(ctx, meetingID, revision)
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetNotesUseCase handles retrieving meeting notes
+type GetNotesUseCase struct {
+	meetingRepo repository.MeetingRepository
+	minutesRepo repository.MinutesRepository
+}
+
+// NewGetNotesUseCase creates a new GetNotesUseCase
+func NewGetNotesUseCase(meetingRepo repository.MeetingRepository, minutesRepo repository.MinutesRepository) *GetNotesUseCase {
+	return &GetNotesUseCase{
+		meetingRepo: meetingRepo,
+		minutesRepo: minutesRepo,
+	}
+}
+
+// Execute retrieves the current notes. Meetings without notes return an
+// empty revision 0.
+func (uc *GetNotesUseCase) Execute(ctx context.Context, meetingID, userID string) (*entities.NoteRevision, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return currentNotes(ctx, uc.minutesRepo, meetingID)
+}
+
+func currentNotes(ctx context.Context, minutesRepo repository.MinutesRepository, meetingID string) (*entities.NoteRevision, error) {
+	notes, err := minutesRepo.GetNotes(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	if notes == nil {
+		return &entities.NoteRevision{MeetingID: meetingID}, nil
+	}
+	return notes, nil
+}
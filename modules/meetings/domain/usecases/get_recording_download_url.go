@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/storage"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetRecordingDownloadURLUseCase handles issuing signed recording download links
+type GetRecordingDownloadURLUseCase struct {
+	meetingRepo   repository.MeetingRepository
+	recordingRepo repository.RecordingRepository
+	blobStore     storage.BlobStore
+	ttl           time.Duration
+}
+
+// NewGetRecordingDownloadURLUseCase creates a new GetRecordingDownloadURLUseCase
+func NewGetRecordingDownloadURLUseCase(
+	meetingRepo repository.MeetingRepository,
+	recordingRepo repository.RecordingRepository,
+	blobStore storage.BlobStore,
+	ttl time.Duration,
+) *GetRecordingDownloadURLUseCase {
+	if ttl <= 0 {
+		ttl = 15 * time.Minute
+	}
+
+	return &GetRecordingDownloadURLUseCase{
+		meetingRepo:   meetingRepo,
+		recordingRepo: recordingRepo,
+		blobStore:     blobStore,
+		ttl:           ttl,
+	}
+}
+
+// DownloadURL represents a time-limited download link
+type DownloadURL struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+// Execute returns a signed download URL for a participant of the meeting
+func (uc *GetRecordingDownloadURLUseCase) Execute(ctx context.Context, meetingID, recordingID, userID string) (*DownloadURL, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, false); err != nil {
+		return nil, err
+	}
+
+	recording, err := uc.recordingRepo.GetByID(ctx, meetingID, recordingID)
+	if err != nil {
+		return nil, err
+	}
+	if recording.Status != entities.RecordingStatusAvailable {
+		return nil, entities.ErrRecordingUnavailable
+	}
+
+	expiresAt := time.Now().Add(uc.ttl)
+	url, err := uc.blobStore.SignedURL(ctx, recording.ObjectKey, uc.ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DownloadURL{URL: url, ExpiresAt: expiresAt}, nil
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/storage"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetRecordingUploadUseCase handles reporting upload progress so clients can resume
+type GetRecordingUploadUseCase struct {
+	meetingRepo   repository.MeetingRepository
+	recordingRepo repository.RecordingRepository
+	blobStore     storage.BlobStore
+}
+
+// NewGetRecordingUploadUseCase creates a new GetRecordingUploadUseCase
+func NewGetRecordingUploadUseCase(
+	meetingRepo repository.MeetingRepository,
+	recordingRepo repository.RecordingRepository,
+	blobStore storage.BlobStore,
+) *GetRecordingUploadUseCase {
+	return &GetRecordingUploadUseCase{
+		meetingRepo:   meetingRepo,
+		recordingRepo: recordingRepo,
+		blobStore:     blobStore,
+	}
+}
+
+// UploadStatus represents the progress of a recording upload
+type UploadStatus struct {
+	Recording     *entities.Recording
+	Parts         []storage.Part
+	UploadedBytes int64
+}
+
+// Execute returns the recording and the parts received so far
+func (uc *GetRecordingUploadUseCase) Execute(ctx context.Context, meetingID, recordingID, userID string) (*UploadStatus, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true); err != nil {
+		return nil, err
+	}
+
+	recording, err := uploadingRecording(ctx, uc.recordingRepo, meetingID, recordingID)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := uc.blobStore.ListParts(ctx, recording.ObjectKey, recording.UploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &UploadStatus{Recording: recording, Parts: parts}
+	for _, part := range parts {
+		status.UploadedBytes += part.Size
+	}
+
+	return status, nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetSummaryUseCase handles retrieving meeting summaries
+type GetSummaryUseCase struct {
+	meetingRepo    repository.MeetingRepository
+	transcriptRepo repository.TranscriptRepository
+}
+
+// NewGetSummaryUseCase creates a new GetSummaryUseCase
+func NewGetSummaryUseCase(meetingRepo repository.MeetingRepository, transcriptRepo repository.TranscriptRepository) *GetSummaryUseCase {
+	return &GetSummaryUseCase{
+		meetingRepo:    meetingRepo,
+		transcriptRepo: transcriptRepo,
+	}
+}
+
+// Execute retrieves the summary of a meeting for one of its participants
+func (uc *GetSummaryUseCase) Execute(ctx context.Context, meetingID, userID string) (*entities.Summary, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.transcriptRepo.GetSummary(ctx, meetingID)
+}
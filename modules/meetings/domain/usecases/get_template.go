@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetTemplateUseCase handles retrieving a meeting template
+type GetTemplateUseCase struct {
+	templateRepo repository.TemplateRepository
+}
+
+// NewGetTemplateUseCase creates a new GetTemplateUseCase
+func NewGetTemplateUseCase(templateRepo repository.TemplateRepository) *GetTemplateUseCase {
+	return &GetTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute returns a template the user owns or that is shared
+func (uc *GetTemplateUseCase) Execute(ctx context.Context, templateID, userID string) (*entities.MeetingTemplate, error) {
+	return visibleTemplate(ctx, uc.templateRepo, templateID, userID)
+}
+
+// visibleTemplate loads a template the user may use. Private templates of
+// others are reported as not found.
+func visibleTemplate(ctx context.Context, templateRepo repository.TemplateRepository, templateID, userID string) (*entities.MeetingTemplate, error) {
+	template, err := templateRepo.GetByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template.OwnerID != userID && !template.Shared {
+		return nil, entities.ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+// ownedTemplate loads a template the user may change
+func ownedTemplate(ctx context.Context, templateRepo repository.TemplateRepository, templateID, userID string) (*entities.MeetingTemplate, error) {
+	template, err := visibleTemplate(ctx, templateRepo, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if template.OwnerID != userID {
+		return nil, entities.ErrTemplateForbidden
+	}
+	return template, nil
+}
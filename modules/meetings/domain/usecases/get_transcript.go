@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// GetTranscriptUseCase handles retrieving meeting transcripts
+type GetTranscriptUseCase struct {
+	meetingRepo    repository.MeetingRepository
+	transcriptRepo repository.TranscriptRepository
+}
+
+// NewGetTranscriptUseCase creates a new GetTranscriptUseCase
+func NewGetTranscriptUseCase(meetingRepo repository.MeetingRepository, transcriptRepo repository.TranscriptRepository) *GetTranscriptUseCase {
+	return &GetTranscriptUseCase{
+		meetingRepo:    meetingRepo,
+		transcriptRepo: transcriptRepo,
+	}
+}
+
+// Execute retrieves the transcript of a meeting for one of its participants
+func (uc *GetTranscriptUseCase) Execute(ctx context.Context, meetingID, userID string) (*entities.Transcript, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.transcriptRepo.GetTranscript(ctx, meetingID)
+}
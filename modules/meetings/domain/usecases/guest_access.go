@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// PasscodeHasher hashes and verifies guest link passcodes
+type PasscodeHasher interface {
+	Hash(passcode string) (string, error)
+	Compare(hashedPasscode, passcode string) error
+}
+
+// GuestTokenIssuer issues restricted Jitsi tokens for guests
+type GuestTokenIssuer interface {
+	CreateGuestToken(roomName, guestID, guestName, guestEmail string, ttl time.Duration) (string, error)
+	GetConfiguredRoomURL(roomName string, settings entities.RoomSettings) string
+}
+
+// linkByToken loads the guest link for a token handed out to guests
+func linkByToken(ctx context.Context, guestRepo repository.GuestRepository, token string) (*entities.GuestLink, error) {
+	if token == "" {
+		return nil, entities.ErrGuestLinkNotFound
+	}
+	return guestRepo.GetLinkByTokenHash(ctx, entities.HashGuestSecret(token))
+}
@@ -0,0 +1,240 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// fakeGuestRepo keeps guest links and lobby entries in memory
+type fakeGuestRepo struct {
+	repository.GuestRepository
+
+	links   map[string]*entities.GuestLink // by token hash
+	entries map[string]*entities.LobbyEntry
+}
+
+func newFakeGuestRepo() *fakeGuestRepo {
+	return &fakeGuestRepo{
+		links:   make(map[string]*entities.GuestLink),
+		entries: make(map[string]*entities.LobbyEntry),
+	}
+}
+
+func (r *fakeGuestRepo) CreateLink(ctx context.Context, link *entities.GuestLink) error {
+	r.links[link.TokenHash] = link
+	return nil
+}
+
+func (r *fakeGuestRepo) GetLinkByTokenHash(ctx context.Context, tokenHash string) (*entities.GuestLink, error) {
+	link, ok := r.links[tokenHash]
+	if !ok {
+		return nil, entities.ErrGuestLinkNotFound
+	}
+	copied := *link
+	return &copied, nil
+}
+
+func (r *fakeGuestRepo) CreateLobbyEntry(ctx context.Context, entry *entities.LobbyEntry) error {
+	for _, link := range r.links {
+		if link.ID != entry.LinkID {
+			continue
+		}
+		if !link.IsUsable(entry.CreatedAt) {
+			return entities.ErrGuestLinkUnavailable
+		}
+		link.UseCount++
+		copied := *entry
+		r.entries[entry.ID] = &copied
+		return nil
+	}
+	return entities.ErrGuestLinkUnavailable
+}
+
+func (r *fakeGuestRepo) GetLobbyEntry(ctx context.Context, meetingID, id string) (*entities.LobbyEntry, error) {
+	entry, ok := r.entries[id]
+	if !ok || entry.MeetingID != meetingID {
+		return nil, entities.ErrLobbyEntryNotFound
+	}
+	copied := *entry
+	return &copied, nil
+}
+
+func (r *fakeGuestRepo) DecideLobbyEntry(ctx context.Context, entry *entities.LobbyEntry) error {
+	if r.entries[entry.ID].Status != entities.LobbyStatusWaiting {
+		return entities.ErrLobbyEntryDecided
+	}
+	copied := *entry
+	r.entries[entry.ID] = &copied
+	return nil
+}
+
+// fakeHasher "hashes" passcodes by prefixing them
+type fakeHasher struct{}
+
+func (fakeHasher) Hash(passcode string) (string, error) {
+	return "hashed:" + passcode, nil
+}
+
+func (fakeHasher) Compare(hashedPasscode, passcode string) error {
+	if hashedPasscode != "hashed:"+passcode {
+		return errors.New("mismatch")
+	}
+	return nil
+}
+
+// fakeGuestTokens issues guest tokens naming the guest
+type fakeGuestTokens struct{}
+
+func (fakeGuestTokens) CreateGuestToken(roomName, guestID, guestName, guestEmail string, ttl time.Duration) (string, error) {
+	return "guest-token:" + guestID, nil
+}
+
+func (fakeGuestTokens) GetConfiguredRoomURL(roomName string, settings entities.RoomSettings) string {
+	return "https://meet.example.com/" + roomName
+}
+
+// guestAccess wires the guest link and lobby use cases together
+type guestAccess struct {
+	meetings *fakeMeetingRepo
+	guests   *fakeGuestRepo
+	meeting  *entities.Meeting
+	notifier *fakeNotifier
+
+	request *RequestGuestAccessUseCase
+	decide  *DecideLobbyEntryUseCase
+	status  *GetLobbyStatusUseCase
+}
+
+func newGuestAccess(t *testing.T, lobby bool) *guestAccess {
+	t.Helper()
+	meetings := newFakeMeetingRepo()
+	meeting := meetings.addMeeting(t, "host", "member")
+	meeting.Settings.LobbyEnabled = lobby
+	guests := newFakeGuestRepo()
+	notifier := &fakeNotifier{}
+
+	return &guestAccess{
+		meetings: meetings,
+		guests:   guests,
+		meeting:  meeting,
+		notifier: notifier,
+		request:  NewRequestGuestAccessUseCase(meetings, guests, fakeHasher{}, notifier),
+		decide:   NewDecideLobbyEntryUseCase(meetings, guests),
+		status:   NewGetLobbyStatusUseCase(meetings, guests, fakeGuestTokens{}, time.Hour),
+	}
+}
+
+// link creates a guest link and returns its token
+func (g *guestAccess) link(t *testing.T, maxUses int, passcode string) (*entities.GuestLink, string) {
+	t.Helper()
+	var passcodeHash string
+	if passcode != "" {
+		passcodeHash, _ = fakeHasher{}.Hash(passcode)
+	}
+	link, token, err := entities.NewGuestLink(g.meeting.ID, "host", time.Now().Add(time.Hour), maxUses, passcodeHash)
+	if err != nil {
+		t.Fatalf("NewGuestLink: %v", err)
+	}
+	g.guests.CreateLink(context.Background(), link)
+	return link, token
+}
+
+func TestGuestAccessThroughTheLobby(t *testing.T) {
+	ctx := context.Background()
+	g := newGuestAccess(t, true)
+	_, token := g.link(t, 0, "")
+
+	requested, err := g.request.Execute(ctx, RequestGuestAccessInput{Token: token, DisplayName: "Dana"})
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if len(g.notifier.sent["host"]) != 1 {
+		t.Fatalf("organizer was not told about the waiting guest")
+	}
+
+	waiting, err := g.status.Execute(ctx, token, requested.Entry.ID, requested.Secret)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if waiting.Entry.Status != entities.LobbyStatusWaiting || waiting.JitsiToken != "" {
+		t.Fatalf("waiting guest got %+v", waiting)
+	}
+
+	if _, err := g.status.Execute(ctx, token, requested.Entry.ID, "guessed"); !errors.Is(err, entities.ErrLobbyEntryNotFound) {
+		t.Fatalf("status with a wrong secret: err = %v, want ErrLobbyEntryNotFound", err)
+	}
+	if _, err := g.decide.Execute(ctx, g.meeting.ID, requested.Entry.ID, "member", true); !errors.Is(err, ErrMeetingForbidden) {
+		t.Fatalf("admitted by a participant: err = %v, want ErrMeetingForbidden", err)
+	}
+
+	if _, err := g.decide.Execute(ctx, g.meeting.ID, requested.Entry.ID, "host", true); err != nil {
+		t.Fatalf("admit: %v", err)
+	}
+	if _, err := g.decide.Execute(ctx, g.meeting.ID, requested.Entry.ID, "host", false); !errors.Is(err, entities.ErrLobbyEntryDecided) {
+		t.Fatalf("second decision: err = %v, want ErrLobbyEntryDecided", err)
+	}
+
+	admitted, err := g.status.Execute(ctx, token, requested.Entry.ID, requested.Secret)
+	if err != nil {
+		t.Fatalf("status after admission: %v", err)
+	}
+	if admitted.JitsiToken != "guest-token:guest-"+requested.Entry.ID || admitted.TokenExpiresAt == nil {
+		t.Fatalf("admitted guest got %+v", admitted)
+	}
+
+	// Ending the meeting ends the guest's access
+	g.meetings.meetings[g.meeting.ID].Status = entities.StatusCompleted
+	if _, err := g.status.Execute(ctx, token, requested.Entry.ID, requested.Secret); !errors.Is(err, entities.ErrMeetingNotActive) {
+		t.Fatalf("status after the meeting: err = %v, want ErrMeetingNotActive", err)
+	}
+}
+
+func TestRequestGuestAccess(t *testing.T) {
+	tests := []struct {
+		name       string
+		lobby      bool
+		maxUses    int
+		passcode   string
+		requests   int
+		input      RequestGuestAccessInput
+		want       error
+		wantStatus entities.LobbyStatus
+	}{
+		{"lobby", true, 0, "", 1, RequestGuestAccessInput{DisplayName: "Dana"}, nil, entities.LobbyStatusWaiting},
+		{"no lobby", false, 0, "", 1, RequestGuestAccessInput{DisplayName: "Dana"}, nil, entities.LobbyStatusAdmitted},
+		{"passcode", true, 0, "1234", 1, RequestGuestAccessInput{DisplayName: "Dana", Passcode: "1234"}, nil, entities.LobbyStatusWaiting},
+		{"wrong passcode", true, 0, "1234", 1, RequestGuestAccessInput{DisplayName: "Dana", Passcode: "0000"}, entities.ErrInvalidPasscode, ""},
+		{"used up", true, 1, "", 2, RequestGuestAccessInput{DisplayName: "Dana"}, entities.ErrGuestLinkUnavailable, ""},
+		{"no name", true, 0, "", 1, RequestGuestAccessInput{}, entities.ErrInvalidGuest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGuestAccess(t, tt.lobby)
+			_, token := g.link(t, tt.maxUses, tt.passcode)
+			tt.input.Token = token
+
+			var output *RequestGuestAccessOutput
+			var err error
+			for i := 0; i < tt.requests; i++ {
+				output, err = g.request.Execute(context.Background(), tt.input)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("request: err = %v, want %v", err, tt.want)
+			}
+			if err == nil && output.Entry.Status != tt.wantStatus {
+				t.Fatalf("status = %s, want %s", output.Entry.Status, tt.wantStatus)
+			}
+		})
+	}
+
+	g := newGuestAccess(t, true)
+	if _, err := g.request.Execute(context.Background(), RequestGuestAccessInput{Token: "unknown", DisplayName: "Dana"}); !errors.Is(err, entities.ErrGuestLinkNotFound) {
+		t.Fatalf("unknown token: err = %v, want ErrGuestLinkNotFound", err)
+	}
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// Jitsi (Prosody mod_event_sync) participant event names
+const (
+	JitsiEventOccupantJoined = "muc-occupant-joined"
+	JitsiEventOccupantLeft   = "muc-occupant-left"
+)
+
+// JitsiEvent represents a participant event reported by Jitsi
+type JitsiEvent struct {
+	Name       string
+	RoomName   string
+	UserID     string
+	OccurredAt time.Time
+}
+
+// HandleJitsiEventUseCase records attendance from Jitsi participant events
+type HandleJitsiEventUseCase struct {
+	meetingRepo    repository.MeetingRepository
+	attendanceRepo repository.AttendanceRepository
+}
+
+// NewHandleJitsiEventUseCase creates a new HandleJitsiEventUseCase
+func NewHandleJitsiEventUseCase(meetingRepo repository.MeetingRepository, attendanceRepo repository.AttendanceRepository) *HandleJitsiEventUseCase {
+	return &HandleJitsiEventUseCase{
+		meetingRepo:    meetingRepo,
+		attendanceRepo: attendanceRepo,
+	}
+}
+
+// Execute records the event and reports whether it was applied. Events for
+// unknown rooms, other event types and occupants who are not meeting
+// participants (e.g. anonymous guests) are ignored.
+func (uc *HandleJitsiEventUseCase) Execute(ctx context.Context, event JitsiEvent) (bool, error) {
+	if event.UserID == "" {
+		return false, nil
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	// Prosody reports the room as a JID, e.g. "<room>@conference.<domain>"
+	roomName, _, _ := strings.Cut(event.RoomName, "@")
+
+	// Other errors fail the webhook, so that Jitsi delivers the event again
+	meeting, err := uc.meetingRepo.GetByRoomID(ctx, roomName)
+	if errors.Is(err, entities.ErrMeetingNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch event.Name {
+	case JitsiEventOccupantJoined:
+		participants, err := uc.meetingRepo.ListParticipants(ctx, meeting.ID)
+		if err != nil {
+			return false, err
+		}
+		if !hasParticipant(participants, event.UserID) {
+			return false, nil
+		}
+
+		session := entities.NewAttendanceSession(meeting.ID, event.UserID, entities.AttendanceSourceJitsi, event.OccurredAt)
+		if err := uc.attendanceRepo.OpenSession(ctx, session); err != nil {
+			return false, err
+		}
+		return true, nil
+
+	case JitsiEventOccupantLeft:
+		closed, err := uc.attendanceRepo.CloseSessions(ctx, meeting.ID, event.UserID, entities.AttendanceSourceJitsi, event.OccurredAt)
+		if err != nil {
+			return false, err
+		}
+		return closed > 0, nil
+	}
+
+	return false, nil
+}
+
+func hasParticipant(participants []*entities.Participant, userID string) bool {
+	for _, participant := range participants {
+		if participant.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
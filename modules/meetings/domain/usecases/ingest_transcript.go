@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// Summarizer generates summaries of meeting transcripts
+type Summarizer interface {
+	Summarize(ctx context.Context, segments []entities.TranscriptSegment) (*entities.Summary, error)
+}
+
+// IngestTranscriptUseCase handles storing meeting transcripts
+type IngestTranscriptUseCase struct {
+	meetingRepo    repository.MeetingRepository
+	transcriptRepo repository.TranscriptRepository
+	summarizer     Summarizer
+}
+
+// NewIngestTranscriptUseCase creates a new IngestTranscriptUseCase
+func NewIngestTranscriptUseCase(meetingRepo repository.MeetingRepository, transcriptRepo repository.TranscriptRepository, summarizer Summarizer) *IngestTranscriptUseCase {
+	return &IngestTranscriptUseCase{
+		meetingRepo:    meetingRepo,
+		transcriptRepo: transcriptRepo,
+		summarizer:     summarizer,
+	}
+}
+
+// IngestTranscriptInput represents transcript ingestion input
+type IngestTranscriptInput struct {
+	MeetingID string
+	UserID    string
+	Format    entities.TranscriptFormat
+	Data      []byte
+}
+
+// IngestTranscriptOutput represents the stored transcript and its summary.
+// Summary is nil if summarizing failed.
+type IngestTranscriptOutput struct {
+	Transcript *entities.Transcript
+	Summary    *entities.Summary
+}
+
+// Execute parses and stores a transcript, replacing any earlier one, and
+// summarizes it. Only hosts may ingest transcripts.
+func (uc *IngestTranscriptUseCase) Execute(ctx context.Context, input IngestTranscriptInput) (*IngestTranscriptOutput, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, input.MeetingID, input.UserID, true); err != nil {
+		return nil, err
+	}
+
+	transcript, err := entities.NewTranscript(input.MeetingID, input.UserID, input.Format, input.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.transcriptRepo.SaveTranscript(ctx, transcript); err != nil {
+		return nil, err
+	}
+
+	output := &IngestTranscriptOutput{Transcript: transcript}
+
+	// The transcript is useful without a summary, so summarizing failures
+	// are logged rather than failing the request
+	summary, err := uc.summarizer.Summarize(ctx, transcript.Segments)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to summarize meeting transcript", "meeting_id", input.MeetingID, "error", err)
+		return output, nil
+	}
+
+	summary.MeetingID = input.MeetingID
+	summary.CreatedAt = time.Now()
+	if err := uc.transcriptRepo.SaveSummary(ctx, summary); err != nil {
+		logging.FromContext(ctx).Warn("failed to save meeting summary", "meeting_id", input.MeetingID, "error", err)
+		return output, nil
+	}
+
+	output.Summary = summary
+	return output, nil
+}
@@ -3,18 +3,27 @@ package usecases
 import (
 	"context"
 	"errors"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/metrics"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
 )
 
 // JitsiService defines Jitsi-related operations
 type JitsiService interface {
-	CreateRoomToken(roomName, userID, userName, userEmail string, moderator bool) (string, error)
+	CreateRoomToken(roomName, userID, userName, userEmail string, moderator bool, settings entities.RoomSettings) (string, error)
 	GetRoomURL(roomName string) string
+	GetConfiguredRoomURL(roomName string, settings entities.RoomSettings) string
 }
 
 // JoinMeetingUseCase handles joining a meeting
 type JoinMeetingUseCase struct {
-	meetingRepo  MeetingRepository
-	jitsiService JitsiService
+	meetingRepo    MeetingRepository
+	attendanceRepo repository.AttendanceRepository
+	jitsiService   JitsiService
+	participants   ParticipantLister
+	publisher      EventPublisher
 }
 
 // MeetingRepository interface for this use case
@@ -30,36 +39,61 @@ type MeetingEntity struct {
 	OrganizerID  string
 	Status       string
 	JitsiRoomURL string
+	Settings     entities.RoomSettings
 }
 
 // NewJoinMeetingUseCase creates a new JoinMeetingUseCase
-func NewJoinMeetingUseCase(meetingRepo MeetingRepository, jitsiService JitsiService) *JoinMeetingUseCase {
+func NewJoinMeetingUseCase(
+	meetingRepo MeetingRepository,
+	attendanceRepo repository.AttendanceRepository,
+	jitsiService JitsiService,
+	participants ParticipantLister,
+	publisher EventPublisher,
+) *JoinMeetingUseCase {
 	return &JoinMeetingUseCase{
-		meetingRepo:  meetingRepo,
-		jitsiService: jitsiService,
+		meetingRepo:    meetingRepo,
+		attendanceRepo: attendanceRepo,
+		jitsiService:   jitsiService,
+		participants:   participants,
+		publisher:      publisher,
 	}
 }
 
-// JoinOutput represents join meeting output
+// JoinOutput represents join meeting output. RoomURL applies the room
+// settings in the browser; JitsiToken carries the user's permissions.
 type JoinOutput struct {
-	MeetingID string
-	RoomURL   string
-	UserName  string
-	UserEmail string
+	MeetingID  string
+	RoomURL    string
+	JitsiToken string
+	Moderator  bool
+	Settings   entities.RoomSettings
+	UserName   string
+	UserEmail  string
 }
 
-// Execute joins a meeting
+// Execute joins a meeting. Only the organizer and invited participants may
+// join; guests are admitted through the lobby instead.
 func (uc *JoinMeetingUseCase) Execute(ctx context.Context, meetingID, userID, userName, userEmail string) (*JoinOutput, error) {
 	meeting, err := uc.meetingRepo.GetByID(ctx, meetingID)
 	if err != nil {
 		return nil, err
 	}
 
+	if meeting.OrganizerID != userID {
+		participants, err := uc.participants.ListParticipants(ctx, meetingID)
+		if err != nil {
+			return nil, err
+		}
+		if !hasParticipant(participants, userID) {
+			return nil, ErrMeetingForbidden
+		}
+	}
+
 	if meeting.Status != "scheduled" && meeting.Status != "ongoing" {
 		return nil, errors.New("meeting is not active")
 	}
 
-	// Get room URL (no JWT needed for public Jitsi)
+	// Get room URL
 	roomURL := uc.jitsiService.GetRoomURL(meeting.RoomID)
 
 	// Update meeting status to ongoing if it's scheduled
@@ -69,12 +103,34 @@ func (uc *JoinMeetingUseCase) Execute(ctx context.Context, meetingID, userID, us
 		if err := uc.meetingRepo.Update(ctx, meeting); err != nil {
 			return nil, err
 		}
+
+		publishStatusChange(ctx, uc.participants, uc.publisher, &entities.StatusChange{
+			MeetingID:   meeting.ID,
+			OrganizerID: meeting.OrganizerID,
+			Status:      entities.StatusOngoing,
+			ChangedAt:   time.Now(),
+		})
+	}
+
+	moderator := meeting.OrganizerID == userID
+	jitsiToken, err := uc.jitsiService.CreateRoomToken(meeting.RoomID, userID, userName, userEmail, moderator, meeting.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	session := entities.NewAttendanceSession(meetingID, userID, entities.AttendanceSourceApp, time.Now())
+	if err := uc.attendanceRepo.OpenSession(ctx, session); err != nil {
+		return nil, err
 	}
+	metrics.MeetingsJoined.Inc()
 
 	return &JoinOutput{
-		MeetingID: meetingID,
-		RoomURL:   roomURL,
-		UserName:  userName,
-		UserEmail: userEmail,
+		MeetingID:  meetingID,
+		RoomURL:    uc.jitsiService.GetConfiguredRoomURL(meeting.RoomID, meeting.Settings),
+		JitsiToken: jitsiToken,
+		Moderator:  moderator,
+		Settings:   meeting.Settings,
+		UserName:   userName,
+		UserEmail:  userEmail,
 	}, nil
 }
@@ -0,0 +1,174 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// joinMeetingRepo exposes fakeMeetingRepo through the join use case's
+// MeetingRepository, like the adapter of the container
+type joinMeetingRepo struct {
+	meetings *fakeMeetingRepo
+}
+
+func (r joinMeetingRepo) GetByID(ctx context.Context, id string) (*MeetingEntity, error) {
+	meeting, err := r.meetings.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &MeetingEntity{
+		ID:           meeting.ID,
+		RoomID:       meeting.RoomID,
+		OrganizerID:  meeting.OrganizerID,
+		Status:       string(meeting.Status),
+		JitsiRoomURL: meeting.JitsiRoomURL,
+		Settings:     meeting.Settings,
+	}, nil
+}
+
+func (r joinMeetingRepo) Update(ctx context.Context, entity *MeetingEntity) error {
+	meeting := r.meetings.meetings[entity.ID]
+	meeting.Status = entities.MeetingStatus(entity.Status)
+	meeting.JitsiRoomURL = entity.JitsiRoomURL
+	return nil
+}
+
+// fakeJitsi issues tokens naming the user and their role
+type fakeJitsi struct{}
+
+func (fakeJitsi) CreateRoomToken(roomName, userID, userName, userEmail string, moderator bool, settings entities.RoomSettings) (string, error) {
+	if moderator {
+		return "moderator:" + userID, nil
+	}
+	return "participant:" + userID, nil
+}
+
+func (fakeJitsi) GetRoomURL(roomName string) string {
+	return "https://meet.example.com/" + roomName
+}
+
+func (fakeJitsi) GetConfiguredRoomURL(roomName string, settings entities.RoomSettings) string {
+	return "https://meet.example.com/" + roomName + "#config"
+}
+
+func TestJoinMeeting(t *testing.T) {
+	tests := []struct {
+		name          string
+		userID        string
+		status        entities.MeetingStatus
+		want          error
+		wantInactive  bool
+		wantModerator bool
+	}{
+		{"organizer", "host", entities.StatusScheduled, nil, false, true},
+		{"invited participant", "guest", entities.StatusOngoing, nil, false, false},
+		{"stranger", "stranger", entities.StatusOngoing, ErrMeetingForbidden, false, false},
+		{"cancelled meeting", "guest", entities.StatusCancelled, nil, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meetings := newFakeMeetingRepo()
+			meeting := meetings.addMeeting(t, "host", "guest")
+			meeting.Status = tt.status
+			attendance := &fakeAttendanceRepo{}
+			publisher := &fakePublisher{}
+			uc := NewJoinMeetingUseCase(joinMeetingRepo{meetings}, attendance, fakeJitsi{}, meetings, publisher)
+
+			output, err := uc.Execute(context.Background(), meeting.ID, tt.userID, "Name", "name@example.com")
+			if tt.wantInactive {
+				if err == nil || len(attendance.sessions) != 0 {
+					t.Fatal("joined an inactive meeting")
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute: err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				if len(attendance.sessions) != 0 || len(meetings.participants[meeting.ID]) != 2 {
+					t.Fatalf("rejected join left attendance or participants behind")
+				}
+				return
+			}
+
+			if output.Moderator != tt.wantModerator {
+				t.Fatalf("moderator = %v, want %v", output.Moderator, tt.wantModerator)
+			}
+			if len(attendance.sessions) != 1 || attendance.sessions[0].UserID != tt.userID || attendance.sessions[0].Source != entities.AttendanceSourceApp {
+				t.Fatalf("attendance sessions = %+v, want one app session", attendance.sessions)
+			}
+			if stored := meetings.meetings[meeting.ID]; stored.Status != entities.StatusOngoing {
+				t.Fatalf("meeting status = %s, want ongoing", stored.Status)
+			}
+			if started := tt.status == entities.StatusScheduled; started != (len(publisher.events) == 1) {
+				t.Fatalf("published %d status changes", len(publisher.events))
+			}
+		})
+	}
+
+	meetings := newFakeMeetingRepo()
+	uc := NewJoinMeetingUseCase(joinMeetingRepo{meetings}, &fakeAttendanceRepo{}, fakeJitsi{}, meetings, &fakePublisher{})
+	if _, err := uc.Execute(context.Background(), "missing", "host", "", ""); !errors.Is(err, entities.ErrMeetingNotFound) {
+		t.Fatalf("join a missing meeting: err = %v, want ErrMeetingNotFound", err)
+	}
+}
+
+func TestHandleJitsiEvent(t *testing.T) {
+	meetings := newFakeMeetingRepo()
+	meeting := meetings.addMeeting(t, "host", "guest")
+	broken := meetings.addMeeting(t, "host")
+	meetings.participantsErr[broken.ID] = errors.New("connection reset")
+	room := meeting.RoomID + "@conference.meet.example.com"
+
+	attendance := &fakeAttendanceRepo{}
+	uc := NewHandleJitsiEventUseCase(meetings, attendance)
+	joinedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		event       JitsiEvent
+		wantApplied bool
+		wantErr     bool
+		wantOpen    int
+	}{
+		{"anonymous occupant", JitsiEvent{Name: JitsiEventOccupantJoined, RoomName: room}, false, false, 0},
+		{"unknown room", JitsiEvent{Name: JitsiEventOccupantJoined, RoomName: "other@conference", UserID: "guest"}, false, false, 0},
+		{"not a participant", JitsiEvent{Name: JitsiEventOccupantJoined, RoomName: room, UserID: "stranger"}, false, false, 0},
+		{"participant lookup fails", JitsiEvent{Name: JitsiEventOccupantJoined, RoomName: broken.RoomID, UserID: "host"}, false, true, 0},
+		{"join", JitsiEvent{Name: JitsiEventOccupantJoined, RoomName: room, UserID: "guest", OccurredAt: joinedAt}, true, false, 1},
+		{"join reported twice", JitsiEvent{Name: JitsiEventOccupantJoined, RoomName: room, UserID: "guest"}, true, false, 1},
+		{"other event", JitsiEvent{Name: "muc-room-created", RoomName: room, UserID: "guest"}, false, false, 1},
+		{"leave", JitsiEvent{Name: JitsiEventOccupantLeft, RoomName: room, UserID: "guest"}, true, false, 0},
+		{"leave without a session", JitsiEvent{Name: JitsiEventOccupantLeft, RoomName: room, UserID: "guest"}, false, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied, err := uc.Execute(context.Background(), tt.event)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute: err = %v, want error %v", err, tt.wantErr)
+			}
+			if applied != tt.wantApplied {
+				t.Fatalf("applied = %v, want %v", applied, tt.wantApplied)
+			}
+			open := 0
+			for _, session := range attendance.sessions {
+				if session.LeftAt == nil {
+					open++
+				}
+			}
+			if open != tt.wantOpen {
+				t.Fatalf("%d open sessions, want %d", open, tt.wantOpen)
+			}
+		})
+	}
+
+	if first := attendance.sessions[0]; !first.JoinedAt.Equal(joinedAt) || first.Source != entities.AttendanceSourceJitsi {
+		t.Fatalf("session = %+v, want a Jitsi session joined at the event time", first)
+	}
+}
@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+var ErrNotInMeeting = errors.New("user is not in the meeting")
+
+// LeaveMeetingUseCase handles leaving a meeting
+type LeaveMeetingUseCase struct {
+	attendanceRepo repository.AttendanceRepository
+}
+
+// NewLeaveMeetingUseCase creates a new LeaveMeetingUseCase
+func NewLeaveMeetingUseCase(attendanceRepo repository.AttendanceRepository) *LeaveMeetingUseCase {
+	return &LeaveMeetingUseCase{attendanceRepo: attendanceRepo}
+}
+
+// Execute records that a user left a meeting
+func (uc *LeaveMeetingUseCase) Execute(ctx context.Context, meetingID, userID string) error {
+	closed, err := uc.attendanceRepo.CloseSessions(ctx, meetingID, userID, "", time.Now())
+	if err != nil {
+		return err
+	}
+	if closed == 0 {
+		return ErrNotInMeeting
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// ListActionItemsUseCase handles listing the action items of a meeting
+type ListActionItemsUseCase struct {
+	meetingRepo repository.MeetingRepository
+	minutesRepo repository.MinutesRepository
+}
+
+// NewListActionItemsUseCase creates a new ListActionItemsUseCase
+func NewListActionItemsUseCase(meetingRepo repository.MeetingRepository, minutesRepo repository.MinutesRepository) *ListActionItemsUseCase {
+	return &ListActionItemsUseCase{
+		meetingRepo: meetingRepo,
+		minutesRepo: minutesRepo,
+	}
+}
+
+// Execute lists the action items of a meeting for one of its participants
+func (uc *ListActionItemsUseCase) Execute(ctx context.Context, meetingID, userID string) ([]*entities.ActionItem, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.minutesRepo.ListActionItems(ctx, meetingID)
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// ListGuestLinksUseCase handles listing the guest links of a meeting
+type ListGuestLinksUseCase struct {
+	meetingRepo repository.MeetingRepository
+	guestRepo   repository.GuestRepository
+}
+
+// NewListGuestLinksUseCase creates a new ListGuestLinksUseCase
+func NewListGuestLinksUseCase(meetingRepo repository.MeetingRepository, guestRepo repository.GuestRepository) *ListGuestLinksUseCase {
+	return &ListGuestLinksUseCase{
+		meetingRepo: meetingRepo,
+		guestRepo:   guestRepo,
+	}
+}
+
+// Execute lists the guest links of a meeting for its hosts
+func (uc *ListGuestLinksUseCase) Execute(ctx context.Context, meetingID, userID string) ([]*entities.GuestLink, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true); err != nil {
+		return nil, err
+	}
+
+	return uc.guestRepo.ListLinks(ctx, meetingID)
+}
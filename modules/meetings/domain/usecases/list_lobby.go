@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// ListLobbyUseCase handles listing guests waiting to be admitted
+type ListLobbyUseCase struct {
+	meetingRepo repository.MeetingRepository
+	guestRepo   repository.GuestRepository
+}
+
+// NewListLobbyUseCase creates a new ListLobbyUseCase
+func NewListLobbyUseCase(meetingRepo repository.MeetingRepository, guestRepo repository.GuestRepository) *ListLobbyUseCase {
+	return &ListLobbyUseCase{
+		meetingRepo: meetingRepo,
+		guestRepo:   guestRepo,
+	}
+}
+
+// Execute lists the waiting guests of a meeting for its hosts
+func (uc *ListLobbyUseCase) Execute(ctx context.Context, meetingID, userID string) ([]*entities.LobbyEntry, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true); err != nil {
+		return nil, err
+	}
+
+	return uc.guestRepo.ListLobby(ctx, meetingID, entities.LobbyStatusWaiting)
+}
@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// ListMyActionItemsUseCase handles listing the action items assigned to a user
+type ListMyActionItemsUseCase struct {
+	minutesRepo repository.MinutesRepository
+}
+
+// NewListMyActionItemsUseCase creates a new ListMyActionItemsUseCase
+func NewListMyActionItemsUseCase(minutesRepo repository.MinutesRepository) *ListMyActionItemsUseCase {
+	return &ListMyActionItemsUseCase{minutesRepo: minutesRepo}
+}
+
+// Execute lists the user's action items, by default only the open ones
+func (uc *ListMyActionItemsUseCase) Execute(ctx context.Context, userID string, includeClosed bool) ([]*entities.ActionItem, error) {
+	return uc.minutesRepo.ListAssignedActionItems(ctx, userID, !includeClosed)
+}
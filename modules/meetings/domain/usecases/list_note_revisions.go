@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// ListNoteRevisionsUseCase handles retrieving the notes history of a meeting
+type ListNoteRevisionsUseCase struct {
+	meetingRepo repository.MeetingRepository
+	minutesRepo repository.MinutesRepository
+}
+
+// NewListNoteRevisionsUseCase creates a new ListNoteRevisionsUseCase
+func NewListNoteRevisionsUseCase(meetingRepo repository.MeetingRepository, minutesRepo repository.MinutesRepository) *ListNoteRevisionsUseCase {
+	return &ListNoteRevisionsUseCase{
+		meetingRepo: meetingRepo,
+		minutesRepo: minutesRepo,
+	}
+}
+
+// Execute lists notes revisions, newest first
+func (uc *ListNoteRevisionsUseCase) Execute(ctx context.Context, meetingID, userID string) ([]*entities.NoteRevision, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.minutesRepo.ListNoteRevisions(ctx, meetingID)
+}
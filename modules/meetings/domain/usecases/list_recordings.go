@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// ListRecordingsUseCase handles listing meeting recordings
+type ListRecordingsUseCase struct {
+	meetingRepo   repository.MeetingRepository
+	recordingRepo repository.RecordingRepository
+}
+
+// NewListRecordingsUseCase creates a new ListRecordingsUseCase
+func NewListRecordingsUseCase(meetingRepo repository.MeetingRepository, recordingRepo repository.RecordingRepository) *ListRecordingsUseCase {
+	return &ListRecordingsUseCase{
+		meetingRepo:   meetingRepo,
+		recordingRepo: recordingRepo,
+	}
+}
+
+// Execute lists the recordings of a meeting for one of its participants
+func (uc *ListRecordingsUseCase) Execute(ctx context.Context, meetingID, userID string) ([]*entities.Recording, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, false); err != nil {
+		return nil, err
+	}
+
+	return uc.recordingRepo.ListByMeeting(ctx, meetingID)
+}
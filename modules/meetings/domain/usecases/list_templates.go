@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// ListTemplatesUseCase handles listing meeting templates
+type ListTemplatesUseCase struct {
+	templateRepo repository.TemplateRepository
+}
+
+// NewListTemplatesUseCase creates a new ListTemplatesUseCase
+func NewListTemplatesUseCase(templateRepo repository.TemplateRepository) *ListTemplatesUseCase {
+	return &ListTemplatesUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute lists the user's own templates and the shared templates of others
+func (uc *ListTemplatesUseCase) Execute(ctx context.Context, userID string) ([]*entities.MeetingTemplate, error) {
+	return uc.templateRepo.ListVisible(ctx, userID)
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+var ErrMeetingForbidden = errors.New("not allowed to access this meeting")
+
+// authorizeParticipant checks that the user is the organizer or a participant
+// of the meeting. With hostOnly, participants must have the host role.
+func authorizeParticipant(ctx context.Context, meetingRepo repository.MeetingRepository, meetingID, userID string, hostOnly bool) (*entities.Meeting, error) {
+	meeting, err := meetingRepo.GetByID(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	if meeting.OrganizerID == userID {
+		return meeting, nil
+	}
+
+	participants, err := meetingRepo.ListParticipants(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	for _, participant := range participants {
+		if participant.UserID != userID {
+			continue
+		}
+		if !hostOnly || participant.Role == entities.ParticipantRoleHost {
+			return meeting, nil
+		}
+	}
+
+	return nil, ErrMeetingForbidden
+}
+
+// uploadingRecording loads a recording whose upload is still in progress
+func uploadingRecording(ctx context.Context, recordingRepo repository.RecordingRepository, meetingID, recordingID string) (*entities.Recording, error) {
+	recording, err := recordingRepo.GetByID(ctx, meetingID, recordingID)
+	if err != nil {
+		return nil, err
+	}
+	if recording.Status != entities.RecordingStatusUploading {
+		return nil, entities.ErrRecordingNotUploading
+	}
+	return recording, nil
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// EventPublisher pushes live events to the connected clients of users
+type EventPublisher interface {
+	Publish(ctx context.Context, userIDs []string, eventType string, data interface{}) error
+}
+
+// ParticipantLister lists the participants of a meeting
+type ParticipantLister interface {
+	ListParticipants(ctx context.Context, meetingID string) ([]*entities.Participant, error)
+}
+
+// eventMeetingStatusChanged matches realtime.EventMeetingStatusChanged
+const eventMeetingStatusChanged = "meeting.status_changed"
+
+// publishStatusChange pushes a status change to the organizer and
+// participants of the meeting. The change is already stored, so failures
+// are logged rather than returned.
+func publishStatusChange(ctx context.Context, participants ParticipantLister, publisher EventPublisher, change *entities.StatusChange) {
+	list, err := participants.ListParticipants(ctx, change.MeetingID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to list participants for meeting status event", "meeting_id", change.MeetingID, "error", err)
+		return
+	}
+
+	userIDs := []string{change.OrganizerID}
+	for _, participant := range list {
+		if participant.UserID != change.OrganizerID {
+			userIDs = append(userIDs, participant.UserID)
+		}
+	}
+
+	data := map[string]interface{}{
+		"meeting_id": change.MeetingID,
+		"status":     change.Status,
+		"changed_at": change.ChangedAt,
+	}
+	if err := publisher.Publish(ctx, userIDs, eventMeetingStatusChanged, data); err != nil {
+		logging.FromContext(ctx).Warn("failed to publish meeting status", "meeting_id", change.MeetingID, "error", err)
+	}
+}
@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+func TestSetAgendaIsHostOnly(t *testing.T) {
+	meetings := newFakeMeetingRepo()
+	meeting := meetings.addMeeting(t, "organizer", "member")
+	meetings.participants[meeting.ID] = append(meetings.participants[meeting.ID],
+		entities.NewParticipant(meeting.ID, "cohost", entities.ParticipantRoleHost))
+	minutes := newFakeMinutesRepo()
+	uc := NewSetAgendaUseCase(meetings, minutes)
+
+	items := []AgendaItemInput{{Title: "Intro", DurationMinutes: 5}, {Title: "Roadmap", DurationMinutes: 20}}
+
+	tests := []struct {
+		userID string
+		items  []AgendaItemInput
+		want   error
+	}{
+		{"organizer", items, nil},
+		{"cohost", items, nil},
+		{"member", items, ErrMeetingForbidden},
+		{"stranger", items, ErrMeetingForbidden},
+		{"organizer", []AgendaItemInput{{Title: "Intro"}, {Title: " "}}, entities.ErrInvalidAgenda},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.userID, func(t *testing.T) {
+			minutes.agendas = make(map[string][]*entities.AgendaItem)
+			agenda, err := uc.Execute(context.Background(), meeting.ID, tt.userID, tt.items)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute: err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				if len(minutes.agendas) != 0 {
+					t.Fatal("rejected agenda was stored")
+				}
+				return
+			}
+			if len(agenda) != 2 || agenda[0].Position != 1 || agenda[1].Position != 2 || len(minutes.agendas[meeting.ID]) != 2 {
+				t.Fatalf("agenda = %+v", agenda)
+			}
+		})
+	}
+}
+
+func TestUpdateNotesRevisions(t *testing.T) {
+	meetings := newFakeMeetingRepo()
+	meeting := meetings.addMeeting(t, "alice", "bob")
+	minutes := newFakeMinutesRepo()
+	uc := NewUpdateNotesUseCase(meetings, minutes)
+
+	tests := []struct {
+		name         string
+		userID       string
+		baseRevision int
+		content      string
+		wantRevision int
+		wantErr      error
+		wantCurrent  string
+	}{
+		{"first edit", "alice", 0, "v1", 1, nil, ""},
+		{"next edit", "bob", 1, "v2", 2, nil, ""},
+		{"stale edit", "alice", 1, "v2 by alice", 0, entities.ErrNotesConflict, "v2"},
+		{"stranger", "mallory", 2, "spam", 0, ErrMeetingForbidden, ""},
+		{"merged edit", "alice", 2, "v3", 3, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revision, err := uc.Execute(context.Background(), UpdateNotesInput{
+				MeetingID:    meeting.ID,
+				UserID:       tt.userID,
+				BaseRevision: tt.baseRevision,
+				Content:      tt.content,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute: err = %v, want %v", err, tt.wantErr)
+			}
+
+			var conflict *NotesConflictError
+			if errors.As(err, &conflict) && conflict.Current.Content != tt.wantCurrent {
+				t.Fatalf("conflict carries %q, want the current notes %q", conflict.Current.Content, tt.wantCurrent)
+			}
+			if err == nil && (revision.Revision != tt.wantRevision || revision.EditedBy != tt.userID) {
+				t.Fatalf("revision = %+v, want revision %d", revision, tt.wantRevision)
+			}
+		})
+	}
+}
+
+func TestUpdateActionItemReassigns(t *testing.T) {
+	meetings := newFakeMeetingRepo()
+	meeting := meetings.addMeeting(t, "alice", "bob", "carol")
+	minutes := newFakeMinutesRepo()
+	item, err := entities.NewActionItem(meeting.ID, "Send notes", "", "bob", nil, "alice")
+	if err != nil {
+		t.Fatalf("NewActionItem: %v", err)
+	}
+	minutes.CreateActionItem(context.Background(), item)
+
+	str := func(s string) *string { return &s }
+	status := func(s entities.ActionItemStatus) *entities.ActionItemStatus { return &s }
+
+	tests := []struct {
+		name         string
+		userID       string
+		input        UpdateActionItemInput
+		want         error
+		wantAssignee string
+		wantNotified []string
+	}{
+		{"to a participant", "alice", UpdateActionItemInput{AssigneeID: str("carol")}, nil, "carol", []string{"carol"}},
+		{"to the organizer by the organizer", "alice", UpdateActionItemInput{AssigneeID: str("alice")}, nil, "alice", nil},
+		{"to a stranger", "alice", UpdateActionItemInput{AssigneeID: str("mallory")}, entities.ErrInvalidActionItem, "alice", nil},
+		{"blank title", "bob", UpdateActionItemInput{Title: str(" ")}, entities.ErrInvalidActionItem, "alice", nil},
+		{"closed item", "bob", UpdateActionItemInput{Status: status(entities.ActionItemDone), AssigneeID: str("bob")}, nil, "bob", nil},
+		{"by a stranger", "mallory", UpdateActionItemInput{AssigneeID: str("carol")}, ErrMeetingForbidden, "bob", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &fakeNotifier{}
+			uc := NewUpdateActionItemUseCase(meetings, minutes, notifier)
+
+			tt.input.MeetingID = meeting.ID
+			tt.input.ItemID = item.ID
+			tt.input.UserID = tt.userID
+			if _, err := uc.Execute(context.Background(), tt.input); !errors.Is(err, tt.want) {
+				t.Fatalf("Execute: err = %v, want %v", err, tt.want)
+			}
+
+			if stored := minutes.actionItems[item.ID]; stored.AssigneeID != tt.wantAssignee {
+				t.Fatalf("assignee = %s, want %s", stored.AssigneeID, tt.wantAssignee)
+			}
+			if len(notifier.sent) != len(tt.wantNotified) {
+				t.Fatalf("notified %v, want %v", notifier.sent, tt.wantNotified)
+			}
+			for _, userID := range tt.wantNotified {
+				if len(notifier.sent[userID]) != 1 {
+					t.Fatalf("%s was not notified", userID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/manab-pr/evtaarpro/internal/storage"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// recordingUpload wires the recording upload use cases to a LocalStore
+type recordingUpload struct {
+	meetings   *fakeMeetingRepo
+	recordings *fakeRecordingRepo
+	store      *storage.LocalStore
+
+	start    *StartRecordingUploadUseCase
+	part     *UploadRecordingPartUseCase
+	status   *GetRecordingUploadUseCase
+	complete *CompleteRecordingUploadUseCase
+	abort    *AbortRecordingUploadUseCase
+}
+
+func newRecordingUpload(t *testing.T, maxSize int64) *recordingUpload {
+	t.Helper()
+	store, err := storage.NewLocalStore(t.TempDir(), "http://localhost/files", "test-signing-key")
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	meetings := newFakeMeetingRepo()
+	recordings := newFakeRecordingRepo()
+
+	return &recordingUpload{
+		meetings:   meetings,
+		recordings: recordings,
+		store:      store,
+		start:      NewStartRecordingUploadUseCase(meetings, recordings, store, "recordings/", maxSize),
+		part:       NewUploadRecordingPartUseCase(meetings, recordings, store),
+		status:     NewGetRecordingUploadUseCase(meetings, recordings, store),
+		complete:   NewCompleteRecordingUploadUseCase(meetings, recordings, store),
+		abort:      NewAbortRecordingUploadUseCase(meetings, recordings, store),
+	}
+}
+
+func (u *recordingUpload) uploadPart(ctx context.Context, recording *entities.Recording, userID string, number int, data []byte) error {
+	_, err := u.part.Execute(ctx, UploadPartInput{
+		MeetingID:   recording.MeetingID,
+		RecordingID: recording.ID,
+		UserID:      userID,
+		PartNumber:  number,
+		Body:        bytes.NewReader(data),
+		Size:        int64(len(data)),
+	})
+	return err
+}
+
+func TestRecordingUploadResumeAndComplete(t *testing.T) {
+	ctx := context.Background()
+	u := newRecordingUpload(t, 100<<20)
+	meeting := u.meetings.addMeeting(t, "host")
+
+	first := bytes.Repeat([]byte("r"), storage.MinPartSize)
+	last := []byte("end of recording")
+	size := int64(len(first) + len(last))
+
+	recording, err := u.start.Execute(ctx, StartUploadInput{
+		MeetingID:   meeting.ID,
+		UserID:      "host",
+		FileName:    "C:\\videos\\standup.webm",
+		ContentType: "video/webm",
+		SizeBytes:   size,
+	})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if recording.FileName != "standup.webm" || recording.UploadID == "" {
+		t.Fatalf("start returned %+v", recording)
+	}
+
+	if err := u.uploadPart(ctx, recording, "host", 1, first); err != nil {
+		t.Fatalf("part 1: %v", err)
+	}
+
+	// The client lost its connection and asks where to resume
+	status, err := u.status.Execute(ctx, meeting.ID, recording.ID, "host")
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if len(status.Parts) != 1 || status.Parts[0].Number != 1 || status.UploadedBytes != int64(len(first)) {
+		t.Fatalf("status = %+v, want part 1 with %d bytes", status, len(first))
+	}
+
+	if _, err := u.complete.Execute(ctx, meeting.ID, recording.ID, "host"); !errors.Is(err, entities.ErrRecordingIncomplete) {
+		t.Fatalf("complete before the last part: err = %v, want ErrRecordingIncomplete", err)
+	}
+
+	if err := u.uploadPart(ctx, recording, "host", 2, last); err != nil {
+		t.Fatalf("part 2: %v", err)
+	}
+
+	completed, err := u.complete.Execute(ctx, meeting.ID, recording.ID, "host")
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if completed.Status != entities.RecordingStatusAvailable || completed.UploadID != "" || completed.CompletedAt == nil {
+		t.Fatalf("completed recording = %+v", completed)
+	}
+	if stored := u.recordings.recordings[recording.ID]; stored.Status != entities.RecordingStatusAvailable {
+		t.Fatalf("stored status = %s, want available", stored.Status)
+	}
+	if _, err := u.store.SignedURL(ctx, recording.ObjectKey, 0); err != nil {
+		t.Fatalf("object missing after completion: %v", err)
+	}
+
+	if _, err := u.status.Execute(ctx, meeting.ID, recording.ID, "host"); !errors.Is(err, entities.ErrRecordingNotUploading) {
+		t.Fatalf("status after completion: err = %v, want ErrRecordingNotUploading", err)
+	}
+}
+
+func TestRecordingUploadSizeLimits(t *testing.T) {
+	ctx := context.Background()
+	u := newRecordingUpload(t, 1024)
+	meeting := u.meetings.addMeeting(t, "host")
+
+	start := func(size int64) (*entities.Recording, error) {
+		return u.start.Execute(ctx, StartUploadInput{
+			MeetingID: meeting.ID,
+			UserID:    "host",
+			FileName:  "standup.webm",
+			SizeBytes: size,
+		})
+	}
+
+	if _, err := start(1025); !errors.Is(err, entities.ErrRecordingTooLarge) {
+		t.Fatalf("start above the maximum: err = %v, want ErrRecordingTooLarge", err)
+	}
+	if len(u.recordings.recordings) != 0 {
+		t.Fatalf("rejected recording was stored")
+	}
+
+	recording, err := start(10)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		number int
+		data   []byte
+		want   error
+	}{
+		{"larger than declared", 1, make([]byte, 11), entities.ErrRecordingTooLarge},
+		{"empty part", 1, nil, storage.ErrInvalidPart},
+		{"fits", 1, make([]byte, 6), nil},
+		{"replacing a part frees its bytes", 1, make([]byte, 8), nil},
+		{"second part over the remainder", 2, make([]byte, 3), entities.ErrRecordingTooLarge},
+		{"second part within the remainder", 2, make([]byte, 2), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.uploadPart(ctx, recording, "host", tt.number, tt.data)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("upload part: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordingUploadAbort(t *testing.T) {
+	ctx := context.Background()
+	u := newRecordingUpload(t, 0)
+	meeting := u.meetings.addMeeting(t, "host")
+
+	recording, err := u.start.Execute(ctx, StartUploadInput{
+		MeetingID: meeting.ID,
+		UserID:    "host",
+		FileName:  "standup.webm",
+		SizeBytes: 4,
+	})
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := u.uploadPart(ctx, recording, "host", 1, []byte("data")); err != nil {
+		t.Fatalf("part: %v", err)
+	}
+
+	if err := u.abort.Execute(ctx, meeting.ID, recording.ID, "host"); err != nil {
+		t.Fatalf("abort: %v", err)
+	}
+	if _, ok := u.recordings.recordings[recording.ID]; ok {
+		t.Fatalf("aborted recording is still stored")
+	}
+	if _, err := u.store.ListParts(ctx, recording.ObjectKey, recording.UploadID); !errors.Is(err, storage.ErrUploadMissing) {
+		t.Fatalf("parts after abort: err = %v, want ErrUploadMissing", err)
+	}
+}
+
+func TestRecordingUploadAuthorization(t *testing.T) {
+	ctx := context.Background()
+	u := newRecordingUpload(t, 0)
+	meeting := u.meetings.addMeeting(t, "host", "guest")
+
+	input := StartUploadInput{MeetingID: meeting.ID, FileName: "standup.webm", SizeBytes: 4}
+
+	for _, userID := range []string{"guest", "stranger"} {
+		input.UserID = userID
+		if _, err := u.start.Execute(ctx, input); !errors.Is(err, ErrMeetingForbidden) {
+			t.Fatalf("start by %s: err = %v, want ErrMeetingForbidden", userID, err)
+		}
+	}
+
+	u.meetings.meetings[meeting.ID].Settings.RecordingAllowed = false
+	input.UserID = "host"
+	if _, err := u.start.Execute(ctx, input); !errors.Is(err, entities.ErrRecordingNotAllowed) {
+		t.Fatalf("start with recording disabled: err = %v, want ErrRecordingNotAllowed", err)
+	}
+}
+
+// failingRecordingRepo cannot store recordings
+type failingRecordingRepo struct {
+	*fakeRecordingRepo
+}
+
+func (r failingRecordingRepo) Create(ctx context.Context, recording *entities.Recording) error {
+	return errors.New("database is down")
+}
+
+// uploadTracker remembers the keys and IDs of the multipart uploads
+// started on a BlobStore
+type uploadTracker struct {
+	storage.BlobStore
+	keys, uploadIDs []string
+}
+
+func (s *uploadTracker) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	uploadID, err := s.BlobStore.CreateMultipartUpload(ctx, key, contentType)
+	s.keys = append(s.keys, key)
+	s.uploadIDs = append(s.uploadIDs, uploadID)
+	return uploadID, err
+}
+
+func TestRecordingUploadAbortsWhenNotStored(t *testing.T) {
+	ctx := context.Background()
+	u := newRecordingUpload(t, 0)
+	meeting := u.meetings.addMeeting(t, "host")
+	store := &uploadTracker{BlobStore: u.store}
+	start := NewStartRecordingUploadUseCase(u.meetings, failingRecordingRepo{u.recordings}, store, "recordings/", 0)
+
+	if _, err := start.Execute(ctx, StartUploadInput{MeetingID: meeting.ID, UserID: "host", FileName: "standup.webm", SizeBytes: 4}); err == nil {
+		t.Fatal("start succeeded without storing the recording")
+	}
+	if len(store.uploadIDs) != 1 {
+		t.Fatalf("started %d uploads, want 1", len(store.uploadIDs))
+	}
+	if _, err := u.store.ListParts(ctx, store.keys[0], store.uploadIDs[0]); !errors.Is(err, storage.ErrUploadMissing) {
+		t.Fatalf("parts of the unstored recording: err = %v, want the upload aborted", err)
+	}
+}
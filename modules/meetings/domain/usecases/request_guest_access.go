@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// RequestGuestAccessUseCase handles guests asking to join through a link
+type RequestGuestAccessUseCase struct {
+	meetingRepo repository.MeetingRepository
+	guestRepo   repository.GuestRepository
+	hasher      PasscodeHasher
+	notifier    Notifier
+}
+
+// NewRequestGuestAccessUseCase creates a new RequestGuestAccessUseCase
+func NewRequestGuestAccessUseCase(meetingRepo repository.MeetingRepository, guestRepo repository.GuestRepository, hasher PasscodeHasher, notifier Notifier) *RequestGuestAccessUseCase {
+	return &RequestGuestAccessUseCase{
+		meetingRepo: meetingRepo,
+		guestRepo:   guestRepo,
+		hasher:      hasher,
+		notifier:    notifier,
+	}
+}
+
+// RequestGuestAccessInput represents a guest's request to join
+type RequestGuestAccessInput struct {
+	Token       string
+	Passcode    string
+	DisplayName string
+	Email       string
+}
+
+// RequestGuestAccessOutput represents a lobby entry. The secret is only
+// returned once and is needed to poll for the host's decision.
+type RequestGuestAccessOutput struct {
+	Entry  *entities.LobbyEntry
+	Secret string
+}
+
+// Execute puts the guest in the meeting lobby and tells the organizer.
+// Without a lobby, guests are admitted right away.
+func (uc *RequestGuestAccessUseCase) Execute(ctx context.Context, input RequestGuestAccessInput) (*RequestGuestAccessOutput, error) {
+	link, err := linkByToken(ctx, uc.guestRepo, input.Token)
+	if err != nil {
+		return nil, err
+	}
+	if !link.IsUsable(time.Now()) {
+		return nil, entities.ErrGuestLinkUnavailable
+	}
+	if link.HasPasscode() {
+		if err := uc.hasher.Compare(link.PasscodeHash, input.Passcode); err != nil {
+			return nil, entities.ErrInvalidPasscode
+		}
+	}
+
+	meeting, err := uc.meetingRepo.GetByID(ctx, link.MeetingID)
+	if err != nil {
+		return nil, err
+	}
+	if !meeting.IsActive() {
+		return nil, entities.ErrMeetingNotActive
+	}
+
+	entry, secret, err := entities.NewLobbyEntry(meeting.ID, link.ID, input.DisplayName, input.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	template := "meeting.guest_waiting"
+	if !meeting.Settings.LobbyEnabled {
+		if err := entry.Decide(true, ""); err != nil {
+			return nil, err
+		}
+		template = "meeting.guest_joining"
+	}
+
+	if err := uc.guestRepo.CreateLobbyEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	if err := uc.notifier.Notify(ctx, meeting.OrganizerID, template,
+		map[string]interface{}{
+			"meeting_id":     meeting.ID,
+			"meeting_title":  meeting.Title,
+			"lobby_entry_id": entry.ID,
+			"guest_name":     entry.DisplayName,
+		},
+	); err != nil {
+		logging.FromContext(ctx).Warn("failed to notify organizer about guest", "meeting_id", meeting.ID, "guest_id", entry.ID, "error", err)
+	}
+
+	return &RequestGuestAccessOutput{Entry: entry, Secret: secret}, nil
+}
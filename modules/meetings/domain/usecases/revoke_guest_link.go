@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// RevokeGuestLinkUseCase handles revoking guest links
+type RevokeGuestLinkUseCase struct {
+	meetingRepo repository.MeetingRepository
+	guestRepo   repository.GuestRepository
+}
+
+// NewRevokeGuestLinkUseCase creates a new RevokeGuestLinkUseCase
+func NewRevokeGuestLinkUseCase(meetingRepo repository.MeetingRepository, guestRepo repository.GuestRepository) *RevokeGuestLinkUseCase {
+	return &RevokeGuestLinkUseCase{
+		meetingRepo: meetingRepo,
+		guestRepo:   guestRepo,
+	}
+}
+
+// Execute revokes a guest link. Guests admitted through it can no longer
+// get a meeting token.
+func (uc *RevokeGuestLinkUseCase) Execute(ctx context.Context, meetingID, linkID, userID string) error {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true); err != nil {
+		return err
+	}
+
+	return uc.guestRepo.RevokeLink(ctx, meetingID, linkID, time.Now())
+}
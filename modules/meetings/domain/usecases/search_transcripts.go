@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// SearchTranscriptsUseCase handles full-text search across transcripts
+type SearchTranscriptsUseCase struct {
+	transcriptRepo repository.TranscriptRepository
+}
+
+// NewSearchTranscriptsUseCase creates a new SearchTranscriptsUseCase
+func NewSearchTranscriptsUseCase(transcriptRepo repository.TranscriptRepository) *SearchTranscriptsUseCase {
+	return &SearchTranscriptsUseCase{transcriptRepo: transcriptRepo}
+}
+
+// Execute searches the transcripts of meetings the user organizes or
+// participates in
+func (uc *SearchTranscriptsUseCase) Execute(ctx context.Context, userID, query string, page, pageSize int) ([]*entities.TranscriptSearchResult, int64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []*entities.TranscriptSearchResult{}, 0, nil
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	return uc.transcriptRepo.Search(ctx, userID, query, page, pageSize)
+}
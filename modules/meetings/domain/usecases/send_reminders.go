@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// Notifier delivers notifications to users
+type Notifier interface {
+	Notify(ctx context.Context, userID, template string, variables map[string]interface{}) error
+}
+
+// SendRemindersUseCase notifies participants of meetings that start soon.
+// Reminders carry the start time as an instant; the notification templates
+// render it in the recipient's timezone. Schedules only flag meetings that
+// start outside the recipient's working hours.
+type SendRemindersUseCase struct {
+	meetingRepo    repository.MeetingRepository
+	scheduleRepo   repository.UserScheduleRepository
+	notifier       Notifier
+	workingHours   entities.WorkingHours
+	reminderBefore time.Duration
+}
+
+// NewSendRemindersUseCase creates a new SendRemindersUseCase
+func NewSendRemindersUseCase(
+	meetingRepo repository.MeetingRepository,
+	scheduleRepo repository.UserScheduleRepository,
+	notifier Notifier,
+	workingHours entities.WorkingHours,
+	reminderBefore time.Duration,
+) *SendRemindersUseCase {
+	if reminderBefore <= 0 {
+		reminderBefore = 15 * time.Minute
+	}
+
+	return &SendRemindersUseCase{
+		meetingRepo:    meetingRepo,
+		scheduleRepo:   scheduleRepo,
+		notifier:       notifier,
+		workingHours:   workingHours,
+		reminderBefore: reminderBefore,
+	}
+}
+
+// Execute sends reminders for meetings starting within the reminder window
+// and returns the number of meetings reminded. A meeting whose participants
+// cannot be loaded is released and retried on the next run, without holding
+// up the rest of the batch.
+func (uc *SendRemindersUseCase) Execute(ctx context.Context) (int, error) {
+	now := time.Now()
+
+	meetings, err := uc.meetingRepo.ClaimDueReminders(ctx, now, now.Add(uc.reminderBefore))
+	if err != nil {
+		return 0, err
+	}
+
+	reminded := 0
+	for _, meeting := range meetings {
+		participants, err := uc.meetingRepo.ListParticipants(ctx, meeting.ID)
+		if err != nil {
+			logging.FromContext(ctx).Error("failed to load participants for meeting reminders", "meeting_id", meeting.ID, "error", err)
+			if err := uc.meetingRepo.ReleaseReminder(ctx, meeting.ID); err != nil {
+				logging.FromContext(ctx).Error("failed to release meeting reminder", "meeting_id", meeting.ID, "error", err)
+			}
+			continue
+		}
+		reminded++
+
+		recipients := []string{meeting.OrganizerID}
+		seen := map[string]bool{meeting.OrganizerID: true}
+		for _, participant := range participants {
+			if !seen[participant.UserID] {
+				seen[participant.UserID] = true
+				recipients = append(recipients, participant.UserID)
+			}
+		}
+
+		// The meeting is already claimed, so a failed lookup falls back
+		// to UTC rather than dropping the reminders.
+		schedules, err := uc.scheduleRepo.ListSchedules(ctx, recipients)
+		if err != nil {
+			logging.FromContext(ctx).Warn("failed to load schedules for meeting reminders", "meeting_id", meeting.ID, "error", err)
+		}
+
+		minutes := int(meeting.StartTime.Sub(now).Round(time.Minute).Minutes())
+		slot := entities.TimeSlot{Start: meeting.StartTime, End: meeting.ScheduledEnd()}
+
+		for _, userID := range recipients {
+			schedule := schedules[userID]
+			variables := map[string]interface{}{
+				"meeting_id":    meeting.ID,
+				"meeting_title": meeting.Title,
+				"minutes":       minutes,
+				"start_time":    meeting.StartTime,
+			}
+			if !schedule.WorkingHours(uc.workingHours).Contains(slot) {
+				variables["outside_working_hours"] = true
+			}
+
+			// Keep going and let the other participants get their reminder.
+			if err := uc.notifier.Notify(ctx, userID, "meeting.reminder", variables); err != nil {
+				logging.FromContext(ctx).Warn("failed to send meeting reminder", "meeting_id", meeting.ID, "user_id", userID, "error", err)
+			}
+		}
+	}
+
+	return reminded, nil
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// fakeNotifier records the notifications sent, failing for the users in fail
+type fakeNotifier struct {
+	sent map[string][]map[string]interface{}
+	fail map[string]bool
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, userID, template string, variables map[string]interface{}) error {
+	if n.fail[userID] {
+		return errors.New("notification channel down")
+	}
+	if n.sent == nil {
+		n.sent = make(map[string][]map[string]interface{})
+	}
+	n.sent[userID] = append(n.sent[userID], variables)
+	return nil
+}
+
+func TestSendReminders(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	hours, err := entities.NewWorkingHours("00:00", "23:59", []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"})
+	if err != nil {
+		t.Fatalf("NewWorkingHours: %v", err)
+	}
+	// Carol only works an hour, early in the morning in New York
+	schedules := &fakeScheduleRepo{schedules: map[string]*entities.UserSchedule{
+		"carol": {UserID: "carol", Location: newYork, WorkStart: "05:00", WorkEnd: "05:01", WorkDays: []string{"monday"}},
+	}}
+
+	meetings := newFakeMeetingRepo()
+	standup := meetings.addMeeting(t, "alice", "bob", "carol", "alice")
+	broken := meetings.addMeeting(t, "dave", "erin")
+	review := meetings.addMeeting(t, "frank")
+	meetings.participantsErr[broken.ID] = errors.New("connection reset")
+	meetings.due = []*entities.Meeting{standup, broken, review}
+
+	notifier := &fakeNotifier{fail: map[string]bool{"bob": true}}
+	uc := NewSendRemindersUseCase(meetings, schedules, notifier, hours, time.Hour)
+
+	reminded, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if reminded != 2 {
+		t.Fatalf("reminded %d meetings, want 2", reminded)
+	}
+	if !reflect.DeepEqual(meetings.released, []string{broken.ID}) {
+		t.Fatalf("released %v, want the meeting whose participants failed to load", meetings.released)
+	}
+
+	tests := []struct {
+		userID  string
+		want    int
+		outside bool
+	}{
+		{"alice", 1, false},
+		{"bob", 0, false}, // the failed notification did not stop the others
+		{"carol", 1, true},
+		{"dave", 0, false},
+		{"erin", 0, false},
+		{"frank", 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.userID, func(t *testing.T) {
+			sent := notifier.sent[tt.userID]
+			if len(sent) != tt.want {
+				t.Fatalf("%s got %d reminders, want %d", tt.userID, len(sent), tt.want)
+			}
+			if tt.want == 0 {
+				return
+			}
+			if outside, _ := sent[0]["outside_working_hours"].(bool); outside != tt.outside {
+				t.Fatalf("outside_working_hours = %v, want %v", outside, tt.outside)
+			}
+			if minutes := sent[0]["minutes"].(int); minutes < 59 || minutes > 60 {
+				t.Fatalf("minutes = %d, want about an hour", minutes)
+			}
+		})
+	}
+}
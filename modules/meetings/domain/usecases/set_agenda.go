@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// SetAgendaUseCase handles replacing a meeting agenda
+type SetAgendaUseCase struct {
+	meetingRepo repository.MeetingRepository
+	minutesRepo repository.MinutesRepository
+}
+
+// NewSetAgendaUseCase creates a new SetAgendaUseCase
+func NewSetAgendaUseCase(meetingRepo repository.MeetingRepository, minutesRepo repository.MinutesRepository) *SetAgendaUseCase {
+	return &SetAgendaUseCase{
+		meetingRepo: meetingRepo,
+		minutesRepo: minutesRepo,
+	}
+}
+
+// AgendaItemInput represents an agenda item to store
+type AgendaItemInput struct {
+	Title           string
+	Description     string
+	DurationMinutes int
+	PresenterID     string
+}
+
+// Execute replaces the agenda of a meeting. Only hosts may edit the agenda.
+func (uc *SetAgendaUseCase) Execute(ctx context.Context, meetingID, userID string, inputs []AgendaItemInput) ([]*entities.AgendaItem, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true); err != nil {
+		return nil, err
+	}
+
+	items := make([]*entities.AgendaItem, len(inputs))
+	for i, input := range inputs {
+		item, err := entities.NewAgendaItem(meetingID, i+1, input.Title, input.Description, input.DurationMinutes, input.PresenterID)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+
+	if err := uc.minutesRepo.ReplaceAgenda(ctx, meetingID, items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/storage"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// StartRecordingUploadUseCase handles starting a resumable recording upload
+type StartRecordingUploadUseCase struct {
+	meetingRepo   repository.MeetingRepository
+	recordingRepo repository.RecordingRepository
+	blobStore     storage.BlobStore
+	keyPrefix     string
+	maxSize       int64
+}
+
+// NewStartRecordingUploadUseCase creates a new StartRecordingUploadUseCase
+func NewStartRecordingUploadUseCase(
+	meetingRepo repository.MeetingRepository,
+	recordingRepo repository.RecordingRepository,
+	blobStore storage.BlobStore,
+	keyPrefix string,
+	maxSize int64,
+) *StartRecordingUploadUseCase {
+	return &StartRecordingUploadUseCase{
+		meetingRepo:   meetingRepo,
+		recordingRepo: recordingRepo,
+		blobStore:     blobStore,
+		keyPrefix:     keyPrefix,
+		maxSize:       maxSize,
+	}
+}
+
+// StartUploadInput represents recording upload start input
+type StartUploadInput struct {
+	MeetingID   string
+	UserID      string
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+}
+
+// Execute registers the recording and opens a multipart upload for it
+func (uc *StartRecordingUploadUseCase) Execute(ctx context.Context, input StartUploadInput) (*entities.Recording, error) {
+	meeting, err := authorizeParticipant(ctx, uc.meetingRepo, input.MeetingID, input.UserID, true)
+	if err != nil {
+		return nil, err
+	}
+	if !meeting.Settings.RecordingAllowed {
+		return nil, entities.ErrRecordingNotAllowed
+	}
+
+	recording, err := entities.NewRecording(
+		input.MeetingID,
+		input.UserID,
+		input.FileName,
+		input.ContentType,
+		input.SizeBytes,
+		uc.maxSize,
+		uc.keyPrefix,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadID, err := uc.blobStore.CreateMultipartUpload(ctx, recording.ObjectKey, recording.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	recording.UploadID = uploadID
+
+	if err := uc.recordingRepo.Create(ctx, recording); err != nil {
+		_ = uc.blobStore.AbortMultipartUpload(ctx, recording.ObjectKey, uploadID)
+		return nil, err
+	}
+
+	return recording, nil
+}
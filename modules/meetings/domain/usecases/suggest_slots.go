@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// SuggestSlotsUseCase finds common free windows within the working hours
+// of every user, each taken in the user's own timezone
+type SuggestSlotsUseCase struct {
+	meetingRepo    repository.MeetingRepository
+	scheduleRepo   repository.UserScheduleRepository
+	workingHours   entities.WorkingHours
+	slotInterval   time.Duration
+	maxSuggestions int
+}
+
+// NewSuggestSlotsUseCase creates a new SuggestSlotsUseCase
+func NewSuggestSlotsUseCase(
+	meetingRepo repository.MeetingRepository,
+	scheduleRepo repository.UserScheduleRepository,
+	workingHours entities.WorkingHours,
+	slotInterval time.Duration,
+	maxSuggestions int,
+) *SuggestSlotsUseCase {
+	if slotInterval <= 0 {
+		slotInterval = 15 * time.Minute
+	}
+	if maxSuggestions <= 0 {
+		maxSuggestions = 10
+	}
+
+	return &SuggestSlotsUseCase{
+		meetingRepo:    meetingRepo,
+		scheduleRepo:   scheduleRepo,
+		workingHours:   workingHours,
+		slotInterval:   slotInterval,
+		maxSuggestions: maxSuggestions,
+	}
+}
+
+// SuggestInput represents slot suggestion input
+type SuggestInput struct {
+	UserIDs  []string
+	From     time.Time
+	To       time.Time
+	Duration time.Duration
+}
+
+// Execute returns free windows shared by all users that fit the duration
+func (uc *SuggestSlotsUseCase) Execute(ctx context.Context, input SuggestInput) ([]entities.TimeSlot, error) {
+	if err := validateRange(input.UserIDs, input.From, input.To); err != nil {
+		return nil, err
+	}
+	if input.Duration <= 0 {
+		return nil, entities.ErrInvalidDuration
+	}
+
+	intervals, err := uc.meetingRepo.ListBusy(ctx, input.UserIDs, input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make([]entities.TimeSlot, 0, len(intervals))
+	for _, interval := range intervals {
+		busy = append(busy, interval.Slot())
+	}
+	busy = entities.MergeSlots(busy)
+
+	schedules, err := uc.scheduleRepo.ListSchedules(ctx, input.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	windows := []entities.TimeSlot{{Start: input.From, End: input.To}}
+	for _, userID := range input.UserIDs {
+		hours := schedules[userID].WorkingHours(uc.workingHours)
+		windows = entities.IntersectSlots(windows, hours.Windows(input.From, input.To))
+	}
+
+	suggestions := make([]entities.TimeSlot, 0, uc.maxSuggestions)
+	for _, window := range windows {
+		for _, free := range entities.SubtractSlots(window, busy) {
+			start := alignUp(free.Start, uc.slotInterval)
+			if free.End.Sub(start) < input.Duration {
+				continue
+			}
+			suggestions = append(suggestions, entities.TimeSlot{Start: start, End: free.End})
+			if len(suggestions) == uc.maxSuggestions {
+				return suggestions, nil
+			}
+		}
+	}
+
+	return suggestions, nil
+}
+
+// alignUp rounds t up to the next multiple of interval
+func alignUp(t time.Time, interval time.Duration) time.Time {
+	truncated := t.Truncate(interval)
+	if truncated.Before(t) {
+		return truncated.Add(interval)
+	}
+	return truncated
+}
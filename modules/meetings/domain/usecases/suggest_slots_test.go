@@ -0,0 +1,151 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// monday returns 2024-03-11 hh:mm in UTC, the day after New York moved to
+// daylight saving time
+func monday(hh, mm int) time.Time {
+	return time.Date(2024, time.March, 11, hh, mm, 0, 0, time.UTC)
+}
+
+func officeHours(t *testing.T) entities.WorkingHours {
+	t.Helper()
+	hours, err := entities.NewWorkingHours("09:00", "17:00", []string{"monday", "tuesday", "wednesday", "thursday", "friday"})
+	if err != nil {
+		t.Fatalf("NewWorkingHours: %v", err)
+	}
+	return hours
+}
+
+func busyFor(userID string, start, end time.Time) *entities.BusyInterval {
+	return &entities.BusyInterval{UserID: userID, MeetingID: "m-" + userID, Start: start, End: end}
+}
+
+func TestSuggestSlots(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	schedules := &fakeScheduleRepo{schedules: map[string]*entities.UserSchedule{
+		// Default hours in New York: 13:00-21:00 UTC
+		"ny": {UserID: "ny", Location: newYork},
+		// Personal hours, 07:00-12:00 UTC
+		"early": {UserID: "early", Location: time.UTC, WorkStart: "07:00", WorkEnd: "12:00", WorkDays: []string{"monday"}},
+	}}
+
+	tests := []struct {
+		name           string
+		userIDs        []string
+		busy           []*entities.BusyInterval
+		duration       time.Duration
+		maxSuggestions int
+		want           []entities.TimeSlot
+	}{
+		{
+			name:     "around a meeting",
+			userIDs:  []string{"utc"},
+			busy:     []*entities.BusyInterval{busyFor("utc", monday(10, 0), monday(11, 0))},
+			duration: time.Hour,
+			want:     []entities.TimeSlot{{Start: monday(9, 0), End: monday(10, 0)}, {Start: monday(11, 0), End: monday(17, 0)}},
+		},
+		{
+			name:     "gaps shorter than the duration",
+			userIDs:  []string{"utc"},
+			busy:     []*entities.BusyInterval{busyFor("utc", monday(9, 30), monday(16, 30))},
+			duration: 45 * time.Minute,
+			want:     []entities.TimeSlot{},
+		},
+		{
+			name:     "aligned to the slot interval",
+			userIDs:  []string{"utc"},
+			busy:     []*entities.BusyInterval{busyFor("utc", monday(9, 0), monday(10, 5))},
+			duration: time.Hour,
+			want:     []entities.TimeSlot{{Start: monday(10, 15), End: monday(17, 0)}},
+		},
+		{
+			name:    "working hours in each timezone",
+			userIDs: []string{"utc", "ny"},
+			busy: []*entities.BusyInterval{
+				busyFor("ny", monday(13, 10), monday(14, 0)),
+			},
+			duration: 30 * time.Minute,
+			want:     []entities.TimeSlot{{Start: monday(14, 0), End: monday(17, 0)}},
+		},
+		{
+			name:     "personal working hours",
+			userIDs:  []string{"early", "utc"},
+			duration: time.Hour,
+			want:     []entities.TimeSlot{{Start: monday(9, 0), End: monday(12, 0)}},
+		},
+		{
+			name:     "no common hours",
+			userIDs:  []string{"early", "ny"},
+			duration: 15 * time.Minute,
+			want:     []entities.TimeSlot{},
+		},
+		{
+			name:           "limited suggestions",
+			userIDs:        []string{"utc"},
+			busy:           []*entities.BusyInterval{busyFor("utc", monday(10, 0), monday(11, 0))},
+			duration:       time.Hour,
+			maxSuggestions: 1,
+			want:           []entities.TimeSlot{{Start: monday(9, 0), End: monday(10, 0)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meetings := newFakeMeetingRepo()
+			meetings.busy = tt.busy
+			uc := NewSuggestSlotsUseCase(meetings, schedules, officeHours(t), 15*time.Minute, tt.maxSuggestions)
+
+			got, err := uc.Execute(context.Background(), SuggestInput{
+				UserIDs:  tt.userIDs,
+				From:     monday(0, 0),
+				To:       monday(24, 0),
+				Duration: tt.duration,
+			})
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("suggestions = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) {
+					t.Fatalf("suggestions = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSuggestSlotsRejectsInvalidInput(t *testing.T) {
+	uc := NewSuggestSlotsUseCase(newFakeMeetingRepo(), &fakeScheduleRepo{}, officeHours(t), 0, 0)
+
+	tests := []struct {
+		name  string
+		input SuggestInput
+		want  error
+	}{
+		{"no users", SuggestInput{From: monday(0, 0), To: monday(24, 0), Duration: time.Hour}, entities.ErrInvalidTimeRange},
+		{"empty range", SuggestInput{UserIDs: []string{"utc"}, From: monday(9, 0), To: monday(9, 0), Duration: time.Hour}, entities.ErrInvalidTimeRange},
+		{"range too long", SuggestInput{UserIDs: []string{"utc"}, From: monday(0, 0), To: monday(0, 0).AddDate(0, 0, 32), Duration: time.Hour}, entities.ErrInvalidTimeRange},
+		{"no duration", SuggestInput{UserIDs: []string{"utc"}, From: monday(0, 0), To: monday(24, 0)}, entities.ErrInvalidDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uc.Execute(context.Background(), tt.input); !errors.Is(err, tt.want) {
+				t.Fatalf("Execute: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// UpdateActionItemUseCase handles editing meeting action items
+type UpdateActionItemUseCase struct {
+	meetingRepo repository.MeetingRepository
+	minutesRepo repository.MinutesRepository
+	notifier    Notifier
+}
+
+// NewUpdateActionItemUseCase creates a new UpdateActionItemUseCase
+func NewUpdateActionItemUseCase(meetingRepo repository.MeetingRepository, minutesRepo repository.MinutesRepository, notifier Notifier) *UpdateActionItemUseCase {
+	return &UpdateActionItemUseCase{
+		meetingRepo: meetingRepo,
+		minutesRepo: minutesRepo,
+		notifier:    notifier,
+	}
+}
+
+// UpdateActionItemInput represents action item update input.
+// Nil fields are left unchanged; ClearDueDate removes the due date.
+type UpdateActionItemInput struct {
+	MeetingID    string
+	ItemID       string
+	UserID       string
+	Title        *string
+	Description  *string
+	AssigneeID   *string
+	DueDate      *time.Time
+	ClearDueDate bool
+	Status       *entities.ActionItemStatus
+}
+
+// Execute updates an action item. A new assignee is notified.
+func (uc *UpdateActionItemUseCase) Execute(ctx context.Context, input UpdateActionItemInput) (*entities.ActionItem, error) {
+	meeting, err := authorizeParticipant(ctx, uc.meetingRepo, input.MeetingID, input.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := uc.minutesRepo.GetActionItem(ctx, input.MeetingID, input.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		title := strings.TrimSpace(*input.Title)
+		if title == "" {
+			return nil, entities.ErrInvalidActionItem
+		}
+		item.Title = title
+	}
+	if input.Description != nil {
+		item.Description = *input.Description
+	}
+	if input.ClearDueDate {
+		item.DueDate = nil
+	} else if input.DueDate != nil {
+		item.DueDate = input.DueDate
+	}
+	if input.Status != nil {
+		if err := item.SetStatus(*input.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	reassigned := false
+	if input.AssigneeID != nil && *input.AssigneeID != item.AssigneeID {
+		if err := checkAssignee(ctx, uc.meetingRepo, meeting, *input.AssigneeID); err != nil {
+			return nil, err
+		}
+		item.AssigneeID = *input.AssigneeID
+		reassigned = true
+	}
+
+	item.UpdatedAt = time.Now()
+	if err := uc.minutesRepo.UpdateActionItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	if reassigned && item.Status.IsOpen() {
+		notifyAssignee(ctx, uc.notifier, meeting, item, input.UserID)
+	}
+
+	return item, nil
+}
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// NotesConflictError carries the current notes when an edit was based on
+// an outdated revision
+type NotesConflictError struct {
+	Current *entities.NoteRevision
+}
+
+func (e *NotesConflictError) Error() string {
+	return entities.ErrNotesConflict.Error()
+}
+
+func (e *NotesConflictError) Unwrap() error {
+	return entities.ErrNotesConflict
+}
+
+// UpdateNotesUseCase handles editing meeting notes
+type UpdateNotesUseCase struct {
+	meetingRepo repository.MeetingRepository
+	minutesRepo repository.MinutesRepository
+}
+
+// NewUpdateNotesUseCase creates a new UpdateNotesUseCase
+func NewUpdateNotesUseCase(meetingRepo repository.MeetingRepository, minutesRepo repository.MinutesRepository) *UpdateNotesUseCase {
+	return &UpdateNotesUseCase{
+		meetingRepo: meetingRepo,
+		minutesRepo: minutesRepo,
+	}
+}
+
+// UpdateNotesInput represents notes edit input
+type UpdateNotesInput struct {
+	MeetingID    string
+	UserID       string
+	BaseRevision int
+	Content      string
+}
+
+// Execute saves a new notes revision. Edits must be based on the latest
+// revision; otherwise a NotesConflictError with the current notes is
+// returned so the client can merge and retry.
+func (uc *UpdateNotesUseCase) Execute(ctx context.Context, input UpdateNotesInput) (*entities.NoteRevision, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, input.MeetingID, input.UserID, false); err != nil {
+		return nil, err
+	}
+
+	revision := &entities.NoteRevision{
+		MeetingID: input.MeetingID,
+		Content:   input.Content,
+		EditedBy:  input.UserID,
+		CreatedAt: time.Now(),
+	}
+
+	err := uc.minutesRepo.SaveNotes(ctx, revision, input.BaseRevision)
+	if errors.Is(err, entities.ErrNotesConflict) {
+		current, getErr := currentNotes(ctx, uc.minutesRepo, input.MeetingID)
+		if getErr != nil {
+			return nil, getErr
+		}
+		return nil, &NotesConflictError{Current: current}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
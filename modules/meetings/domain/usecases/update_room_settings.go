@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// RoomSettingsInput represents changes to room settings.
+// Nil fields are left unchanged; an empty passcode removes it.
+type RoomSettingsInput struct {
+	LobbyEnabled     *bool
+	StartMuted       *bool
+	RecordingAllowed *bool
+	Passcode         *string
+}
+
+// apply returns settings with the input's changes applied
+func (in *RoomSettingsInput) apply(settings entities.RoomSettings) (entities.RoomSettings, error) {
+	if in != nil {
+		if in.LobbyEnabled != nil {
+			settings.LobbyEnabled = *in.LobbyEnabled
+		}
+		if in.StartMuted != nil {
+			settings.StartMuted = *in.StartMuted
+		}
+		if in.RecordingAllowed != nil {
+			settings.RecordingAllowed = *in.RecordingAllowed
+		}
+		if in.Passcode != nil {
+			settings.Passcode = *in.Passcode
+		}
+	}
+
+	if err := settings.Validate(); err != nil {
+		return settings, err
+	}
+	return settings, nil
+}
+
+// UpdateRoomSettingsUseCase handles changing the room settings of a meeting
+type UpdateRoomSettingsUseCase struct {
+	meetingRepo repository.MeetingRepository
+}
+
+// NewUpdateRoomSettingsUseCase creates a new UpdateRoomSettingsUseCase
+func NewUpdateRoomSettingsUseCase(meetingRepo repository.MeetingRepository) *UpdateRoomSettingsUseCase {
+	return &UpdateRoomSettingsUseCase{
+		meetingRepo: meetingRepo,
+	}
+}
+
+// Execute updates the room settings of a meeting. Only hosts may change
+// them; they apply to everyone joining afterwards.
+func (uc *UpdateRoomSettingsUseCase) Execute(ctx context.Context, meetingID, userID string, input RoomSettingsInput) (*entities.Meeting, error) {
+	meeting, err := authorizeParticipant(ctx, uc.meetingRepo, meetingID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := input.apply(meeting.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	meeting.Settings = settings
+	meeting.UpdatedAt = time.Now()
+	if err := uc.meetingRepo.Update(ctx, meeting); err != nil {
+		return nil, err
+	}
+
+	return meeting, nil
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// UpdateTemplateUseCase handles replacing a meeting template
+type UpdateTemplateUseCase struct {
+	templateRepo repository.TemplateRepository
+}
+
+// NewUpdateTemplateUseCase creates a new UpdateTemplateUseCase
+func NewUpdateTemplateUseCase(templateRepo repository.TemplateRepository) *UpdateTemplateUseCase {
+	return &UpdateTemplateUseCase{
+		templateRepo: templateRepo,
+	}
+}
+
+// Execute replaces the fields of a template. Only the owner may change it;
+// meetings created from it earlier are not affected.
+func (uc *UpdateTemplateUseCase) Execute(ctx context.Context, templateID, userID string, input TemplateInput) (*entities.MeetingTemplate, error) {
+	template, err := ownedTemplate(ctx, uc.templateRepo, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := input.spec()
+	if err != nil {
+		return nil, err
+	}
+	if err := template.Update(spec); err != nil {
+		return nil, err
+	}
+
+	if err := uc.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"io"
+
+	"github.com/manab-pr/evtaarpro/internal/storage"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// UploadRecordingPartUseCase handles uploading one part of a recording
+type UploadRecordingPartUseCase struct {
+	meetingRepo   repository.MeetingRepository
+	recordingRepo repository.RecordingRepository
+	blobStore     storage.BlobStore
+}
+
+// NewUploadRecordingPartUseCase creates a new UploadRecordingPartUseCase
+func NewUploadRecordingPartUseCase(
+	meetingRepo repository.MeetingRepository,
+	recordingRepo repository.RecordingRepository,
+	blobStore storage.BlobStore,
+) *UploadRecordingPartUseCase {
+	return &UploadRecordingPartUseCase{
+		meetingRepo:   meetingRepo,
+		recordingRepo: recordingRepo,
+		blobStore:     blobStore,
+	}
+}
+
+// UploadPartInput represents recording part upload input
+type UploadPartInput struct {
+	MeetingID   string
+	RecordingID string
+	UserID      string
+	PartNumber  int
+	Body        io.Reader
+	Size        int64
+}
+
+// Execute stores the part. Re-uploading a part number replaces it, so
+// interrupted parts can simply be retried.
+func (uc *UploadRecordingPartUseCase) Execute(ctx context.Context, input UploadPartInput) (storage.Part, error) {
+	if _, err := authorizeParticipant(ctx, uc.meetingRepo, input.MeetingID, input.UserID, true); err != nil {
+		return storage.Part{}, err
+	}
+
+	recording, err := uploadingRecording(ctx, uc.recordingRepo, input.MeetingID, input.RecordingID)
+	if err != nil {
+		return storage.Part{}, err
+	}
+
+	if input.Size <= 0 {
+		return storage.Part{}, storage.ErrInvalidPart
+	}
+	remaining, err := uc.remaining(ctx, recording, input.PartNumber)
+	if err != nil {
+		return storage.Part{}, err
+	}
+	if input.Size > remaining {
+		return storage.Part{}, entities.ErrRecordingTooLarge
+	}
+
+	return uc.blobStore.UploadPart(ctx, recording.ObjectKey, recording.UploadID, input.PartNumber, input.Body, input.Size)
+}
+
+// remaining returns how many bytes the given part may hold without the
+// upload exceeding the declared recording size
+func (uc *UploadRecordingPartUseCase) remaining(ctx context.Context, recording *entities.Recording, partNumber int) (int64, error) {
+	parts, err := uc.blobStore.ListParts(ctx, recording.ObjectKey, recording.UploadID)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := recording.SizeBytes
+	for _, part := range parts {
+		if part.Number != partNumber {
+			remaining -= part.Size
+		}
+	}
+	return remaining, nil
+}
@@ -0,0 +1,118 @@
+package cached
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/cache"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/repository"
+)
+
+// ListTag is carried by every cached list of meetings
+const ListTag = "meetings"
+
+// MeetingTag is carried by the cached reads of the meeting with the given ID
+func MeetingTag(id string) string {
+	return "meeting:" + id
+}
+
+// meetingList is a cached page of meetings
+type meetingList struct {
+	Meetings []*entities.Meeting `json:"meetings"`
+	Total    int64               `json:"total"`
+}
+
+// MeetingRepository caches meeting reads of a repository.MeetingRepository
+// and invalidates them on writes. Other methods go straight to the
+// repository.
+type MeetingRepository struct {
+	repository.MeetingRepository
+	cache *cache.Cache
+}
+
+// NewMeetingRepository creates a MeetingRepository caching the reads of repo
+func NewMeetingRepository(repo repository.MeetingRepository, cache *cache.Cache) *MeetingRepository {
+	return &MeetingRepository{MeetingRepository: repo, cache: cache}
+}
+
+// Create creates a new meeting
+func (r *MeetingRepository) Create(ctx context.Context, meeting *entities.Meeting) error {
+	if err := r.MeetingRepository.Create(ctx, meeting); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, ListTag)
+	return nil
+}
+
+// GetByID retrieves a meeting by ID
+func (r *MeetingRepository) GetByID(ctx context.Context, id string) (*entities.Meeting, error) {
+	return cache.Fetch(ctx, r.cache, "id:"+id, []string{MeetingTag(id)}, func(ctx context.Context) (*entities.Meeting, error) {
+		return r.MeetingRepository.GetByID(ctx, id)
+	})
+}
+
+// List retrieves meetings with pagination
+func (r *MeetingRepository) List(ctx context.Context, page, pageSize int, userID string) ([]*entities.Meeting, int64, error) {
+	key := fmt.Sprintf("list:%s:%d:%d", userID, page, pageSize)
+	return r.fetchList(ctx, key, func(ctx context.Context) ([]*entities.Meeting, int64, error) {
+		return r.MeetingRepository.List(ctx, page, pageSize, userID)
+	})
+}
+
+// ListByOrganizer retrieves meetings by organizer
+func (r *MeetingRepository) ListByOrganizer(ctx context.Context, organizerID string, page, pageSize int) ([]*entities.Meeting, int64, error) {
+	key := fmt.Sprintf("organizer:%s:%d:%d", organizerID, page, pageSize)
+	return r.fetchList(ctx, key, func(ctx context.Context) ([]*entities.Meeting, int64, error) {
+		return r.MeetingRepository.ListByOrganizer(ctx, organizerID, page, pageSize)
+	})
+}
+
+// fetchList caches the page of meetings returned by load under key
+func (r *MeetingRepository) fetchList(ctx context.Context, key string, load func(ctx context.Context) ([]*entities.Meeting, int64, error)) ([]*entities.Meeting, int64, error) {
+	list, err := cache.Fetch(ctx, r.cache, key, []string{ListTag}, func(ctx context.Context) (*meetingList, error) {
+		meetings, total, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &meetingList{Meetings: meetings, Total: total}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return list.Meetings, list.Total, nil
+}
+
+// Update updates a meeting
+func (r *MeetingRepository) Update(ctx context.Context, meeting *entities.Meeting) error {
+	if err := r.MeetingRepository.Update(ctx, meeting); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, MeetingTag(meeting.ID), ListTag)
+	return nil
+}
+
+// Delete deletes a meeting
+func (r *MeetingRepository) Delete(ctx context.Context, id string) error {
+	if err := r.MeetingRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, MeetingTag(id), ListTag)
+	return nil
+}
+
+// CloseOverdue marks meetings whose scheduled end is before cutoff as
+// missed (never started) or completed (ongoing) and returns the changes
+func (r *MeetingRepository) CloseOverdue(ctx context.Context, cutoff time.Time) ([]*entities.StatusChange, error) {
+	changes, err := r.MeetingRepository.CloseOverdue(ctx, cutoff)
+	if len(changes) > 0 {
+		// Changes are returned with an error when only part of them applied
+		tags := []string{ListTag}
+		for _, change := range changes {
+			tags = append(tags, MeetingTag(change.MeetingID))
+		}
+		r.cache.Invalidate(ctx, tags...)
+	}
+	return changes, err
+}
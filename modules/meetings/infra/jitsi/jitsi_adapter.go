@@ -1,6 +1,9 @@
 package jitsi
 
 import (
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
 	"github.com/manab-pr/evtaarpro/pkg/clients/jitsi"
 )
 
@@ -16,12 +19,32 @@ func NewJitsiAdapter(domain, appID, appSecret string) *JitsiAdapter {
 	}
 }
 
-// CreateRoomToken generates a JWT token for a Jitsi room
-func (a *JitsiAdapter) CreateRoomToken(roomName, userID, userName, userEmail string, moderator bool) (string, error) {
-	return a.client.CreateRoomToken(roomName, userID, userName, userEmail, moderator)
+// CreateRoomToken generates a JWT token for a Jitsi room. Only moderators
+// may record or stream, and only when the room settings allow it.
+func (a *JitsiAdapter) CreateRoomToken(roomName, userID, userName, userEmail string, moderator bool, settings entities.RoomSettings) (string, error) {
+	canRecord := moderator && settings.RecordingAllowed
+	return a.client.CreateRoomToken(roomName, userID, userName, userEmail, moderator, map[string]bool{
+		"recording":     canRecord,
+		"livestreaming": canRecord,
+	})
+}
+
+// CreateGuestToken generates a restricted JWT token for a guest
+func (a *JitsiAdapter) CreateGuestToken(roomName, guestID, guestName, guestEmail string, ttl time.Duration) (string, error) {
+	return a.client.CreateGuestToken(roomName, guestID, guestName, guestEmail, ttl)
 }
 
 // GetRoomURL returns the full URL for a Jitsi room
 func (a *JitsiAdapter) GetRoomURL(roomName string) string {
 	return a.client.GetRoomURL(roomName)
 }
+
+// GetConfiguredRoomURL returns the URL for a Jitsi room that applies the
+// room settings in the browser
+func (a *JitsiAdapter) GetConfiguredRoomURL(roomName string, settings entities.RoomSettings) string {
+	return a.client.GetRoomURLWithConfig(roomName, map[string]interface{}{
+		"startWithAudioMuted":   settings.StartMuted,
+		"fileRecordingsEnabled": settings.RecordingAllowed,
+		"liveStreamingEnabled":  settings.RecordingAllowed,
+	})
+}
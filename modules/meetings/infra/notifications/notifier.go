@@ -0,0 +1,28 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/usecases"
+)
+
+// Notifier adapts the notifications module to meeting use case needs
+type Notifier struct {
+	dispatchUC *usecases.DispatchNotificationUseCase
+}
+
+// NewNotifier creates a new Notifier
+func NewNotifier(dispatchUC *usecases.DispatchNotificationUseCase) *Notifier {
+	return &Notifier{dispatchUC: dispatchUC}
+}
+
+// Notify renders a notification template in the user's language and sends
+// it over the channels they enabled
+func (n *Notifier) Notify(ctx context.Context, userID, template string, variables map[string]interface{}) error {
+	_, err := n.dispatchUC.Execute(ctx, usecases.DispatchInput{
+		UserID:    userID,
+		Template:  template,
+		Variables: variables,
+	})
+	return err
+}
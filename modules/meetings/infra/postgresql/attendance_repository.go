@@ -0,0 +1,149 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// AttendanceRepository implements repository.AttendanceRepository
+type AttendanceRepository struct {
+	db *sql.DB
+}
+
+// NewAttendanceRepository creates a new AttendanceRepository
+func NewAttendanceRepository(db *sql.DB) *AttendanceRepository {
+	return &AttendanceRepository{db: db}
+}
+
+// OpenSession records a join. Only existing participants are marked as
+// joined; the session never adds anyone to the meeting.
+func (r *AttendanceRepository) OpenSession(ctx context.Context, session *entities.AttendanceSession) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer datastore.Rollback(ctx, tx)
+
+	participantQuery := `
+		UPDATE meeting_participants
+		SET joined_at = COALESCE(joined_at, $3),
+		    left_at = NULL
+		WHERE meeting_id = $1 AND user_id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, participantQuery,
+		session.MeetingID,
+		session.UserID,
+		session.JoinedAt,
+	); err != nil {
+		return err
+	}
+
+	sessionQuery := `
+		INSERT INTO meeting_attendance_sessions (id, meeting_id, user_id, source, joined_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (meeting_id, user_id, source) WHERE left_at IS NULL DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, sessionQuery,
+		session.ID,
+		session.MeetingID,
+		session.UserID,
+		session.Source,
+		session.JoinedAt,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CloseSessions records a leave by closing the user's open sessions
+func (r *AttendanceRepository) CloseSessions(ctx context.Context, meetingID, userID string, source entities.AttendanceSource, leftAt time.Time) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer datastore.Rollback(ctx, tx)
+
+	// Event timestamps may come from another clock, so never end a session
+	// before it started
+	sessionQuery := `
+		UPDATE meeting_attendance_sessions
+		SET left_at = GREATEST(joined_at, $4)
+		WHERE meeting_id = $1 AND user_id = $2 AND left_at IS NULL
+		  AND ($3 = '' OR source = $3)
+	`
+
+	result, err := tx.ExecContext(ctx, sessionQuery, meetingID, userID, source, leftAt)
+	if err != nil {
+		return 0, err
+	}
+
+	closed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	// The participant has left once no session from any source is open
+	participantQuery := `
+		UPDATE meeting_participants
+		SET left_at = $3
+		WHERE meeting_id = $1 AND user_id = $2
+		  AND NOT EXISTS (
+		      SELECT 1 FROM meeting_attendance_sessions
+		      WHERE meeting_id = $1 AND user_id = $2 AND left_at IS NULL
+		  )
+	`
+
+	if _, err := tx.ExecContext(ctx, participantQuery, meetingID, userID, leftAt); err != nil {
+		return 0, err
+	}
+
+	return closed, tx.Commit()
+}
+
+// ListSessions retrieves all attendance sessions of a meeting
+func (r *AttendanceRepository) ListSessions(ctx context.Context, meetingID string) ([]*entities.AttendanceSession, error) {
+	query := `
+		SELECT id, meeting_id, user_id, source, joined_at, left_at
+		FROM meeting_attendance_sessions
+		WHERE meeting_id = $1
+		ORDER BY joined_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]*entities.AttendanceSession, 0)
+	for rows.Next() {
+		session := &entities.AttendanceSession{}
+		var leftAt sql.NullTime
+
+		if err := rows.Scan(
+			&session.ID,
+			&session.MeetingID,
+			&session.UserID,
+			&session.Source,
+			&session.JoinedAt,
+			&leftAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if leftAt.Valid {
+			session.LeftAt = &leftAt.Time
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
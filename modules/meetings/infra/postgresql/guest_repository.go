@@ -0,0 +1,298 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// GuestRepository implements repository.GuestRepository
+type GuestRepository struct {
+	db *sql.DB
+}
+
+// NewGuestRepository creates a new GuestRepository
+func NewGuestRepository(db *sql.DB) *GuestRepository {
+	return &GuestRepository{db: db}
+}
+
+// CreateLink creates a new guest link
+func (r *GuestRepository) CreateLink(ctx context.Context, link *entities.GuestLink) error {
+	query := `
+		INSERT INTO meeting_guest_links (id, meeting_id, token_hash, passcode_hash, expires_at, max_uses, use_count, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		link.ID,
+		link.MeetingID,
+		link.TokenHash,
+		nullString(link.PasscodeHash),
+		link.ExpiresAt,
+		link.MaxUses,
+		link.UseCount,
+		nullString(link.CreatedBy),
+		link.CreatedAt,
+	)
+
+	return err
+}
+
+// GetLinkByTokenHash retrieves a guest link by the hash of its token
+func (r *GuestRepository) GetLinkByTokenHash(ctx context.Context, tokenHash string) (*entities.GuestLink, error) {
+	query := `
+		SELECT id, meeting_id, token_hash, passcode_hash, expires_at, max_uses, use_count, created_by, created_at, revoked_at
+		FROM meeting_guest_links
+		WHERE token_hash = $1
+	`
+
+	link, err := scanGuestLink(r.db.QueryRowContext(ctx, query, tokenHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrGuestLinkNotFound
+		}
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// ListLinks retrieves the guest links of a meeting, newest first
+func (r *GuestRepository) ListLinks(ctx context.Context, meetingID string) ([]*entities.GuestLink, error) {
+	query := `
+		SELECT id, meeting_id, token_hash, passcode_hash, expires_at, max_uses, use_count, created_by, created_at, revoked_at
+		FROM meeting_guest_links
+		WHERE meeting_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make([]*entities.GuestLink, 0)
+	for rows.Next() {
+		link, err := scanGuestLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// RevokeLink revokes a guest link of a meeting
+func (r *GuestRepository) RevokeLink(ctx context.Context, meetingID, id string, revokedAt time.Time) error {
+	query := `
+		UPDATE meeting_guest_links
+		SET revoked_at = COALESCE(revoked_at, $3)
+		WHERE meeting_id = $1 AND id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, meetingID, id, revokedAt)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrGuestLinkNotFound
+	}
+
+	return nil
+}
+
+// CreateLobbyEntry adds a guest to the lobby and counts a use of the link
+func (r *GuestRepository) CreateLobbyEntry(ctx context.Context, entry *entities.LobbyEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer datastore.Rollback(ctx, tx)
+
+	// Checking and counting in one statement keeps max_uses exact under
+	// concurrent requests
+	useQuery := `
+		UPDATE meeting_guest_links
+		SET use_count = use_count + 1
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2
+		  AND (max_uses = 0 OR use_count < max_uses)
+	`
+
+	result, err := tx.ExecContext(ctx, useQuery, entry.LinkID, entry.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrGuestLinkUnavailable
+	}
+
+	entryQuery := `
+		INSERT INTO meeting_guest_lobby (id, meeting_id, link_id, display_name, email, secret_hash, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	if _, err := tx.ExecContext(ctx, entryQuery,
+		entry.ID,
+		entry.MeetingID,
+		entry.LinkID,
+		entry.DisplayName,
+		nullString(entry.Email),
+		entry.SecretHash,
+		entry.Status,
+		entry.CreatedAt,
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetLobbyEntry retrieves a lobby entry of a meeting by ID
+func (r *GuestRepository) GetLobbyEntry(ctx context.Context, meetingID, id string) (*entities.LobbyEntry, error) {
+	query := `
+		SELECT id, meeting_id, link_id, display_name, email, secret_hash, status, created_at, decided_at, decided_by
+		FROM meeting_guest_lobby
+		WHERE meeting_id = $1 AND id = $2
+	`
+
+	entry, err := scanLobbyEntry(r.db.QueryRowContext(ctx, query, meetingID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrLobbyEntryNotFound
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ListLobby retrieves the lobby entries of a meeting with the given status
+func (r *GuestRepository) ListLobby(ctx context.Context, meetingID string, status entities.LobbyStatus) ([]*entities.LobbyEntry, error) {
+	query := `
+		SELECT id, meeting_id, link_id, display_name, email, secret_hash, status, created_at, decided_at, decided_by
+		FROM meeting_guest_lobby
+		WHERE meeting_id = $1 AND status = $2
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, meetingID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*entities.LobbyEntry, 0)
+	for rows.Next() {
+		entry, err := scanLobbyEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// DecideLobbyEntry stores the decision for a waiting lobby entry
+func (r *GuestRepository) DecideLobbyEntry(ctx context.Context, entry *entities.LobbyEntry) error {
+	query := `
+		UPDATE meeting_guest_lobby
+		SET status = $3, decided_at = $4, decided_by = $5
+		WHERE meeting_id = $1 AND id = $2 AND status = 'waiting'
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		entry.MeetingID,
+		entry.ID,
+		entry.Status,
+		entry.DecidedAt,
+		nullString(entry.DecidedBy),
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrLobbyEntryDecided
+	}
+
+	return nil
+}
+
+func scanGuestLink(row rowScanner) (*entities.GuestLink, error) {
+	link := &entities.GuestLink{}
+	var passcodeHash, createdBy sql.NullString
+	var revokedAt sql.NullTime
+
+	if err := row.Scan(
+		&link.ID,
+		&link.MeetingID,
+		&link.TokenHash,
+		&passcodeHash,
+		&link.ExpiresAt,
+		&link.MaxUses,
+		&link.UseCount,
+		&createdBy,
+		&link.CreatedAt,
+		&revokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	link.PasscodeHash = passcodeHash.String
+	link.CreatedBy = createdBy.String
+	if revokedAt.Valid {
+		link.RevokedAt = &revokedAt.Time
+	}
+
+	return link, nil
+}
+
+func scanLobbyEntry(row rowScanner) (*entities.LobbyEntry, error) {
+	entry := &entities.LobbyEntry{}
+	var email, decidedBy sql.NullString
+	var decidedAt sql.NullTime
+
+	if err := row.Scan(
+		&entry.ID,
+		&entry.MeetingID,
+		&entry.LinkID,
+		&entry.DisplayName,
+		&email,
+		&entry.SecretHash,
+		&entry.Status,
+		&entry.CreatedAt,
+		&decidedAt,
+		&decidedBy,
+	); err != nil {
+		return nil, err
+	}
+
+	entry.Email = email.String
+	entry.DecidedBy = decidedBy.String
+	if decidedAt.Valid {
+		entry.DecidedAt = &decidedAt.Time
+	}
+
+	return entry, nil
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
+	"github.com/lib/pq"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
 	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
 )
 
@@ -21,19 +24,25 @@ func NewMeetingRepository(db *sql.DB) *MeetingRepository {
 // Create creates a new meeting
 func (r *MeetingRepository) Create(ctx context.Context, meeting *entities.Meeting) error {
 	query := `
-		INSERT INTO meetings (id, room_id, title, description, organizer_id, start_time, status, max_participants, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO meetings (id, room_id, title, description, organizer_id, start_time, duration_minutes, status, max_participants,
+			lobby_enabled, start_muted, recording_allowed, room_passcode, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := datastore.Conn(ctx, r.db).ExecContext(ctx, query,
 		meeting.ID,
 		meeting.RoomID,
 		meeting.Title,
 		meeting.Description,
 		meeting.OrganizerID,
 		meeting.StartTime,
+		int(meeting.Duration.Minutes()),
 		meeting.Status,
 		meeting.MaxParticipants,
+		meeting.Settings.LobbyEnabled,
+		meeting.Settings.StartMuted,
+		meeting.Settings.RecordingAllowed,
+		nullString(meeting.Settings.Passcode),
 		meeting.CreatedAt,
 		meeting.UpdatedAt,
 	)
@@ -43,39 +52,112 @@ func (r *MeetingRepository) Create(ctx context.Context, meeting *entities.Meetin
 
 // GetByID retrieves a meeting by ID
 func (r *MeetingRepository) GetByID(ctx context.Context, id string) (*entities.Meeting, error) {
+	return r.getByID(ctx, id, "")
+}
+
+// GetByIDForUpdate retrieves a meeting by ID and locks it until the
+// transaction carried by ctx ends
+func (r *MeetingRepository) GetByIDForUpdate(ctx context.Context, id string) (*entities.Meeting, error) {
+	return r.getByID(ctx, id, "FOR UPDATE")
+}
+
+func (r *MeetingRepository) getByID(ctx context.Context, id, lock string) (*entities.Meeting, error) {
 	query := `
-		SELECT id, room_id, title, description, organizer_id, start_time, end_time, status, jitsi_room_url, recording_url, max_participants, created_at, updated_at
+		SELECT id, room_id, title, description, organizer_id, start_time, duration_minutes, end_time, status, jitsi_room_url, recording_url, max_participants, lobby_enabled, start_muted, recording_allowed, room_passcode, created_at, updated_at
 		FROM meetings
 		WHERE id = $1
+	` + lock
+
+	meeting := &entities.Meeting{}
+	var endTime sql.NullTime
+	var jitsiURL, recordingURL, passcode sql.NullString
+	var durationMinutes int
+
+	err := datastore.Conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&meeting.ID,
+		&meeting.RoomID,
+		&meeting.Title,
+		&meeting.Description,
+		&meeting.OrganizerID,
+		&meeting.StartTime,
+		&durationMinutes,
+		&endTime,
+		&meeting.Status,
+		&jitsiURL,
+		&recordingURL,
+		&meeting.MaxParticipants,
+		&meeting.Settings.LobbyEnabled,
+		&meeting.Settings.StartMuted,
+		&meeting.Settings.RecordingAllowed,
+		&passcode,
+		&meeting.CreatedAt,
+		&meeting.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrMeetingNotFound
+		}
+		return nil, err
+	}
+
+	meeting.Duration = time.Duration(durationMinutes) * time.Minute
+	if endTime.Valid {
+		meeting.EndTime = &endTime.Time
+	}
+	if jitsiURL.Valid {
+		meeting.JitsiRoomURL = jitsiURL.String
+	}
+	if recordingURL.Valid {
+		meeting.RecordingURL = recordingURL.String
+	}
+	meeting.Settings.Passcode = passcode.String
+
+	return meeting, nil
+}
+
+// GetByRoomID retrieves a meeting by its Jitsi room ID
+func (r *MeetingRepository) GetByRoomID(ctx context.Context, roomID string) (*entities.Meeting, error) {
+	query := `
+		SELECT id, room_id, title, description, organizer_id, start_time, duration_minutes, end_time, status, jitsi_room_url, recording_url, max_participants, lobby_enabled, start_muted, recording_allowed, room_passcode, created_at, updated_at
+		FROM meetings
+		WHERE room_id = $1
 	`
 
 	meeting := &entities.Meeting{}
 	var endTime sql.NullTime
-	var jitsiURL, recordingURL sql.NullString
+	var jitsiURL, recordingURL, passcode sql.NullString
+	var durationMinutes int
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, roomID).Scan(
 		&meeting.ID,
 		&meeting.RoomID,
 		&meeting.Title,
 		&meeting.Description,
 		&meeting.OrganizerID,
 		&meeting.StartTime,
+		&durationMinutes,
 		&endTime,
 		&meeting.Status,
 		&jitsiURL,
 		&recordingURL,
 		&meeting.MaxParticipants,
+		&meeting.Settings.LobbyEnabled,
+		&meeting.Settings.StartMuted,
+		&meeting.Settings.RecordingAllowed,
+		&passcode,
 		&meeting.CreatedAt,
 		&meeting.UpdatedAt,
 	)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("meeting not found")
+			return nil, entities.ErrMeetingNotFound
 		}
 		return nil, err
 	}
 
+	meeting.Duration = time.Duration(durationMinutes) * time.Minute
 	if endTime.Valid {
 		meeting.EndTime = &endTime.Time
 	}
@@ -85,6 +167,7 @@ func (r *MeetingRepository) GetByID(ctx context.Context, id string) (*entities.M
 	if recordingURL.Valid {
 		meeting.RecordingURL = recordingURL.String
 	}
+	meeting.Settings.Passcode = passcode.String
 
 	return meeting, nil
 }
@@ -102,7 +185,7 @@ func (r *MeetingRepository) List(ctx context.Context, page, pageSize int, userID
 
 	// Get meetings
 	query := `
-		SELECT id, room_id, title, description, organizer_id, start_time, end_time, status, jitsi_room_url, recording_url, max_participants, created_at, updated_at
+		SELECT id, room_id, title, description, organizer_id, start_time, duration_minutes, end_time, status, jitsi_room_url, recording_url, max_participants, lobby_enabled, start_muted, recording_allowed, room_passcode, created_at, updated_at
 		FROM meetings
 		ORDER BY start_time DESC
 		LIMIT $1 OFFSET $2
@@ -118,7 +201,8 @@ func (r *MeetingRepository) List(ctx context.Context, page, pageSize int, userID
 	for rows.Next() {
 		meeting := &entities.Meeting{}
 		var endTime sql.NullTime
-		var jitsiURL, recordingURL sql.NullString
+		var jitsiURL, recordingURL, passcode sql.NullString
+		var durationMinutes int
 
 		if err := rows.Scan(
 			&meeting.ID,
@@ -127,17 +211,23 @@ func (r *MeetingRepository) List(ctx context.Context, page, pageSize int, userID
 			&meeting.Description,
 			&meeting.OrganizerID,
 			&meeting.StartTime,
+			&durationMinutes,
 			&endTime,
 			&meeting.Status,
 			&jitsiURL,
 			&recordingURL,
 			&meeting.MaxParticipants,
+			&meeting.Settings.LobbyEnabled,
+			&meeting.Settings.StartMuted,
+			&meeting.Settings.RecordingAllowed,
+			&passcode,
 			&meeting.CreatedAt,
 			&meeting.UpdatedAt,
 		); err != nil {
 			return nil, 0, err
 		}
 
+		meeting.Duration = time.Duration(durationMinutes) * time.Minute
 		if endTime.Valid {
 			meeting.EndTime = &endTime.Time
 		}
@@ -147,6 +237,7 @@ func (r *MeetingRepository) List(ctx context.Context, page, pageSize int, userID
 		if recordingURL.Valid {
 			meeting.RecordingURL = recordingURL.String
 		}
+		meeting.Settings.Passcode = passcode.String
 
 		meetings = append(meetings, meeting)
 	}
@@ -167,7 +258,7 @@ func (r *MeetingRepository) ListByOrganizer(ctx context.Context, organizerID str
 
 	// Get meetings
 	query := `
-		SELECT id, room_id, title, description, organizer_id, start_time, end_time, status, jitsi_room_url, recording_url, max_participants, created_at, updated_at
+		SELECT id, room_id, title, description, organizer_id, start_time, duration_minutes, end_time, status, jitsi_room_url, recording_url, max_participants, lobby_enabled, start_muted, recording_allowed, room_passcode, created_at, updated_at
 		FROM meetings
 		WHERE organizer_id = $1
 		ORDER BY start_time DESC
@@ -184,7 +275,8 @@ func (r *MeetingRepository) ListByOrganizer(ctx context.Context, organizerID str
 	for rows.Next() {
 		meeting := &entities.Meeting{}
 		var endTime sql.NullTime
-		var jitsiURL, recordingURL sql.NullString
+		var jitsiURL, recordingURL, passcode sql.NullString
+		var durationMinutes int
 
 		if err := rows.Scan(
 			&meeting.ID,
@@ -193,17 +285,23 @@ func (r *MeetingRepository) ListByOrganizer(ctx context.Context, organizerID str
 			&meeting.Description,
 			&meeting.OrganizerID,
 			&meeting.StartTime,
+			&durationMinutes,
 			&endTime,
 			&meeting.Status,
 			&jitsiURL,
 			&recordingURL,
 			&meeting.MaxParticipants,
+			&meeting.Settings.LobbyEnabled,
+			&meeting.Settings.StartMuted,
+			&meeting.Settings.RecordingAllowed,
+			&passcode,
 			&meeting.CreatedAt,
 			&meeting.UpdatedAt,
 		); err != nil {
 			return nil, 0, err
 		}
 
+		meeting.Duration = time.Duration(durationMinutes) * time.Minute
 		if endTime.Valid {
 			meeting.EndTime = &endTime.Time
 		}
@@ -213,6 +311,7 @@ func (r *MeetingRepository) ListByOrganizer(ctx context.Context, organizerID str
 		if recordingURL.Valid {
 			meeting.RecordingURL = recordingURL.String
 		}
+		meeting.Settings.Passcode = passcode.String
 
 		meetings = append(meetings, meeting)
 	}
@@ -220,24 +319,30 @@ func (r *MeetingRepository) ListByOrganizer(ctx context.Context, organizerID str
 	return meetings, total, nil
 }
 
-// Update updates a meeting
+// Update updates a meeting, inside the transaction of ctx if there is one
 func (r *MeetingRepository) Update(ctx context.Context, meeting *entities.Meeting) error {
 	query := `
 		UPDATE meetings
-		SET title = $2, description = $3, start_time = $4, end_time = $5, status = $6, jitsi_room_url = $7, recording_url = $8, updated_at = $9
+		SET title = $2, description = $3, start_time = $4, duration_minutes = $5, end_time = $6, status = $7, jitsi_room_url = $8, recording_url = $9, updated_at = $10,
+			lobby_enabled = $11, start_muted = $12, recording_allowed = $13, room_passcode = $14
 		WHERE id = $1
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := datastore.Conn(ctx, r.db).ExecContext(ctx, query,
 		meeting.ID,
 		meeting.Title,
 		meeting.Description,
 		meeting.StartTime,
+		int(meeting.Duration.Minutes()),
 		meeting.EndTime,
 		meeting.Status,
 		meeting.JitsiRoomURL,
 		meeting.RecordingURL,
 		meeting.UpdatedAt,
+		meeting.Settings.LobbyEnabled,
+		meeting.Settings.StartMuted,
+		meeting.Settings.RecordingAllowed,
+		nullString(meeting.Settings.Passcode),
 	)
 
 	return err
@@ -253,7 +358,7 @@ func (r *MeetingRepository) Delete(ctx context.Context, id string) error {
 // GetUpcoming retrieves upcoming meetings
 func (r *MeetingRepository) GetUpcoming(ctx context.Context, userID string, limit int) ([]*entities.Meeting, error) {
 	query := `
-		SELECT id, room_id, title, description, organizer_id, start_time, end_time, status, jitsi_room_url, recording_url, max_participants, created_at, updated_at
+		SELECT id, room_id, title, description, organizer_id, start_time, duration_minutes, end_time, status, jitsi_room_url, recording_url, max_participants, lobby_enabled, start_muted, recording_allowed, room_passcode, created_at, updated_at
 		FROM meetings
 		WHERE status IN ('scheduled', 'ongoing')
 		ORDER BY start_time ASC
@@ -270,7 +375,8 @@ func (r *MeetingRepository) GetUpcoming(ctx context.Context, userID string, limi
 	for rows.Next() {
 		meeting := &entities.Meeting{}
 		var endTime sql.NullTime
-		var jitsiURL, recordingURL sql.NullString
+		var jitsiURL, recordingURL, passcode sql.NullString
+		var durationMinutes int
 
 		if err := rows.Scan(
 			&meeting.ID,
@@ -279,17 +385,23 @@ func (r *MeetingRepository) GetUpcoming(ctx context.Context, userID string, limi
 			&meeting.Description,
 			&meeting.OrganizerID,
 			&meeting.StartTime,
+			&durationMinutes,
 			&endTime,
 			&meeting.Status,
 			&jitsiURL,
 			&recordingURL,
 			&meeting.MaxParticipants,
+			&meeting.Settings.LobbyEnabled,
+			&meeting.Settings.StartMuted,
+			&meeting.Settings.RecordingAllowed,
+			&passcode,
 			&meeting.CreatedAt,
 			&meeting.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
 
+		meeting.Duration = time.Duration(durationMinutes) * time.Minute
 		if endTime.Valid {
 			meeting.EndTime = &endTime.Time
 		}
@@ -299,9 +411,251 @@ func (r *MeetingRepository) GetUpcoming(ctx context.Context, userID string, limi
 		if recordingURL.Valid {
 			meeting.RecordingURL = recordingURL.String
 		}
+		meeting.Settings.Passcode = passcode.String
 
 		meetings = append(meetings, meeting)
 	}
 
 	return meetings, nil
 }
+
+// AddParticipants adds participants to a meeting, ignoring existing ones
+func (r *MeetingRepository) AddParticipants(ctx context.Context, participants []*entities.Participant) error {
+	query := `
+		INSERT INTO meeting_participants (meeting_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (meeting_id, user_id) DO NOTHING
+	`
+
+	conn := datastore.Conn(ctx, r.db)
+	for _, participant := range participants {
+		if _, err := conn.ExecContext(ctx, query,
+			participant.MeetingID,
+			participant.UserID,
+			participant.Role,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListParticipants retrieves the participants of a meeting
+func (r *MeetingRepository) ListParticipants(ctx context.Context, meetingID string) ([]*entities.Participant, error) {
+	query := `
+		SELECT meeting_id, user_id, role, joined_at, left_at
+		FROM meeting_participants
+		WHERE meeting_id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	participants := make([]*entities.Participant, 0)
+	for rows.Next() {
+		participant := &entities.Participant{}
+		var joinedAt, leftAt sql.NullTime
+
+		if err := rows.Scan(
+			&participant.MeetingID,
+			&participant.UserID,
+			&participant.Role,
+			&joinedAt,
+			&leftAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if joinedAt.Valid {
+			participant.JoinedAt = &joinedAt.Time
+		}
+		if leftAt.Valid {
+			participant.LeftAt = &leftAt.Time
+		}
+
+		participants = append(participants, participant)
+	}
+
+	return participants, rows.Err()
+}
+
+// ListBusy retrieves active meetings of the given users overlapping [from, to)
+func (r *MeetingRepository) ListBusy(ctx context.Context, userIDs []string, from, to time.Time) ([]*entities.BusyInterval, error) {
+	// Organizers are matched directly so meetings created before participants
+	// were recorded still count as busy time.
+	query := `
+		SELECT user_id, id, title, start_time, end_time
+		FROM (
+			SELECT p.user_id, m.id, m.title, m.start_time,
+			       m.start_time + make_interval(mins => m.duration_minutes) AS end_time
+			FROM meetings m
+			JOIN meeting_participants p ON p.meeting_id = m.id
+			WHERE p.user_id = ANY($1) AND m.status IN ('scheduled', 'ongoing')
+			UNION
+			SELECT m.organizer_id, m.id, m.title, m.start_time,
+			       m.start_time + make_interval(mins => m.duration_minutes) AS end_time
+			FROM meetings m
+			WHERE m.organizer_id = ANY($1) AND m.status IN ('scheduled', 'ongoing')
+		) busy
+		WHERE start_time < $3 AND end_time > $2
+		ORDER BY start_time ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs), from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	intervals := make([]*entities.BusyInterval, 0)
+	for rows.Next() {
+		interval := &entities.BusyInterval{}
+		if err := rows.Scan(
+			&interval.UserID,
+			&interval.MeetingID,
+			&interval.Title,
+			&interval.Start,
+			&interval.End,
+		); err != nil {
+			return nil, err
+		}
+		intervals = append(intervals, interval)
+	}
+
+	return intervals, rows.Err()
+}
+
+// ClaimDueReminders marks scheduled meetings starting in (now, before] as
+// reminded and returns them. SKIP LOCKED keeps concurrent callers from
+// claiming the same meeting.
+func (r *MeetingRepository) ClaimDueReminders(ctx context.Context, now, before time.Time) ([]*entities.Meeting, error) {
+	query := `
+		UPDATE meetings
+		SET reminder_sent_at = $1
+		WHERE id IN (
+			SELECT id FROM meetings
+			WHERE status = 'scheduled' AND reminder_sent_at IS NULL
+			  AND start_time > $1 AND start_time <= $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, room_id, title, description, organizer_id, start_time, duration_minutes, end_time, status, jitsi_room_url, recording_url, max_participants, lobby_enabled, start_muted, recording_allowed, room_passcode, created_at, updated_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, now, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	meetings := make([]*entities.Meeting, 0)
+	for rows.Next() {
+		meeting := &entities.Meeting{}
+		var endTime sql.NullTime
+		var jitsiURL, recordingURL, passcode sql.NullString
+		var durationMinutes int
+
+		if err := rows.Scan(
+			&meeting.ID,
+			&meeting.RoomID,
+			&meeting.Title,
+			&meeting.Description,
+			&meeting.OrganizerID,
+			&meeting.StartTime,
+			&durationMinutes,
+			&endTime,
+			&meeting.Status,
+			&jitsiURL,
+			&recordingURL,
+			&meeting.MaxParticipants,
+			&meeting.Settings.LobbyEnabled,
+			&meeting.Settings.StartMuted,
+			&meeting.Settings.RecordingAllowed,
+			&passcode,
+			&meeting.CreatedAt,
+			&meeting.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		meeting.Duration = time.Duration(durationMinutes) * time.Minute
+		if endTime.Valid {
+			meeting.EndTime = &endTime.Time
+		}
+		if jitsiURL.Valid {
+			meeting.JitsiRoomURL = jitsiURL.String
+		}
+		if recordingURL.Valid {
+			meeting.RecordingURL = recordingURL.String
+		}
+		meeting.Settings.Passcode = passcode.String
+
+		meetings = append(meetings, meeting)
+	}
+
+	return meetings, rows.Err()
+}
+
+// ReleaseReminder clears the reminder claim of a meeting
+func (r *MeetingRepository) ReleaseReminder(ctx context.Context, meetingID string) error {
+	query := `UPDATE meetings SET reminder_sent_at = NULL WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, meetingID)
+	return err
+}
+
+// CloseOverdue marks meetings whose scheduled end is before cutoff as
+// missed (never started) or completed (ongoing) and returns the changes
+func (r *MeetingRepository) CloseOverdue(ctx context.Context, cutoff time.Time) ([]*entities.StatusChange, error) {
+	now := time.Now()
+
+	missedQuery := `
+		UPDATE meetings
+		SET status = 'missed', updated_at = $2
+		WHERE status = 'scheduled'
+		  AND start_time + make_interval(mins => duration_minutes) < $1
+		RETURNING id, organizer_id
+	`
+	changes, err := r.collectStatusChanges(ctx, entities.StatusMissed, now, missedQuery, cutoff, now)
+	if err != nil {
+		return nil, err
+	}
+
+	completedQuery := `
+		UPDATE meetings
+		SET status = 'completed',
+		    end_time = start_time + make_interval(mins => duration_minutes),
+		    updated_at = $2
+		WHERE status = 'ongoing'
+		  AND start_time + make_interval(mins => duration_minutes) < $1
+		RETURNING id, organizer_id
+	`
+	completed, err := r.collectStatusChanges(ctx, entities.StatusCompleted, now, completedQuery, cutoff, now)
+	if err != nil {
+		return changes, err
+	}
+
+	return append(changes, completed...), nil
+}
+
+// collectStatusChanges runs a status update returning id and organizer_id
+func (r *MeetingRepository) collectStatusChanges(ctx context.Context, status entities.MeetingStatus, changedAt time.Time, query string, args ...interface{}) ([]*entities.StatusChange, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]*entities.StatusChange, 0)
+	for rows.Next() {
+		change := &entities.StatusChange{Status: status, ChangedAt: changedAt}
+		if err := rows.Scan(&change.MeetingID, &change.OrganizerID); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
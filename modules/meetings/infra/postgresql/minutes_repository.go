@@ -0,0 +1,367 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// MinutesRepository implements repository.MinutesRepository
+type MinutesRepository struct {
+	db *sql.DB
+}
+
+// NewMinutesRepository creates a new MinutesRepository
+func NewMinutesRepository(db *sql.DB) *MinutesRepository {
+	return &MinutesRepository{db: db}
+}
+
+// ReplaceAgenda replaces the agenda of a meeting. It joins the transaction
+// carried by ctx, or runs in its own.
+func (r *MinutesRepository) ReplaceAgenda(ctx context.Context, meetingID string, items []*entities.AgendaItem) error {
+	if _, ok := datastore.TxFromContext(ctx); ok {
+		return replaceAgenda(ctx, datastore.Conn(ctx, r.db), meetingID, items)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer datastore.Rollback(ctx, tx)
+
+	if err := replaceAgenda(ctx, tx, meetingID, items); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func replaceAgenda(ctx context.Context, conn datastore.Executor, meetingID string, items []*entities.AgendaItem) error {
+	if _, err := conn.ExecContext(ctx, `DELETE FROM meeting_agenda_items WHERE meeting_id = $1`, meetingID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO meeting_agenda_items (id, meeting_id, position, title, description, duration_minutes, presenter_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	for _, item := range items {
+		if _, err := conn.ExecContext(ctx, query,
+			item.ID,
+			meetingID,
+			item.Position,
+			item.Title,
+			nullString(item.Description),
+			sql.NullInt64{Int64: int64(item.DurationMinutes), Valid: item.DurationMinutes > 0},
+			nullString(item.PresenterID),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListAgenda retrieves the agenda of a meeting in order
+func (r *MinutesRepository) ListAgenda(ctx context.Context, meetingID string) ([]*entities.AgendaItem, error) {
+	query := `
+		SELECT id, meeting_id, position, title, description, duration_minutes, presenter_id
+		FROM meeting_agenda_items
+		WHERE meeting_id = $1
+		ORDER BY position ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entities.AgendaItem, 0)
+	for rows.Next() {
+		item := &entities.AgendaItem{}
+		var description, presenterID sql.NullString
+		var durationMinutes sql.NullInt64
+
+		if err := rows.Scan(
+			&item.ID,
+			&item.MeetingID,
+			&item.Position,
+			&item.Title,
+			&description,
+			&durationMinutes,
+			&presenterID,
+		); err != nil {
+			return nil, err
+		}
+
+		item.Description = description.String
+		item.DurationMinutes = int(durationMinutes.Int64)
+		item.PresenterID = presenterID.String
+
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// GetNotes retrieves the latest notes revision
+func (r *MinutesRepository) GetNotes(ctx context.Context, meetingID string) (*entities.NoteRevision, error) {
+	query := `
+		SELECT meeting_id, revision, content, edited_by, created_at
+		FROM meeting_note_revisions
+		WHERE meeting_id = $1
+		ORDER BY revision DESC
+		LIMIT 1
+	`
+
+	revision, err := scanNoteRevision(r.db.QueryRowContext(ctx, query, meetingID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// SaveNotes stores a new revision if baseRevision is still the latest
+func (r *MinutesRepository) SaveNotes(ctx context.Context, revision *entities.NoteRevision, baseRevision int) error {
+	query := `
+		INSERT INTO meeting_note_revisions (meeting_id, revision, content, edited_by, created_at)
+		SELECT $1, $2::int + 1, $3, $4, $5
+		WHERE COALESCE((SELECT MAX(revision) FROM meeting_note_revisions WHERE meeting_id = $1), 0) = $2::int
+		ON CONFLICT (meeting_id, revision) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		revision.MeetingID,
+		baseRevision,
+		revision.Content,
+		nullString(revision.EditedBy),
+		revision.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	saved, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if saved == 0 {
+		return entities.ErrNotesConflict
+	}
+
+	revision.Revision = baseRevision + 1
+	return nil
+}
+
+// ListNoteRevisions retrieves all notes revisions, newest first
+func (r *MinutesRepository) ListNoteRevisions(ctx context.Context, meetingID string) ([]*entities.NoteRevision, error) {
+	query := `
+		SELECT meeting_id, revision, content, edited_by, created_at
+		FROM meeting_note_revisions
+		WHERE meeting_id = $1
+		ORDER BY revision DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]*entities.NoteRevision, 0)
+	for rows.Next() {
+		revision, err := scanNoteRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// GetNoteRevision retrieves one notes revision
+func (r *MinutesRepository) GetNoteRevision(ctx context.Context, meetingID string, revision int) (*entities.NoteRevision, error) {
+	query := `
+		SELECT meeting_id, revision, content, edited_by, created_at
+		FROM meeting_note_revisions
+		WHERE meeting_id = $1 AND revision = $2
+	`
+
+	noteRevision, err := scanNoteRevision(r.db.QueryRowContext(ctx, query, meetingID, revision))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+
+	return noteRevision, nil
+}
+
+// CreateActionItem creates a new action item
+func (r *MinutesRepository) CreateActionItem(ctx context.Context, item *entities.ActionItem) error {
+	query := `
+		INSERT INTO meeting_action_items (id, meeting_id, title, description, assignee_id, due_date, status, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		item.ID,
+		item.MeetingID,
+		item.Title,
+		nullString(item.Description),
+		item.AssigneeID,
+		item.DueDate,
+		item.Status,
+		nullString(item.CreatedBy),
+		item.CreatedAt,
+		item.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetActionItem retrieves an action item of a meeting by ID
+func (r *MinutesRepository) GetActionItem(ctx context.Context, meetingID, id string) (*entities.ActionItem, error) {
+	query := `
+		SELECT id, meeting_id, title, description, assignee_id, due_date, status, created_by, created_at, updated_at, completed_at
+		FROM meeting_action_items
+		WHERE meeting_id = $1 AND id = $2
+	`
+
+	item, err := scanActionItem(r.db.QueryRowContext(ctx, query, meetingID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrActionItemNotFound
+		}
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// UpdateActionItem updates an action item
+func (r *MinutesRepository) UpdateActionItem(ctx context.Context, item *entities.ActionItem) error {
+	query := `
+		UPDATE meeting_action_items
+		SET title = $2, description = $3, assignee_id = $4, due_date = $5, status = $6, completed_at = $7, updated_at = $8
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		item.ID,
+		item.Title,
+		nullString(item.Description),
+		item.AssigneeID,
+		item.DueDate,
+		item.Status,
+		item.CompletedAt,
+		item.UpdatedAt,
+	)
+
+	return err
+}
+
+// ListActionItems retrieves the action items of a meeting
+func (r *MinutesRepository) ListActionItems(ctx context.Context, meetingID string) ([]*entities.ActionItem, error) {
+	query := `
+		SELECT id, meeting_id, title, description, assignee_id, due_date, status, created_by, created_at, updated_at, completed_at
+		FROM meeting_action_items
+		WHERE meeting_id = $1
+		ORDER BY created_at ASC
+	`
+
+	return r.queryActionItems(ctx, query, meetingID)
+}
+
+// ListAssignedActionItems retrieves action items assigned to a user
+func (r *MinutesRepository) ListAssignedActionItems(ctx context.Context, assigneeID string, openOnly bool) ([]*entities.ActionItem, error) {
+	query := `
+		SELECT id, meeting_id, title, description, assignee_id, due_date, status, created_by, created_at, updated_at, completed_at
+		FROM meeting_action_items
+		WHERE assignee_id = $1 AND (NOT $2 OR status IN ('open', 'in_progress'))
+		ORDER BY due_date ASC NULLS LAST, created_at ASC
+	`
+
+	return r.queryActionItems(ctx, query, assigneeID, openOnly)
+}
+
+func (r *MinutesRepository) queryActionItems(ctx context.Context, query string, args ...interface{}) ([]*entities.ActionItem, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entities.ActionItem, 0)
+	for rows.Next() {
+		item, err := scanActionItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+func scanNoteRevision(row rowScanner) (*entities.NoteRevision, error) {
+	revision := &entities.NoteRevision{}
+	var editedBy sql.NullString
+
+	if err := row.Scan(
+		&revision.MeetingID,
+		&revision.Revision,
+		&revision.Content,
+		&editedBy,
+		&revision.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	revision.EditedBy = editedBy.String
+	return revision, nil
+}
+
+func scanActionItem(row rowScanner) (*entities.ActionItem, error) {
+	item := &entities.ActionItem{}
+	var description, createdBy sql.NullString
+	var dueDate, completedAt sql.NullTime
+
+	if err := row.Scan(
+		&item.ID,
+		&item.MeetingID,
+		&item.Title,
+		&description,
+		&item.AssigneeID,
+		&dueDate,
+		&item.Status,
+		&createdBy,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+		&completedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	item.Description = description.String
+	item.CreatedBy = createdBy.String
+	if dueDate.Valid {
+		item.DueDate = &dueDate.Time
+	}
+	if completedAt.Valid {
+		item.CompletedAt = &completedAt.Time
+	}
+
+	return item, nil
+}
@@ -0,0 +1,151 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// RecordingRepository implements repository.RecordingRepository
+type RecordingRepository struct {
+	db *sql.DB
+}
+
+// NewRecordingRepository creates a new RecordingRepository
+func NewRecordingRepository(db *sql.DB) *RecordingRepository {
+	return &RecordingRepository{db: db}
+}
+
+// Create creates a new recording
+func (r *RecordingRepository) Create(ctx context.Context, recording *entities.Recording) error {
+	query := `
+		INSERT INTO meeting_recordings (id, meeting_id, object_key, file_name, content_type, size_bytes, upload_id, status, uploaded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		recording.ID,
+		recording.MeetingID,
+		recording.ObjectKey,
+		recording.FileName,
+		recording.ContentType,
+		recording.SizeBytes,
+		nullString(recording.UploadID),
+		recording.Status,
+		nullString(recording.UploadedBy),
+		recording.CreatedAt,
+	)
+
+	return err
+}
+
+// GetByID retrieves a recording of a meeting by ID
+func (r *RecordingRepository) GetByID(ctx context.Context, meetingID, id string) (*entities.Recording, error) {
+	query := `
+		SELECT id, meeting_id, object_key, file_name, content_type, size_bytes, upload_id, status, uploaded_by, created_at, completed_at
+		FROM meeting_recordings
+		WHERE meeting_id = $1 AND id = $2
+	`
+
+	recording, err := scanRecording(r.db.QueryRowContext(ctx, query, meetingID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrRecordingNotFound
+		}
+		return nil, err
+	}
+
+	return recording, nil
+}
+
+// ListByMeeting retrieves the recordings of a meeting
+func (r *RecordingRepository) ListByMeeting(ctx context.Context, meetingID string) ([]*entities.Recording, error) {
+	query := `
+		SELECT id, meeting_id, object_key, file_name, content_type, size_bytes, upload_id, status, uploaded_by, created_at, completed_at
+		FROM meeting_recordings
+		WHERE meeting_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recordings := make([]*entities.Recording, 0)
+	for rows.Next() {
+		recording, err := scanRecording(rows)
+		if err != nil {
+			return nil, err
+		}
+		recordings = append(recordings, recording)
+	}
+
+	return recordings, rows.Err()
+}
+
+// Update updates a recording
+func (r *RecordingRepository) Update(ctx context.Context, recording *entities.Recording) error {
+	query := `
+		UPDATE meeting_recordings
+		SET upload_id = $2, status = $3, completed_at = $4
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		recording.ID,
+		nullString(recording.UploadID),
+		recording.Status,
+		recording.CompletedAt,
+	)
+
+	return err
+}
+
+// Delete deletes a recording
+func (r *RecordingRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM meeting_recordings WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRecording(row rowScanner) (*entities.Recording, error) {
+	recording := &entities.Recording{}
+	var uploadID, uploadedBy sql.NullString
+	var completedAt sql.NullTime
+
+	if err := row.Scan(
+		&recording.ID,
+		&recording.MeetingID,
+		&recording.ObjectKey,
+		&recording.FileName,
+		&recording.ContentType,
+		&recording.SizeBytes,
+		&uploadID,
+		&recording.Status,
+		&uploadedBy,
+		&recording.CreatedAt,
+		&completedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	recording.UploadID = uploadID.String
+	recording.UploadedBy = uploadedBy.String
+	if completedAt.Valid {
+		recording.CompletedAt = &completedAt.Time
+	}
+
+	return recording, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,210 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// TemplateRepository implements repository.TemplateRepository
+type TemplateRepository struct {
+	db *sql.DB
+}
+
+// NewTemplateRepository creates a new TemplateRepository
+func NewTemplateRepository(db *sql.DB) *TemplateRepository {
+	return &TemplateRepository{db: db}
+}
+
+// Create creates a new meeting template
+func (r *TemplateRepository) Create(ctx context.Context, template *entities.MeetingTemplate) error {
+	agenda, err := json.Marshal(template.Agenda)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO meeting_templates (id, owner_id, name, title_pattern, description, duration_minutes, max_participants, invitee_ids, agenda,
+			lobby_enabled, start_muted, recording_allowed, room_passcode, shared, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		template.ID,
+		template.OwnerID,
+		template.Name,
+		template.TitlePattern,
+		nullString(template.Description),
+		int(template.Duration.Minutes()),
+		template.MaxParticipants,
+		pq.Array(template.InviteeIDs),
+		agenda,
+		template.Settings.LobbyEnabled,
+		template.Settings.StartMuted,
+		template.Settings.RecordingAllowed,
+		nullString(template.Settings.Passcode),
+		template.Shared,
+		template.CreatedAt,
+		template.UpdatedAt,
+	)
+
+	return err
+}
+
+// GetByID retrieves a meeting template by ID
+func (r *TemplateRepository) GetByID(ctx context.Context, id string) (*entities.MeetingTemplate, error) {
+	query := `
+		SELECT id, owner_id, name, title_pattern, description, duration_minutes, max_participants, invitee_ids, agenda,
+			lobby_enabled, start_muted, recording_allowed, room_passcode, shared, created_at, updated_at
+		FROM meeting_templates
+		WHERE id = $1
+	`
+
+	template, err := scanTemplate(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// ListVisible retrieves the templates a user owns and the shared templates
+// of others, ordered by name
+func (r *TemplateRepository) ListVisible(ctx context.Context, userID string) ([]*entities.MeetingTemplate, error) {
+	query := `
+		SELECT id, owner_id, name, title_pattern, description, duration_minutes, max_participants, invitee_ids, agenda,
+			lobby_enabled, start_muted, recording_allowed, room_passcode, shared, created_at, updated_at
+		FROM meeting_templates
+		WHERE owner_id = $1 OR shared
+		ORDER BY lower(name), created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]*entities.MeetingTemplate, 0)
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, rows.Err()
+}
+
+// Update updates a meeting template
+func (r *TemplateRepository) Update(ctx context.Context, template *entities.MeetingTemplate) error {
+	agenda, err := json.Marshal(template.Agenda)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE meeting_templates
+		SET name = $2, title_pattern = $3, description = $4, duration_minutes = $5, max_participants = $6, invitee_ids = $7, agenda = $8,
+			lobby_enabled = $9, start_muted = $10, recording_allowed = $11, room_passcode = $12, shared = $13, updated_at = $14
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		template.ID,
+		template.Name,
+		template.TitlePattern,
+		nullString(template.Description),
+		int(template.Duration.Minutes()),
+		template.MaxParticipants,
+		pq.Array(template.InviteeIDs),
+		agenda,
+		template.Settings.LobbyEnabled,
+		template.Settings.StartMuted,
+		template.Settings.RecordingAllowed,
+		nullString(template.Settings.Passcode),
+		template.Shared,
+		template.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes a meeting template
+func (r *TemplateRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM meeting_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+func scanTemplate(row rowScanner) (*entities.MeetingTemplate, error) {
+	template := &entities.MeetingTemplate{}
+	var description, passcode sql.NullString
+	var durationMinutes int
+	var agenda []byte
+
+	if err := row.Scan(
+		&template.ID,
+		&template.OwnerID,
+		&template.Name,
+		&template.TitlePattern,
+		&description,
+		&durationMinutes,
+		&template.MaxParticipants,
+		pq.Array(&template.InviteeIDs),
+		&agenda,
+		&template.Settings.LobbyEnabled,
+		&template.Settings.StartMuted,
+		&template.Settings.RecordingAllowed,
+		&passcode,
+		&template.Shared,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(agenda, &template.Agenda); err != nil {
+		return nil, err
+	}
+
+	template.Description = description.String
+	template.Duration = time.Duration(durationMinutes) * time.Minute
+	template.Settings.Passcode = passcode.String
+	if template.InviteeIDs == nil {
+		template.InviteeIDs = []string{}
+	}
+
+	return template, nil
+}
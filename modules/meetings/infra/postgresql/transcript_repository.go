@@ -0,0 +1,281 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// segmentInsertBatch is the number of segments inserted per statement
+const segmentInsertBatch = 500
+
+// TranscriptRepository implements repository.TranscriptRepository
+type TranscriptRepository struct {
+	db *sql.DB
+}
+
+// NewTranscriptRepository creates a new TranscriptRepository
+func NewTranscriptRepository(db *sql.DB) *TranscriptRepository {
+	return &TranscriptRepository{db: db}
+}
+
+// SaveTranscript stores a transcript, replacing any earlier one of the meeting
+func (r *TranscriptRepository) SaveTranscript(ctx context.Context, transcript *entities.Transcript) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer datastore.Rollback(ctx, tx)
+
+	// Segments are removed with the transcript by the cascading foreign key
+	if _, err := tx.ExecContext(ctx, `DELETE FROM meeting_transcripts WHERE meeting_id = $1`, transcript.MeetingID); err != nil {
+		return err
+	}
+
+	transcriptQuery := `
+		INSERT INTO meeting_transcripts (meeting_id, format, segment_count, uploaded_by, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	if _, err := tx.ExecContext(ctx, transcriptQuery,
+		transcript.MeetingID,
+		transcript.Format,
+		len(transcript.Segments),
+		nullString(transcript.UploadedBy),
+		transcript.CreatedAt,
+	); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(transcript.Segments); start += segmentInsertBatch {
+		end := start + segmentInsertBatch
+		if end > len(transcript.Segments) {
+			end = len(transcript.Segments)
+		}
+		if err := insertSegments(ctx, tx, transcript.MeetingID, transcript.Segments[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertSegments(ctx context.Context, tx *sql.Tx, meetingID string, segments []entities.TranscriptSegment) error {
+	var query strings.Builder
+	query.WriteString("INSERT INTO meeting_transcript_segments (meeting_id, seq, start_ms, end_ms, speaker, text) VALUES ")
+
+	args := make([]interface{}, 0, len(segments)*6)
+	for i, segment := range segments {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		n := len(args)
+		fmt.Fprintf(&query, "($%d, $%d, $%d, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6)
+		args = append(args,
+			meetingID,
+			segment.Seq,
+			segment.Start.Milliseconds(),
+			segment.End.Milliseconds(),
+			nullString(segment.Speaker),
+			segment.Text,
+		)
+	}
+
+	_, err := tx.ExecContext(ctx, query.String(), args...)
+	return err
+}
+
+// GetTranscript retrieves the transcript of a meeting
+func (r *TranscriptRepository) GetTranscript(ctx context.Context, meetingID string) (*entities.Transcript, error) {
+	transcript := &entities.Transcript{}
+	var uploadedBy sql.NullString
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT meeting_id, format, uploaded_by, created_at
+		FROM meeting_transcripts
+		WHERE meeting_id = $1
+	`, meetingID).Scan(
+		&transcript.MeetingID,
+		&transcript.Format,
+		&uploadedBy,
+		&transcript.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrTranscriptNotFound
+		}
+		return nil, err
+	}
+	transcript.UploadedBy = uploadedBy.String
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT seq, start_ms, end_ms, speaker, text
+		FROM meeting_transcript_segments
+		WHERE meeting_id = $1
+		ORDER BY seq ASC
+	`, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transcript.Segments = make([]entities.TranscriptSegment, 0)
+	for rows.Next() {
+		segment, err := scanSegment(rows)
+		if err != nil {
+			return nil, err
+		}
+		transcript.Segments = append(transcript.Segments, segment)
+	}
+
+	return transcript, rows.Err()
+}
+
+// SaveSummary stores a summary, replacing any earlier one of the meeting
+func (r *TranscriptRepository) SaveSummary(ctx context.Context, summary *entities.Summary) error {
+	keyPoints, err := json.Marshal(summary.KeyPoints)
+	if err != nil {
+		return err
+	}
+	actionItems, err := json.Marshal(summary.ActionItems)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO meeting_summaries (meeting_id, summary, key_points, action_items, generator, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (meeting_id) DO UPDATE
+		SET summary = EXCLUDED.summary,
+		    key_points = EXCLUDED.key_points,
+		    action_items = EXCLUDED.action_items,
+		    generator = EXCLUDED.generator,
+		    created_at = EXCLUDED.created_at
+	`
+
+	_, err = r.db.ExecContext(ctx, query,
+		summary.MeetingID,
+		summary.Text,
+		keyPoints,
+		actionItems,
+		summary.Generator,
+		summary.CreatedAt,
+	)
+
+	return err
+}
+
+// GetSummary retrieves the summary of a meeting
+func (r *TranscriptRepository) GetSummary(ctx context.Context, meetingID string) (*entities.Summary, error) {
+	summary := &entities.Summary{}
+	var keyPoints, actionItems []byte
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT meeting_id, summary, key_points, action_items, generator, created_at
+		FROM meeting_summaries
+		WHERE meeting_id = $1
+	`, meetingID).Scan(
+		&summary.MeetingID,
+		&summary.Text,
+		&keyPoints,
+		&actionItems,
+		&summary.Generator,
+		&summary.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrSummaryNotFound
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(keyPoints, &summary.KeyPoints); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(actionItems, &summary.ActionItems); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// Search finds transcript segments matching a query in the user's meetings
+func (r *TranscriptRepository) Search(ctx context.Context, userID, query string, page, pageSize int) ([]*entities.TranscriptSearchResult, int64, error) {
+	offset := (page - 1) * pageSize
+
+	sqlQuery := `
+		SELECT s.meeting_id, m.title, s.seq, s.start_ms, s.end_ms, s.speaker, s.text,
+		       ts_headline('english', s.text, q, 'StartSel=**, StopSel=**, MaxWords=30, MinWords=10'),
+		       ts_rank(s.search, q),
+		       COUNT(*) OVER ()
+		FROM meeting_transcript_segments s
+		JOIN meetings m ON m.id = s.meeting_id
+		CROSS JOIN websearch_to_tsquery('english', $1) q
+		WHERE s.search @@ q
+		  AND (m.organizer_id = $2 OR EXISTS (
+		      SELECT 1 FROM meeting_participants p
+		      WHERE p.meeting_id = s.meeting_id AND p.user_id = $2
+		  ))
+		ORDER BY ts_rank(s.search, q) DESC, m.start_time DESC, s.seq ASC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, sqlQuery, query, userID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	results := make([]*entities.TranscriptSearchResult, 0)
+	var total int64
+	for rows.Next() {
+		result := &entities.TranscriptSearchResult{}
+		var startMs, endMs int64
+		var speaker sql.NullString
+
+		if err := rows.Scan(
+			&result.MeetingID,
+			&result.MeetingTitle,
+			&result.Segment.Seq,
+			&startMs,
+			&endMs,
+			&speaker,
+			&result.Segment.Text,
+			&result.Snippet,
+			&result.Rank,
+			&total,
+		); err != nil {
+			return nil, 0, err
+		}
+
+		result.Segment.Start = time.Duration(startMs) * time.Millisecond
+		result.Segment.End = time.Duration(endMs) * time.Millisecond
+		result.Segment.Speaker = speaker.String
+		results = append(results, result)
+	}
+
+	return results, total, rows.Err()
+}
+
+func scanSegment(row rowScanner) (entities.TranscriptSegment, error) {
+	var segment entities.TranscriptSegment
+	var startMs, endMs int64
+	var speaker sql.NullString
+
+	if err := row.Scan(&segment.Seq, &startMs, &endMs, &speaker, &segment.Text); err != nil {
+		return segment, err
+	}
+
+	segment.Start = time.Duration(startMs) * time.Millisecond
+	segment.End = time.Duration(endMs) * time.Millisecond
+	segment.Speaker = speaker.String
+
+	return segment, nil
+}
@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// UserScheduleRepository implements repository.UserScheduleRepository
+// on the users table
+type UserScheduleRepository struct {
+	db *sql.DB
+}
+
+// NewUserScheduleRepository creates a new UserScheduleRepository
+func NewUserScheduleRepository(db *sql.DB) *UserScheduleRepository {
+	return &UserScheduleRepository{db: db}
+}
+
+// ListSchedules retrieves the schedules of the given users keyed by user ID
+func (r *UserScheduleRepository) ListSchedules(ctx context.Context, userIDs []string) (map[string]*entities.UserSchedule, error) {
+	query := `
+		SELECT id, timezone,
+			COALESCE(to_char(working_hours_start, 'HH24:MI'), ''),
+			COALESCE(to_char(working_hours_end, 'HH24:MI'), ''),
+			working_days
+		FROM users
+		WHERE id = ANY($1)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := make(map[string]*entities.UserSchedule, len(userIDs))
+	for rows.Next() {
+		schedule := &entities.UserSchedule{}
+		var timezone string
+		if err := rows.Scan(
+			&schedule.UserID,
+			&timezone,
+			&schedule.WorkStart,
+			&schedule.WorkEnd,
+			pq.Array(&schedule.WorkDays),
+		); err != nil {
+			return nil, err
+		}
+
+		// A timezone removed from the tz database falls back to UTC
+		// rather than failing every lookup that includes the user.
+		schedule.Location, err = time.LoadLocation(timezone)
+		if err != nil {
+			logging.FromContext(ctx).Warn("unknown user timezone, using UTC", "target_user_id", schedule.UserID, "timezone", timezone, "error", err)
+			schedule.Location = time.UTC
+		}
+		schedules[schedule.UserID] = schedule
+	}
+
+	return schedules, rows.Err()
+}
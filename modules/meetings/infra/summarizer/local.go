@@ -0,0 +1,215 @@
+package summarizer
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+// LocalGenerator identifies summaries produced by LocalSummarizer
+const LocalGenerator = "local-extractive"
+
+const (
+	// maxActionItems limits the detected action items per transcript
+	maxActionItems = 20
+	// minSentenceWords skips short utterances such as "Sounds good."
+	minSentenceWords = 4
+)
+
+var (
+	sentenceEndPattern = regexp.MustCompile(`([.!?]+)\s+`)
+
+	// actionPatterns match sentences that commit someone to future work
+	actionPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(action item|to-?do|follow[ -]up)\b`),
+		regexp.MustCompile(`(?i)\b(i|we)('ll| will| need to| should| must|'m going to| am going to|'re going to| are going to)\b`),
+		regexp.MustCompile(`(?i)\b(can|could) you\b`),
+		regexp.MustCompile(`(?i)\blet's\b`),
+		regexp.MustCompile(`(?i)\b(by|before|until) (monday|tuesday|wednesday|thursday|friday|tomorrow|next week|end of (the )?(day|week|month))\b`),
+	}
+	// requestPattern marks questions that are still requests for work
+	requestPattern = regexp.MustCompile(`(?i)\b(can|could) you\b`)
+
+	stopWords = toSet(`a about above after again all also am an and any are aren't as at be because been
+		before being below between both but by can could did do does doing don't down during each few for
+		from further had has have having he her here hers him his how i i'm i'll if in into is isn't it it's
+		its itself just know let's like me more most my no nor not now of off okay on once only or other our
+		ours out over own really right same she should so some such than that that's the their theirs them
+		then there these they this those through to too um uh under until up us very was we we'll we're
+		were what when where which while who whom why will with would yeah yes you you're your yours
+		going gonna think well thing things get got one actually maybe sure mean kind sort`)
+)
+
+// LocalSummarizer builds extractive summaries and detects action items with
+// simple heuristics. It needs no network access and its output depends only
+// on the transcript.
+type LocalSummarizer struct {
+	maxKeyPoints     int
+	summarySentences int
+}
+
+// NewLocalSummarizer creates a new LocalSummarizer
+func NewLocalSummarizer() *LocalSummarizer {
+	return &LocalSummarizer{
+		maxKeyPoints:     5,
+		summarySentences: 3,
+	}
+}
+
+// sentence is a sentence of a transcript segment
+type sentence struct {
+	index   int
+	seq     int
+	speaker string
+	text    string
+	words   []string
+	score   float64
+}
+
+// Summarize summarizes transcript segments
+func (s *LocalSummarizer) Summarize(ctx context.Context, segments []entities.TranscriptSegment) (*entities.Summary, error) {
+	sentences := splitSentences(segments)
+
+	frequencies := make(map[string]float64)
+	var maxFrequency float64
+	for _, sent := range sentences {
+		for _, word := range sent.words {
+			frequencies[word]++
+			maxFrequency = math.Max(maxFrequency, frequencies[word])
+		}
+	}
+
+	candidates := make([]*sentence, 0, len(sentences))
+	for _, sent := range sentences {
+		if len(sent.words) < minSentenceWords {
+			continue
+		}
+		var total float64
+		for _, word := range sent.words {
+			total += frequencies[word] / maxFrequency
+		}
+		// Dampen the advantage of long sentences
+		sent.score = total / math.Sqrt(float64(len(sent.words)))
+		candidates = append(candidates, sent)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+
+	keyPoints := topInOrder(candidates, s.maxKeyPoints)
+	summarySentences := topInOrder(candidates, s.summarySentences)
+
+	summary := &entities.Summary{
+		KeyPoints:   make([]string, len(keyPoints)),
+		ActionItems: detectActionItems(sentences),
+		Generator:   LocalGenerator,
+	}
+	for i, sent := range keyPoints {
+		summary.KeyPoints[i] = withSpeaker(sent)
+	}
+
+	texts := make([]string, len(summarySentences))
+	for i, sent := range summarySentences {
+		texts[i] = withSpeaker(sent)
+	}
+	summary.Text = strings.Join(texts, " ")
+
+	return summary, nil
+}
+
+// splitSentences splits segments into sentences, keeping their origin
+func splitSentences(segments []entities.TranscriptSegment) []*sentence {
+	sentences := make([]*sentence, 0, len(segments))
+	for _, segment := range segments {
+		text := sentenceEndPattern.ReplaceAllString(segment.Text, "$1\n")
+		for _, part := range strings.Split(text, "\n") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			sentences = append(sentences, &sentence{
+				index:   len(sentences),
+				seq:     segment.Seq,
+				speaker: segment.Speaker,
+				text:    part,
+				words:   contentWords(part),
+			})
+		}
+	}
+	return sentences
+}
+
+// contentWords returns the lowercase words of text that carry meaning
+func contentWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, "'")
+		if len([]rune(field)) < 3 || stopWords[field] {
+			continue
+		}
+		words = append(words, field)
+	}
+	return words
+}
+
+// topInOrder returns the n best ranked sentences in transcript order
+func topInOrder(ranked []*sentence, n int) []*sentence {
+	if n > len(ranked) {
+		n = len(ranked)
+	}
+
+	top := append([]*sentence(nil), ranked[:n]...)
+	sort.Slice(top, func(i, j int) bool {
+		return top[i].index < top[j].index
+	})
+	return top
+}
+
+// detectActionItems finds sentences that look like commitments or requests
+func detectActionItems(sentences []*sentence) []entities.DetectedActionItem {
+	items := make([]entities.DetectedActionItem, 0)
+	for _, sent := range sentences {
+		if len(items) == maxActionItems {
+			break
+		}
+		if strings.HasSuffix(sent.text, "?") && !requestPattern.MatchString(sent.text) {
+			continue
+		}
+		for _, pattern := range actionPatterns {
+			if pattern.MatchString(sent.text) {
+				items = append(items, entities.DetectedActionItem{
+					Text:    sent.text,
+					Speaker: sent.speaker,
+					Seq:     sent.seq,
+				})
+				break
+			}
+		}
+	}
+	return items
+}
+
+func withSpeaker(sent *sentence) string {
+	if sent.speaker == "" {
+		return sent.text
+	}
+	return sent.speaker + ": " + sent.text
+}
+
+func toSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
@@ -0,0 +1,139 @@
+package summarizer
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+)
+
+func TestDetectActionItems(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"I'll send the slides to the team.", true},
+		{"We need to fix the login bug.", true},
+		{"Could you review the budget?", true},
+		{"Let's meet again on the roadmap.", true},
+		{"Action item: update the pricing page.", true},
+		{"The report is due by Friday.", true},
+		{"Will we need to fix it?", false},
+		{"The demo went well.", false},
+		{"Sounds good.", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			items := detectActionItems(splitSentences([]entities.TranscriptSegment{{Seq: 7, Speaker: "Alice", Text: tt.text}}))
+			if got := len(items) == 1; got != tt.want {
+				t.Fatalf("detected %v, want %v", items, tt.want)
+			}
+			if tt.want && (items[0].Seq != 7 || items[0].Speaker != "Alice" || items[0].Text != tt.text) {
+				t.Fatalf("item = %+v", items[0])
+			}
+		})
+	}
+}
+
+func TestDetectActionItemsIsCapped(t *testing.T) {
+	segments := make([]entities.TranscriptSegment, maxActionItems+5)
+	for i := range segments {
+		segments[i] = entities.TranscriptSegment{Seq: i + 1, Text: "I'll follow up on that."}
+	}
+
+	if items := detectActionItems(splitSentences(segments)); len(items) != maxActionItems {
+		t.Fatalf("detected %d items, want %d", len(items), maxActionItems)
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	sentences := splitSentences([]entities.TranscriptSegment{
+		{Seq: 1, Speaker: "Alice", Text: "Budget is final. Really?! Yes. "},
+		{Seq: 2, Speaker: "Bob", Text: "  "},
+		{Seq: 3, Speaker: "Bob", Text: "Version 2.5 ships today"},
+	})
+
+	var texts []string
+	for i, sent := range sentences {
+		if sent.index != i {
+			t.Fatalf("sentence %d has index %d", i, sent.index)
+		}
+		texts = append(texts, sent.text)
+	}
+	want := []string{"Budget is final.", "Really?!", "Yes.", "Version 2.5 ships today"}
+	if !reflect.DeepEqual(texts, want) {
+		t.Fatalf("sentences = %q, want %q", texts, want)
+	}
+	if sentences[3].seq != 3 || sentences[3].speaker != "Bob" {
+		t.Fatalf("last sentence = %+v, want segment 3 by Bob", sentences[3])
+	}
+}
+
+func TestContentWords(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"We'll ship the new Billing API, okay?", []string{"ship", "new", "billing", "api"}},
+		{"Um, yeah, I think so.", []string{}},
+		{"'Quoted' words and 2024 numbers", []string{"quoted", "words", "2024", "numbers"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := contentWords(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("contentWords(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalSummarizerSummarize(t *testing.T) {
+	segments := []entities.TranscriptSegment{
+		{Seq: 1, Speaker: "Alice", Text: "Welcome everyone."},
+		{Seq: 2, Speaker: "Alice", Text: "The billing migration moves every customer invoice to the new billing service."},
+		{Seq: 3, Speaker: "Bob", Text: "The new billing service handles invoice retries for every customer."},
+		{Seq: 4, Speaker: "Carol", Text: "Lunch was great today, thanks."},
+		{Seq: 5, Speaker: "Bob", Text: "I'll write the billing migration runbook by Friday."},
+		{Seq: 6, Speaker: "Carol", Text: "Okay."},
+	}
+
+	s := &LocalSummarizer{maxKeyPoints: 3, summarySentences: 2}
+	summary, err := s.Summarize(context.Background(), segments)
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+
+	if summary.Generator != LocalGenerator {
+		t.Fatalf("generator = %q, want %q", summary.Generator, LocalGenerator)
+	}
+	wantKeyPoints := []string{
+		"Alice: " + segments[1].Text,
+		"Bob: " + segments[2].Text,
+		"Bob: " + segments[4].Text,
+	}
+	if !reflect.DeepEqual(summary.KeyPoints, wantKeyPoints) {
+		t.Fatalf("key points = %q, want %q", summary.KeyPoints, wantKeyPoints)
+	}
+	if !strings.HasPrefix(summary.Text, "Alice: The billing migration") || strings.Contains(summary.Text, "Lunch") {
+		t.Fatalf("summary = %q", summary.Text)
+	}
+	wantItems := []entities.DetectedActionItem{{Text: segments[4].Text, Speaker: "Bob", Seq: 5}}
+	if !reflect.DeepEqual(summary.ActionItems, wantItems) {
+		t.Fatalf("action items = %+v, want %+v", summary.ActionItems, wantItems)
+	}
+
+	// The output depends only on the transcript
+	again, _ := s.Summarize(context.Background(), segments)
+	if !reflect.DeepEqual(again, summary) {
+		t.Fatal("summarizing the same transcript twice gave different summaries")
+	}
+
+	empty, err := s.Summarize(context.Background(), nil)
+	if err != nil || empty.Text != "" || len(empty.KeyPoints) != 0 || empty.ActionItems == nil {
+		t.Fatalf("empty transcript: summary = %+v, err = %v", empty, err)
+	}
+}
@@ -2,34 +2,406 @@ package dto
 
 import "time"
 
-// CreateMeetingRequest represents a meeting creation request
+// CreateMeetingRequest represents a meeting creation request. Title and
+// duration may be left out when a template provides them.
 type CreateMeetingRequest struct {
-	Title           string    `json:"title" binding:"required"`
-	Description     string    `json:"description"`
-	StartTime       time.Time `json:"start_time" binding:"required"`
-	MaxParticipants int       `json:"max_participants"`
+	Title           string               `json:"title"`
+	Description     string               `json:"description"`
+	StartTime       time.Time            `json:"start_time" binding:"required"`
+	DurationMinutes int                  `json:"duration_minutes" binding:"omitempty,min=1,max=1440"`
+	MaxParticipants int                  `json:"max_participants"`
+	InviteeIDs      []string             `json:"invitee_ids"`
+	TemplateID      string               `json:"template_id"`
+	Settings        *RoomSettingsRequest `json:"settings"`
+}
+
+// RoomSettingsRequest represents room settings changes. Omitted fields keep
+// their value; an empty passcode removes it.
+type RoomSettingsRequest struct {
+	LobbyEnabled     *bool   `json:"lobby_enabled"`
+	StartMuted       *bool   `json:"start_muted"`
+	RecordingAllowed *bool   `json:"recording_allowed"`
+	Passcode         *string `json:"passcode"`
+}
+
+// RoomSettingsResponse represents the room settings of a meeting. The
+// passcode is only shown to hosts and to people joining the room.
+type RoomSettingsResponse struct {
+	LobbyEnabled     bool   `json:"lobby_enabled"`
+	StartMuted       bool   `json:"start_muted"`
+	RecordingAllowed bool   `json:"recording_allowed"`
+	HasPasscode      bool   `json:"has_passcode"`
+	Passcode         string `json:"passcode,omitempty"`
 }
 
 // MeetingResponse represents a meeting response
 type MeetingResponse struct {
-	ID              string     `json:"id"`
-	RoomID          string     `json:"room_id"`
-	Title           string     `json:"title"`
-	Description     string     `json:"description"`
-	OrganizerID     string     `json:"organizer_id"`
-	StartTime       time.Time  `json:"start_time"`
-	EndTime         *time.Time `json:"end_time,omitempty"`
-	Status          string     `json:"status"`
-	JitsiRoomURL    string     `json:"jitsi_room_url,omitempty"`
-	RecordingURL    string     `json:"recording_url,omitempty"`
-	MaxParticipants int        `json:"max_participants"`
-	CreatedAt       time.Time  `json:"created_at"`
+	ID              string               `json:"id"`
+	RoomID          string               `json:"room_id"`
+	Title           string               `json:"title"`
+	Description     string               `json:"description"`
+	OrganizerID     string               `json:"organizer_id"`
+	StartTime       time.Time            `json:"start_time"`
+	DurationMinutes int                  `json:"duration_minutes"`
+	EndTime         *time.Time           `json:"end_time,omitempty"`
+	Status          string               `json:"status"`
+	JitsiRoomURL    string               `json:"jitsi_room_url,omitempty"`
+	RecordingURL    string               `json:"recording_url,omitempty"`
+	MaxParticipants int                  `json:"max_participants"`
+	Settings        RoomSettingsResponse `json:"settings"`
+	CreatedAt       time.Time            `json:"created_at"`
 }
 
 // JoinMeetingResponse represents join meeting response
 type JoinMeetingResponse struct {
-	MeetingID string `json:"meeting_id"`
-	RoomURL   string `json:"room_url"`
-	UserName  string `json:"user_name"`
-	UserEmail string `json:"user_email"`
+	MeetingID  string               `json:"meeting_id"`
+	RoomURL    string               `json:"room_url"`
+	JitsiToken string               `json:"jitsi_token"`
+	Moderator  bool                 `json:"moderator"`
+	Settings   RoomSettingsResponse `json:"settings"`
+	UserName   string               `json:"user_name"`
+	UserEmail  string               `json:"user_email"`
+}
+
+// CreateMeetingResponse represents a created meeting with scheduling conflicts
+// and the participants for whom it falls outside their working hours
+type CreateMeetingResponse struct {
+	MeetingResponse
+	Conflicts           []ConflictResponse `json:"conflicts,omitempty"`
+	OutsideWorkingHours []string           `json:"outside_working_hours,omitempty"`
+}
+
+// ConflictResponse represents an invitee's overlapping meeting.
+// Details of the other meeting are not exposed.
+type ConflictResponse struct {
+	UserID    string    `json:"user_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// AvailabilityQuery represents free/busy and slot suggestion query parameters
+type AvailabilityQuery struct {
+	UserIDs         []string  `form:"user_ids" binding:"required"`
+	From            time.Time `form:"from" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	To              time.Time `form:"to" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	DurationMinutes int       `form:"duration_minutes"`
+}
+
+// BusyIntervalResponse represents a busy interval
+type BusyIntervalResponse struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// FreeBusyResponse represents the busy intervals of a user along with
+// their timezone and working hours in the requested range
+type FreeBusyResponse struct {
+	UserID       string                 `json:"user_id"`
+	Timezone     string                 `json:"timezone"`
+	WorkingHours []TimeSlotResponse     `json:"working_hours"`
+	Busy         []BusyIntervalResponse `json:"busy"`
+}
+
+// TimeSlotResponse represents a free time window
+type TimeSlotResponse struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+// JitsiEventRequest represents a participant event posted by Jitsi (Prosody mod_event_sync)
+type JitsiEventRequest struct {
+	EventName string        `json:"event_name" binding:"required"`
+	RoomName  string        `json:"room_name" binding:"required"`
+	Occupant  JitsiOccupant `json:"occupant"`
+}
+
+// JitsiOccupant represents the participant of a Jitsi event.
+// Timestamps are Unix seconds.
+type JitsiOccupant struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Email    string  `json:"email"`
+	JoinedAt float64 `json:"joined_at"`
+	LeftAt   float64 `json:"left_at"`
+}
+
+// AttendanceRecordResponse represents the attendance of a participant
+type AttendanceRecordResponse struct {
+	UserID             string     `json:"user_id"`
+	Role               string     `json:"role"`
+	FirstJoin          *time.Time `json:"first_join,omitempty"`
+	LastLeave          *time.Time `json:"last_leave,omitempty"`
+	TimePresentSeconds int64      `json:"time_present_seconds"`
+	Sessions           int        `json:"sessions"`
+	NoShow             bool       `json:"no_show"`
+}
+
+// AttendanceReportResponse represents a meeting attendance report
+type AttendanceReportResponse struct {
+	MeetingID string                     `json:"meeting_id"`
+	Attendees []AttendanceRecordResponse `json:"attendees"`
+}
+
+// StartRecordingUploadRequest represents a recording upload start request
+type StartRecordingUploadRequest struct {
+	FileName    string `json:"file_name" binding:"required"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes" binding:"required,min=1"`
+}
+
+// RecordingResponse represents a meeting recording
+type RecordingResponse struct {
+	ID          string     `json:"id"`
+	MeetingID   string     `json:"meeting_id"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	SizeBytes   int64      `json:"size_bytes"`
+	Status      string     `json:"status"`
+	UploadedBy  string     `json:"uploaded_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// UploadPartResponse represents an uploaded part
+type UploadPartResponse struct {
+	PartNumber int    `json:"part_number"`
+	Size       int64  `json:"size"`
+	ETag       string `json:"etag,omitempty"`
+}
+
+// RecordingUploadResponse represents the progress of a recording upload
+type RecordingUploadResponse struct {
+	RecordingResponse
+	UploadedBytes int64                `json:"uploaded_bytes"`
+	MinPartSize   int64                `json:"min_part_size"`
+	Parts         []UploadPartResponse `json:"parts"`
+}
+
+// DownloadURLResponse represents a time-limited download link
+type DownloadURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// AgendaItemRequest represents an agenda item in a set agenda request
+type AgendaItemRequest struct {
+	Title           string `json:"title" binding:"required"`
+	Description     string `json:"description"`
+	DurationMinutes int    `json:"duration_minutes" binding:"min=0"`
+	PresenterID     string `json:"presenter_id"`
+}
+
+// SetAgendaRequest represents a request to replace a meeting agenda
+type SetAgendaRequest struct {
+	Items []AgendaItemRequest `json:"items" binding:"dive"`
+}
+
+// AgendaItemResponse represents an agenda item
+type AgendaItemResponse struct {
+	ID              string `json:"id"`
+	Position        int    `json:"position"`
+	Title           string `json:"title"`
+	Description     string `json:"description,omitempty"`
+	DurationMinutes int    `json:"duration_minutes,omitempty"`
+	PresenterID     string `json:"presenter_id,omitempty"`
+}
+
+// UpdateNotesRequest represents a notes edit. BaseRevision is the revision
+// the edit started from.
+type UpdateNotesRequest struct {
+	Content      string `json:"content"`
+	BaseRevision int    `json:"base_revision" binding:"min=0"`
+}
+
+// NoteRevisionResponse represents a version of meeting notes
+type NoteRevisionResponse struct {
+	MeetingID string     `json:"meeting_id"`
+	Revision  int        `json:"revision"`
+	Content   string     `json:"content"`
+	EditedBy  string     `json:"edited_by,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// CreateActionItemRequest represents an action item creation request
+type CreateActionItemRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	AssigneeID  string `json:"assignee_id" binding:"required"`
+	DueDate     string `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// UpdateActionItemRequest represents an action item update. Omitted fields
+// are left unchanged; an empty due_date clears it.
+type UpdateActionItemRequest struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	AssigneeID  *string `json:"assignee_id"`
+	DueDate     *string `json:"due_date"`
+	Status      *string `json:"status" binding:"omitempty,oneof=open in_progress done cancelled"`
+}
+
+// ActionItemResponse represents a meeting action item
+type ActionItemResponse struct {
+	ID          string     `json:"id"`
+	MeetingID   string     `json:"meeting_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	AssigneeID  string     `json:"assignee_id"`
+	DueDate     string     `json:"due_date,omitempty"`
+	Status      string     `json:"status"`
+	CreatedBy   string     `json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// CreateGuestLinkRequest represents a guest link creation request
+type CreateGuestLinkRequest struct {
+	ExpiresAt *time.Time `json:"expires_at"`
+	Passcode  string     `json:"passcode" binding:"omitempty,min=4,max=64"`
+	MaxUses   int        `json:"max_uses" binding:"min=0"`
+}
+
+// GuestLinkResponse represents a guest link. Token and URL are only
+// returned when the link is created.
+type GuestLinkResponse struct {
+	ID          string     `json:"id"`
+	MeetingID   string     `json:"meeting_id"`
+	Token       string     `json:"token,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	HasPasscode bool       `json:"has_passcode"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	MaxUses     int        `json:"max_uses"`
+	UseCount    int        `json:"use_count"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// GuestLinkInfoResponse represents what guests see before joining
+type GuestLinkInfoResponse struct {
+	MeetingTitle     string    `json:"meeting_title"`
+	StartTime        time.Time `json:"start_time"`
+	DurationMinutes  int       `json:"duration_minutes"`
+	RequiresPasscode bool      `json:"requires_passcode"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// GuestAccessRequest represents a guest's request to join a meeting
+type GuestAccessRequest struct {
+	DisplayName string `json:"display_name" binding:"required,max=100"`
+	Email       string `json:"email" binding:"omitempty,email"`
+	Passcode    string `json:"passcode"`
+}
+
+// LobbyEntryResponse represents a guest in the lobby
+type LobbyEntryResponse struct {
+	ID          string     `json:"id"`
+	DisplayName string     `json:"display_name"`
+	Email       string     `json:"email,omitempty"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DecidedAt   *time.Time `json:"decided_at,omitempty"`
+}
+
+// GuestAccessResponse represents a new lobby entry. The lobby token must be
+// sent as X-Lobby-Token when polling for the host's decision.
+type GuestAccessResponse struct {
+	LobbyEntryResponse
+	LobbyToken string `json:"lobby_token"`
+}
+
+// LobbyStatusResponse represents the state of a guest's lobby entry and,
+// once admitted, the credentials to enter the room
+type LobbyStatusResponse struct {
+	LobbyEntryResponse
+	RoomURL        string     `json:"room_url,omitempty"`
+	JitsiToken     string     `json:"jitsi_token,omitempty"`
+	TokenExpiresAt *time.Time `json:"token_expires_at,omitempty"`
+	RoomPasscode   string     `json:"room_passcode,omitempty"`
+}
+
+// TranscriptSegmentResponse represents a timed transcript segment
+type TranscriptSegmentResponse struct {
+	Seq     int    `json:"seq"`
+	StartMs int64  `json:"start_ms"`
+	EndMs   int64  `json:"end_ms"`
+	Speaker string `json:"speaker,omitempty"`
+	Text    string `json:"text"`
+}
+
+// TranscriptResponse represents a meeting transcript
+type TranscriptResponse struct {
+	MeetingID  string                      `json:"meeting_id"`
+	Format     string                      `json:"format"`
+	UploadedBy string                      `json:"uploaded_by,omitempty"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	Segments   []TranscriptSegmentResponse `json:"segments"`
+}
+
+// DetectedActionItemResponse represents a possible action item found in a transcript
+type DetectedActionItemResponse struct {
+	Text    string `json:"text"`
+	Speaker string `json:"speaker,omitempty"`
+	Seq     int    `json:"seq"`
+}
+
+// SummaryResponse represents a generated meeting summary
+type SummaryResponse struct {
+	MeetingID   string                       `json:"meeting_id"`
+	Summary     string                       `json:"summary"`
+	KeyPoints   []string                     `json:"key_points"`
+	ActionItems []DetectedActionItemResponse `json:"action_items"`
+	Generator   string                       `json:"generator"`
+	CreatedAt   time.Time                    `json:"created_at"`
+}
+
+// IngestTranscriptResponse represents a stored transcript and its summary
+type IngestTranscriptResponse struct {
+	MeetingID    string           `json:"meeting_id"`
+	Format       string           `json:"format"`
+	SegmentCount int              `json:"segment_count"`
+	Summary      *SummaryResponse `json:"summary,omitempty"`
+}
+
+// TranscriptSearchResultResponse represents a transcript segment matching a
+// search. Matched terms in the snippet are wrapped in "**".
+type TranscriptSearchResultResponse struct {
+	MeetingID    string `json:"meeting_id"`
+	MeetingTitle string `json:"meeting_title"`
+	Seq          int    `json:"seq"`
+	StartMs      int64  `json:"start_ms"`
+	EndMs        int64  `json:"end_ms"`
+	Speaker      string `json:"speaker,omitempty"`
+	Snippet      string `json:"snippet"`
+}
+
+// TemplateRequest represents a meeting template create or replace request.
+// The title pattern may contain {date}, {time}, {weekday} and {month}.
+type TemplateRequest struct {
+	Name            string               `json:"name" binding:"required,max=100"`
+	TitlePattern    string               `json:"title_pattern" binding:"required,max=200"`
+	Description     string               `json:"description"`
+	DurationMinutes int                  `json:"duration_minutes" binding:"required,min=1,max=1440"`
+	MaxParticipants int                  `json:"max_participants" binding:"min=0"`
+	InviteeIDs      []string             `json:"invitee_ids"`
+	Agenda          []AgendaItemRequest  `json:"agenda" binding:"dive"`
+	Settings        *RoomSettingsRequest `json:"settings"`
+	Shared          bool                 `json:"shared"`
+}
+
+// TemplateResponse represents a meeting template
+type TemplateResponse struct {
+	ID              string               `json:"id"`
+	OwnerID         string               `json:"owner_id"`
+	Name            string               `json:"name"`
+	TitlePattern    string               `json:"title_pattern"`
+	Description     string               `json:"description,omitempty"`
+	DurationMinutes int                  `json:"duration_minutes"`
+	MaxParticipants int                  `json:"max_participants,omitempty"`
+	InviteeIDs      []string             `json:"invitee_ids"`
+	Agenda          []AgendaItemRequest  `json:"agenda"`
+	Settings        RoomSettingsResponse `json:"settings"`
+	Shared          bool                 `json:"shared"`
+	CreatedAt       time.Time            `json:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at"`
 }
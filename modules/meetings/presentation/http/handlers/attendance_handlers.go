@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/dto"
+)
+
+// JitsiWebhookSecretHeader carries the shared secret of Jitsi webhooks
+const JitsiWebhookSecretHeader = "X-Webhook-Secret"
+
+// AttendanceHandlers contains attendance-related HTTP handlers
+type AttendanceHandlers struct {
+	leaveMeetingUC        *usecases.LeaveMeetingUseCase
+	getAttendanceReportUC *usecases.GetAttendanceReportUseCase
+	handleJitsiEventUC    *usecases.HandleJitsiEventUseCase
+	webhookSecret         string
+}
+
+// NewAttendanceHandlers creates new AttendanceHandlers
+func NewAttendanceHandlers(
+	leaveMeetingUC *usecases.LeaveMeetingUseCase,
+	getAttendanceReportUC *usecases.GetAttendanceReportUseCase,
+	handleJitsiEventUC *usecases.HandleJitsiEventUseCase,
+	webhookSecret string,
+) *AttendanceHandlers {
+	return &AttendanceHandlers{
+		leaveMeetingUC:        leaveMeetingUC,
+		getAttendanceReportUC: getAttendanceReportUC,
+		handleJitsiEventUC:    handleJitsiEventUC,
+		webhookSecret:         webhookSecret,
+	}
+}
+
+// LeaveMeeting leaves a meeting
+// @Summary Leave meeting
+// @Description Record that the current user left a meeting
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response
+// @Failure 400 {object} response.Response
+// @Router /meetings/{id}/leave [post]
+func (h *AttendanceHandlers) LeaveMeeting(c *gin.Context) {
+	meetingID := c.Param("id")
+	userID, _ := c.Get("user_id")
+
+	if err := h.leaveMeetingUC.Execute(c.Request.Context(), meetingID, userID.(string)); err != nil {
+		if errors.Is(err, usecases.ErrNotInMeeting) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		response.InternalServerError(c, "Failed to leave meeting")
+		return
+	}
+
+	response.OK(c, "Left meeting successfully", nil)
+}
+
+// GetAttendance returns the attendance report of a meeting
+// @Summary Meeting attendance
+// @Description Get first join, last leave, time present and no-shows per participant
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=dto.AttendanceReportResponse}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /meetings/{id}/attendance [get]
+func (h *AttendanceHandlers) GetAttendance(c *gin.Context) {
+	meetingID := c.Param("id")
+	userID, _ := c.Get("user_id")
+	role, _ := c.Get("role")
+	roleStr, _ := role.(string)
+
+	report, err := h.getAttendanceReportUC.Execute(c.Request.Context(), meetingID, userID.(string), roleStr)
+	if err != nil {
+		if errors.Is(err, usecases.ErrAttendanceForbidden) {
+			response.Forbidden(c, err.Error())
+			return
+		}
+		response.NotFound(c, "Meeting not found")
+		return
+	}
+
+	attendees := make([]dto.AttendanceRecordResponse, len(report.Records))
+	for i, record := range report.Records {
+		attendees[i] = dto.AttendanceRecordResponse{
+			UserID:             record.UserID,
+			Role:               string(record.Role),
+			FirstJoin:          record.FirstJoin,
+			LastLeave:          record.LastLeave,
+			TimePresentSeconds: int64(record.TimePresent.Seconds()),
+			Sessions:           record.Sessions,
+			NoShow:             record.NoShow,
+		}
+	}
+
+	response.OK(c, "Attendance retrieved successfully", dto.AttendanceReportResponse{
+		MeetingID: report.MeetingID,
+		Attendees: attendees,
+	})
+}
+
+// JitsiWebhook receives Jitsi participant events
+// @Summary Jitsi participant webhook
+// @Description Record joins and leaves reported by Jitsi. Authenticated with the X-Webhook-Secret header.
+// @Tags meetings
+// @Accept json
+// @Produce json
+// @Param request body dto.JitsiEventRequest true "Participant event"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /meetings/webhooks/jitsi [post]
+func (h *AttendanceHandlers) JitsiWebhook(c *gin.Context) {
+	secret := c.GetHeader(JitsiWebhookSecretHeader)
+	if h.webhookSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(h.webhookSecret)) != 1 {
+		response.Unauthorized(c, "Invalid webhook secret")
+		return
+	}
+
+	var req dto.JitsiEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	occurredAt := req.Occupant.JoinedAt
+	if req.EventName == usecases.JitsiEventOccupantLeft {
+		occurredAt = req.Occupant.LeftAt
+	}
+
+	recorded, err := h.handleJitsiEventUC.Execute(c.Request.Context(), usecases.JitsiEvent{
+		Name:       req.EventName,
+		RoomName:   req.RoomName,
+		UserID:     req.Occupant.ID,
+		OccurredAt: unixSeconds(occurredAt),
+	})
+	if err != nil {
+		response.InternalServerError(c, "Failed to record event")
+		return
+	}
+
+	response.OK(c, "Event processed", gin.H{"recorded": recorded})
+}
+
+func unixSeconds(seconds float64) time.Time {
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*1e9))
+}
@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/dto"
+)
+
+// lobbyTokenHeader carries the secret guests use to poll their lobby entry
+const lobbyTokenHeader = "X-Lobby-Token"
+
+// GuestHandlers contains guest link and lobby HTTP handlers
+type GuestHandlers struct {
+	createLinkUC     *usecases.CreateGuestLinkUseCase
+	listLinksUC      *usecases.ListGuestLinksUseCase
+	revokeLinkUC     *usecases.RevokeGuestLinkUseCase
+	getLinkUC        *usecases.GetGuestLinkUseCase
+	requestAccessUC  *usecases.RequestGuestAccessUseCase
+	getLobbyStatusUC *usecases.GetLobbyStatusUseCase
+	listLobbyUC      *usecases.ListLobbyUseCase
+	decideUC         *usecases.DecideLobbyEntryUseCase
+}
+
+// NewGuestHandlers creates new GuestHandlers
+func NewGuestHandlers(
+	createLinkUC *usecases.CreateGuestLinkUseCase,
+	listLinksUC *usecases.ListGuestLinksUseCase,
+	revokeLinkUC *usecases.RevokeGuestLinkUseCase,
+	getLinkUC *usecases.GetGuestLinkUseCase,
+	requestAccessUC *usecases.RequestGuestAccessUseCase,
+	getLobbyStatusUC *usecases.GetLobbyStatusUseCase,
+	listLobbyUC *usecases.ListLobbyUseCase,
+	decideUC *usecases.DecideLobbyEntryUseCase,
+) *GuestHandlers {
+	return &GuestHandlers{
+		createLinkUC:     createLinkUC,
+		listLinksUC:      listLinksUC,
+		revokeLinkUC:     revokeLinkUC,
+		getLinkUC:        getLinkUC,
+		requestAccessUC:  requestAccessUC,
+		getLobbyStatusUC: getLobbyStatusUC,
+		listLobbyUC:      listLobbyUC,
+		decideUC:         decideUC,
+	}
+}
+
+// CreateGuestLink creates a guest access link
+// @Summary Create guest link
+// @Description Create an expiring, optionally passcode-protected link for people without an account (hosts only). The token is only returned once.
+// @Tags meetings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param request body dto.CreateGuestLinkRequest true "Link options"
+// @Success 201 {object} response.Response{data=dto.GuestLinkResponse}
+// @Failure 403 {object} response.Response
+// @Router /meetings/{id}/guest-links [post]
+func (h *GuestHandlers) CreateGuestLink(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.CreateGuestLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	output, err := h.createLinkUC.Execute(c.Request.Context(), usecases.CreateGuestLinkInput{
+		MeetingID: c.Param("id"),
+		UserID:    userID.(string),
+		ExpiresAt: req.ExpiresAt,
+		Passcode:  req.Passcode,
+		MaxUses:   req.MaxUses,
+	})
+	if err != nil {
+		handleGuestError(c, err)
+		return
+	}
+
+	link := mapGuestLinkToResponse(output.Link)
+	link.Token = output.Token
+	link.URL = output.URL
+
+	response.Created(c, "Guest link created successfully", link)
+}
+
+// ListGuestLinks lists the guest links of a meeting
+// @Summary List guest links
+// @Description List guest links of a meeting with their usage (hosts only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=[]dto.GuestLinkResponse}
+// @Router /meetings/{id}/guest-links [get]
+func (h *GuestHandlers) ListGuestLinks(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	links, err := h.listLinksUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handleGuestError(c, err)
+		return
+	}
+
+	linkResponses := make([]dto.GuestLinkResponse, len(links))
+	for i, link := range links {
+		linkResponses[i] = mapGuestLinkToResponse(link)
+	}
+
+	response.OK(c, "Guest links retrieved successfully", linkResponses)
+}
+
+// RevokeGuestLink revokes a guest link
+// @Summary Revoke guest link
+// @Description Revoke a guest link; guests admitted through it can no longer get a room token (hosts only)
+// @Tags meetings
+// @Security BearerAuth
+// @Param id path string true "Meeting ID"
+// @Param linkId path string true "Guest link ID"
+// @Success 204
+// @Router /meetings/{id}/guest-links/{linkId} [delete]
+func (h *GuestHandlers) RevokeGuestLink(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	if err := h.revokeLinkUC.Execute(c.Request.Context(), c.Param("id"), c.Param("linkId"), userID.(string)); err != nil {
+		handleGuestError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// ListLobby lists guests waiting to be admitted
+// @Summary List lobby
+// @Description List guests waiting in the lobby (hosts only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=[]dto.LobbyEntryResponse}
+// @Router /meetings/{id}/lobby [get]
+func (h *GuestHandlers) ListLobby(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	entries, err := h.listLobbyUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handleGuestError(c, err)
+		return
+	}
+
+	entryResponses := make([]dto.LobbyEntryResponse, len(entries))
+	for i, entry := range entries {
+		entryResponses[i] = mapLobbyEntryToResponse(entry)
+	}
+
+	response.OK(c, "Lobby retrieved successfully", entryResponses)
+}
+
+// AdmitGuest admits a waiting guest
+// @Summary Admit guest
+// @Description Admit a guest from the lobby (hosts only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param entryId path string true "Lobby entry ID"
+// @Success 200 {object} response.Response{data=dto.LobbyEntryResponse}
+// @Failure 409 {object} response.Response
+// @Router /meetings/{id}/lobby/{entryId}/admit [post]
+func (h *GuestHandlers) AdmitGuest(c *gin.Context) {
+	h.decide(c, true)
+}
+
+// DenyGuest denies a waiting guest
+// @Summary Deny guest
+// @Description Deny a guest in the lobby (hosts only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param entryId path string true "Lobby entry ID"
+// @Success 200 {object} response.Response{data=dto.LobbyEntryResponse}
+// @Failure 409 {object} response.Response
+// @Router /meetings/{id}/lobby/{entryId}/deny [post]
+func (h *GuestHandlers) DenyGuest(c *gin.Context) {
+	h.decide(c, false)
+}
+
+func (h *GuestHandlers) decide(c *gin.Context, admit bool) {
+	userID, _ := c.Get("user_id")
+
+	entry, err := h.decideUC.Execute(c.Request.Context(), c.Param("id"), c.Param("entryId"), userID.(string), admit)
+	if err != nil {
+		handleGuestError(c, err)
+		return
+	}
+
+	response.OK(c, "Lobby request updated successfully", mapLobbyEntryToResponse(entry))
+}
+
+// GetGuestLink returns the meeting behind a guest link
+// @Summary Guest link details
+// @Description Get the meeting title and time behind a guest link and whether a passcode is required. No authentication.
+// @Tags guests
+// @Produce json
+// @Param token path string true "Guest link token"
+// @Success 200 {object} response.Response{data=dto.GuestLinkInfoResponse}
+// @Failure 410 {object} response.Response
+// @Router /meetings/guest/{token} [get]
+func (h *GuestHandlers) GetGuestLink(c *gin.Context) {
+	info, err := h.getLinkUC.Execute(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleGuestError(c, err)
+		return
+	}
+
+	response.OK(c, "Guest link retrieved successfully", dto.GuestLinkInfoResponse{
+		MeetingTitle:     info.MeetingTitle,
+		StartTime:        info.StartTime,
+		DurationMinutes:  int(info.Duration.Minutes()),
+		RequiresPasscode: info.RequiresPasscode,
+		ExpiresAt:        info.ExpiresAt,
+	})
+}
+
+// RequestGuestAccess puts a guest in the lobby
+// @Summary Request guest access
+// @Description Ask to join a meeting through a guest link. The guest waits in the lobby until a host decides. No authentication.
+// @Tags guests
+// @Accept json
+// @Produce json
+// @Param token path string true "Guest link token"
+// @Param request body dto.GuestAccessRequest true "Guest details"
+// @Success 201 {object} response.Response{data=dto.GuestAccessResponse}
+// @Failure 401 {object} response.Response
+// @Failure 410 {object} response.Response
+// @Router /meetings/guest/{token}/lobby [post]
+func (h *GuestHandlers) RequestGuestAccess(c *gin.Context) {
+	var req dto.GuestAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	output, err := h.requestAccessUC.Execute(c.Request.Context(), usecases.RequestGuestAccessInput{
+		Token:       c.Param("token"),
+		Passcode:    req.Passcode,
+		DisplayName: req.DisplayName,
+		Email:       req.Email,
+	})
+	if err != nil {
+		handleGuestError(c, err)
+		return
+	}
+
+	response.Created(c, "Waiting for a host to admit you", dto.GuestAccessResponse{
+		LobbyEntryResponse: mapLobbyEntryToResponse(output.Entry),
+		LobbyToken:         output.Secret,
+	})
+}
+
+// GetLobbyStatus returns a guest's lobby state
+// @Summary Guest lobby status
+// @Description Poll for the host's decision. Once admitted, returns the room URL and a restricted Jitsi token. No authentication.
+// @Tags guests
+// @Produce json
+// @Param token path string true "Guest link token"
+// @Param entryId path string true "Lobby entry ID"
+// @Param X-Lobby-Token header string true "Lobby token from the access request"
+// @Success 200 {object} response.Response{data=dto.LobbyStatusResponse}
+// @Failure 404 {object} response.Response
+// @Router /meetings/guest/{token}/lobby/{entryId} [get]
+func (h *GuestHandlers) GetLobbyStatus(c *gin.Context) {
+	output, err := h.getLobbyStatusUC.Execute(c.Request.Context(), c.Param("token"), c.Param("entryId"), c.GetHeader(lobbyTokenHeader))
+	if err != nil {
+		handleGuestError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	response.OK(c, "Lobby status retrieved successfully", dto.LobbyStatusResponse{
+		LobbyEntryResponse: mapLobbyEntryToResponse(output.Entry),
+		RoomURL:            output.RoomURL,
+		JitsiToken:         output.JitsiToken,
+		TokenExpiresAt:     output.TokenExpiresAt,
+		RoomPasscode:       output.RoomPasscode,
+	})
+}
+
+func handleGuestError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrMeetingForbidden):
+		response.Forbidden(c, err.Error())
+	case errors.Is(err, entities.ErrMeetingNotFound),
+		errors.Is(err, entities.ErrGuestLinkNotFound),
+		errors.Is(err, entities.ErrLobbyEntryNotFound):
+		response.NotFound(c, err.Error())
+	case errors.Is(err, entities.ErrGuestLinkUnavailable):
+		response.Error(c, http.StatusGone, "LINK_UNAVAILABLE", err.Error(), "")
+	case errors.Is(err, entities.ErrInvalidPasscode):
+		response.Error(c, http.StatusUnauthorized, "INVALID_PASSCODE", err.Error(), "")
+	case errors.Is(err, entities.ErrMeetingNotActive),
+		errors.Is(err, entities.ErrLobbyEntryDecided):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, entities.ErrInvalidGuestLink),
+		errors.Is(err, entities.ErrInvalidGuest):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalServerError(c, "Failed to process guest access")
+	}
+}
+
+func mapGuestLinkToResponse(link *entities.GuestLink) dto.GuestLinkResponse {
+	return dto.GuestLinkResponse{
+		ID:          link.ID,
+		MeetingID:   link.MeetingID,
+		HasPasscode: link.HasPasscode(),
+		ExpiresAt:   link.ExpiresAt,
+		MaxUses:     link.MaxUses,
+		UseCount:    link.UseCount,
+		CreatedAt:   link.CreatedAt,
+		RevokedAt:   link.RevokedAt,
+	}
+}
+
+func mapLobbyEntryToResponse(entry *entities.LobbyEntry) dto.LobbyEntryResponse {
+	return dto.LobbyEntryResponse{
+		ID:          entry.ID,
+		DisplayName: entry.DisplayName,
+		Email:       entry.Email,
+		Status:      string(entry.Status),
+		CreatedAt:   entry.CreatedAt,
+		DecidedAt:   entry.DecidedAt,
+	}
+}
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"errors"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/manab-pr/evtaarpro/internal/response"
@@ -12,10 +15,12 @@ import (
 
 // MeetingHandlers contains meeting-related HTTP handlers
 type MeetingHandlers struct {
-	createMeetingUC *usecases.CreateMeetingUseCase
-	getMeetingUC    *usecases.GetMeetingUseCase
-	listMeetingsUC  *usecases.ListMeetingsUseCase
-	joinMeetingUC   *usecases.JoinMeetingUseCase
+	createMeetingUC  *usecases.CreateMeetingUseCase
+	getMeetingUC     *usecases.GetMeetingUseCase
+	listMeetingsUC   *usecases.ListMeetingsUseCase
+	joinMeetingUC    *usecases.JoinMeetingUseCase
+	updateSettingsUC *usecases.UpdateRoomSettingsUseCase
+	cancelMeetingUC  *usecases.CancelMeetingUseCase
 }
 
 // NewMeetingHandlers creates new MeetingHandlers
@@ -24,24 +29,30 @@ func NewMeetingHandlers(
 	getMeetingUC *usecases.GetMeetingUseCase,
 	listMeetingsUC *usecases.ListMeetingsUseCase,
 	joinMeetingUC *usecases.JoinMeetingUseCase,
+	updateSettingsUC *usecases.UpdateRoomSettingsUseCase,
+	cancelMeetingUC *usecases.CancelMeetingUseCase,
 ) *MeetingHandlers {
 	return &MeetingHandlers{
-		createMeetingUC: createMeetingUC,
-		getMeetingUC:    getMeetingUC,
-		listMeetingsUC:  listMeetingsUC,
-		joinMeetingUC:   joinMeetingUC,
+		createMeetingUC:  createMeetingUC,
+		getMeetingUC:     getMeetingUC,
+		listMeetingsUC:   listMeetingsUC,
+		joinMeetingUC:    joinMeetingUC,
+		updateSettingsUC: updateSettingsUC,
+		cancelMeetingUC:  cancelMeetingUC,
 	}
 }
 
 // CreateMeeting creates a new meeting
 // @Summary Create meeting
-// @Description Create a new meeting
+// @Description Create a new meeting, optionally from a template. With template_id, title, description, duration and max participants default to the template's, invitees are added to its default invitees, its agenda is copied and settings override its room settings. start_time must carry an offset; it is stored in UTC. Participants for whom the meeting falls outside their local working hours are listed in outside_working_hours.
 // @Tags meetings
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param request body dto.CreateMeetingRequest true "Meeting details"
-// @Success 201 {object} response.Response{data=dto.MeetingResponse}
+// @Success 201 {object} response.Response{data=dto.CreateMeetingResponse}
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response{data=[]dto.ConflictResponse}
 // @Router /meetings [post]
 func (h *MeetingHandlers) CreateMeeting(c *gin.Context) {
 	userID, _ := c.Get("user_id")
@@ -52,20 +63,49 @@ func (h *MeetingHandlers) CreateMeeting(c *gin.Context) {
 		return
 	}
 
-	meeting, err := h.createMeetingUC.Execute(c.Request.Context(), usecases.CreateInput{
+	output, err := h.createMeetingUC.Execute(c.Request.Context(), usecases.CreateInput{
 		Title:           req.Title,
 		Description:     req.Description,
 		OrganizerID:     userID.(string),
 		StartTime:       req.StartTime,
+		Duration:        time.Duration(req.DurationMinutes) * time.Minute,
 		MaxParticipants: req.MaxParticipants,
+		InviteeIDs:      req.InviteeIDs,
+		TemplateID:      req.TemplateID,
+		Settings:        mapRoomSettingsInput(req.Settings),
 	})
 
 	if err != nil {
+		var conflictErr *usecases.ConflictError
+		if errors.As(err, &conflictErr) {
+			response.ErrorWithData(c, http.StatusConflict, "SCHEDULING_CONFLICT",
+				"Meeting overlaps existing meetings of invitees", mapConflictsToResponse(conflictErr.Conflicts))
+			return
+		}
+		if errors.Is(err, entities.ErrInvalidMeetingData) ||
+			errors.Is(err, entities.ErrInvalidDuration) ||
+			errors.Is(err, entities.ErrInvalidRoomSettings) ||
+			errors.Is(err, entities.ErrInvalidAgenda) {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		if errors.Is(err, entities.ErrTemplateNotFound) {
+			response.NotFound(c, err.Error())
+			return
+		}
 		response.InternalServerError(c, "Failed to create meeting")
 		return
 	}
 
-	response.Created(c, "Meeting created successfully", mapMeetingToResponse(meeting))
+	// The organizer is a host and may see the passcode
+	meetingResponse := mapMeetingToResponse(output.Meeting)
+	meetingResponse.Settings = mapRoomSettingsToResponse(output.Meeting.Settings, true)
+
+	response.Created(c, "Meeting created successfully", dto.CreateMeetingResponse{
+		MeetingResponse:     meetingResponse,
+		Conflicts:           mapConflictsToResponse(output.Conflicts),
+		OutsideWorkingHours: output.OutsideWorkingHours,
+	})
 }
 
 // GetMeeting retrieves a meeting
@@ -126,6 +166,8 @@ func (h *MeetingHandlers) ListMeetings(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Meeting ID"
 // @Success 200 {object} response.Response{data=dto.JoinMeetingResponse}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
 // @Router /meetings/{id}/join [post]
 func (h *MeetingHandlers) JoinMeeting(c *gin.Context) {
 	meetingID := c.Param("id")
@@ -137,18 +179,101 @@ func (h *MeetingHandlers) JoinMeeting(c *gin.Context) {
 
 	output, err := h.joinMeetingUC.Execute(c.Request.Context(), meetingID, userID.(string), userName, email.(string))
 	if err != nil {
-		response.InternalServerError(c, "Failed to join meeting")
+		switch {
+		case errors.Is(err, usecases.ErrMeetingForbidden):
+			response.Forbidden(c, err.Error())
+		case errors.Is(err, entities.ErrMeetingNotFound):
+			response.NotFound(c, err.Error())
+		default:
+			response.InternalServerError(c, "Failed to join meeting")
+		}
 		return
 	}
 
 	response.OK(c, "Joined meeting successfully", dto.JoinMeetingResponse{
-		MeetingID: output.MeetingID,
-		RoomURL:   output.RoomURL,
-		UserName:  output.UserName,
-		UserEmail: output.UserEmail,
+		MeetingID:  output.MeetingID,
+		RoomURL:    output.RoomURL,
+		JitsiToken: output.JitsiToken,
+		Moderator:  output.Moderator,
+		Settings:   mapRoomSettingsToResponse(output.Settings, true),
+		UserName:   output.UserName,
+		UserEmail:  output.UserEmail,
 	})
 }
 
+// UpdateRoomSettings changes the room settings of a meeting
+// @Summary Update room settings
+// @Description Change the lobby, start muted, recording and passcode settings of a meeting (hosts only). Omitted fields keep their value; an empty passcode removes it. The settings apply to everyone joining afterwards.
+// @Tags meetings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param request body dto.RoomSettingsRequest true "Room settings"
+// @Success 200 {object} response.Response{data=dto.RoomSettingsResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /meetings/{id}/settings [patch]
+func (h *MeetingHandlers) UpdateRoomSettings(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.RoomSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	meeting, err := h.updateSettingsUC.Execute(c.Request.Context(), c.Param("id"), userID.(string), *mapRoomSettingsInput(&req))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrMeetingForbidden):
+			response.Forbidden(c, err.Error())
+		case errors.Is(err, entities.ErrMeetingNotFound):
+			response.NotFound(c, err.Error())
+		case errors.Is(err, entities.ErrInvalidRoomSettings):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalServerError(c, "Failed to update room settings")
+		}
+		return
+	}
+
+	response.OK(c, "Room settings updated successfully", mapRoomSettingsToResponse(meeting.Settings, true))
+}
+
+// CancelMeeting cancels a meeting
+// @Summary Cancel meeting
+// @Description Cancel a scheduled or ongoing meeting (hosts only). The organizer and participants are notified.
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=dto.MeetingResponse}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /meetings/{id}/cancel [post]
+func (h *MeetingHandlers) CancelMeeting(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	meeting, err := h.cancelMeetingUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrMeetingForbidden):
+			response.Forbidden(c, err.Error())
+		case errors.Is(err, entities.ErrMeetingNotFound):
+			response.NotFound(c, err.Error())
+		case errors.Is(err, entities.ErrMeetingNotActive):
+			response.Conflict(c, err.Error())
+		default:
+			response.InternalServerError(c, "Failed to cancel meeting")
+		}
+		return
+	}
+
+	response.OK(c, "Meeting cancelled successfully", mapMeetingToResponse(meeting))
+}
+
 func mapMeetingToResponse(meeting *entities.Meeting) dto.MeetingResponse {
 	return dto.MeetingResponse{
 		ID:              meeting.ID,
@@ -157,11 +282,52 @@ func mapMeetingToResponse(meeting *entities.Meeting) dto.MeetingResponse {
 		Description:     meeting.Description,
 		OrganizerID:     meeting.OrganizerID,
 		StartTime:       meeting.StartTime,
+		DurationMinutes: int(meeting.Duration.Minutes()),
 		EndTime:         meeting.EndTime,
 		Status:          string(meeting.Status),
 		JitsiRoomURL:    meeting.JitsiRoomURL,
 		RecordingURL:    meeting.RecordingURL,
 		MaxParticipants: meeting.MaxParticipants,
+		Settings:        mapRoomSettingsToResponse(meeting.Settings, false),
 		CreatedAt:       meeting.CreatedAt,
 	}
 }
+
+// mapRoomSettingsToResponse maps room settings, including the passcode
+// only when withPasscode is set
+func mapRoomSettingsToResponse(settings entities.RoomSettings, withPasscode bool) dto.RoomSettingsResponse {
+	resp := dto.RoomSettingsResponse{
+		LobbyEnabled:     settings.LobbyEnabled,
+		StartMuted:       settings.StartMuted,
+		RecordingAllowed: settings.RecordingAllowed,
+		HasPasscode:      settings.Passcode != "",
+	}
+	if withPasscode {
+		resp.Passcode = settings.Passcode
+	}
+	return resp
+}
+
+func mapRoomSettingsInput(req *dto.RoomSettingsRequest) *usecases.RoomSettingsInput {
+	if req == nil {
+		return nil
+	}
+	return &usecases.RoomSettingsInput{
+		LobbyEnabled:     req.LobbyEnabled,
+		StartMuted:       req.StartMuted,
+		RecordingAllowed: req.RecordingAllowed,
+		Passcode:         req.Passcode,
+	}
+}
+
+func mapConflictsToResponse(conflicts []*entities.Conflict) []dto.ConflictResponse {
+	conflictResponses := make([]dto.ConflictResponse, len(conflicts))
+	for i, conflict := range conflicts {
+		conflictResponses[i] = dto.ConflictResponse{
+			UserID:    conflict.UserID,
+			StartTime: conflict.Start,
+			EndTime:   conflict.End,
+		}
+	}
+	return conflictResponses
+}
@@ -0,0 +1,458 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/dto"
+)
+
+// dueDateLayout is the format of action item due dates
+const dueDateLayout = "2006-01-02"
+
+// MinutesHandlers contains agenda, notes and action item HTTP handlers
+type MinutesHandlers struct {
+	setAgendaUC         *usecases.SetAgendaUseCase
+	getAgendaUC         *usecases.GetAgendaUseCase
+	getNotesUC          *usecases.GetNotesUseCase
+	updateNotesUC       *usecases.UpdateNotesUseCase
+	listRevisionsUC     *usecases.ListNoteRevisionsUseCase
+	getRevisionUC       *usecases.GetNoteRevisionUseCase
+	createActionItemUC  *usecases.CreateActionItemUseCase
+	updateActionItemUC  *usecases.UpdateActionItemUseCase
+	listActionItemsUC   *usecases.ListActionItemsUseCase
+	listMyActionItemsUC *usecases.ListMyActionItemsUseCase
+}
+
+// NewMinutesHandlers creates new MinutesHandlers
+func NewMinutesHandlers(
+	setAgendaUC *usecases.SetAgendaUseCase,
+	getAgendaUC *usecases.GetAgendaUseCase,
+	getNotesUC *usecases.GetNotesUseCase,
+	updateNotesUC *usecases.UpdateNotesUseCase,
+	listRevisionsUC *usecases.ListNoteRevisionsUseCase,
+	getRevisionUC *usecases.GetNoteRevisionUseCase,
+	createActionItemUC *usecases.CreateActionItemUseCase,
+	updateActionItemUC *usecases.UpdateActionItemUseCase,
+	listActionItemsUC *usecases.ListActionItemsUseCase,
+	listMyActionItemsUC *usecases.ListMyActionItemsUseCase,
+) *MinutesHandlers {
+	return &MinutesHandlers{
+		setAgendaUC:         setAgendaUC,
+		getAgendaUC:         getAgendaUC,
+		getNotesUC:          getNotesUC,
+		updateNotesUC:       updateNotesUC,
+		listRevisionsUC:     listRevisionsUC,
+		getRevisionUC:       getRevisionUC,
+		createActionItemUC:  createActionItemUC,
+		updateActionItemUC:  updateActionItemUC,
+		listActionItemsUC:   listActionItemsUC,
+		listMyActionItemsUC: listMyActionItemsUC,
+	}
+}
+
+// GetAgenda returns the agenda of a meeting
+// @Summary Get agenda
+// @Description Get the ordered agenda of a meeting (participants only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=[]dto.AgendaItemResponse}
+// @Failure 403 {object} response.Response
+// @Router /meetings/{id}/agenda [get]
+func (h *MinutesHandlers) GetAgenda(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	items, err := h.getAgendaUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handleMinutesError(c, err)
+		return
+	}
+
+	response.OK(c, "Agenda retrieved successfully", mapAgendaToResponse(items))
+}
+
+// SetAgenda replaces the agenda of a meeting
+// @Summary Set agenda
+// @Description Replace the agenda of a meeting; items are ordered as sent (hosts only)
+// @Tags meetings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param request body dto.SetAgendaRequest true "Agenda items"
+// @Success 200 {object} response.Response{data=[]dto.AgendaItemResponse}
+// @Failure 403 {object} response.Response
+// @Router /meetings/{id}/agenda [put]
+func (h *MinutesHandlers) SetAgenda(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.SetAgendaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	inputs := make([]usecases.AgendaItemInput, len(req.Items))
+	for i, item := range req.Items {
+		inputs[i] = usecases.AgendaItemInput{
+			Title:           item.Title,
+			Description:     item.Description,
+			DurationMinutes: item.DurationMinutes,
+			PresenterID:     item.PresenterID,
+		}
+	}
+
+	items, err := h.setAgendaUC.Execute(c.Request.Context(), c.Param("id"), userID.(string), inputs)
+	if err != nil {
+		handleMinutesError(c, err)
+		return
+	}
+
+	response.OK(c, "Agenda updated successfully", mapAgendaToResponse(items))
+}
+
+// GetNotes returns the current notes of a meeting
+// @Summary Get notes
+// @Description Get the latest notes revision (participants only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=dto.NoteRevisionResponse}
+// @Router /meetings/{id}/notes [get]
+func (h *MinutesHandlers) GetNotes(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	notes, err := h.getNotesUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handleMinutesError(c, err)
+		return
+	}
+
+	response.OK(c, "Notes retrieved successfully", mapNoteRevisionToResponse(notes))
+}
+
+// UpdateNotes saves a new revision of the meeting notes
+// @Summary Update notes
+// @Description Save notes based on base_revision. If someone saved in between, 409 is returned with the current notes.
+// @Tags meetings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param request body dto.UpdateNotesRequest true "Notes"
+// @Success 200 {object} response.Response{data=dto.NoteRevisionResponse}
+// @Failure 409 {object} response.Response{data=dto.NoteRevisionResponse}
+// @Router /meetings/{id}/notes [put]
+func (h *MinutesHandlers) UpdateNotes(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.UpdateNotesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	notes, err := h.updateNotesUC.Execute(c.Request.Context(), usecases.UpdateNotesInput{
+		MeetingID:    c.Param("id"),
+		UserID:       userID.(string),
+		BaseRevision: req.BaseRevision,
+		Content:      req.Content,
+	})
+	if err != nil {
+		var conflictErr *usecases.NotesConflictError
+		if errors.As(err, &conflictErr) {
+			response.ErrorWithData(c, http.StatusConflict, "NOTES_CONFLICT",
+				conflictErr.Error(), mapNoteRevisionToResponse(conflictErr.Current))
+			return
+		}
+		handleMinutesError(c, err)
+		return
+	}
+
+	response.OK(c, "Notes saved successfully", mapNoteRevisionToResponse(notes))
+}
+
+// ListNoteRevisions returns the notes history of a meeting
+// @Summary List notes revisions
+// @Description List all notes revisions, newest first (participants only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=[]dto.NoteRevisionResponse}
+// @Router /meetings/{id}/notes/revisions [get]
+func (h *MinutesHandlers) ListNoteRevisions(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	revisions, err := h.listRevisionsUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handleMinutesError(c, err)
+		return
+	}
+
+	revisionResponses := make([]dto.NoteRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		revisionResponses[i] = mapNoteRevisionToResponse(revision)
+	}
+
+	response.OK(c, "Notes revisions retrieved successfully", revisionResponses)
+}
+
+// GetNoteRevision returns one version of the meeting notes
+// @Summary Get notes revision
+// @Description Get a specific notes revision (participants only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} response.Response{data=dto.NoteRevisionResponse}
+// @Failure 404 {object} response.Response
+// @Router /meetings/{id}/notes/revisions/{revision} [get]
+func (h *MinutesHandlers) GetNoteRevision(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil || revision < 1 {
+		response.BadRequest(c, "Invalid revision")
+		return
+	}
+
+	notes, err := h.getRevisionUC.Execute(c.Request.Context(), c.Param("id"), userID.(string), revision)
+	if err != nil {
+		handleMinutesError(c, err)
+		return
+	}
+
+	response.OK(c, "Notes revision retrieved successfully", mapNoteRevisionToResponse(notes))
+}
+
+// ListActionItems returns the action items of a meeting
+// @Summary List action items
+// @Description List the action items of a meeting (participants only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=[]dto.ActionItemResponse}
+// @Router /meetings/{id}/action-items [get]
+func (h *MinutesHandlers) ListActionItems(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	items, err := h.listActionItemsUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handleMinutesError(c, err)
+		return
+	}
+
+	response.OK(c, "Action items retrieved successfully", mapActionItemsToResponse(items))
+}
+
+// CreateActionItem adds an action item to a meeting
+// @Summary Create action item
+// @Description Assign a follow-up task to a meeting participant, who is notified
+// @Tags meetings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param request body dto.CreateActionItemRequest true "Action item"
+// @Success 201 {object} response.Response{data=dto.ActionItemResponse}
+// @Failure 400 {object} response.Response
+// @Router /meetings/{id}/action-items [post]
+func (h *MinutesHandlers) CreateActionItem(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.CreateActionItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	var dueDate *time.Time
+	if req.DueDate != "" {
+		parsed, err := time.Parse(dueDateLayout, req.DueDate)
+		if err != nil {
+			response.BadRequest(c, "Invalid due_date, expected YYYY-MM-DD")
+			return
+		}
+		dueDate = &parsed
+	}
+
+	item, err := h.createActionItemUC.Execute(c.Request.Context(), usecases.CreateActionItemInput{
+		MeetingID:   c.Param("id"),
+		UserID:      userID.(string),
+		Title:       req.Title,
+		Description: req.Description,
+		AssigneeID:  req.AssigneeID,
+		DueDate:     dueDate,
+	})
+	if err != nil {
+		handleMinutesError(c, err)
+		return
+	}
+
+	response.Created(c, "Action item created successfully", mapActionItemToResponse(item))
+}
+
+// UpdateActionItem edits an action item
+// @Summary Update action item
+// @Description Update fields of an action item; omitted fields are unchanged and an empty due_date clears it
+// @Tags meetings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param itemId path string true "Action item ID"
+// @Param request body dto.UpdateActionItemRequest true "Changes"
+// @Success 200 {object} response.Response{data=dto.ActionItemResponse}
+// @Failure 404 {object} response.Response
+// @Router /meetings/{id}/action-items/{itemId} [patch]
+func (h *MinutesHandlers) UpdateActionItem(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.UpdateActionItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	input := usecases.UpdateActionItemInput{
+		MeetingID:   c.Param("id"),
+		ItemID:      c.Param("itemId"),
+		UserID:      userID.(string),
+		Title:       req.Title,
+		Description: req.Description,
+		AssigneeID:  req.AssigneeID,
+	}
+
+	if req.DueDate != nil {
+		if *req.DueDate == "" {
+			input.ClearDueDate = true
+		} else {
+			parsed, err := time.Parse(dueDateLayout, *req.DueDate)
+			if err != nil {
+				response.BadRequest(c, "Invalid due_date, expected YYYY-MM-DD")
+				return
+			}
+			input.DueDate = &parsed
+		}
+	}
+	if req.Status != nil {
+		status := entities.ActionItemStatus(*req.Status)
+		input.Status = &status
+	}
+
+	item, err := h.updateActionItemUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		handleMinutesError(c, err)
+		return
+	}
+
+	response.OK(c, "Action item updated successfully", mapActionItemToResponse(item))
+}
+
+// ListMyActionItems returns the action items assigned to the current user
+// @Summary List my action items
+// @Description List action items assigned to the current user across meetings. Only open items are returned unless all=true.
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param all query bool false "Include done and cancelled items"
+// @Success 200 {object} response.Response{data=[]dto.ActionItemResponse}
+// @Router /meetings/action-items [get]
+func (h *MinutesHandlers) ListMyActionItems(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	includeClosed, _ := strconv.ParseBool(c.DefaultQuery("all", "false"))
+
+	items, err := h.listMyActionItemsUC.Execute(c.Request.Context(), userID.(string), includeClosed)
+	if err != nil {
+		handleMinutesError(c, err)
+		return
+	}
+
+	response.OK(c, "Action items retrieved successfully", mapActionItemsToResponse(items))
+}
+
+func handleMinutesError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrMeetingForbidden):
+		response.Forbidden(c, err.Error())
+	case errors.Is(err, entities.ErrMeetingNotFound):
+		response.NotFound(c, "Meeting not found")
+	case errors.Is(err, entities.ErrRevisionNotFound),
+		errors.Is(err, entities.ErrActionItemNotFound):
+		response.NotFound(c, err.Error())
+	case errors.Is(err, entities.ErrInvalidAgenda),
+		errors.Is(err, entities.ErrInvalidActionItem),
+		errors.Is(err, entities.ErrInvalidActionStatus):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalServerError(c, "Failed to process meeting minutes")
+	}
+}
+
+func mapAgendaToResponse(items []*entities.AgendaItem) []dto.AgendaItemResponse {
+	itemResponses := make([]dto.AgendaItemResponse, len(items))
+	for i, item := range items {
+		itemResponses[i] = dto.AgendaItemResponse{
+			ID:              item.ID,
+			Position:        item.Position,
+			Title:           item.Title,
+			Description:     item.Description,
+			DurationMinutes: item.DurationMinutes,
+			PresenterID:     item.PresenterID,
+		}
+	}
+	return itemResponses
+}
+
+func mapNoteRevisionToResponse(notes *entities.NoteRevision) dto.NoteRevisionResponse {
+	resp := dto.NoteRevisionResponse{
+		MeetingID: notes.MeetingID,
+		Revision:  notes.Revision,
+		Content:   notes.Content,
+		EditedBy:  notes.EditedBy,
+	}
+	if !notes.CreatedAt.IsZero() {
+		resp.CreatedAt = &notes.CreatedAt
+	}
+	return resp
+}
+
+func mapActionItemToResponse(item *entities.ActionItem) dto.ActionItemResponse {
+	resp := dto.ActionItemResponse{
+		ID:          item.ID,
+		MeetingID:   item.MeetingID,
+		Title:       item.Title,
+		Description: item.Description,
+		AssigneeID:  item.AssigneeID,
+		Status:      string(item.Status),
+		CreatedBy:   item.CreatedBy,
+		CreatedAt:   item.CreatedAt,
+		UpdatedAt:   item.UpdatedAt,
+		CompletedAt: item.CompletedAt,
+	}
+	if item.DueDate != nil {
+		resp.DueDate = item.DueDate.Format(dueDateLayout)
+	}
+	return resp
+}
+
+func mapActionItemsToResponse(items []*entities.ActionItem) []dto.ActionItemResponse {
+	itemResponses := make([]dto.ActionItemResponse, len(items))
+	for i, item := range items {
+		itemResponses[i] = mapActionItemToResponse(item)
+	}
+	return itemResponses
+}
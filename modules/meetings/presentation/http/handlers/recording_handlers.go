@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/internal/storage"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/dto"
+)
+
+// RecordingHandlers contains recording-related HTTP handlers
+type RecordingHandlers struct {
+	startUploadUC    *usecases.StartRecordingUploadUseCase
+	uploadPartUC     *usecases.UploadRecordingPartUseCase
+	getUploadUC      *usecases.GetRecordingUploadUseCase
+	completeUploadUC *usecases.CompleteRecordingUploadUseCase
+	abortUploadUC    *usecases.AbortRecordingUploadUseCase
+	listRecordingsUC *usecases.ListRecordingsUseCase
+	downloadURLUC    *usecases.GetRecordingDownloadURLUseCase
+	maxFileSize      int64
+}
+
+// NewRecordingHandlers creates new RecordingHandlers
+func NewRecordingHandlers(
+	startUploadUC *usecases.StartRecordingUploadUseCase,
+	uploadPartUC *usecases.UploadRecordingPartUseCase,
+	getUploadUC *usecases.GetRecordingUploadUseCase,
+	completeUploadUC *usecases.CompleteRecordingUploadUseCase,
+	abortUploadUC *usecases.AbortRecordingUploadUseCase,
+	listRecordingsUC *usecases.ListRecordingsUseCase,
+	downloadURLUC *usecases.GetRecordingDownloadURLUseCase,
+	maxFileSize int64,
+) *RecordingHandlers {
+	return &RecordingHandlers{
+		startUploadUC:    startUploadUC,
+		uploadPartUC:     uploadPartUC,
+		getUploadUC:      getUploadUC,
+		completeUploadUC: completeUploadUC,
+		abortUploadUC:    abortUploadUC,
+		listRecordingsUC: listRecordingsUC,
+		downloadURLUC:    downloadURLUC,
+		maxFileSize:      maxFileSize,
+	}
+}
+
+// ListRecordings lists the recordings of a meeting
+// @Summary List recordings
+// @Description List recordings of a meeting (participants only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=[]dto.RecordingResponse}
+// @Failure 403 {object} response.Response
+// @Router /meetings/{id}/recordings [get]
+func (h *RecordingHandlers) ListRecordings(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	recordings, err := h.listRecordingsUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handleRecordingError(c, err)
+		return
+	}
+
+	recordingResponses := make([]dto.RecordingResponse, len(recordings))
+	for i, recording := range recordings {
+		recordingResponses[i] = mapRecordingToResponse(recording)
+	}
+
+	response.OK(c, "Recordings retrieved successfully", recordingResponses)
+}
+
+// StartUpload starts a resumable recording upload
+// @Summary Start recording upload
+// @Description Register a recording and open a multipart upload (hosts only)
+// @Tags meetings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param request body dto.StartRecordingUploadRequest true "Recording details"
+// @Success 201 {object} response.Response{data=dto.RecordingUploadResponse}
+// @Failure 413 {object} response.Response
+// @Router /meetings/{id}/recordings [post]
+func (h *RecordingHandlers) StartUpload(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.StartRecordingUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	recording, err := h.startUploadUC.Execute(c.Request.Context(), usecases.StartUploadInput{
+		MeetingID:   c.Param("id"),
+		UserID:      userID.(string),
+		FileName:    req.FileName,
+		ContentType: req.ContentType,
+		SizeBytes:   req.SizeBytes,
+	})
+	if err != nil {
+		handleRecordingError(c, err)
+		return
+	}
+
+	response.Created(c, "Recording upload started", mapUploadToResponse(&usecases.UploadStatus{Recording: recording}))
+}
+
+// GetUpload returns the progress of a recording upload
+// @Summary Recording upload status
+// @Description Get the parts received so far to resume an interrupted upload
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param recordingId path string true "Recording ID"
+// @Success 200 {object} response.Response{data=dto.RecordingUploadResponse}
+// @Router /meetings/{id}/recordings/{recordingId}/upload [get]
+func (h *RecordingHandlers) GetUpload(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	status, err := h.getUploadUC.Execute(c.Request.Context(), c.Param("id"), c.Param("recordingId"), userID.(string))
+	if err != nil {
+		handleRecordingError(c, err)
+		return
+	}
+
+	response.OK(c, "Upload status retrieved successfully", mapUploadToResponse(status))
+}
+
+// UploadPart uploads one part of a recording
+// @Summary Upload recording part
+// @Description Upload a part as the raw request body. Parts except the last must be at least 5 MiB. Re-uploading a part number replaces it.
+// @Tags meetings
+// @Security BearerAuth
+// @Accept application/octet-stream
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param recordingId path string true "Recording ID"
+// @Param partNumber path int true "Part number (1-10000)"
+// @Success 200 {object} response.Response{data=dto.UploadPartResponse}
+// @Failure 411 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Router /meetings/{id}/recordings/{recordingId}/parts/{partNumber} [put]
+func (h *RecordingHandlers) UploadPart(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	partNumber, err := strconv.Atoi(c.Param("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > storage.MaxParts {
+		response.BadRequest(c, "Invalid part number")
+		return
+	}
+
+	size := c.Request.ContentLength
+	if size < 0 {
+		response.Error(c, http.StatusLengthRequired, "LENGTH_REQUIRED", "Content-Length header is required", "")
+		return
+	}
+	if h.maxFileSize > 0 && size > h.maxFileSize {
+		response.Error(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", entities.ErrRecordingTooLarge.Error(), "")
+		return
+	}
+
+	part, err := h.uploadPartUC.Execute(c.Request.Context(), usecases.UploadPartInput{
+		MeetingID:   c.Param("id"),
+		RecordingID: c.Param("recordingId"),
+		UserID:      userID.(string),
+		PartNumber:  partNumber,
+		Body:        http.MaxBytesReader(c.Writer, c.Request.Body, size),
+		Size:        size,
+	})
+	if err != nil {
+		handleRecordingError(c, err)
+		return
+	}
+
+	response.OK(c, "Part uploaded successfully", dto.UploadPartResponse{
+		PartNumber: part.Number,
+		Size:       part.Size,
+		ETag:       part.ETag,
+	})
+}
+
+// CompleteUpload finishes a recording upload
+// @Summary Complete recording upload
+// @Description Assemble the uploaded parts and make the recording available
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param recordingId path string true "Recording ID"
+// @Success 200 {object} response.Response{data=dto.RecordingResponse}
+// @Router /meetings/{id}/recordings/{recordingId}/complete [post]
+func (h *RecordingHandlers) CompleteUpload(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	recording, err := h.completeUploadUC.Execute(c.Request.Context(), c.Param("id"), c.Param("recordingId"), userID.(string))
+	if err != nil {
+		handleRecordingError(c, err)
+		return
+	}
+
+	response.OK(c, "Recording uploaded successfully", mapRecordingToResponse(recording))
+}
+
+// AbortUpload cancels a recording upload
+// @Summary Abort recording upload
+// @Description Discard an unfinished recording upload
+// @Tags meetings
+// @Security BearerAuth
+// @Param id path string true "Meeting ID"
+// @Param recordingId path string true "Recording ID"
+// @Success 204
+// @Router /meetings/{id}/recordings/{recordingId}/upload [delete]
+func (h *RecordingHandlers) AbortUpload(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	if err := h.abortUploadUC.Execute(c.Request.Context(), c.Param("id"), c.Param("recordingId"), userID.(string)); err != nil {
+		handleRecordingError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+// GetDownloadURL returns a signed download link for a recording
+// @Summary Recording download link
+// @Description Get a time-limited download URL (participants only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param recordingId path string true "Recording ID"
+// @Success 200 {object} response.Response{data=dto.DownloadURLResponse}
+// @Failure 403 {object} response.Response
+// @Router /meetings/{id}/recordings/{recordingId}/download [get]
+func (h *RecordingHandlers) GetDownloadURL(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	download, err := h.downloadURLUC.Execute(c.Request.Context(), c.Param("id"), c.Param("recordingId"), userID.(string))
+	if err != nil {
+		handleRecordingError(c, err)
+		return
+	}
+
+	response.OK(c, "Download link created successfully", dto.DownloadURLResponse{
+		URL:       download.URL,
+		ExpiresAt: download.ExpiresAt,
+	})
+}
+
+func handleRecordingError(c *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, usecases.ErrMeetingForbidden),
+		errors.Is(err, entities.ErrRecordingNotAllowed):
+		response.Forbidden(c, err.Error())
+	case errors.Is(err, entities.ErrMeetingNotFound),
+		errors.Is(err, entities.ErrRecordingNotFound),
+		errors.Is(err, storage.ErrUploadMissing),
+		errors.Is(err, storage.ErrNotFound):
+		response.NotFound(c, "Recording not found")
+	case errors.Is(err, entities.ErrRecordingTooLarge), errors.As(err, &maxBytesErr):
+		response.Error(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", entities.ErrRecordingTooLarge.Error(), "")
+	case errors.Is(err, entities.ErrRecordingNotUploading),
+		errors.Is(err, entities.ErrRecordingUnavailable):
+		response.Conflict(c, err.Error())
+	case errors.Is(err, entities.ErrInvalidRecording),
+		errors.Is(err, entities.ErrRecordingIncomplete),
+		errors.Is(err, storage.ErrInvalidPart):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalServerError(c, "Failed to process recording")
+	}
+}
+
+func mapRecordingToResponse(recording *entities.Recording) dto.RecordingResponse {
+	return dto.RecordingResponse{
+		ID:          recording.ID,
+		MeetingID:   recording.MeetingID,
+		FileName:    recording.FileName,
+		ContentType: recording.ContentType,
+		SizeBytes:   recording.SizeBytes,
+		Status:      string(recording.Status),
+		UploadedBy:  recording.UploadedBy,
+		CreatedAt:   recording.CreatedAt,
+		CompletedAt: recording.CompletedAt,
+	}
+}
+
+func mapUploadToResponse(status *usecases.UploadStatus) dto.RecordingUploadResponse {
+	parts := make([]dto.UploadPartResponse, len(status.Parts))
+	for i, part := range status.Parts {
+		parts[i] = dto.UploadPartResponse{
+			PartNumber: part.Number,
+			Size:       part.Size,
+			ETag:       part.ETag,
+		}
+	}
+
+	return dto.RecordingUploadResponse{
+		RecordingResponse: mapRecordingToResponse(status.Recording),
+		UploadedBytes:     status.UploadedBytes,
+		MinPartSize:       storage.MinPartSize,
+		Parts:             parts,
+	}
+}
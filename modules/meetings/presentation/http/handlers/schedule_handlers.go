@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/dto"
+)
+
+// ScheduleHandlers contains availability-related HTTP handlers
+type ScheduleHandlers struct {
+	getFreeBusyUC  *usecases.GetFreeBusyUseCase
+	suggestSlotsUC *usecases.SuggestSlotsUseCase
+}
+
+// NewScheduleHandlers creates new ScheduleHandlers
+func NewScheduleHandlers(
+	getFreeBusyUC *usecases.GetFreeBusyUseCase,
+	suggestSlotsUC *usecases.SuggestSlotsUseCase,
+) *ScheduleHandlers {
+	return &ScheduleHandlers{
+		getFreeBusyUC:  getFreeBusyUC,
+		suggestSlotsUC: suggestSlotsUC,
+	}
+}
+
+// GetFreeBusy returns busy intervals for a set of users
+// @Summary Free/busy lookup
+// @Description Get busy intervals of users over a time range, with each user's timezone and working-hour windows
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param user_ids query []string true "User IDs (repeated or comma-separated)"
+// @Param from query string true "Range start (RFC3339)"
+// @Param to query string true "Range end (RFC3339)"
+// @Success 200 {object} response.Response{data=[]dto.FreeBusyResponse}
+// @Failure 400 {object} response.Response
+// @Router /meetings/free-busy [get]
+func (h *ScheduleHandlers) GetFreeBusy(c *gin.Context) {
+	var query dto.AvailabilityQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.getFreeBusyUC.Execute(c.Request.Context(), splitIDs(query.UserIDs), query.From, query.To)
+	if err != nil {
+		handleScheduleError(c, err)
+		return
+	}
+
+	freeBusyResponses := make([]dto.FreeBusyResponse, len(result))
+	for i, userBusy := range result {
+		busy := make([]dto.BusyIntervalResponse, len(userBusy.Busy))
+		for j, interval := range userBusy.Busy {
+			busy[j] = dto.BusyIntervalResponse{
+				StartTime: interval.Start,
+				EndTime:   interval.End,
+			}
+		}
+		workingHours := make([]dto.TimeSlotResponse, len(userBusy.WorkingHours))
+		for j, window := range userBusy.WorkingHours {
+			workingHours[j] = dto.TimeSlotResponse{
+				StartTime: window.Start,
+				EndTime:   window.End,
+			}
+		}
+		freeBusyResponses[i] = dto.FreeBusyResponse{
+			UserID:       userBusy.UserID,
+			Timezone:     userBusy.Timezone,
+			WorkingHours: workingHours,
+			Busy:         busy,
+		}
+	}
+
+	response.OK(c, "Free/busy retrieved successfully", freeBusyResponses)
+}
+
+// SuggestSlots finds common free windows in working hours
+// @Summary Suggest meeting slots
+// @Description Find free windows shared by all users within each user's local working hours
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param user_ids query []string true "User IDs (repeated or comma-separated)"
+// @Param from query string true "Range start (RFC3339)"
+// @Param to query string true "Range end (RFC3339)"
+// @Param duration_minutes query int true "Required meeting duration"
+// @Success 200 {object} response.Response{data=[]dto.TimeSlotResponse}
+// @Failure 400 {object} response.Response
+// @Router /meetings/suggest-slots [get]
+func (h *ScheduleHandlers) SuggestSlots(c *gin.Context) {
+	var query dto.AvailabilityQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	slots, err := h.suggestSlotsUC.Execute(c.Request.Context(), usecases.SuggestInput{
+		UserIDs:  splitIDs(query.UserIDs),
+		From:     query.From,
+		To:       query.To,
+		Duration: time.Duration(query.DurationMinutes) * time.Minute,
+	})
+	if err != nil {
+		handleScheduleError(c, err)
+		return
+	}
+
+	slotResponses := make([]dto.TimeSlotResponse, len(slots))
+	for i, slot := range slots {
+		slotResponses[i] = dto.TimeSlotResponse{
+			StartTime: slot.Start,
+			EndTime:   slot.End,
+		}
+	}
+
+	response.OK(c, "Slots suggested successfully", slotResponses)
+}
+
+func handleScheduleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entities.ErrInvalidTimeRange), errors.Is(err, entities.ErrInvalidDuration):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalServerError(c, "Failed to compute availability")
+	}
+}
+
+// splitIDs accepts both repeated and comma-separated query values
+func splitIDs(values []string) []string {
+	ids := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
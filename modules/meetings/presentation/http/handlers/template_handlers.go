@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/dto"
+)
+
+// TemplateHandlers contains meeting template HTTP handlers
+type TemplateHandlers struct {
+	createUC *usecases.CreateTemplateUseCase
+	listUC   *usecases.ListTemplatesUseCase
+	getUC    *usecases.GetTemplateUseCase
+	updateUC *usecases.UpdateTemplateUseCase
+	deleteUC *usecases.DeleteTemplateUseCase
+}
+
+// NewTemplateHandlers creates new TemplateHandlers
+func NewTemplateHandlers(
+	createUC *usecases.CreateTemplateUseCase,
+	listUC *usecases.ListTemplatesUseCase,
+	getUC *usecases.GetTemplateUseCase,
+	updateUC *usecases.UpdateTemplateUseCase,
+	deleteUC *usecases.DeleteTemplateUseCase,
+) *TemplateHandlers {
+	return &TemplateHandlers{
+		createUC: createUC,
+		listUC:   listUC,
+		getUC:    getUC,
+		updateUC: updateUC,
+		deleteUC: deleteUC,
+	}
+}
+
+// CreateTemplate creates a meeting template
+// @Summary Create meeting template
+// @Description Create a reusable meeting template. Shared templates can be used by everyone but only changed by their owner.
+// @Tags meetings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.TemplateRequest true "Template"
+// @Success 201 {object} response.Response{data=dto.TemplateResponse}
+// @Failure 400 {object} response.Response
+// @Router /meetings/templates [post]
+func (h *TemplateHandlers) CreateTemplate(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	template, err := h.createUC.Execute(c.Request.Context(), userID.(string), mapTemplateInput(req))
+	if err != nil {
+		handleTemplateError(c, err)
+		return
+	}
+
+	response.Created(c, "Template created successfully", mapTemplateToResponse(template, userID.(string)))
+}
+
+// ListTemplates lists meeting templates
+// @Summary List meeting templates
+// @Description List the user's own templates and the shared templates of others
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.Response{data=[]dto.TemplateResponse}
+// @Router /meetings/templates [get]
+func (h *TemplateHandlers) ListTemplates(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	templates, err := h.listUC.Execute(c.Request.Context(), userID.(string))
+	if err != nil {
+		handleTemplateError(c, err)
+		return
+	}
+
+	templateResponses := make([]dto.TemplateResponse, len(templates))
+	for i, template := range templates {
+		templateResponses[i] = mapTemplateToResponse(template, userID.(string))
+	}
+
+	response.OK(c, "Templates retrieved successfully", templateResponses)
+}
+
+// GetTemplate returns a meeting template
+// @Summary Get meeting template
+// @Description Get a template the user owns or that is shared
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Success 200 {object} response.Response{data=dto.TemplateResponse}
+// @Failure 404 {object} response.Response
+// @Router /meetings/templates/{templateId} [get]
+func (h *TemplateHandlers) GetTemplate(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	template, err := h.getUC.Execute(c.Request.Context(), c.Param("templateId"), userID.(string))
+	if err != nil {
+		handleTemplateError(c, err)
+		return
+	}
+
+	response.OK(c, "Template retrieved successfully", mapTemplateToResponse(template, userID.(string)))
+}
+
+// UpdateTemplate replaces a meeting template
+// @Summary Update meeting template
+// @Description Replace a template (owner only). Meetings created from it earlier are not changed.
+// @Tags meetings
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param templateId path string true "Template ID"
+// @Param request body dto.TemplateRequest true "Template"
+// @Success 200 {object} response.Response{data=dto.TemplateResponse}
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /meetings/templates/{templateId} [put]
+func (h *TemplateHandlers) UpdateTemplate(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	template, err := h.updateUC.Execute(c.Request.Context(), c.Param("templateId"), userID.(string), mapTemplateInput(req))
+	if err != nil {
+		handleTemplateError(c, err)
+		return
+	}
+
+	response.OK(c, "Template updated successfully", mapTemplateToResponse(template, userID.(string)))
+}
+
+// DeleteTemplate deletes a meeting template
+// @Summary Delete meeting template
+// @Description Delete a template (owner only)
+// @Tags meetings
+// @Security BearerAuth
+// @Param templateId path string true "Template ID"
+// @Success 204
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /meetings/templates/{templateId} [delete]
+func (h *TemplateHandlers) DeleteTemplate(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	if err := h.deleteUC.Execute(c.Request.Context(), c.Param("templateId"), userID.(string)); err != nil {
+		handleTemplateError(c, err)
+		return
+	}
+
+	response.NoContent(c)
+}
+
+func handleTemplateError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entities.ErrTemplateForbidden):
+		response.Forbidden(c, err.Error())
+	case errors.Is(err, entities.ErrTemplateNotFound):
+		response.NotFound(c, err.Error())
+	case errors.Is(err, entities.ErrInvalidTemplate),
+		errors.Is(err, entities.ErrInvalidAgenda),
+		errors.Is(err, entities.ErrInvalidRoomSettings):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalServerError(c, "Failed to process meeting template")
+	}
+}
+
+func mapTemplateInput(req dto.TemplateRequest) usecases.TemplateInput {
+	agenda := make([]usecases.AgendaItemInput, len(req.Agenda))
+	for i, item := range req.Agenda {
+		agenda[i] = usecases.AgendaItemInput{
+			Title:           item.Title,
+			Description:     item.Description,
+			DurationMinutes: item.DurationMinutes,
+			PresenterID:     item.PresenterID,
+		}
+	}
+
+	return usecases.TemplateInput{
+		Name:            req.Name,
+		TitlePattern:    req.TitlePattern,
+		Description:     req.Description,
+		Duration:        time.Duration(req.DurationMinutes) * time.Minute,
+		MaxParticipants: req.MaxParticipants,
+		InviteeIDs:      req.InviteeIDs,
+		Agenda:          agenda,
+		Settings:        mapRoomSettingsInput(req.Settings),
+		Shared:          req.Shared,
+	}
+}
+
+// mapTemplateToResponse maps a template; only its owner sees the passcode
+func mapTemplateToResponse(template *entities.MeetingTemplate, userID string) dto.TemplateResponse {
+	agenda := make([]dto.AgendaItemRequest, len(template.Agenda))
+	for i, item := range template.Agenda {
+		agenda[i] = dto.AgendaItemRequest{
+			Title:           item.Title,
+			Description:     item.Description,
+			DurationMinutes: item.DurationMinutes,
+			PresenterID:     item.PresenterID,
+		}
+	}
+
+	return dto.TemplateResponse{
+		ID:              template.ID,
+		OwnerID:         template.OwnerID,
+		Name:            template.Name,
+		TitlePattern:    template.TitlePattern,
+		Description:     template.Description,
+		DurationMinutes: int(template.Duration.Minutes()),
+		MaxParticipants: template.MaxParticipants,
+		InviteeIDs:      template.InviteeIDs,
+		Agenda:          agenda,
+		Settings:        mapRoomSettingsToResponse(template.Settings, template.OwnerID == userID),
+		Shared:          template.Shared,
+		CreatedAt:       template.CreatedAt,
+		UpdatedAt:       template.UpdatedAt,
+	}
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/meetings/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/dto"
+)
+
+// maxTranscriptSize is the largest accepted transcript upload
+const maxTranscriptSize = 10 << 20 // 10 MiB
+
+// transcriptFormats maps upload content types to transcript formats
+var transcriptFormats = map[string]entities.TranscriptFormat{
+	"text/vtt":             entities.TranscriptFormatVTT,
+	"application/x-subrip": entities.TranscriptFormatSRT,
+	"text/srt":             entities.TranscriptFormatSRT,
+	"application/json":     entities.TranscriptFormatJSON,
+}
+
+// TranscriptHandlers contains transcript and summary HTTP handlers
+type TranscriptHandlers struct {
+	ingestUC     *usecases.IngestTranscriptUseCase
+	getUC        *usecases.GetTranscriptUseCase
+	getSummaryUC *usecases.GetSummaryUseCase
+	searchUC     *usecases.SearchTranscriptsUseCase
+}
+
+// NewTranscriptHandlers creates new TranscriptHandlers
+func NewTranscriptHandlers(
+	ingestUC *usecases.IngestTranscriptUseCase,
+	getUC *usecases.GetTranscriptUseCase,
+	getSummaryUC *usecases.GetSummaryUseCase,
+	searchUC *usecases.SearchTranscriptsUseCase,
+) *TranscriptHandlers {
+	return &TranscriptHandlers{
+		ingestUC:     ingestUC,
+		getUC:        getUC,
+		getSummaryUC: getSummaryUC,
+		searchUC:     searchUC,
+	}
+}
+
+// IngestTranscript stores the transcript of a meeting
+// @Summary Ingest transcript
+// @Description Upload a WebVTT, SRT or JSON transcript as the raw request body, replacing any earlier one, and summarize it (hosts only). The format is taken from the format query parameter or the Content-Type (text/vtt, application/x-subrip, application/json). JSON is an array of {start, end, speaker, text} with times in seconds, or an object with a "segments" array.
+// @Tags meetings
+// @Security BearerAuth
+// @Accept plain
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Param format query string false "Transcript format (vtt, srt, json)"
+// @Success 201 {object} response.Response{data=dto.IngestTranscriptResponse}
+// @Failure 400 {object} response.Response
+// @Failure 413 {object} response.Response
+// @Router /meetings/{id}/transcript [put]
+func (h *TranscriptHandlers) IngestTranscript(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	format := entities.TranscriptFormat(c.Query("format"))
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(c.ContentType())
+		format = transcriptFormats[mediaType]
+	}
+	if format == "" {
+		response.BadRequest(c, "Unknown transcript format; set the format query parameter or Content-Type")
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxTranscriptSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "Transcript is too large", "")
+			return
+		}
+		response.BadRequest(c, "Failed to read transcript")
+		return
+	}
+
+	output, err := h.ingestUC.Execute(c.Request.Context(), usecases.IngestTranscriptInput{
+		MeetingID: c.Param("id"),
+		UserID:    userID.(string),
+		Format:    format,
+		Data:      data,
+	})
+	if err != nil {
+		handleTranscriptError(c, err)
+		return
+	}
+
+	resp := dto.IngestTranscriptResponse{
+		MeetingID:    output.Transcript.MeetingID,
+		Format:       string(output.Transcript.Format),
+		SegmentCount: len(output.Transcript.Segments),
+	}
+	if output.Summary != nil {
+		summary := mapSummaryToResponse(output.Summary)
+		resp.Summary = &summary
+	}
+
+	response.Created(c, "Transcript ingested successfully", resp)
+}
+
+// GetTranscript returns the transcript of a meeting
+// @Summary Get transcript
+// @Description Get the timed transcript segments of a meeting (participants only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=dto.TranscriptResponse}
+// @Failure 404 {object} response.Response
+// @Router /meetings/{id}/transcript [get]
+func (h *TranscriptHandlers) GetTranscript(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	transcript, err := h.getUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handleTranscriptError(c, err)
+		return
+	}
+
+	segments := make([]dto.TranscriptSegmentResponse, len(transcript.Segments))
+	for i, segment := range transcript.Segments {
+		segments[i] = mapSegmentToResponse(segment)
+	}
+
+	response.OK(c, "Transcript retrieved successfully", dto.TranscriptResponse{
+		MeetingID:  transcript.MeetingID,
+		Format:     string(transcript.Format),
+		UploadedBy: transcript.UploadedBy,
+		CreatedAt:  transcript.CreatedAt,
+		Segments:   segments,
+	})
+}
+
+// GetSummary returns the summary of a meeting
+// @Summary Get summary
+// @Description Get the summary, key points and detected action items of a meeting transcript (participants only)
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Meeting ID"
+// @Success 200 {object} response.Response{data=dto.SummaryResponse}
+// @Failure 404 {object} response.Response
+// @Router /meetings/{id}/summary [get]
+func (h *TranscriptHandlers) GetSummary(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	summary, err := h.getSummaryUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handleTranscriptError(c, err)
+		return
+	}
+
+	response.OK(c, "Summary retrieved successfully", mapSummaryToResponse(summary))
+}
+
+// SearchTranscripts searches meeting transcripts
+// @Summary Search transcripts
+// @Description Full-text search across transcripts of meetings the user organizes or participates in. Supports quoted phrases, OR and -exclusions.
+// @Tags meetings
+// @Security BearerAuth
+// @Produce json
+// @Param q query string true "Search query"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.PaginatedResponse{data=[]dto.TranscriptSearchResultResponse}
+// @Router /meetings/transcripts/search [get]
+func (h *TranscriptHandlers) SearchTranscripts(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	results, total, err := h.searchUC.Execute(c.Request.Context(), userID.(string), c.Query("q"), page, pageSize)
+	if err != nil {
+		response.InternalServerError(c, "Failed to search transcripts")
+		return
+	}
+
+	resultResponses := make([]dto.TranscriptSearchResultResponse, len(results))
+	for i, result := range results {
+		resultResponses[i] = dto.TranscriptSearchResultResponse{
+			MeetingID:    result.MeetingID,
+			MeetingTitle: result.MeetingTitle,
+			Seq:          result.Segment.Seq,
+			StartMs:      result.Segment.Start.Milliseconds(),
+			EndMs:        result.Segment.End.Milliseconds(),
+			Speaker:      result.Segment.Speaker,
+			Snippet:      result.Snippet,
+		}
+	}
+
+	response.Paginated(c, resultResponses, page, pageSize, total)
+}
+
+func handleTranscriptError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrMeetingForbidden):
+		response.Forbidden(c, err.Error())
+	case errors.Is(err, entities.ErrMeetingNotFound),
+		errors.Is(err, entities.ErrTranscriptNotFound),
+		errors.Is(err, entities.ErrSummaryNotFound):
+		response.NotFound(c, err.Error())
+	case errors.Is(err, entities.ErrInvalidTranscript),
+		errors.Is(err, entities.ErrUnsupportedTranscript):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalServerError(c, "Failed to process transcript")
+	}
+}
+
+func mapSegmentToResponse(segment entities.TranscriptSegment) dto.TranscriptSegmentResponse {
+	return dto.TranscriptSegmentResponse{
+		Seq:     segment.Seq,
+		StartMs: segment.Start.Milliseconds(),
+		EndMs:   segment.End.Milliseconds(),
+		Speaker: segment.Speaker,
+		Text:    segment.Text,
+	}
+}
+
+func mapSummaryToResponse(summary *entities.Summary) dto.SummaryResponse {
+	actionItems := make([]dto.DetectedActionItemResponse, len(summary.ActionItems))
+	for i, item := range summary.ActionItems {
+		actionItems[i] = dto.DetectedActionItemResponse{
+			Text:    item.Text,
+			Speaker: item.Speaker,
+			Seq:     item.Seq,
+		}
+	}
+
+	keyPoints := summary.KeyPoints
+	if keyPoints == nil {
+		keyPoints = []string{}
+	}
+
+	return dto.SummaryResponse{
+		MeetingID:   summary.MeetingID,
+		Summary:     summary.Text,
+		KeyPoints:   keyPoints,
+		ActionItems: actionItems,
+		Generator:   summary.Generator,
+		CreatedAt:   summary.CreatedAt,
+	}
+}
@@ -1,19 +1,88 @@
 package routes
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/manab-pr/evtaarpro/internal/middleware"
 	"github.com/manab-pr/evtaarpro/modules/meetings/presentation/http/handlers"
 )
 
 // RegisterRoutes registers meeting routes
-func RegisterRoutes(rg *gin.RouterGroup, handlers *handlers.MeetingHandlers, jwtSecret string) {
+func RegisterRoutes(
+	rg *gin.RouterGroup,
+	handlers *handlers.MeetingHandlers,
+	scheduleHandlers *handlers.ScheduleHandlers,
+	attendanceHandlers *handlers.AttendanceHandlers,
+	recordingHandlers *handlers.RecordingHandlers,
+	minutesHandlers *handlers.MinutesHandlers,
+	guestHandlers *handlers.GuestHandlers,
+	transcriptHandlers *handlers.TranscriptHandlers,
+	templateHandlers *handlers.TemplateHandlers,
+	blobHandler http.Handler,
+	jwtSecret string,
+) {
+	// Webhooks authenticate with a shared secret instead of a user token
+	rg.POST("/meetings/webhooks/jitsi", attendanceHandlers.JitsiWebhook)
+
+	// Download links of the local blob store are signed instead; other
+	// stores sign their own URLs
+	if blobHandler != nil {
+		rg.GET("/blobs/*key", gin.WrapH(http.StripPrefix(rg.BasePath()+"/blobs", blobHandler)))
+	}
+
+	// Guests have no account; the link token in the path grants access
+	guests := rg.Group("/meetings/guest")
+	{
+		guests.GET("/:token", guestHandlers.GetGuestLink)
+		guests.POST("/:token/lobby", guestHandlers.RequestGuestAccess)
+		guests.GET("/:token/lobby/:entryId", guestHandlers.GetLobbyStatus)
+	}
+
 	meetings := rg.Group("/meetings")
 	meetings.Use(middleware.AuthMiddleware(jwtSecret))
 	{
 		meetings.POST("", handlers.CreateMeeting)
 		meetings.GET("", handlers.ListMeetings)
+		meetings.GET("/free-busy", scheduleHandlers.GetFreeBusy)
+		meetings.GET("/suggest-slots", scheduleHandlers.SuggestSlots)
+		meetings.GET("/action-items", minutesHandlers.ListMyActionItems)
+		meetings.GET("/transcripts/search", transcriptHandlers.SearchTranscripts)
+		meetings.GET("/templates", templateHandlers.ListTemplates)
+		meetings.POST("/templates", templateHandlers.CreateTemplate)
+		meetings.GET("/templates/:templateId", templateHandlers.GetTemplate)
+		meetings.PUT("/templates/:templateId", templateHandlers.UpdateTemplate)
+		meetings.DELETE("/templates/:templateId", templateHandlers.DeleteTemplate)
 		meetings.GET("/:id", handlers.GetMeeting)
 		meetings.POST("/:id/join", handlers.JoinMeeting)
+		meetings.PATCH("/:id/settings", handlers.UpdateRoomSettings)
+		meetings.POST("/:id/cancel", handlers.CancelMeeting)
+		meetings.POST("/:id/leave", attendanceHandlers.LeaveMeeting)
+		meetings.GET("/:id/attendance", attendanceHandlers.GetAttendance)
+		meetings.GET("/:id/recordings", recordingHandlers.ListRecordings)
+		meetings.POST("/:id/recordings", recordingHandlers.StartUpload)
+		meetings.GET("/:id/recordings/:recordingId/upload", recordingHandlers.GetUpload)
+		meetings.DELETE("/:id/recordings/:recordingId/upload", recordingHandlers.AbortUpload)
+		meetings.PUT("/:id/recordings/:recordingId/parts/:partNumber", recordingHandlers.UploadPart)
+		meetings.POST("/:id/recordings/:recordingId/complete", recordingHandlers.CompleteUpload)
+		meetings.GET("/:id/recordings/:recordingId/download", recordingHandlers.GetDownloadURL)
+		meetings.GET("/:id/agenda", minutesHandlers.GetAgenda)
+		meetings.PUT("/:id/agenda", minutesHandlers.SetAgenda)
+		meetings.GET("/:id/notes", minutesHandlers.GetNotes)
+		meetings.PUT("/:id/notes", minutesHandlers.UpdateNotes)
+		meetings.GET("/:id/notes/revisions", minutesHandlers.ListNoteRevisions)
+		meetings.GET("/:id/notes/revisions/:revision", minutesHandlers.GetNoteRevision)
+		meetings.GET("/:id/action-items", minutesHandlers.ListActionItems)
+		meetings.POST("/:id/action-items", minutesHandlers.CreateActionItem)
+		meetings.PATCH("/:id/action-items/:itemId", minutesHandlers.UpdateActionItem)
+		meetings.GET("/:id/guest-links", guestHandlers.ListGuestLinks)
+		meetings.POST("/:id/guest-links", guestHandlers.CreateGuestLink)
+		meetings.DELETE("/:id/guest-links/:linkId", guestHandlers.RevokeGuestLink)
+		meetings.GET("/:id/lobby", guestHandlers.ListLobby)
+		meetings.POST("/:id/lobby/:entryId/admit", guestHandlers.AdmitGuest)
+		meetings.POST("/:id/lobby/:entryId/deny", guestHandlers.DenyGuest)
+		meetings.GET("/:id/transcript", transcriptHandlers.GetTranscript)
+		meetings.PUT("/:id/transcript", transcriptHandlers.IngestTranscript)
+		meetings.GET("/:id/summary", transcriptHandlers.GetSummary)
 	}
 }
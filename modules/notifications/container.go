@@ -1,22 +1,213 @@
 package notifications
 
 import (
+	"context"
+	"log/slog"
+	"os"
+
 	"github.com/gin-gonic/gin"
 	"github.com/manab-pr/evtaarpro/internal/config"
 	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/internal/jobs"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/realtime"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/notifications/infra/channels"
 	"github.com/manab-pr/evtaarpro/modules/notifications/infra/postgresql"
+	"github.com/manab-pr/evtaarpro/modules/notifications/infra/pubsub"
+	"github.com/manab-pr/evtaarpro/modules/notifications/infra/templates"
+	"github.com/manab-pr/evtaarpro/modules/notifications/presentation/events"
 	"github.com/manab-pr/evtaarpro/modules/notifications/presentation/http/handlers"
 	"github.com/manab-pr/evtaarpro/modules/notifications/presentation/http/routes"
 )
 
 // RegisterRoutes registers notifications module routes
-func RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
+func RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore, gateway *realtime.Gateway) {
 	// Infrastructure
-	notificationRepo := postgresql.NewNotificationRepository(pgStore.DB)
+	notificationRepo := NewNotificationRepository(pgStore, redisStore)
+	settingsRepo := postgresql.NewSettingsRepository(pgStore.DB)
+	deliveryRepo := postgresql.NewDeliveryRepository(pgStore.DB)
+
+	// Use cases
+	dispatchUC := NewDispatchNotificationUseCase(cfg, pgStore, redisStore)
+	broadcastUC := usecases.NewBroadcastNotificationUseCase(postgresql.NewRecipientRepository(pgStore.DB), dispatchUC)
+	getPreferencesUC := usecases.NewGetPreferencesUseCase(settingsRepo)
+	updatePreferencesUC := usecases.NewUpdatePreferencesUseCase(settingsRepo)
+	listDeliveriesUC := usecases.NewListDeliveriesUseCase(notificationRepo, deliveryRepo)
 
 	// Handlers
-	notificationHandlers := handlers.NewNotificationHandlers(notificationRepo)
+	notificationHandlers := handlers.NewNotificationHandlers(notificationRepo, dispatchUC, broadcastUC)
+	streamHandlers := handlers.NewStreamHandlers(notificationRepo, gateway, cfg.WebSocket.PingInterval)
+	preferenceHandlers := handlers.NewPreferenceHandlers(
+		getPreferencesUC,
+		updatePreferencesUC,
+		listDeliveriesUC,
+		append([]string{entities.ChannelInApp}, configuredChannels(cfg)...),
+		cfg.Notifications.DefaultChannels,
+	)
 
 	// Register routes
-	routes.RegisterRoutes(rg, notificationHandlers, cfg.JWT.Secret)
+	routes.RegisterRoutes(rg, notificationHandlers, streamHandlers, preferenceHandlers, cfg.JWT.Secret, cfg.Notifications.BroadcastRoles)
+}
+
+// RegisterSubscribers subscribes the notifications module to the domain
+// events of other modules
+func RegisterSubscribers(bus *eventbus.Bus, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
+	subscribers := events.NewSubscribers(
+		NewDispatchNotificationUseCase(cfg, pgStore, redisStore),
+		postgresql.NewRecipientRepository(pgStore.DB),
+	)
+	subscribers.Register(bus)
+}
+
+// RegisterJobs registers notifications module background jobs
+func RegisterJobs(scheduler *jobs.Scheduler, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
+	processDeliveriesUC := usecases.NewProcessDeliveriesUseCase(
+		postgresql.NewDeliveryRepository(pgStore.DB),
+		postgresql.NewNotificationRepository(pgStore.DB),
+		postgresql.NewSettingsRepository(pgStore.DB),
+		postgresql.NewRecipientRepository(pgStore.DB),
+		newTemplateRegistry(cfg),
+		channelSenders(cfg),
+		entities.RetryPolicy{
+			MaxAttempts: cfg.Notifications.MaxAttempts,
+			Backoff:     cfg.Notifications.RetryBackoff,
+			MaxBackoff:  cfg.Notifications.MaxBackoff,
+		},
+		cfg.Notifications.BatchSize,
+	)
+
+	sendDigestsUC := usecases.NewSendDigestsUseCase(
+		postgresql.NewDeliveryRepository(pgStore.DB),
+		NewNotificationRepository(pgStore, redisStore),
+		postgresql.NewRecipientRepository(pgStore.DB),
+		newTemplateRegistry(cfg),
+		pgStore,
+		cfg.Notifications.BatchSize,
+	)
+
+	archiveExpiredUC := usecases.NewArchiveExpiredUseCase(NewNotificationRepository(pgStore, redisStore))
+
+	scheduler.Register(jobs.Job{
+		Name:     "notifications.archive",
+		Interval: cfg.Notifications.JobInterval,
+		Run: func(ctx context.Context) error {
+			users, err := archiveExpiredUC.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if users > 0 {
+				logging.FromContext(ctx).Info("archived expired notifications", "users", users)
+			}
+			return nil
+		},
+	})
+
+	scheduler.Register(jobs.Job{
+		Name:     "notifications.digest",
+		Interval: cfg.Notifications.JobInterval,
+		Run: func(ctx context.Context) error {
+			output, err := sendDigestsUC.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if output.Digests > 0 {
+				logging.FromContext(ctx).Info("sent notification digests", "digests", output.Digests, "notifications", output.Notifications)
+			}
+			return nil
+		},
+	})
+
+	scheduler.Register(jobs.Job{
+		Name:     "notifications.deliver",
+		Interval: cfg.Notifications.JobInterval,
+		Run: func(ctx context.Context) error {
+			output, err := processDeliveriesUC.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if output.Sent+output.Retrying+output.Failed > 0 {
+				logging.FromContext(ctx).Info("processed notification deliveries", "sent", output.Sent, "retrying", output.Retrying, "failed", output.Failed)
+			}
+			return nil
+		},
+	})
+}
+
+// NewNotificationRepository returns the notification repository. Stored
+// notifications are pushed to the recipient's connected clients.
+func NewNotificationRepository(pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) ports.NotificationRepository {
+	return pubsub.NewNotificationRepository(
+		postgresql.NewNotificationRepository(pgStore.DB),
+		realtime.NewRedisPublisher(redisStore),
+	)
+}
+
+// NewDispatchNotificationUseCase returns the use case other modules send
+// notifications through. It honours the recipient's channel preferences
+// and quiet hours.
+func NewDispatchNotificationUseCase(cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) *usecases.DispatchNotificationUseCase {
+	return usecases.NewDispatchNotificationUseCase(
+		NewNotificationRepository(pgStore, redisStore),
+		postgresql.NewSettingsRepository(pgStore.DB),
+		postgresql.NewRecipientRepository(pgStore.DB),
+		postgresql.NewDeliveryRepository(pgStore.DB),
+		newTemplateRegistry(cfg),
+		configuredChannels(cfg),
+		cfg.Notifications.DefaultChannels,
+		cfg.Notifications.DailyDigestAt,
+		cfg.Notifications.DefaultTTL,
+	)
+}
+
+// newTemplateRegistry loads the notification templates. They are embedded
+// in the binary, so an invalid template is a build defect.
+func newTemplateRegistry(cfg *config.Config) *templates.Registry {
+	fallback := cfg.Notifications.FallbackLocale
+	if fallback == "" {
+		fallback = "en"
+	}
+	registry, err := templates.NewRegistry(fallback)
+	if err != nil {
+		slog.Error("failed to load notification templates", "error", err)
+		os.Exit(1)
+	}
+	return registry
+}
+
+// configuredChannels lists the configured channels besides in-app
+func configuredChannels(cfg *config.Config) []string {
+	configured := make([]string, 0, 3)
+	if cfg.Notifications.SMTP.Host != "" {
+		configured = append(configured, entities.ChannelEmail)
+	}
+	configured = append(configured, entities.ChannelWebhook)
+	if cfg.Notifications.LogSink.Enabled {
+		configured = append(configured, entities.ChannelLog)
+	}
+	return configured
+}
+
+// channelSenders creates the senders of the configured channels
+func channelSenders(cfg *config.Config) []ports.ChannelSender {
+	senders := make([]ports.ChannelSender, 0, 3)
+	for _, channel := range configuredChannels(cfg) {
+		switch channel {
+		case entities.ChannelEmail:
+			senders = append(senders, channels.NewEmailSender(cfg.Notifications.SMTP))
+		case entities.ChannelWebhook:
+			senders = append(senders, channels.NewWebhookSender(cfg.Notifications.Webhook))
+		case entities.ChannelLog:
+			sender, err := channels.NewLogSender(cfg.Notifications.LogSink.Path)
+			if err != nil {
+				slog.Warn("notification log sink disabled", "error", err)
+				continue
+			}
+			senders = append(senders, sender)
+		}
+	}
+	return senders
 }
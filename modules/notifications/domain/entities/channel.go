@@ -0,0 +1,55 @@
+package entities
+
+// Channels notifications are delivered over
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+	ChannelLog     = "log"
+)
+
+// Notification types
+const (
+	TypeMeeting = "meeting"
+	TypePayroll = "payroll"
+	TypeCRM     = "crm"
+	TypeSystem  = "system"
+
+	// TypeAll matches every notification type in preferences
+	TypeAll = "*"
+)
+
+// Notification priorities
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// IsValidChannel reports whether channel is a known delivery channel
+func IsValidChannel(channel string) bool {
+	switch channel {
+	case ChannelInApp, ChannelEmail, ChannelWebhook, ChannelLog:
+		return true
+	}
+	return false
+}
+
+// IsValidType reports whether notificationType is a known notification type
+func IsValidType(notificationType string) bool {
+	switch notificationType {
+	case TypeMeeting, TypePayroll, TypeCRM, TypeSystem:
+		return true
+	}
+	return false
+}
+
+// IsValidPriority reports whether priority is a known notification priority
+func IsValidPriority(priority string) bool {
+	switch priority {
+	case PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Delivery statuses
+const (
+	DeliveryPending = "pending"
+	DeliverySent    = "sent"
+	DeliveryFailed  = "failed"
+	DeliverySkipped = "skipped"
+)
+
+// ErrUndeliverable marks delivery errors that retrying cannot fix
+var ErrUndeliverable = errors.New("notification cannot be delivered over this channel")
+
+// Delivery records the delivery of a notification over one channel
+type Delivery struct {
+	ID             string
+	NotificationID string
+	UserID         string
+	Channel        string
+	Status         string
+	Attempts       int
+	Batched        bool    // waits for the recipient's next digest
+	DigestID       *string // digest notification the delivery was sent in
+	NextAttemptAt  *time.Time
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewDelivery creates a pending delivery due at the given time
+func NewDelivery(notification *Notification, channel string, dueAt time.Time) *Delivery {
+	now := time.Now()
+	return &Delivery{
+		ID:             uuid.New().String(),
+		NotificationID: notification.ID,
+		UserID:         notification.UserID,
+		Channel:        channel,
+		Status:         DeliveryPending,
+		NextAttemptAt:  &dueAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// Defer moves a pending delivery to a later time without counting an attempt
+func (d *Delivery) Defer(until time.Time) {
+	d.NextAttemptAt = &until
+	d.UpdatedAt = time.Now()
+}
+
+// Batch holds the delivery back until the digest sent at dueAt
+func (d *Delivery) Batch(dueAt time.Time) {
+	d.Batched = true
+	d.NextAttemptAt = &dueAt
+	d.UpdatedAt = time.Now()
+}
+
+// MarkSent records a successful delivery
+func (d *Delivery) MarkSent(at time.Time) {
+	d.Status = DeliverySent
+	d.Attempts++
+	d.NextAttemptAt = nil
+	d.LastError = ""
+	d.DeliveredAt = &at
+	d.UpdatedAt = time.Now()
+}
+
+// MarkSkipped records that the notification is not delivered over the channel
+func (d *Delivery) MarkSkipped(reason string) {
+	d.Status = DeliverySkipped
+	d.NextAttemptAt = nil
+	d.LastError = reason
+	d.UpdatedAt = time.Now()
+}
+
+// RecordFailure records a failed attempt. The delivery is retried after the
+// backoff of the policy until it runs out of attempts or the error is
+// ErrUndeliverable.
+func (d *Delivery) RecordFailure(err error, now time.Time, policy RetryPolicy) {
+	d.Attempts++
+	d.LastError = err.Error()
+	d.UpdatedAt = time.Now()
+
+	if errors.Is(err, ErrUndeliverable) || d.Attempts >= policy.MaxAttempts {
+		d.Status = DeliveryFailed
+		d.NextAttemptAt = nil
+		return
+	}
+
+	retryAt := now.Add(policy.Delay(d.Attempts))
+	d.NextAttemptAt = &retryAt
+}
+
+// RetryPolicy controls how failed deliveries are retried
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// Delay returns the wait after the given number of failed attempts; it
+// doubles with every attempt up to MaxBackoff, if set
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempts && delay > 0 && delay <= math.MaxInt64/2; i++ {
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
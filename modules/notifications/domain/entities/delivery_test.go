@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, Backoff: time.Minute, MaxBackoff: 10 * time.Minute}
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		attempts int
+		want     time.Duration
+	}{
+		{"first failure", policy, 1, time.Minute},
+		{"third failure", policy, 3, 4 * time.Minute},
+		{"capped", policy, 5, 10 * time.Minute},
+		{"no cap", RetryPolicy{Backoff: time.Minute}, 5, 16 * time.Minute},
+		{"no backoff", RetryPolicy{MaxBackoff: time.Hour}, 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Delay(tt.attempts); got != tt.want {
+				t.Fatalf("Delay(%d) = %v, want %v", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeliveryRecordFailure(t *testing.T) {
+	now := time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}
+	errTimeout := errors.New("smtp: timeout")
+
+	tests := []struct {
+		name       string
+		attempts   int // before the failure
+		err        error
+		wantStatus string
+		wantRetry  time.Duration // from now; zero for no retry
+	}{
+		{"first failure", 0, errTimeout, DeliveryPending, time.Minute},
+		{"second failure", 1, errTimeout, DeliveryPending, 2 * time.Minute},
+		{"out of attempts", 2, errTimeout, DeliveryFailed, 0},
+		{"undeliverable", 0, fmt.Errorf("no email address: %w", ErrUndeliverable), DeliveryFailed, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := NewDelivery(&Notification{ID: "n-1", UserID: "alice"}, ChannelEmail, now)
+			delivery.Attempts = tt.attempts
+
+			delivery.RecordFailure(tt.err, now, policy)
+			if delivery.Status != tt.wantStatus || delivery.Attempts != tt.attempts+1 || delivery.LastError != tt.err.Error() {
+				t.Fatalf("delivery = %s after %d attempts (%q), want %s", delivery.Status, delivery.Attempts, delivery.LastError, tt.wantStatus)
+			}
+			if tt.wantRetry == 0 {
+				if delivery.NextAttemptAt != nil {
+					t.Fatalf("retried at %v, want no retry", delivery.NextAttemptAt)
+				}
+				return
+			}
+			if delivery.NextAttemptAt == nil || !delivery.NextAttemptAt.Equal(now.Add(tt.wantRetry)) {
+				t.Fatalf("retried at %v, want %v", delivery.NextAttemptAt, now.Add(tt.wantRetry))
+			}
+		})
+	}
+}
+
+func TestDeliveryTransitions(t *testing.T) {
+	now := time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)
+	delivery := NewDelivery(&Notification{ID: "n-1", UserID: "alice"}, ChannelEmail, now)
+	if delivery.Status != DeliveryPending || !delivery.NextAttemptAt.Equal(now) || delivery.UserID != "alice" {
+		t.Fatalf("new delivery = %+v", delivery)
+	}
+
+	// Deferring for quiet hours does not count an attempt
+	delivery.Defer(now.Add(8 * time.Hour))
+	if delivery.Attempts != 0 || !delivery.NextAttemptAt.Equal(now.Add(8*time.Hour)) {
+		t.Fatalf("deferred delivery = %+v", delivery)
+	}
+
+	delivery.RecordFailure(errors.New("smtp: timeout"), now, RetryPolicy{MaxAttempts: 3, Backoff: time.Minute})
+	delivery.MarkSent(now.Add(time.Hour))
+	if delivery.Status != DeliverySent || delivery.Attempts != 2 || delivery.LastError != "" || delivery.NextAttemptAt != nil || !delivery.DeliveredAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("sent delivery = %+v", delivery)
+	}
+
+	skipped := NewDelivery(&Notification{ID: "n-2"}, ChannelWebhook, now)
+	skipped.MarkSkipped("no webhook URL")
+	if skipped.Status != DeliverySkipped || skipped.NextAttemptAt != nil || skipped.LastError != "no webhook URL" {
+		t.Fatalf("skipped delivery = %+v", skipped)
+	}
+}
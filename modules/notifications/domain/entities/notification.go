@@ -1,16 +1,56 @@
 package entities
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotificationNotFound is returned for notifications that do not exist
+	// or belong to someone else
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrInvalidPriority      = errors.New("invalid notification priority")
+	ErrInvalidType          = errors.New("invalid notification type")
+	ErrInvalidData          = errors.New("notification data must be a JSON object")
+	ErrInvalidExpiry        = errors.New("notification expiry must be in the future")
+	ErrEmptyAudience        = errors.New("broadcast needs at least one role or department")
+)
 
 // Notification represents a notification entity
 type Notification struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Type      string    `json:"type"` // meeting_reminder, payroll_generated, customer_assigned, etc.
-	Title     string    `json:"title"`
-	Message   string    `json:"message"`
-	Data      string    `json:"data"` // JSON data
-	Read      bool      `json:"read"`
-	CreatedAt time.Time `json:"created_at"`
-	ReadAt    *time.Time `json:"read_at,omitempty"`
+	ID         string          `json:"id"`
+	UserID     string          `json:"user_id"`
+	Type       string          `json:"type"`               // meeting, payroll, crm or system
+	Priority   string          `json:"priority"`           // low, medium, high or urgent
+	Template   string          `json:"template,omitempty"` // template key, e.g. meeting.reminder; empty for free-form notifications
+	Locale     string          `json:"locale,omitempty"`   // locale Title and Message were rendered in
+	Title      string          `json:"title"`
+	Message    string          `json:"message"`
+	Data       json.RawMessage `json:"data,omitempty"` // JSON object; the template variables for templated notifications
+	Read       bool            `json:"read"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ReadAt     *time.Time      `json:"read_at,omitempty"`
+	ExpiresAt  *time.Time      `json:"expires_at,omitempty"`
+	ArchivedAt *time.Time      `json:"archived_at,omitempty"` // set once expired; archived notifications are hidden
+}
+
+// Expired reports whether the notification is past its expiry at now
+func (n *Notification) Expired(now time.Time) bool {
+	return n.ArchivedAt != nil || (n.ExpiresAt != nil && !n.ExpiresAt.After(now))
+}
+
+// ValidateData checks that data is empty or a JSON object
+func ValidateData(data json.RawMessage) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(trimmed, &object); err != nil {
+		return ErrInvalidData
+	}
+	return nil
 }
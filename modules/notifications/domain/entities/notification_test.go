@@ -0,0 +1,58 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNotificationExpired(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	tests := []struct {
+		name         string
+		notification Notification
+		want         bool
+	}{
+		{"no expiry", Notification{}, false},
+		{"expires later", Notification{ExpiresAt: &future}, false},
+		{"expires now", Notification{ExpiresAt: &now}, true},
+		{"expired", Notification{ExpiresAt: &past}, true},
+		{"archived", Notification{ExpiresAt: &future, ArchivedAt: &past}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.notification.Expired(now); got != tt.want {
+				t.Fatalf("Expired = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateData(t *testing.T) {
+	tests := []struct {
+		data string
+		want error
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"null", nil},
+		{"{}", nil},
+		{`{"meeting_id": "m-1", "nested": {"a": [1, 2]}}`, nil},
+		{"[]", ErrInvalidData},
+		{`"text"`, ErrInvalidData},
+		{"42", ErrInvalidData},
+		{"{", ErrInvalidData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			if err := ValidateData(json.RawMessage(tt.data)); !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateData(%s): err = %v, want %v", tt.data, err, tt.want)
+			}
+		})
+	}
+}
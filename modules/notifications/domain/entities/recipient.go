@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrRecipientNotFound = errors.New("recipient not found")
+
+// Recipient holds what channels need to reach a user
+type Recipient struct {
+	UserID     string
+	Email      string
+	Name       string
+	Location   *time.Location
+	Locale     string // BCP 47 tag of the preferred language
+	WebhookURL string
+}
+
+// Message is a notification addressed to its recipient
+type Message struct {
+	Notification *Notification
+	Recipient    *Recipient
+	HTML         string // rendered email body, if the template has one
+}
@@ -0,0 +1,205 @@
+package entities
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+var (
+	ErrInvalidPreference = errors.New("invalid notification preference")
+	ErrInvalidQuietHours = errors.New("invalid quiet hours")
+	ErrInvalidWebhookURL = errors.New("webhook URL must be an https URL")
+	ErrInvalidDigest     = errors.New("invalid digest frequency")
+)
+
+// Digest frequencies
+const (
+	DigestImmediate = "immediate"
+	DigestHourly    = "hourly"
+	DigestDaily     = "daily"
+)
+
+// Preference enables or disables a channel for a notification type, or for
+// every type when Type is TypeAll
+type Preference struct {
+	Type    string
+	Channel string
+	Enabled bool
+}
+
+// QuietHours is a daily window, in the user's timezone, during which only
+// in-app notifications are delivered. It wraps midnight when End is before
+// Start.
+type QuietHours struct {
+	Start string // HH:MM
+	End   string // HH:MM
+}
+
+// Settings holds a user's notification delivery settings
+type Settings struct {
+	UserID      string
+	Preferences []Preference
+	QuietHours  *QuietHours
+	WebhookURL  string
+	Digest      string // immediate, hourly or daily
+	UpdatedAt   time.Time
+}
+
+// Enabled reports whether the channel is enabled for the notification
+// type. A preference for the type wins over one for every type; without
+// either the default channels apply.
+func (s *Settings) Enabled(notificationType, channel string, defaults []string) bool {
+	var all *bool
+	for _, preference := range s.Preferences {
+		if preference.Channel != channel {
+			continue
+		}
+		if preference.Type == notificationType {
+			return preference.Enabled
+		}
+		if preference.Type == TypeAll {
+			enabled := preference.Enabled
+			all = &enabled
+		}
+	}
+	if all != nil {
+		return *all
+	}
+
+	for _, defaultChannel := range defaults {
+		if defaultChannel == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPreference adds or replaces the preference for a type and channel
+func (s *Settings) SetPreference(preference Preference) error {
+	if (preference.Type != TypeAll && !IsValidType(preference.Type)) || !IsValidChannel(preference.Channel) {
+		return ErrInvalidPreference
+	}
+
+	for i, existing := range s.Preferences {
+		if existing.Type == preference.Type && existing.Channel == preference.Channel {
+			s.Preferences[i] = preference
+			s.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+
+	s.Preferences = append(s.Preferences, preference)
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetQuietHours sets the quiet hours; nil removes them
+func (s *Settings) SetQuietHours(hours *QuietHours) error {
+	if hours != nil {
+		start, err := time.Parse("15:04", hours.Start)
+		if err != nil {
+			return ErrInvalidQuietHours
+		}
+		end, err := time.Parse("15:04", hours.End)
+		if err != nil || start.Equal(end) {
+			return ErrInvalidQuietHours
+		}
+		hours = &QuietHours{Start: start.Format("15:04"), End: end.Format("15:04")}
+	}
+
+	s.QuietHours = hours
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetWebhookURL sets the https URL webhook deliveries are posted to; empty
+// disables them
+func (s *Settings) SetWebhookURL(rawURL string) error {
+	if rawURL != "" {
+		parsed, err := url.Parse(rawURL)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" || parsed.User != nil {
+			return ErrInvalidWebhookURL
+		}
+	}
+
+	s.WebhookURL = rawURL
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetDigest sets how often batched notifications are sent
+func (s *Settings) SetDigest(frequency string) error {
+	switch frequency {
+	case DigestImmediate, DigestHourly, DigestDaily:
+	default:
+		return ErrInvalidDigest
+	}
+
+	s.Digest = frequency
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Batches reports whether notifications of the priority wait for the next
+// digest. Only low and medium priority notifications are batched.
+func (s *Settings) Batches(priority string) bool {
+	if s.Digest != DigestHourly && s.Digest != DigestDaily {
+		return false
+	}
+	return priority == PriorityLow || priority == PriorityMedium
+}
+
+// NextDigestAt returns when the digest after t is sent: at the next full
+// hour, or daily at dailyAt (HH:MM), in the user's timezone
+func (s *Settings) NextDigestAt(t time.Time, loc *time.Location, dailyAt string) time.Time {
+	local := t.In(loc)
+	if s.Digest == DigestHourly {
+		return time.Date(local.Year(), local.Month(), local.Day(), local.Hour()+1, 0, 0, 0, loc)
+	}
+
+	clock, err := time.Parse("15:04", dailyAt)
+	if err != nil {
+		clock = time.Date(0, 1, 1, 8, 0, 0, 0, time.UTC)
+	}
+	next := time.Date(local.Year(), local.Month(), local.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	if !next.After(local) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+	return next
+}
+
+// QuietUntil returns when the quiet hours around t end, or t itself
+// outside quiet hours
+func (s *Settings) QuietUntil(t time.Time, loc *time.Location) time.Time {
+	if s.QuietHours == nil {
+		return t
+	}
+	start, errStart := time.Parse("15:04", s.QuietHours.Start)
+	end, errEnd := time.Parse("15:04", s.QuietHours.End)
+	if errStart != nil || errEnd != nil {
+		return t
+	}
+
+	local := t.In(loc)
+	at := func(day time.Time, clock time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+	startToday, endToday := at(local, start), at(local, end)
+
+	if startToday.Before(endToday) {
+		if !local.Before(startToday) && local.Before(endToday) {
+			return endToday
+		}
+		return t
+	}
+
+	// The window wraps midnight
+	if !local.Before(startToday) {
+		return at(local.AddDate(0, 0, 1), end)
+	}
+	if local.Before(endToday) {
+		return endToday
+	}
+	return t
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidEventID = errors.New("invalid event ID")
+
+// StreamPosition is the place of a notification in a user's stream.
+// Notifications are streamed in (CreatedAt, ID) order.
+type StreamPosition struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Position returns the stream position of the notification. Times are kept
+// to the microsecond, as stored by the database.
+func (n *Notification) Position() StreamPosition {
+	return StreamPosition{
+		CreatedAt: n.CreatedAt.UTC().Truncate(time.Microsecond),
+		ID:        n.ID,
+	}
+}
+
+// EventID encodes the position as a stream event ID. It stays usable for
+// resuming after the notification itself is deleted.
+func (p StreamPosition) EventID() string {
+	return strconv.FormatInt(p.CreatedAt.UnixMicro(), 10) + "-" + p.ID
+}
+
+// ParseEventID decodes a stream event ID
+func ParseEventID(eventID string) (StreamPosition, error) {
+	micros, id, found := strings.Cut(eventID, "-")
+	if !found || id == "" {
+		return StreamPosition{}, ErrInvalidEventID
+	}
+
+	value, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil || value < 0 {
+		return StreamPosition{}, ErrInvalidEventID
+	}
+
+	return StreamPosition{CreatedAt: time.UnixMicro(value).UTC(), ID: id}, nil
+}
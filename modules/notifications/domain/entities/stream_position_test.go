@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStreamPositionEventID(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	// The nanoseconds are beyond what the database keeps
+	createdAt := time.Date(2024, time.March, 11, 15, 30, 0, 123456789, kolkata)
+	notification := &Notification{ID: "4b1f-9c2e", CreatedAt: createdAt}
+
+	position := notification.Position()
+	if position.EventID() != "1710151200123456-4b1f-9c2e" {
+		t.Fatalf("EventID = %q", position.EventID())
+	}
+
+	parsed, err := ParseEventID(position.EventID())
+	if err != nil {
+		t.Fatalf("ParseEventID: %v", err)
+	}
+	if parsed != position || parsed.CreatedAt.Location() != time.UTC {
+		t.Fatalf("ParseEventID = %+v, want %+v", parsed, position)
+	}
+}
+
+func TestParseEventID(t *testing.T) {
+	tests := []struct {
+		eventID string
+		want    error
+	}{
+		{"1710151200123456-abc", nil},
+		{"0-abc", nil},
+		{"", ErrInvalidEventID},
+		{"1710151200123456", ErrInvalidEventID},
+		{"1710151200123456-", ErrInvalidEventID},
+		{"-abc", ErrInvalidEventID},
+		{"soon-abc", ErrInvalidEventID},
+		{"-5-abc", ErrInvalidEventID},
+		{"99999999999999999999-abc", ErrInvalidEventID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventID, func(t *testing.T) {
+			if _, err := ParseEventID(tt.eventID); !errors.Is(err, tt.want) {
+				t.Fatalf("ParseEventID(%q): err = %v, want %v", tt.eventID, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package entities
+
+import "errors"
+
+var (
+	ErrUnknownTemplate          = errors.New("unknown notification template")
+	ErrInvalidTemplateVariables = errors.New("invalid notification template variables")
+)
+
+// Rendered is a notification template rendered for a recipient
+type Rendered struct {
+	Type      string
+	Priority  string // default priority of the template
+	Locale    string
+	Title     string
+	Message   string
+	HTML      string                 // email body; empty when the template has none
+	Variables map[string]interface{} // validated variables, with times as RFC 3339 strings
+}
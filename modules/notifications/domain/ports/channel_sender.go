@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// ChannelSender delivers messages over one channel. Errors wrapping
+// entities.ErrUndeliverable are not retried.
+type ChannelSender interface {
+	Channel() string
+	Send(ctx context.Context, message *entities.Message) error
+}
@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// DeliveryRepository defines delivery record persistence operations
+type DeliveryRepository interface {
+	// CreateBatch stores the deliveries of a notification
+	CreateBatch(ctx context.Context, deliveries []*entities.Delivery) error
+
+	// ListDue retrieves up to limit pending deliveries due at now, oldest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*entities.Delivery, error)
+
+	// ListDigestDue retrieves up to limit batched deliveries whose digest is
+	// due at now, ordered by user
+	ListDigestDue(ctx context.Context, now time.Time, limit int) ([]*entities.Delivery, error)
+
+	// ListByNotification retrieves the deliveries of a notification
+	ListByNotification(ctx context.Context, notificationID string) ([]*entities.Delivery, error)
+
+	// ClaimForDigest marks the given batched deliveries as sent in the
+	// digest digestID and returns how many it claimed. Deliveries no longer
+	// pending, e.g. claimed by another run, are left alone.
+	ClaimForDigest(ctx context.Context, ids []string, digestID string, at time.Time) (int64, error)
+
+	// Update updates a delivery
+	Update(ctx context.Context, delivery *entities.Delivery) error
+}
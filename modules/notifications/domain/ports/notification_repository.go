@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+	"time"
+
 	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
 )
 
@@ -10,8 +12,14 @@ type NotificationRepository interface {
 	Create(ctx context.Context, notification *entities.Notification) error
 	GetByID(ctx context.Context, id string) (*entities.Notification, error)
 	ListByUser(ctx context.Context, userID string, limit, offset int) ([]*entities.Notification, int, error)
+	// ListAfter retrieves up to limit notifications of a user that come
+	// after the given stream position, oldest first
+	ListAfter(ctx context.Context, userID string, after entities.StreamPosition, limit int) ([]*entities.Notification, error)
 	MarkAsRead(ctx context.Context, id string) error
 	MarkAllAsRead(ctx context.Context, userID string) error
 	Delete(ctx context.Context, id string) error
 	GetUnreadCount(ctx context.Context, userID string) (int, error)
+	// ArchiveExpired archives the notifications that expired at now and
+	// returns the users they belonged to
+	ArchiveExpired(ctx context.Context, now time.Time) ([]string, error)
 }
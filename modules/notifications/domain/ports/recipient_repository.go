@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// RecipientRepository looks up how to reach users
+type RecipientRepository interface {
+	GetRecipient(ctx context.Context, userID string) (*entities.Recipient, error)
+	// ListAudience retrieves the active users with one of the roles and in
+	// one of the departments; an empty list does not filter
+	ListAudience(ctx context.Context, roles, departments []string) ([]string, error)
+}
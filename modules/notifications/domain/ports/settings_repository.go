@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// SettingsRepository defines notification settings persistence operations
+type SettingsRepository interface {
+	// Get retrieves the settings of a user; users who never changed them
+	// get empty settings
+	Get(ctx context.Context, userID string) (*entities.Settings, error)
+
+	// Save stores the settings of a user, replacing their preferences
+	Save(ctx context.Context, settings *entities.Settings) error
+}
@@ -0,0 +1,12 @@
+package ports
+
+import "github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+
+// TemplateRenderer renders notification templates in the language of the
+// recipient
+type TemplateRenderer interface {
+	// Render validates the variables against the schema of the template
+	// and renders it. Errors wrap entities.ErrUnknownTemplate or
+	// entities.ErrInvalidTemplateVariables.
+	Render(key string, recipient *entities.Recipient, variables map[string]interface{}) (*entities.Rendered, error)
+}
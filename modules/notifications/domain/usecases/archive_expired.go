@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// ArchiveExpiredUseCase archives notifications past their expiry
+type ArchiveExpiredUseCase struct {
+	notificationRepo ports.NotificationRepository
+}
+
+// NewArchiveExpiredUseCase creates a new ArchiveExpiredUseCase
+func NewArchiveExpiredUseCase(notificationRepo ports.NotificationRepository) *ArchiveExpiredUseCase {
+	return &ArchiveExpiredUseCase{notificationRepo: notificationRepo}
+}
+
+// Execute archives the expired notifications and returns how many users
+// they belonged to
+func (uc *ArchiveExpiredUseCase) Execute(ctx context.Context) (int, error) {
+	userIDs, err := uc.notificationRepo.ArchiveExpired(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	return len(userIDs), nil
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// BroadcastInput represents a notification to send to every active user
+// with one of the roles and in one of the departments
+type BroadcastInput struct {
+	Roles        []string
+	Departments  []string
+	Notification DispatchInput // UserID is ignored
+}
+
+// BroadcastOutput represents the outcome of a broadcast
+type BroadcastOutput struct {
+	Recipients int
+	Failed     int
+}
+
+// BroadcastNotificationUseCase sends a notification to an audience
+type BroadcastNotificationUseCase struct {
+	recipientRepo ports.RecipientRepository
+	dispatchUC    *DispatchNotificationUseCase
+}
+
+// NewBroadcastNotificationUseCase creates a new BroadcastNotificationUseCase
+func NewBroadcastNotificationUseCase(recipientRepo ports.RecipientRepository, dispatchUC *DispatchNotificationUseCase) *BroadcastNotificationUseCase {
+	return &BroadcastNotificationUseCase{
+		recipientRepo: recipientRepo,
+		dispatchUC:    dispatchUC,
+	}
+}
+
+// Execute dispatches the notification to each member of the audience, in
+// their own language and according to their own preferences. A failure for
+// one member is logged and counted; the others still get the notification.
+func (uc *BroadcastNotificationUseCase) Execute(ctx context.Context, input BroadcastInput) (*BroadcastOutput, error) {
+	if len(input.Roles) == 0 && len(input.Departments) == 0 {
+		return nil, entities.ErrEmptyAudience
+	}
+	if err := input.Notification.Validate(time.Now()); err != nil {
+		return nil, err
+	}
+
+	userIDs, err := uc.recipientRepo.ListAudience(ctx, input.Roles, input.Departments)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &BroadcastOutput{}
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		dispatch := input.Notification
+		dispatch.UserID = userID
+		if _, err := uc.dispatchUC.Execute(ctx, dispatch); err != nil {
+			// Template variables are the same for everyone, so a rejected
+			// variable set fails the first member already
+			if output.Recipients == 0 && output.Failed == 0 && isInputError(err) {
+				return nil, err
+			}
+			logging.FromContext(ctx).Warn("failed to broadcast notification", "target_user_id", userID, "error", err)
+			output.Failed++
+			continue
+		}
+		output.Recipients++
+	}
+
+	return output, nil
+}
@@ -0,0 +1,229 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// keyNamespace derives notification IDs from dispatch keys
+var keyNamespace = uuid.MustParse("6f1c7d9e-3b2a-4c58-9e0d-5a7b8c2f4e61")
+
+// DispatchNotificationUseCase stores a notification and schedules its
+// delivery over the channels the recipient enabled for its type
+type DispatchNotificationUseCase struct {
+	notificationRepo ports.NotificationRepository
+	settingsRepo     ports.SettingsRepository
+	recipientRepo    ports.RecipientRepository
+	deliveryRepo     ports.DeliveryRepository
+	renderer         ports.TemplateRenderer
+	channels         []string
+	defaultChannels  []string
+	dailyDigestAt    string
+	defaultTTL       time.Duration
+}
+
+// NewDispatchNotificationUseCase creates a new DispatchNotificationUseCase.
+// channels lists the configured channels besides in-app; daily digests are
+// sent at dailyDigestAt (HH:MM) in the recipient's timezone. Notifications
+// without an expiry expire after defaultTTL; zero keeps them forever.
+func NewDispatchNotificationUseCase(
+	notificationRepo ports.NotificationRepository,
+	settingsRepo ports.SettingsRepository,
+	recipientRepo ports.RecipientRepository,
+	deliveryRepo ports.DeliveryRepository,
+	renderer ports.TemplateRenderer,
+	channels []string,
+	defaultChannels []string,
+	dailyDigestAt string,
+	defaultTTL time.Duration,
+) *DispatchNotificationUseCase {
+	return &DispatchNotificationUseCase{
+		notificationRepo: notificationRepo,
+		settingsRepo:     settingsRepo,
+		recipientRepo:    recipientRepo,
+		deliveryRepo:     deliveryRepo,
+		renderer:         renderer,
+		channels:         channels,
+		defaultChannels:  defaultChannels,
+		dailyDigestAt:    dailyDigestAt,
+		defaultTTL:       defaultTTL,
+	}
+}
+
+// DispatchInput represents a notification to send. With a Template, the
+// title, message and type come from the template rendered with Variables;
+// otherwise Type, Title, Message and Data are used as given. Priority
+// defaults to the template's priority, or medium. A Key makes dispatching
+// idempotent: the same key is only dispatched once to each user.
+type DispatchInput struct {
+	UserID    string
+	Key       string
+	Template  string
+	Variables map[string]interface{}
+	Priority  string
+	Type      string
+	Title     string
+	Message   string
+	Data      json.RawMessage
+	ExpiresAt *time.Time
+}
+
+// Validate checks the parts of the input that do not depend on the
+// recipient
+func (input DispatchInput) Validate(now time.Time) error {
+	if input.Priority != "" && !entities.IsValidPriority(input.Priority) {
+		return entities.ErrInvalidPriority
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return entities.ErrInvalidExpiry
+	}
+	if input.Template != "" {
+		return nil
+	}
+	if !entities.IsValidType(input.Type) {
+		return entities.ErrInvalidType
+	}
+	return entities.ValidateData(input.Data)
+}
+
+// Execute dispatches a notification. It is always stored; when the user
+// disabled in-app delivery for its type it is stored as read, so it shows
+// in the history but not as new. Other channels are sent by the delivery
+// job, after the recipient's quiet hours. For users with hourly or daily
+// digests, low and medium priority notifications are stored as read and
+// every delivery waits for the next digest. With a Key, a notification
+// already dispatched under it is returned as is.
+func (uc *DispatchNotificationUseCase) Execute(ctx context.Context, input DispatchInput) (*entities.Notification, error) {
+	if err := input.Validate(time.Now()); err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	if input.Key != "" {
+		id = uuid.NewSHA1(keyNamespace, []byte(input.Key+"/"+input.UserID)).String()
+		existing, err := uc.notificationRepo.GetByID(ctx, id)
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, entities.ErrNotificationNotFound) {
+			return nil, err
+		}
+	}
+
+	settings, err := uc.settingsRepo.Get(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := uc.recipientRepo.GetRecipient(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	notification := &entities.Notification{
+		ID:        id,
+		UserID:    input.UserID,
+		Type:      input.Type,
+		Priority:  entities.PriorityMedium,
+		Title:     input.Title,
+		Message:   input.Message,
+		Data:      input.Data,
+		ExpiresAt: input.ExpiresAt,
+		CreatedAt: now,
+	}
+	if notification.ExpiresAt == nil && uc.defaultTTL > 0 {
+		expiresAt := now.Add(uc.defaultTTL)
+		notification.ExpiresAt = &expiresAt
+	}
+
+	// Templated notifications are stored in the recipient's language; the
+	// variables are kept so other channels can render them again at send
+	// time
+	if input.Template != "" {
+		rendered, err := uc.renderer.Render(input.Template, recipient, input.Variables)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(rendered.Variables)
+		if err != nil {
+			return nil, err
+		}
+		notification.Type = rendered.Type
+		notification.Priority = rendered.Priority
+		notification.Template = input.Template
+		notification.Locale = rendered.Locale
+		notification.Title = rendered.Title
+		notification.Message = rendered.Message
+		notification.Data = data
+	}
+
+	if input.Priority != "" {
+		notification.Priority = input.Priority
+	}
+	batched := settings.Batches(notification.Priority)
+	dueAt := settings.QuietUntil(now, recipient.Location)
+	if batched {
+		dueAt = settings.NextDigestAt(now, recipient.Location, uc.dailyDigestAt)
+	}
+
+	inApp := entities.NewDelivery(notification, entities.ChannelInApp, now)
+	switch {
+	case !settings.Enabled(notification.Type, entities.ChannelInApp, uc.defaultChannels):
+		notification.Read = true
+		notification.ReadAt = &now
+		inApp.MarkSkipped("disabled by preference")
+	case batched:
+		notification.Read = true
+		notification.ReadAt = &now
+		inApp.Batch(dueAt)
+	default:
+		inApp.MarkSent(now)
+	}
+
+	if err := uc.notificationRepo.Create(ctx, notification); err != nil {
+		return nil, err
+	}
+	if inApp.Status == entities.DeliverySent {
+		metrics.NotificationsDelivered.WithLabelValues(inApp.Channel).Inc()
+	}
+
+	deliveries := []*entities.Delivery{inApp}
+	for _, channel := range uc.channels {
+		delivery := entities.NewDelivery(notification, channel, dueAt)
+		switch {
+		case !settings.Enabled(notification.Type, channel, uc.defaultChannels):
+			delivery.MarkSkipped("disabled by preference")
+		case channel == entities.ChannelEmail && recipient.Email == "":
+			delivery.MarkSkipped("no email address")
+		case channel == entities.ChannelWebhook && recipient.WebhookURL == "":
+			delivery.MarkSkipped("no webhook URL")
+		case batched:
+			delivery.Batch(dueAt)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := uc.deliveryRepo.CreateBatch(ctx, deliveries); err != nil {
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+// isInputError reports whether err rejects the dispatch input itself rather
+// than a failure to deliver it
+func isInputError(err error) bool {
+	return errors.Is(err, entities.ErrInvalidPriority) ||
+		errors.Is(err, entities.ErrInvalidType) ||
+		errors.Is(err, entities.ErrInvalidData) ||
+		errors.Is(err, entities.ErrInvalidExpiry) ||
+		errors.Is(err, entities.ErrUnknownTemplate) ||
+		errors.Is(err, entities.ErrInvalidTemplateVariables)
+}
@@ -0,0 +1,201 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// audienceRepo returns a fixed audience; recipients in missing cannot be
+// looked up
+type audienceRepo struct {
+	fakeRecipientRepo
+	audience []string
+	missing  map[string]bool
+}
+
+func (r audienceRepo) GetRecipient(ctx context.Context, userID string) (*entities.Recipient, error) {
+	if r.missing[userID] {
+		return nil, entities.ErrRecipientNotFound
+	}
+	return r.fakeRecipientRepo.GetRecipient(ctx, userID)
+}
+
+func (r audienceRepo) ListAudience(ctx context.Context, roles, departments []string) ([]string, error) {
+	return r.audience, nil
+}
+
+// rejectingRenderer rejects every set of template variables
+type rejectingRenderer struct{}
+
+func (rejectingRenderer) Render(key string, recipient *entities.Recipient, variables map[string]interface{}) (*entities.Rendered, error) {
+	return nil, entities.ErrInvalidTemplateVariables
+}
+
+func newDispatch(notifications *fakeNotificationRepo, recipients audienceRepo, renderer ports.TemplateRenderer, defaultTTL time.Duration) *DispatchNotificationUseCase {
+	return NewDispatchNotificationUseCase(notifications, fakeSettingsRepo{}, recipients, newFakeDeliveryRepo(), renderer,
+		nil, []string{entities.ChannelInApp}, "09:00", defaultTTL)
+}
+
+func TestDispatchInputValidate(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		input DispatchInput
+		want  error
+	}{
+		{"free-form", DispatchInput{Type: entities.TypeSystem, Title: "Maintenance"}, nil},
+		{"with data and expiry", DispatchInput{Type: entities.TypeCRM, Data: json.RawMessage(`{"customer_id":"c-1"}`), ExpiresAt: &future}, nil},
+		{"template without a type", DispatchInput{Template: "payroll.generated"}, nil},
+		{"unknown type", DispatchInput{Type: "marketing"}, entities.ErrInvalidType},
+		{"no type", DispatchInput{Title: "Maintenance"}, entities.ErrInvalidType},
+		{"data that is not an object", DispatchInput{Type: entities.TypeSystem, Data: json.RawMessage(`[1]`)}, entities.ErrInvalidData},
+		{"unknown priority", DispatchInput{Type: entities.TypeSystem, Priority: "critical"}, entities.ErrInvalidPriority},
+		{"expired", DispatchInput{Type: entities.TypeSystem, ExpiresAt: &past}, entities.ErrInvalidExpiry},
+		{"expired template", DispatchInput{Template: "payroll.generated", ExpiresAt: &now}, entities.ErrInvalidExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.input.Validate(now); !errors.Is(err, tt.want) {
+				t.Fatalf("Validate: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatchNotificationExpiryAndKey(t *testing.T) {
+	expiresAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name       string
+		defaultTTL time.Duration
+		expiresAt  *time.Time
+		wantExpiry time.Duration // from now; zero for no expiry
+	}{
+		{"kept forever", 0, nil, 0},
+		{"default expiry", 24 * time.Hour, nil, 24 * time.Hour},
+		{"explicit expiry", 24 * time.Hour, &expiresAt, 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifications := newFakeNotificationRepo()
+			uc := newDispatch(notifications, audienceRepo{}, fakeRenderer{}, tt.defaultTTL)
+
+			notification, err := uc.Execute(context.Background(), DispatchInput{
+				UserID: "alice", Type: entities.TypeSystem, Title: "Maintenance", ExpiresAt: tt.expiresAt,
+			})
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+			if tt.wantExpiry == 0 {
+				if notification.ExpiresAt != nil {
+					t.Fatalf("expires at %v, want never", notification.ExpiresAt)
+				}
+				return
+			}
+			if notification.ExpiresAt == nil {
+				t.Fatal("notification never expires")
+			}
+			if d := time.Until(*notification.ExpiresAt) - tt.wantExpiry; d > time.Second || d < -time.Second {
+				t.Fatalf("expires at %v, want in %v", notification.ExpiresAt, tt.wantExpiry)
+			}
+		})
+	}
+
+	// A key dispatches once per user
+	notifications := newFakeNotificationRepo()
+	uc := newDispatch(notifications, audienceRepo{}, fakeRenderer{}, 0)
+	input := DispatchInput{UserID: "alice", Key: "payroll-2025-01", Template: "payroll.generated"}
+	first, err := uc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	again, err := uc.Execute(context.Background(), input)
+	if err != nil || again.ID != first.ID {
+		t.Fatalf("repeated key dispatched %v (err %v), want %s", again, err, first.ID)
+	}
+	input.UserID = "bob"
+	if other, _ := uc.Execute(context.Background(), input); other.ID == first.ID || len(notifications.notifications) != 2 {
+		t.Fatalf("key of another user reused notification %s", other.ID)
+	}
+}
+
+func TestBroadcastNotification(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          BroadcastInput
+		recipients     audienceRepo
+		rejectTemplate bool
+		want           error
+		wantOutput     BroadcastOutput
+	}{
+		{
+			name:       "everyone",
+			input:      BroadcastInput{Roles: []string{"employee"}, Notification: DispatchInput{Type: entities.TypeSystem, Title: "Maintenance"}},
+			recipients: audienceRepo{audience: []string{"alice", "bob"}},
+			wantOutput: BroadcastOutput{Recipients: 2},
+		},
+		{
+			name:       "member who cannot be reached",
+			input:      BroadcastInput{Departments: []string{"sales"}, Notification: DispatchInput{Type: entities.TypeSystem, Title: "Maintenance"}},
+			recipients: audienceRepo{audience: []string{"alice", "ghost", "bob"}, missing: map[string]bool{"ghost": true}},
+			wantOutput: BroadcastOutput{Recipients: 2, Failed: 1},
+		},
+		{
+			name:       "no audience",
+			input:      BroadcastInput{Notification: DispatchInput{Type: entities.TypeSystem, Title: "Maintenance"}},
+			recipients: audienceRepo{audience: []string{"alice"}},
+			want:       entities.ErrEmptyAudience,
+		},
+		{
+			name:       "invalid notification",
+			input:      BroadcastInput{Roles: []string{"employee"}, Notification: DispatchInput{Type: "marketing"}},
+			recipients: audienceRepo{audience: []string{"alice"}},
+			want:       entities.ErrInvalidType,
+		},
+		{
+			name:           "rejected template variables",
+			input:          BroadcastInput{Roles: []string{"employee"}, Notification: DispatchInput{Template: "payroll.generated"}},
+			recipients:     audienceRepo{audience: []string{"alice", "bob"}},
+			rejectTemplate: true,
+			want:           entities.ErrInvalidTemplateVariables,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifications := newFakeNotificationRepo()
+			var renderer ports.TemplateRenderer = fakeRenderer{}
+			if tt.rejectTemplate {
+				renderer = rejectingRenderer{}
+			}
+			uc := NewBroadcastNotificationUseCase(tt.recipients, newDispatch(notifications, tt.recipients, renderer, 0))
+
+			output, err := uc.Execute(context.Background(), tt.input)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute: err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				if len(notifications.notifications) != 0 {
+					t.Fatal("rejected broadcast stored notifications")
+				}
+				return
+			}
+			if *output != tt.wantOutput {
+				t.Fatalf("output = %+v, want %+v", *output, tt.wantOutput)
+			}
+			if len(notifications.notifications) != tt.wantOutput.Recipients {
+				t.Fatalf("stored %d notifications, want %d", len(notifications.notifications), tt.wantOutput.Recipients)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// fakeNotificationRepo keeps notifications in memory. Methods the tests do
+// not need panic through the embedded nil interface.
+type fakeNotificationRepo struct {
+	ports.NotificationRepository
+	notifications map[string]*entities.Notification
+}
+
+func newFakeNotificationRepo() *fakeNotificationRepo {
+	return &fakeNotificationRepo{notifications: make(map[string]*entities.Notification)}
+}
+
+func (r *fakeNotificationRepo) Create(ctx context.Context, notification *entities.Notification) error {
+	copied := *notification
+	r.notifications[notification.ID] = &copied
+	return nil
+}
+
+func (r *fakeNotificationRepo) GetByID(ctx context.Context, id string) (*entities.Notification, error) {
+	notification, ok := r.notifications[id]
+	if !ok {
+		return nil, entities.ErrNotificationNotFound
+	}
+	copied := *notification
+	return &copied, nil
+}
+
+func (r *fakeNotificationRepo) snapshot() func() {
+	saved := make(map[string]*entities.Notification, len(r.notifications))
+	for id, notification := range r.notifications {
+		saved[id] = notification
+	}
+	return func() { r.notifications = saved }
+}
+
+// fakeDeliveryRepo keeps deliveries in memory
+type fakeDeliveryRepo struct {
+	deliveries map[string]*entities.Delivery
+	// beforeClaim runs at the start of ClaimForDigest, e.g. to simulate a
+	// concurrent run claiming deliveries first
+	beforeClaim func()
+}
+
+func newFakeDeliveryRepo() *fakeDeliveryRepo {
+	return &fakeDeliveryRepo{deliveries: make(map[string]*entities.Delivery)}
+}
+
+func (r *fakeDeliveryRepo) CreateBatch(ctx context.Context, deliveries []*entities.Delivery) error {
+	for _, delivery := range deliveries {
+		copied := *delivery
+		r.deliveries[delivery.ID] = &copied
+	}
+	return nil
+}
+
+func (r *fakeDeliveryRepo) ListDue(ctx context.Context, now time.Time, limit int) ([]*entities.Delivery, error) {
+	return r.filter(func(d *entities.Delivery) bool {
+		return d.Status == entities.DeliveryPending && !d.Batched && !d.NextAttemptAt.After(now)
+	}, limit), nil
+}
+
+func (r *fakeDeliveryRepo) ListDigestDue(ctx context.Context, now time.Time, limit int) ([]*entities.Delivery, error) {
+	return r.filter(func(d *entities.Delivery) bool {
+		return d.Status == entities.DeliveryPending && d.Batched && !d.NextAttemptAt.After(now)
+	}, limit), nil
+}
+
+func (r *fakeDeliveryRepo) ListByNotification(ctx context.Context, notificationID string) ([]*entities.Delivery, error) {
+	return r.filter(func(d *entities.Delivery) bool {
+		return d.NotificationID == notificationID
+	}, 0), nil
+}
+
+func (r *fakeDeliveryRepo) ClaimForDigest(ctx context.Context, ids []string, digestID string, at time.Time) (int64, error) {
+	if r.beforeClaim != nil {
+		r.beforeClaim()
+	}
+	var claimed int64
+	for _, id := range ids {
+		delivery, ok := r.deliveries[id]
+		if !ok || delivery.Status != entities.DeliveryPending || !delivery.Batched {
+			continue
+		}
+		updated := *delivery
+		updated.MarkSent(at)
+		updated.DigestID = &digestID
+		r.deliveries[id] = &updated
+		claimed++
+	}
+	return claimed, nil
+}
+
+func (r *fakeDeliveryRepo) Update(ctx context.Context, delivery *entities.Delivery) error {
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+func (r *fakeDeliveryRepo) filter(match func(d *entities.Delivery) bool, limit int) []*entities.Delivery {
+	deliveries := make([]*entities.Delivery, 0)
+	for _, delivery := range r.deliveries {
+		if match(delivery) {
+			copied := *delivery
+			deliveries = append(deliveries, &copied)
+		}
+	}
+	// Ordered by user and creation time, like the repository
+	sort.Slice(deliveries, func(i, j int) bool {
+		if deliveries[i].UserID != deliveries[j].UserID {
+			return deliveries[i].UserID < deliveries[j].UserID
+		}
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries
+}
+
+func (r *fakeDeliveryRepo) snapshot() func() {
+	saved := make(map[string]*entities.Delivery, len(r.deliveries))
+	for id, delivery := range r.deliveries {
+		saved[id] = delivery
+	}
+	return func() { r.deliveries = saved }
+}
+
+// fakeTransactor rolls the repositories back when fn fails
+type fakeTransactor struct {
+	snapshots []func() func()
+	calls     int
+}
+
+func (t *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.calls++
+	restores := make([]func(), len(t.snapshots))
+	for i, snapshot := range t.snapshots {
+		restores[i] = snapshot()
+	}
+	if err := fn(ctx); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
+
+// fakeSettingsRepo returns empty settings, like the repository does for
+// users who never changed theirs
+type fakeSettingsRepo struct{}
+
+func (fakeSettingsRepo) Get(ctx context.Context, userID string) (*entities.Settings, error) {
+	return &entities.Settings{UserID: userID}, nil
+}
+
+func (fakeSettingsRepo) Save(ctx context.Context, settings *entities.Settings) error {
+	return nil
+}
+
+// fakeRecipientRepo returns a recipient for every user
+type fakeRecipientRepo struct{}
+
+func (fakeRecipientRepo) GetRecipient(ctx context.Context, userID string) (*entities.Recipient, error) {
+	return &entities.Recipient{UserID: userID, Location: time.UTC, Locale: "en"}, nil
+}
+
+func (fakeRecipientRepo) ListAudience(ctx context.Context, roles, departments []string) ([]string, error) {
+	return nil, nil
+}
+
+// fakeRenderer renders every template as its key and keeps the variables
+type fakeRenderer struct{}
+
+func (fakeRenderer) Render(key string, recipient *entities.Recipient, variables map[string]interface{}) (*entities.Rendered, error) {
+	return &entities.Rendered{
+		Type:      entities.TypeSystem,
+		Priority:  entities.PriorityLow,
+		Locale:    "en",
+		Title:     key,
+		Message:   key,
+		Variables: variables,
+	}, nil
+}
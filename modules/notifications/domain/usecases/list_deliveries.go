@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// ListDeliveriesUseCase retrieves the delivery status of a notification
+type ListDeliveriesUseCase struct {
+	notificationRepo ports.NotificationRepository
+	deliveryRepo     ports.DeliveryRepository
+}
+
+// NewListDeliveriesUseCase creates a new ListDeliveriesUseCase
+func NewListDeliveriesUseCase(notificationRepo ports.NotificationRepository, deliveryRepo ports.DeliveryRepository) *ListDeliveriesUseCase {
+	return &ListDeliveriesUseCase{
+		notificationRepo: notificationRepo,
+		deliveryRepo:     deliveryRepo,
+	}
+}
+
+// Execute lists the deliveries of one of the user's notifications
+func (uc *ListDeliveriesUseCase) Execute(ctx context.Context, notificationID, userID string) ([]*entities.Delivery, error) {
+	notification, err := uc.notificationRepo.GetByID(ctx, notificationID)
+	if err != nil {
+		return nil, err
+	}
+	if notification.UserID != userID {
+		return nil, entities.ErrNotificationNotFound
+	}
+
+	return uc.deliveryRepo.ListByNotification(ctx, notificationID)
+}
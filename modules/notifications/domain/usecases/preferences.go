@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// GetPreferencesUseCase retrieves a user's notification settings
+type GetPreferencesUseCase struct {
+	settingsRepo ports.SettingsRepository
+}
+
+// NewGetPreferencesUseCase creates a new GetPreferencesUseCase
+func NewGetPreferencesUseCase(settingsRepo ports.SettingsRepository) *GetPreferencesUseCase {
+	return &GetPreferencesUseCase{settingsRepo: settingsRepo}
+}
+
+// Execute retrieves the settings of a user
+func (uc *GetPreferencesUseCase) Execute(ctx context.Context, userID string) (*entities.Settings, error) {
+	return uc.settingsRepo.Get(ctx, userID)
+}
+
+// UpdatePreferencesInput represents changes to notification settings.
+// Preferences are merged into the existing ones; nil fields are kept.
+type UpdatePreferencesInput struct {
+	Preferences     []entities.Preference
+	QuietHours      *entities.QuietHours
+	ClearQuietHours bool
+	WebhookURL      *string
+	Digest          *string
+}
+
+// UpdatePreferencesUseCase changes a user's notification settings
+type UpdatePreferencesUseCase struct {
+	settingsRepo ports.SettingsRepository
+}
+
+// NewUpdatePreferencesUseCase creates a new UpdatePreferencesUseCase
+func NewUpdatePreferencesUseCase(settingsRepo ports.SettingsRepository) *UpdatePreferencesUseCase {
+	return &UpdatePreferencesUseCase{settingsRepo: settingsRepo}
+}
+
+// Execute applies the changes and stores the settings
+func (uc *UpdatePreferencesUseCase) Execute(ctx context.Context, userID string, input UpdatePreferencesInput) (*entities.Settings, error) {
+	settings, err := uc.settingsRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, preference := range input.Preferences {
+		if err := settings.SetPreference(preference); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.ClearQuietHours {
+		if err := settings.SetQuietHours(nil); err != nil {
+			return nil, err
+		}
+	} else if input.QuietHours != nil {
+		if err := settings.SetQuietHours(input.QuietHours); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.WebhookURL != nil {
+		if err := settings.SetWebhookURL(*input.WebhookURL); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Digest != nil {
+		if err := settings.SetDigest(*input.Digest); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.settingsRepo.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// ProcessDeliveriesUseCase sends the pending deliveries that are due
+type ProcessDeliveriesUseCase struct {
+	deliveryRepo     ports.DeliveryRepository
+	notificationRepo ports.NotificationRepository
+	settingsRepo     ports.SettingsRepository
+	recipientRepo    ports.RecipientRepository
+	renderer         ports.TemplateRenderer
+	senders          map[string]ports.ChannelSender
+	policy           entities.RetryPolicy
+	batchSize        int
+}
+
+// NewProcessDeliveriesUseCase creates a new ProcessDeliveriesUseCase
+func NewProcessDeliveriesUseCase(
+	deliveryRepo ports.DeliveryRepository,
+	notificationRepo ports.NotificationRepository,
+	settingsRepo ports.SettingsRepository,
+	recipientRepo ports.RecipientRepository,
+	renderer ports.TemplateRenderer,
+	senders []ports.ChannelSender,
+	policy entities.RetryPolicy,
+	batchSize int,
+) *ProcessDeliveriesUseCase {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 5
+	}
+	if policy.Backoff <= 0 {
+		policy.Backoff = 30 * time.Second
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	sendersByChannel := make(map[string]ports.ChannelSender, len(senders))
+	for _, sender := range senders {
+		sendersByChannel[sender.Channel()] = sender
+	}
+
+	return &ProcessDeliveriesUseCase{
+		deliveryRepo:     deliveryRepo,
+		notificationRepo: notificationRepo,
+		settingsRepo:     settingsRepo,
+		recipientRepo:    recipientRepo,
+		renderer:         renderer,
+		senders:          sendersByChannel,
+		policy:           policy,
+		batchSize:        batchSize,
+	}
+}
+
+// errExpired skips deliveries of notifications that expired before they
+// were sent
+var errExpired = errors.New("notification expired")
+
+// ProcessOutput represents the outcome of a delivery run
+type ProcessOutput struct {
+	Sent     int
+	Retrying int
+	Failed   int
+	Deferred int
+	Expired  int
+}
+
+// Execute sends one batch of due deliveries. Deliveries that come due
+// during the recipient's quiet hours are deferred until they end.
+func (uc *ProcessDeliveriesUseCase) Execute(ctx context.Context) (*ProcessOutput, error) {
+	now := time.Now()
+	deliveries, err := uc.deliveryRepo.ListDue(ctx, now, uc.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ProcessOutput{}
+	settingsByUser := make(map[string]*entities.Settings)
+	recipients := make(map[string]*entities.Recipient)
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		settings, ok := settingsByUser[delivery.UserID]
+		if !ok {
+			if settings, err = uc.settingsRepo.Get(ctx, delivery.UserID); err != nil {
+				logging.FromContext(ctx).Warn("failed to load notification settings", "user_id", delivery.UserID, "error", err)
+				continue
+			}
+			settingsByUser[delivery.UserID] = settings
+		}
+		recipient, ok := recipients[delivery.UserID]
+		if !ok {
+			if recipient, err = uc.recipientRepo.GetRecipient(ctx, delivery.UserID); err != nil {
+				logging.FromContext(ctx).Warn("failed to load recipient", "user_id", delivery.UserID, "error", err)
+				continue
+			}
+			recipients[delivery.UserID] = recipient
+		}
+
+		if until := settings.QuietUntil(now, recipient.Location); until.After(now) {
+			delivery.Defer(until)
+			output.Deferred++
+		} else if err := uc.send(ctx, delivery, recipient); errors.Is(err, errExpired) {
+			delivery.MarkSkipped("expired")
+			output.Expired++
+		} else if err != nil {
+			delivery.RecordFailure(err, time.Now(), uc.policy)
+			if delivery.Status == entities.DeliveryFailed {
+				output.Failed++
+			} else {
+				output.Retrying++
+			}
+		} else {
+			delivery.MarkSent(time.Now())
+			metrics.NotificationsDelivered.WithLabelValues(delivery.Channel).Inc()
+			output.Sent++
+		}
+
+		if err := uc.deliveryRepo.Update(ctx, delivery); err != nil {
+			logging.FromContext(ctx).Error("failed to update notification delivery", "delivery_id", delivery.ID, "error", err)
+		}
+	}
+
+	return output, nil
+}
+
+func (uc *ProcessDeliveriesUseCase) send(ctx context.Context, delivery *entities.Delivery, recipient *entities.Recipient) error {
+	sender, ok := uc.senders[delivery.Channel]
+	if !ok {
+		return fmt.Errorf("%w: channel %s is not configured", entities.ErrUndeliverable, delivery.Channel)
+	}
+
+	notification, err := uc.notificationRepo.GetByID(ctx, delivery.NotificationID)
+	if err != nil {
+		return err
+	}
+	if notification.Expired(time.Now()) {
+		return errExpired
+	}
+
+	message := &entities.Message{Notification: notification, Recipient: recipient}
+	if notification.Template != "" {
+		uc.render(ctx, message)
+	}
+
+	return sender.Send(ctx, message)
+}
+
+// render renders a templated notification again in the recipient's current
+// language. When that fails the stored text is sent instead.
+func (uc *ProcessDeliveriesUseCase) render(ctx context.Context, message *entities.Message) {
+	notification := message.Notification
+
+	var variables map[string]interface{}
+	if err := json.Unmarshal(notification.Data, &variables); err != nil {
+		logging.FromContext(ctx).Warn("failed to decode notification variables", "notification_id", notification.ID, "error", err)
+		return
+	}
+
+	rendered, err := uc.renderer.Render(notification.Template, message.Recipient, variables)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to render notification", "notification_id", notification.ID, "error", err)
+		return
+	}
+
+	notification.Locale = rendered.Locale
+	notification.Title = rendered.Title
+	notification.Message = rendered.Message
+	message.HTML = rendered.HTML
+}
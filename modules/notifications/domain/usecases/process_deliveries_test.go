@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// fakeSender records the messages it is given and fails with err
+type fakeSender struct {
+	channel string
+	err     error
+	sent    []*entities.Message
+}
+
+func (s *fakeSender) Channel() string {
+	return s.channel
+}
+
+func (s *fakeSender) Send(ctx context.Context, message *entities.Message) error {
+	s.sent = append(s.sent, message)
+	return s.err
+}
+
+// quietSettingsRepo returns the same settings for every user
+type quietSettingsRepo struct {
+	fakeSettingsRepo
+	quietHours *entities.QuietHours
+}
+
+func (r quietSettingsRepo) Get(ctx context.Context, userID string) (*entities.Settings, error) {
+	return &entities.Settings{UserID: userID, QuietHours: r.quietHours}, nil
+}
+
+func TestProcessDeliveries(t *testing.T) {
+	now := time.Now().UTC()
+	past := now.Add(-time.Minute)
+	// Quiet hours around now, in UTC like the fake recipients
+	aroundNow := &entities.QuietHours{Start: now.Add(-time.Hour).Format("15:04"), End: now.Add(time.Hour).Format("15:04")}
+	policy := entities.RetryPolicy{MaxAttempts: 3, Backoff: time.Minute}
+
+	tests := []struct {
+		name         string
+		channel      string
+		sendErr      error
+		attempts     int
+		template     string
+		expiresAt    *time.Time
+		quietHours   *entities.QuietHours
+		wantOutput   ProcessOutput
+		wantStatus   string
+		wantAttempts int
+		wantTitle    string // of the sent message
+	}{
+		{name: "sent", channel: entities.ChannelEmail, wantOutput: ProcessOutput{Sent: 1}, wantStatus: entities.DeliverySent, wantAttempts: 1, wantTitle: "Payroll ready"},
+		{name: "template rendered again", channel: entities.ChannelEmail, template: "payroll.generated", wantOutput: ProcessOutput{Sent: 1}, wantStatus: entities.DeliverySent, wantAttempts: 1, wantTitle: "payroll.generated"},
+		{name: "retrying", channel: entities.ChannelEmail, sendErr: errors.New("smtp: timeout"), wantOutput: ProcessOutput{Retrying: 1}, wantStatus: entities.DeliveryPending, wantAttempts: 1},
+		{name: "out of attempts", channel: entities.ChannelEmail, sendErr: errors.New("smtp: timeout"), attempts: 2, wantOutput: ProcessOutput{Failed: 1}, wantStatus: entities.DeliveryFailed, wantAttempts: 3},
+		{name: "undeliverable", channel: entities.ChannelEmail, sendErr: fmt.Errorf("no address: %w", entities.ErrUndeliverable), wantOutput: ProcessOutput{Failed: 1}, wantStatus: entities.DeliveryFailed, wantAttempts: 1},
+		{name: "channel not configured", channel: entities.ChannelWebhook, wantOutput: ProcessOutput{Failed: 1}, wantStatus: entities.DeliveryFailed, wantAttempts: 1},
+		{name: "expired", channel: entities.ChannelEmail, expiresAt: &past, wantOutput: ProcessOutput{Expired: 1}, wantStatus: entities.DeliverySkipped},
+		{name: "quiet hours", channel: entities.ChannelEmail, quietHours: aroundNow, wantOutput: ProcessOutput{Deferred: 1}, wantStatus: entities.DeliveryPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifications := newFakeNotificationRepo()
+			notification := &entities.Notification{
+				ID: "n-1", UserID: "alice", Type: entities.TypePayroll, Title: "Payroll ready",
+				Template: tt.template, Data: []byte(`{"period":"2025-01"}`), ExpiresAt: tt.expiresAt,
+			}
+			_ = notifications.Create(context.Background(), notification)
+
+			deliveries := newFakeDeliveryRepo()
+			delivery := entities.NewDelivery(notification, tt.channel, now.Add(-time.Second))
+			delivery.Attempts = tt.attempts
+			_ = deliveries.CreateBatch(context.Background(), []*entities.Delivery{delivery})
+
+			sender := &fakeSender{channel: entities.ChannelEmail, err: tt.sendErr}
+			uc := NewProcessDeliveriesUseCase(deliveries, notifications, quietSettingsRepo{quietHours: tt.quietHours},
+				fakeRecipientRepo{}, fakeRenderer{}, []ports.ChannelSender{sender}, policy, 0)
+
+			output, err := uc.Execute(context.Background())
+			if err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+			if *output != tt.wantOutput {
+				t.Fatalf("output = %+v, want %+v", *output, tt.wantOutput)
+			}
+
+			updated := deliveries.deliveries[delivery.ID]
+			if updated.Status != tt.wantStatus || updated.Attempts != tt.wantAttempts {
+				t.Fatalf("delivery %s after %d attempts, want %s after %d", updated.Status, updated.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if tt.wantOutput.Deferred > 0 && !updated.NextAttemptAt.After(now) {
+				t.Fatalf("deferred to %v, want after the quiet hours", updated.NextAttemptAt)
+			}
+			if tt.wantTitle != "" && (len(sender.sent) != 1 || sender.sent[0].Notification.Title != tt.wantTitle) {
+				t.Fatalf("sent %d messages, want one titled %q", len(sender.sent), tt.wantTitle)
+			}
+		})
+	}
+}
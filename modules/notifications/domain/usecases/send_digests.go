@@ -0,0 +1,230 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// digestTemplate renders the summary of batched notifications
+const digestTemplate = "notification.digest"
+
+// maxDigestTitles caps how many notifications a digest lists by title
+const maxDigestTitles = 20
+
+// errDigestClaimed is returned when another run claimed some of the
+// deliveries of a digest first
+var errDigestClaimed = errors.New("notifications already claimed by another digest")
+
+// SendDigestsUseCase groups the batched notifications of each user whose
+// digest is due into a single digest notification
+type SendDigestsUseCase struct {
+	deliveryRepo     ports.DeliveryRepository
+	notificationRepo ports.NotificationRepository
+	recipientRepo    ports.RecipientRepository
+	renderer         ports.TemplateRenderer
+	transactor       datastore.Transactor
+	batchSize        int
+}
+
+// NewSendDigestsUseCase creates a new SendDigestsUseCase
+func NewSendDigestsUseCase(
+	deliveryRepo ports.DeliveryRepository,
+	notificationRepo ports.NotificationRepository,
+	recipientRepo ports.RecipientRepository,
+	renderer ports.TemplateRenderer,
+	transactor datastore.Transactor,
+	batchSize int,
+) *SendDigestsUseCase {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	return &SendDigestsUseCase{
+		deliveryRepo:     deliveryRepo,
+		notificationRepo: notificationRepo,
+		recipientRepo:    recipientRepo,
+		renderer:         renderer,
+		transactor:       transactor,
+		batchSize:        batchSize,
+	}
+}
+
+// SendDigestsOutput represents the outcome of a digest run
+type SendDigestsOutput struct {
+	Digests       int
+	Notifications int
+}
+
+// Execute sends the due digests. A digest goes out over every channel one
+// of its notifications was batched for; its email and webhook deliveries
+// are then sent, and retried, by the delivery job.
+func (uc *SendDigestsUseCase) Execute(ctx context.Context) (*SendDigestsOutput, error) {
+	deliveries, err := uc.deliveryRepo.ListDigestDue(ctx, time.Now(), uc.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var userIDs []string
+	byUser := make(map[string][]*entities.Delivery)
+	for _, delivery := range deliveries {
+		if _, ok := byUser[delivery.UserID]; !ok {
+			userIDs = append(userIDs, delivery.UserID)
+		}
+		byUser[delivery.UserID] = append(byUser[delivery.UserID], delivery)
+	}
+
+	// A full batch may cut off the deliveries of the last user; they get
+	// their whole digest on the next run instead of two halves
+	if len(deliveries) == uc.batchSize && len(userIDs) > 1 {
+		userIDs = userIDs[:len(userIDs)-1]
+	}
+
+	output := &SendDigestsOutput{}
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		count, err := uc.send(ctx, userID, byUser[userID])
+		if err != nil {
+			logging.FromContext(ctx).Warn("failed to send notification digest", "user_id", userID, "error", err)
+			continue
+		}
+		if count == 0 {
+			continue
+		}
+		output.Digests++
+		output.Notifications += count
+	}
+
+	return output, nil
+}
+
+// send sends the digest of one user and returns how many notifications it
+// covers; none when they all expired
+func (uc *SendDigestsUseCase) send(ctx context.Context, userID string, deliveries []*entities.Delivery) (int, error) {
+	recipient, err := uc.recipientRepo.GetRecipient(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Notifications that expired while waiting are left out
+	now := time.Now()
+	var titles []string
+	var included []*entities.Delivery
+	channels := make(map[string]bool)
+	expired := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, delivery := range deliveries {
+		if !seen[delivery.NotificationID] && !expired[delivery.NotificationID] {
+			notification, err := uc.notificationRepo.GetByID(ctx, delivery.NotificationID)
+			if err != nil {
+				return 0, err
+			}
+			if notification.Expired(now) {
+				expired[notification.ID] = true
+			} else {
+				seen[notification.ID] = true
+				titles = append(titles, notification.Title)
+			}
+		}
+
+		if expired[delivery.NotificationID] {
+			delivery.MarkSkipped("expired")
+			if err := uc.deliveryRepo.Update(ctx, delivery); err != nil {
+				logging.FromContext(ctx).Error("failed to update notification delivery", "delivery_id", delivery.ID, "error", err)
+			}
+			continue
+		}
+		channels[delivery.Channel] = true
+		included = append(included, delivery)
+	}
+	if len(included) == 0 {
+		return 0, nil
+	}
+
+	variables := map[string]interface{}{"count": len(seen)}
+	if len(titles) > maxDigestTitles {
+		variables["more"] = len(titles) - maxDigestTitles
+		titles = titles[:maxDigestTitles]
+	}
+	variables["titles"] = titles
+
+	rendered, err := uc.renderer.Render(digestTemplate, recipient, variables)
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(rendered.Variables)
+	if err != nil {
+		return 0, err
+	}
+
+	digest := &entities.Notification{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Type:      rendered.Type,
+		Priority:  rendered.Priority,
+		Template:  digestTemplate,
+		Locale:    rendered.Locale,
+		Title:     rendered.Title,
+		Message:   rendered.Message,
+		Data:      data,
+		CreatedAt: now,
+	}
+	if !channels[entities.ChannelInApp] {
+		digest.Read = true
+		digest.ReadAt = &now
+	}
+
+	digestDeliveries := make([]*entities.Delivery, 0, len(channels))
+	for channel := range channels {
+		delivery := entities.NewDelivery(digest, channel, now)
+		if channel == entities.ChannelInApp {
+			delivery.MarkSent(now)
+		}
+		digestDeliveries = append(digestDeliveries, delivery)
+	}
+
+	ids := make([]string, len(included))
+	for i, delivery := range included {
+		ids[i] = delivery.ID
+	}
+
+	// The notifications are claimed for the digest in the same transaction
+	// that stores it, so they are either sent exactly once or stay pending
+	// for the next run
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.notificationRepo.Create(ctx, digest); err != nil {
+			return err
+		}
+		claimed, err := uc.deliveryRepo.ClaimForDigest(ctx, ids, digest.ID, now)
+		if err != nil {
+			return err
+		}
+		if claimed != int64(len(ids)) {
+			return errDigestClaimed
+		}
+		return uc.deliveryRepo.CreateBatch(ctx, digestDeliveries)
+	})
+	if errors.Is(err, errDigestClaimed) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if channels[entities.ChannelInApp] {
+		metrics.NotificationsDelivered.WithLabelValues(entities.ChannelInApp).Inc()
+	}
+
+	return len(seen), nil
+}
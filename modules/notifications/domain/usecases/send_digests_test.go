@@ -0,0 +1,194 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// digestFixture wires SendDigestsUseCase to in-memory repositories
+type digestFixture struct {
+	notifications *fakeNotificationRepo
+	deliveries    *fakeDeliveryRepo
+	transactor    *fakeTransactor
+	uc            *SendDigestsUseCase
+}
+
+func newDigestFixture(batchSize int) *digestFixture {
+	notifications := newFakeNotificationRepo()
+	deliveries := newFakeDeliveryRepo()
+	transactor := &fakeTransactor{snapshots: []func() func(){notifications.snapshot, deliveries.snapshot}}
+
+	return &digestFixture{
+		notifications: notifications,
+		deliveries:    deliveries,
+		transactor:    transactor,
+		uc:            NewSendDigestsUseCase(deliveries, notifications, fakeRecipientRepo{}, fakeRenderer{}, transactor, batchSize),
+	}
+}
+
+// batch stores a notification for userID with batched deliveries over the
+// channels, due an hour ago
+func (f *digestFixture) batch(userID, title string, channels ...string) *entities.Notification {
+	notification := &entities.Notification{
+		ID:        title + "-" + userID,
+		UserID:    userID,
+		Type:      entities.TypeMeeting,
+		Priority:  entities.PriorityLow,
+		Title:     title,
+		CreatedAt: time.Now(),
+	}
+	f.notifications.notifications[notification.ID] = notification
+	for _, channel := range channels {
+		delivery := entities.NewDelivery(notification, channel, time.Now())
+		delivery.Batch(time.Now().Add(-time.Hour))
+		f.deliveries.deliveries[delivery.ID] = delivery
+	}
+	return notification
+}
+
+// digests returns the digest notifications created for userID
+func (f *digestFixture) digests(userID string) []*entities.Notification {
+	var digests []*entities.Notification
+	for _, notification := range f.notifications.notifications {
+		if notification.UserID == userID && notification.Template == digestTemplate {
+			digests = append(digests, notification)
+		}
+	}
+	return digests
+}
+
+func TestSendDigestsGroupsNotificationsPerUser(t *testing.T) {
+	f := newDigestFixture(100)
+	f.batch("alice", "first", entities.ChannelInApp, entities.ChannelEmail)
+	f.batch("alice", "second", entities.ChannelEmail)
+	f.batch("bob", "third", entities.ChannelEmail)
+
+	output, err := f.uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if output.Digests != 2 || output.Notifications != 3 {
+		t.Fatalf("output = %+v, want 2 digests of 3 notifications", output)
+	}
+
+	digests := f.digests("alice")
+	if len(digests) != 1 {
+		t.Fatalf("alice got %d digests, want 1", len(digests))
+	}
+	digest := digests[0]
+	if digest.Read {
+		t.Fatal("a digest with an in-app delivery is stored as read")
+	}
+
+	digestChannels := make(map[string]string)
+	for _, delivery := range f.deliveries.deliveries {
+		switch {
+		case delivery.NotificationID == digest.ID:
+			digestChannels[delivery.Channel] = delivery.Status
+		case delivery.UserID == "alice":
+			if delivery.Status != entities.DeliverySent || delivery.DigestID == nil || *delivery.DigestID != digest.ID {
+				t.Fatalf("batched delivery %+v was not claimed by digest %s", delivery, digest.ID)
+			}
+		}
+	}
+	if len(digestChannels) != 2 || digestChannels[entities.ChannelInApp] != entities.DeliverySent || digestChannels[entities.ChannelEmail] != entities.DeliveryPending {
+		t.Fatalf("digest deliveries = %v, want in_app sent and email pending", digestChannels)
+	}
+
+	// Nothing is left for the next run
+	output, err = f.uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("second Execute: %v", err)
+	}
+	if output.Digests != 0 {
+		t.Fatalf("second run sent %d digests, want 0", output.Digests)
+	}
+}
+
+func TestSendDigestsSkipsDeliveriesClaimedConcurrently(t *testing.T) {
+	f := newDigestFixture(100)
+	f.batch("alice", "first", entities.ChannelEmail)
+	f.batch("alice", "second", entities.ChannelEmail)
+
+	// Another replica claims one of the deliveries between listing and
+	// claiming
+	f.deliveries.beforeClaim = func() {
+		f.deliveries.beforeClaim = nil
+		for _, delivery := range f.deliveries.deliveries {
+			delivery.MarkSent(time.Now())
+			break
+		}
+	}
+
+	output, err := f.uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if output.Digests != 0 {
+		t.Fatalf("output = %+v, want no digest", output)
+	}
+	if digests := f.digests("alice"); len(digests) != 0 {
+		t.Fatalf("a digest was stored although its claim failed: %+v", digests[0])
+	}
+
+	pending := 0
+	for _, delivery := range f.deliveries.deliveries {
+		if delivery.Status == entities.DeliveryPending {
+			if delivery.DigestID != nil {
+				t.Fatalf("rolled back delivery kept digest %s", *delivery.DigestID)
+			}
+			pending++
+		}
+	}
+	if pending != 1 {
+		t.Fatalf("%d deliveries pending, want the unclaimed one", pending)
+	}
+}
+
+func TestSendDigestsLeavesOutExpiredNotifications(t *testing.T) {
+	f := newDigestFixture(100)
+	f.batch("alice", "current", entities.ChannelEmail)
+	expired := f.batch("alice", "expired", entities.ChannelEmail)
+	expiresAt := time.Now().Add(-time.Minute)
+	expired.ExpiresAt = &expiresAt
+
+	output, err := f.uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if output.Digests != 1 || output.Notifications != 1 {
+		t.Fatalf("output = %+v, want 1 digest of 1 notification", output)
+	}
+
+	for _, delivery := range f.deliveries.deliveries {
+		if delivery.NotificationID == expired.ID && delivery.Status != entities.DeliverySkipped {
+			t.Fatalf("expired delivery status = %s, want skipped", delivery.Status)
+		}
+	}
+}
+
+func TestSendDigestsKeepsLastUserOfAFullBatchWhole(t *testing.T) {
+	f := newDigestFixture(2)
+	f.batch("alice", "first", entities.ChannelEmail)
+	f.batch("bob", "second", entities.ChannelEmail)
+	f.batch("bob", "third", entities.ChannelEmail)
+
+	output, err := f.uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if output.Digests != 1 || len(f.digests("alice")) != 1 || len(f.digests("bob")) != 0 {
+		t.Fatalf("first run: output = %+v, want only alice's digest", output)
+	}
+
+	if _, err := f.uc.Execute(context.Background()); err != nil {
+		t.Fatalf("second Execute: %v", err)
+	}
+	digests := f.digests("bob")
+	if len(digests) != 1 {
+		t.Fatalf("bob got %d digests, want 1", len(digests))
+	}
+}
@@ -0,0 +1,149 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// EmailSender delivers notifications by email over SMTP
+type EmailSender struct {
+	cfg  config.SMTPConfig
+	addr string
+}
+
+// NewEmailSender creates a new EmailSender
+func NewEmailSender(cfg config.SMTPConfig) *EmailSender {
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	return &EmailSender{
+		cfg:  cfg,
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+	}
+}
+
+// Channel returns the channel the sender delivers over
+func (s *EmailSender) Channel() string {
+	return entities.ChannelEmail
+}
+
+// Send emails the notification to the recipient. STARTTLS is used when the
+// server offers it.
+func (s *EmailSender) Send(ctx context.Context, message *entities.Message) error {
+	to, err := mail.ParseAddress(message.Recipient.Email)
+	if err != nil {
+		return fmt.Errorf("%w: invalid email address", entities.ErrUndeliverable)
+	}
+	from, err := mail.ParseAddress(s.cfg.From)
+	if err != nil {
+		return fmt.Errorf("%w: invalid sender address", entities.ErrUndeliverable)
+	}
+	to.Name = message.Recipient.Name
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if s.cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(s.compose(from, to, message)); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// compose builds the email. Templates with an HTML body are sent as
+// multipart/alternative with the plain text message as the first part.
+func (s *EmailSender) compose(from, to *mail.Address, message *entities.Message) []byte {
+	notification := message.Notification
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", from.String())
+	fmt.Fprintf(&body, "To: %s\r\n", to.String())
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Title))
+	fmt.Fprintf(&body, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	body.WriteString("MIME-Version: 1.0\r\n")
+
+	if message.HTML == "" {
+		body.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		body.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+		body.WriteString(notification.Message)
+		body.WriteString("\r\n")
+		return body.Bytes()
+	}
+
+	parts := multipart.NewWriter(&body)
+	fmt.Fprintf(&body, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+	writePart(parts, "text/plain; charset=utf-8", notification.Message)
+	writePart(parts, "text/html; charset=utf-8", message.HTML)
+	parts.Close()
+	return body.Bytes()
+}
+
+func writePart(parts *multipart.Writer, contentType, content string) {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	// Writes go to a bytes.Buffer, which does not fail
+	part, _ := parts.CreatePart(header)
+	encoder := quotedprintable.NewWriter(part)
+	encoder.Write([]byte(content))
+	encoder.Close()
+}
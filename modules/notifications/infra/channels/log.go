@@ -0,0 +1,57 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// LogSender writes notifications as JSON lines to a file or stdout. It
+// lets development setups see what would be delivered.
+type LogSender struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewLogSender creates a LogSender appending to path, or writing to stdout
+// when path is empty
+func NewLogSender(path string) (*LogSender, error) {
+	if path == "" {
+		return &LogSender{out: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &LogSender{out: file}, nil
+}
+
+// Channel returns the channel the sender delivers over
+func (s *LogSender) Channel() string {
+	return entities.ChannelLog
+}
+
+// Send writes the notification and its recipient
+func (s *LogSender) Send(ctx context.Context, message *entities.Message) error {
+	line, err := json.Marshal(map[string]interface{}{
+		"channel":      entities.ChannelLog,
+		"sent_at":      time.Now().UTC(),
+		"recipient":    map[string]string{"user_id": message.Recipient.UserID, "email": message.Recipient.Email},
+		"notification": message.Notification,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.out.Write(append(line, '\n'))
+	return err
+}
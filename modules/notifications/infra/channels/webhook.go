@@ -0,0 +1,103 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/netguard"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/infra/sender"
+)
+
+// WebhookSender posts notifications as JSON to the webhook URL of the
+// recipient
+type WebhookSender struct {
+	client *http.Client
+	secret string
+}
+
+// NewWebhookSender creates a new WebhookSender. Since users choose the URLs,
+// only https URLs resolving to public addresses are dialed, and redirects
+// are not followed, so deliveries cannot reach internal services.
+func NewWebhookSender(cfg config.WebhookConfig) *WebhookSender {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &WebhookSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: netguard.NewTransport(timeout),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		secret: cfg.SigningSecret,
+	}
+}
+
+// Channel returns the channel the sender delivers over
+func (s *WebhookSender) Channel() string {
+	return entities.ChannelWebhook
+}
+
+// Send posts the notification. With a signing secret configured it is
+// signed like outbound webhooks, so receivers verify both the same way: the
+// X-Webhook-Signature header holds "sha256=" and sender.Sign of the
+// X-Webhook-Timestamp and the body. Client errors other than timeouts and
+// rate limits are not retried.
+func (s *WebhookSender) Send(ctx context.Context, message *entities.Message) error {
+	if message.Recipient.WebhookURL == "" {
+		return fmt.Errorf("%w: no webhook URL", entities.ErrUndeliverable)
+	}
+
+	body, err := json.Marshal(message.Notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, message.Recipient.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", entities.ErrUndeliverable, err)
+	}
+	// URLs saved before https was required are not delivered to
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("%w: webhook URL must use https", entities.ErrUndeliverable)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Notification-ID", message.Notification.ID)
+	if s.secret != "" {
+		timestamp := time.Now().Unix()
+		req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+		req.Header.Set("X-Webhook-Signature", "sha256="+sender.Sign(s.secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		if errors.Is(err, netguard.ErrForbiddenAddress) {
+			return fmt.Errorf("%w: %v", entities.ErrUndeliverable, err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		return fmt.Errorf("%w: webhook returned %s", entities.ErrUndeliverable, resp.Status)
+	default:
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+}
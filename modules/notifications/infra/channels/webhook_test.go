@@ -0,0 +1,100 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	webhooks "github.com/manab-pr/evtaarpro/modules/webhooks/infra/sender"
+)
+
+func webhookMessage(url string) *entities.Message {
+	return &entities.Message{
+		Notification: &entities.Notification{ID: "notification-1", Title: "Hello"},
+		Recipient:    &entities.Recipient{UserID: "user-1", WebhookURL: url},
+	}
+}
+
+func TestWebhookSenderRefusesInternalAddresses(t *testing.T) {
+	var called bool
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	sender := NewWebhookSender(config.WebhookConfig{Timeout: time.Second})
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"loopback server", server.URL},
+		{"plain http", "http://hooks.example.com/notify"},
+		{"metadata service", "https://169.254.169.254/latest/meta-data/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sender.Send(context.Background(), webhookMessage(tt.url))
+			if !errors.Is(err, entities.ErrUndeliverable) {
+				t.Fatalf("Send: err = %v, want ErrUndeliverable", err)
+			}
+		})
+	}
+	if called {
+		t.Fatal("the loopback server was reached")
+	}
+}
+
+func TestWebhookSenderSignsAndDoesNotFollowRedirects(t *testing.T) {
+	var redirected bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/internal", func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+	})
+
+	var body []byte
+	var timestamp, signature string
+	mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		timestamp = r.Header.Get("X-Webhook-Timestamp")
+		signature = r.Header.Get("X-Webhook-Signature")
+	})
+
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	sender := NewWebhookSender(config.WebhookConfig{Timeout: time.Second, SigningSecret: "secret"})
+	// Trust the test certificate and allow the loopback address, keeping
+	// the redirect policy of the sender
+	sender.client.Transport = server.Client().Transport
+
+	if err := sender.Send(context.Background(), webhookMessage(server.URL+"/notify")); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	// Signed the same way as outbound webhooks
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.Unix(sentAt, 0)) > time.Minute {
+		t.Fatalf("X-Webhook-Timestamp = %q, want the time of sending", timestamp)
+	}
+	if want := "sha256=" + webhooks.Sign("secret", sentAt, body); signature != want {
+		t.Fatalf("X-Webhook-Signature = %q, want %q", signature, want)
+	}
+
+	if err := sender.Send(context.Background(), webhookMessage(server.URL+"/redirect")); err == nil {
+		t.Fatal("Send to a redirecting webhook succeeded")
+	}
+	if redirected {
+		t.Fatal("the redirect was followed")
+	}
+}
@@ -0,0 +1,184 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// DeliveryRepository implements ports.DeliveryRepository
+type DeliveryRepository struct {
+	db *sql.DB
+}
+
+// NewDeliveryRepository creates a new DeliveryRepository
+func NewDeliveryRepository(db *sql.DB) *DeliveryRepository {
+	return &DeliveryRepository{db: db}
+}
+
+// CreateBatch stores the deliveries of a notification. It joins the
+// transaction carried by ctx, or runs in its own.
+func (r *DeliveryRepository) CreateBatch(ctx context.Context, deliveries []*entities.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if _, ok := datastore.TxFromContext(ctx); ok {
+		return createDeliveries(ctx, datastore.Conn(ctx, r.db), deliveries)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer datastore.Rollback(ctx, tx)
+
+	if err := createDeliveries(ctx, tx, deliveries); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func createDeliveries(ctx context.Context, conn datastore.Executor, deliveries []*entities.Delivery) error {
+	query := `
+		INSERT INTO notification_deliveries (id, notification_id, user_id, channel, status, attempts, batched, digest_id,
+			next_attempt_at, last_error, delivered_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	for _, delivery := range deliveries {
+		_, err := conn.ExecContext(ctx, query,
+			delivery.ID,
+			delivery.NotificationID,
+			delivery.UserID,
+			delivery.Channel,
+			delivery.Status,
+			delivery.Attempts,
+			delivery.Batched,
+			delivery.DigestID,
+			delivery.NextAttemptAt,
+			nullString(delivery.LastError),
+			delivery.DeliveredAt,
+			delivery.CreatedAt,
+			delivery.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListDue retrieves pending deliveries due at now, oldest first
+func (r *DeliveryRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*entities.Delivery, error) {
+	query := `
+		SELECT id, notification_id, user_id, channel, status, attempts, batched, digest_id, next_attempt_at, last_error,
+			delivered_at, created_at, updated_at
+		FROM notification_deliveries
+		WHERE status = 'pending' AND NOT batched AND next_attempt_at <= $1
+		ORDER BY next_attempt_at
+		LIMIT $2
+	`
+	return r.list(ctx, query, now, limit)
+}
+
+// ListDigestDue retrieves batched deliveries whose digest is due at now,
+// grouped by user
+func (r *DeliveryRepository) ListDigestDue(ctx context.Context, now time.Time, limit int) ([]*entities.Delivery, error) {
+	query := `
+		SELECT id, notification_id, user_id, channel, status, attempts, batched, digest_id, next_attempt_at, last_error,
+			delivered_at, created_at, updated_at
+		FROM notification_deliveries
+		WHERE status = 'pending' AND batched AND next_attempt_at <= $1
+		ORDER BY user_id, created_at
+		LIMIT $2
+	`
+	return r.list(ctx, query, now, limit)
+}
+
+// ListByNotification retrieves the deliveries of a notification
+func (r *DeliveryRepository) ListByNotification(ctx context.Context, notificationID string) ([]*entities.Delivery, error) {
+	query := `
+		SELECT id, notification_id, user_id, channel, status, attempts, batched, digest_id, next_attempt_at, last_error,
+			delivered_at, created_at, updated_at
+		FROM notification_deliveries
+		WHERE notification_id = $1
+		ORDER BY channel
+	`
+	return r.list(ctx, query, notificationID)
+}
+
+// ClaimForDigest marks pending batched deliveries as sent in a digest
+func (r *DeliveryRepository) ClaimForDigest(ctx context.Context, ids []string, digestID string, at time.Time) (int64, error) {
+	query := `
+		UPDATE notification_deliveries
+		SET status = 'sent', attempts = attempts + 1, digest_id = $2, next_attempt_at = NULL, last_error = NULL,
+			delivered_at = $3, updated_at = $3
+		WHERE id = ANY($1) AND status = 'pending' AND batched
+	`
+	result, err := datastore.Conn(ctx, r.db).ExecContext(ctx, query, pq.Array(ids), digestID, at)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Update updates a delivery
+func (r *DeliveryRepository) Update(ctx context.Context, delivery *entities.Delivery) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status = $2, attempts = $3, digest_id = $4, next_attempt_at = $5, last_error = $6, delivered_at = $7,
+			updated_at = $8
+		WHERE id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.DigestID,
+		delivery.NextAttemptAt,
+		nullString(delivery.LastError),
+		delivery.DeliveredAt,
+		delivery.UpdatedAt,
+	)
+	return err
+}
+
+func (r *DeliveryRepository) list(ctx context.Context, query string, args ...interface{}) ([]*entities.Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*entities.Delivery, 0)
+	for rows.Next() {
+		delivery := &entities.Delivery{}
+		var lastError sql.NullString
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.NotificationID,
+			&delivery.UserID,
+			&delivery.Channel,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.Batched,
+			&delivery.DigestID,
+			&delivery.NextAttemptAt,
+			&lastError,
+			&delivery.DeliveredAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		delivery.LastError = lastError.String
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
@@ -1,10 +1,14 @@
 package postgresql
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"time"
 
+	"github.com/manab-pr/evtaarpro/internal/datastore"
 	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
 )
 
@@ -21,12 +25,15 @@ func NewNotificationRepository(db *sql.DB) *NotificationRepository {
 // Create creates a new notification
 func (r *NotificationRepository) Create(ctx context.Context, notification *entities.Notification) error {
 	query := `
-		INSERT INTO notifications (id, user_id, type, title, message, data, read, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO notifications (id, user_id, type, priority, template, locale, title, message, data, is_read, read_at,
+			expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
-	_, err := r.db.ExecContext(ctx, query,
-		notification.ID, notification.UserID, notification.Type, notification.Title,
-		notification.Message, notification.Data, notification.Read, notification.CreatedAt,
+	_, err := datastore.Conn(ctx, r.db).ExecContext(ctx, query,
+		notification.ID, notification.UserID, notification.Type, notification.Priority,
+		nullString(notification.Template), nullString(notification.Locale), notification.Title,
+		notification.Message, nullJSON(notification.Data), notification.Read, notification.ReadAt,
+		notification.ExpiresAt, notification.CreatedAt,
 	)
 	return err
 }
@@ -34,16 +41,15 @@ func (r *NotificationRepository) Create(ctx context.Context, notification *entit
 // GetByID retrieves a notification by ID
 func (r *NotificationRepository) GetByID(ctx context.Context, id string) (*entities.Notification, error) {
 	query := `
-		SELECT id, user_id, type, title, message, data, read, created_at, read_at
+		SELECT id, user_id, type, priority, template, locale, title, message, data, is_read, created_at, read_at,
+			expires_at, archived_at
 		FROM notifications WHERE id = $1
 	`
-	notification := &entities.Notification{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&notification.ID, &notification.UserID, &notification.Type, &notification.Title,
-		&notification.Message, &notification.Data, &notification.Read,
-		&notification.CreatedAt, &notification.ReadAt,
-	)
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrNotificationNotFound
+		}
 		return nil, err
 	}
 	return notification, nil
@@ -52,9 +58,10 @@ func (r *NotificationRepository) GetByID(ctx context.Context, id string) (*entit
 // ListByUser retrieves notifications for a user
 func (r *NotificationRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*entities.Notification, int, error) {
 	query := `
-		SELECT id, user_id, type, title, message, data, read, created_at, read_at
+		SELECT id, user_id, type, priority, template, locale, title, message, data, is_read, created_at, read_at,
+			expires_at, archived_at
 		FROM notifications
-		WHERE user_id = $1
+		WHERE user_id = $1 AND archived_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
@@ -66,12 +73,7 @@ func (r *NotificationRepository) ListByUser(ctx context.Context, userID string,
 
 	var notifications []*entities.Notification
 	for rows.Next() {
-		notification := &entities.Notification{}
-		err := rows.Scan(
-			&notification.ID, &notification.UserID, &notification.Type, &notification.Title,
-			&notification.Message, &notification.Data, &notification.Read,
-			&notification.CreatedAt, &notification.ReadAt,
-		)
+		notification, err := scanNotification(rows)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -80,7 +82,7 @@ func (r *NotificationRepository) ListByUser(ctx context.Context, userID string,
 
 	// Get total count
 	var total int
-	countQuery := `SELECT COUNT(*) FROM notifications WHERE user_id = $1`
+	countQuery := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND archived_at IS NULL`
 	err = r.db.QueryRowContext(ctx, countQuery, userID).Scan(&total)
 	if err != nil {
 		return nil, 0, err
@@ -89,12 +91,41 @@ func (r *NotificationRepository) ListByUser(ctx context.Context, userID string,
 	return notifications, total, nil
 }
 
+// ListAfter retrieves notifications of a user that come after the given
+// stream position, oldest first
+func (r *NotificationRepository) ListAfter(ctx context.Context, userID string, after entities.StreamPosition, limit int) ([]*entities.Notification, error) {
+	query := `
+		SELECT id, user_id, type, priority, template, locale, title, message, data, is_read, created_at, read_at,
+			expires_at, archived_at
+		FROM notifications
+		WHERE user_id = $1 AND archived_at IS NULL AND (created_at, id) > ($2, $3)
+		ORDER BY created_at, id
+		LIMIT $4
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, after.CreatedAt, after.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]*entities.Notification, 0)
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, rows.Err()
+}
+
 // MarkAsRead marks a notification as read
 func (r *NotificationRepository) MarkAsRead(ctx context.Context, id string) error {
 	query := `
 		UPDATE notifications
-		SET read = true, read_at = $2
-		WHERE id = $1
+		SET is_read = true, read_at = $2
+		WHERE id = $1 AND is_read = false
 	`
 	_, err := r.db.ExecContext(ctx, query, id, time.Now())
 	return err
@@ -104,8 +135,8 @@ func (r *NotificationRepository) MarkAsRead(ctx context.Context, id string) erro
 func (r *NotificationRepository) MarkAllAsRead(ctx context.Context, userID string) error {
 	query := `
 		UPDATE notifications
-		SET read = true, read_at = $2
-		WHERE user_id = $1 AND read = false
+		SET is_read = true, read_at = $2
+		WHERE user_id = $1 AND is_read = false
 	`
 	_, err := r.db.ExecContext(ctx, query, userID, time.Now())
 	return err
@@ -120,7 +151,7 @@ func (r *NotificationRepository) Delete(ctx context.Context, id string) error {
 
 // GetUnreadCount gets the count of unread notifications for a user
 func (r *NotificationRepository) GetUnreadCount(ctx context.Context, userID string) (int, error) {
-	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read = false`
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND is_read = false AND archived_at IS NULL`
 	var count int
 	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
 	if err != nil {
@@ -128,3 +159,67 @@ func (r *NotificationRepository) GetUnreadCount(ctx context.Context, userID stri
 	}
 	return count, nil
 }
+
+// ArchiveExpired archives the notifications that expired at now and returns
+// the users they belonged to
+func (r *NotificationRepository) ArchiveExpired(ctx context.Context, now time.Time) ([]string, error) {
+	query := `
+		WITH archived AS (
+			UPDATE notifications
+			SET archived_at = $1
+			WHERE archived_at IS NULL AND expires_at <= $1
+			RETURNING user_id
+		)
+		SELECT DISTINCT user_id FROM archived
+	`
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := make([]string, 0)
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanNotification(row rowScanner) (*entities.Notification, error) {
+	notification := &entities.Notification{}
+	var template, locale sql.NullString
+	var data []byte
+	err := row.Scan(
+		&notification.ID, &notification.UserID, &notification.Type, &notification.Priority, &template, &locale,
+		&notification.Title, &notification.Message, &data, &notification.Read,
+		&notification.CreatedAt, &notification.ReadAt, &notification.ExpiresAt, &notification.ArchivedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	notification.Template = template.String
+	notification.Locale = locale.String
+	if len(data) > 0 {
+		notification.Data = json.RawMessage(data)
+	}
+	return notification, nil
+}
+
+// nullJSON stores empty JSON as NULL. JSON is passed as a string because
+// lib/pq sends []byte as bytea.
+func nullJSON(data json.RawMessage) interface{} {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil
+	}
+	return string(trimmed)
+}
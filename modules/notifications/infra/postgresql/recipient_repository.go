@@ -0,0 +1,88 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// RecipientRepository implements ports.RecipientRepository on the users
+// table
+type RecipientRepository struct {
+	db *sql.DB
+}
+
+// NewRecipientRepository creates a new RecipientRepository
+func NewRecipientRepository(db *sql.DB) *RecipientRepository {
+	return &RecipientRepository{db: db}
+}
+
+// GetRecipient retrieves how to reach a user
+func (r *RecipientRepository) GetRecipient(ctx context.Context, userID string) (*entities.Recipient, error) {
+	query := `
+		SELECT u.id, u.email, trim(u.first_name || ' ' || u.last_name), u.timezone, u.locale, COALESCE(s.webhook_url, '')
+		FROM users u
+		LEFT JOIN notification_settings s ON s.user_id = u.id
+		WHERE u.id = $1
+	`
+
+	recipient := &entities.Recipient{}
+	var timezone string
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&recipient.UserID,
+		&recipient.Email,
+		&recipient.Name,
+		&timezone,
+		&recipient.Locale,
+		&recipient.WebhookURL,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrRecipientNotFound
+		}
+		return nil, err
+	}
+
+	// A timezone removed from the tz database falls back to UTC
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		logging.FromContext(ctx).Warn("unknown user timezone, using UTC", "target_user_id", userID, "timezone", timezone, "error", err)
+		location = time.UTC
+	}
+	recipient.Location = location
+
+	return recipient, nil
+}
+
+// ListAudience retrieves the active users with one of the roles and in one
+// of the departments; an empty list does not filter
+func (r *RecipientRepository) ListAudience(ctx context.Context, roles, departments []string) ([]string, error) {
+	query := `
+		SELECT id
+		FROM users
+		WHERE is_active
+			AND (cardinality($1::text[]) = 0 OR role = ANY($1))
+			AND (cardinality($2::text[]) = 0 OR department = ANY($2))
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(roles), pq.Array(departments))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIDs := make([]string, 0)
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
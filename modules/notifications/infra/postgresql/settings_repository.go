@@ -0,0 +1,108 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+// SettingsRepository implements ports.SettingsRepository
+type SettingsRepository struct {
+	db *sql.DB
+}
+
+// NewSettingsRepository creates a new SettingsRepository
+func NewSettingsRepository(db *sql.DB) *SettingsRepository {
+	return &SettingsRepository{db: db}
+}
+
+// Get retrieves the settings of a user
+func (r *SettingsRepository) Get(ctx context.Context, userID string) (*entities.Settings, error) {
+	settings := &entities.Settings{UserID: userID, Preferences: []entities.Preference{}, Digest: entities.DigestImmediate}
+
+	query := `
+		SELECT COALESCE(to_char(quiet_hours_start, 'HH24:MI'), ''),
+			COALESCE(to_char(quiet_hours_end, 'HH24:MI'), ''),
+			COALESCE(webhook_url, ''), digest, updated_at
+		FROM notification_settings
+		WHERE user_id = $1
+	`
+	var quietStart, quietEnd string
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&quietStart, &quietEnd, &settings.WebhookURL, &settings.Digest, &settings.UpdatedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if quietStart != "" {
+		settings.QuietHours = &entities.QuietHours{Start: quietStart, End: quietEnd}
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT type, channel, enabled
+		FROM notification_preferences
+		WHERE user_id = $1
+		ORDER BY type, channel
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var preference entities.Preference
+		if err := rows.Scan(&preference.Type, &preference.Channel, &preference.Enabled); err != nil {
+			return nil, err
+		}
+		settings.Preferences = append(settings.Preferences, preference)
+	}
+
+	return settings, rows.Err()
+}
+
+// Save stores the settings of a user, replacing their preferences
+func (r *SettingsRepository) Save(ctx context.Context, settings *entities.Settings) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer datastore.Rollback(ctx, tx)
+
+	var quietStart, quietEnd sql.NullString
+	if settings.QuietHours != nil {
+		quietStart = sql.NullString{String: settings.QuietHours.Start, Valid: true}
+		quietEnd = sql.NullString{String: settings.QuietHours.End, Valid: true}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO notification_settings (user_id, quiet_hours_start, quiet_hours_end, webhook_url, digest, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE
+		SET quiet_hours_start = EXCLUDED.quiet_hours_start, quiet_hours_end = EXCLUDED.quiet_hours_end,
+			webhook_url = EXCLUDED.webhook_url, digest = EXCLUDED.digest, updated_at = EXCLUDED.updated_at
+	`, settings.UserID, quietStart, quietEnd, nullString(settings.WebhookURL), settings.Digest, settings.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM notification_preferences WHERE user_id = $1`, settings.UserID); err != nil {
+		return err
+	}
+
+	for _, preference := range settings.Preferences {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notification_preferences (user_id, type, channel, enabled, updated_at)
+			VALUES ($1, $2, $3, $4, $5)
+		`, settings.UserID, preference.Type, preference.Channel, preference.Enabled, settings.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,114 @@
+package pubsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/realtime"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+// NotificationRepository pushes every notification it stores, and every
+// change of a user's unread count, to the user's connected clients
+type NotificationRepository struct {
+	ports.NotificationRepository
+	publisher realtime.Publisher
+}
+
+// NewNotificationRepository wraps a notification repository
+func NewNotificationRepository(repo ports.NotificationRepository, publisher realtime.Publisher) *NotificationRepository {
+	return &NotificationRepository{
+		NotificationRepository: repo,
+		publisher:              publisher,
+	}
+}
+
+// Create stores a notification and publishes it once stored, i.e. after the
+// transaction commits, unless it is stored as read. The notification is
+// already saved when publishing fails, so the error is only logged; clients
+// catch up from the list endpoint.
+func (r *NotificationRepository) Create(ctx context.Context, notification *entities.Notification) error {
+	if err := r.NotificationRepository.Create(ctx, notification); err != nil {
+		return err
+	}
+	if notification.Read {
+		return nil
+	}
+
+	datastore.AfterCommit(ctx, func(ctx context.Context) {
+		if err := r.publisher.Publish(ctx, []string{notification.UserID}, realtime.EventNotificationCreated, notification); err != nil {
+			logging.FromContext(ctx).Warn("failed to publish notification", "notification_id", notification.ID, "error", err)
+		}
+		r.publishUnreadCount(ctx, notification.UserID)
+	})
+
+	return nil
+}
+
+// MarkAsRead marks a notification as read and publishes the new unread count
+func (r *NotificationRepository) MarkAsRead(ctx context.Context, id string) error {
+	notification, lookupErr := r.NotificationRepository.GetByID(ctx, id)
+
+	if err := r.NotificationRepository.MarkAsRead(ctx, id); err != nil {
+		return err
+	}
+
+	if lookupErr == nil {
+		r.publishUnreadCount(ctx, notification.UserID)
+	}
+	return nil
+}
+
+// MarkAllAsRead marks all notifications of a user as read and publishes the
+// new unread count
+func (r *NotificationRepository) MarkAllAsRead(ctx context.Context, userID string) error {
+	if err := r.NotificationRepository.MarkAllAsRead(ctx, userID); err != nil {
+		return err
+	}
+
+	r.publishUnreadCount(ctx, userID)
+	return nil
+}
+
+// Delete deletes a notification and publishes the new unread count
+func (r *NotificationRepository) Delete(ctx context.Context, id string) error {
+	notification, lookupErr := r.NotificationRepository.GetByID(ctx, id)
+
+	if err := r.NotificationRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if lookupErr == nil {
+		r.publishUnreadCount(ctx, notification.UserID)
+	}
+	return nil
+}
+
+// ArchiveExpired archives expired notifications and publishes the new unread
+// count of every user who lost one
+func (r *NotificationRepository) ArchiveExpired(ctx context.Context, now time.Time) ([]string, error) {
+	userIDs, err := r.NotificationRepository.ArchiveExpired(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userID := range userIDs {
+		r.publishUnreadCount(ctx, userID)
+	}
+	return userIDs, nil
+}
+
+func (r *NotificationRepository) publishUnreadCount(ctx context.Context, userID string) {
+	count, err := r.NotificationRepository.GetUnreadCount(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to count unread notifications", "target_user_id", userID, "error", err)
+		return
+	}
+
+	if err := r.publisher.Publish(ctx, []string{userID}, realtime.EventUnreadCountChanged, map[string]int{"count": count}); err != nil {
+		logging.FromContext(ctx).Warn("failed to publish unread count", "target_user_id", userID, "error", err)
+	}
+}
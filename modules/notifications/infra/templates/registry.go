@@ -0,0 +1,398 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed schema.yaml locales/*.yaml
+var files embed.FS
+
+// Variable types
+const (
+	typeString = "string"
+	typeNumber = "number"
+	typeBool   = "bool"
+	typeTime   = "time"
+	typeDate   = "date"
+	typeList   = "list"
+)
+
+type variableSchema struct {
+	Type     string `yaml:"type"`
+	Required bool   `yaml:"required"`
+}
+
+type templateSchema struct {
+	Type      string                    `yaml:"type"`
+	Priority  string                    `yaml:"priority"`
+	Variables map[string]variableSchema `yaml:"variables"`
+}
+
+type localeFile struct {
+	Formats struct {
+		Time string `yaml:"time"`
+		Date string `yaml:"date"`
+	} `yaml:"formats"`
+	Templates map[string]struct {
+		Title   string `yaml:"title"`
+		Message string `yaml:"message"`
+		HTML    string `yaml:"html"`
+	} `yaml:"templates"`
+}
+
+type compiledTemplate struct {
+	title   *texttemplate.Template
+	message *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+type locale struct {
+	tag        string
+	timeLayout string
+	dateLayout string
+	templates  map[string]*compiledTemplate
+}
+
+// Registry holds the notification templates of every locale and renders
+// them. Templates a locale does not translate fall back to the fallback
+// locale.
+type Registry struct {
+	schemas  map[string]templateSchema
+	locales  map[string]*locale
+	fallback *locale
+}
+
+// NewRegistry loads the embedded templates. Every template must parse,
+// reference only variables of its schema and exist in the fallback locale.
+func NewRegistry(fallbackLocale string) (*Registry, error) {
+	data, err := files.ReadFile("schema.yaml")
+	if err != nil {
+		return nil, err
+	}
+	var schemas map[string]templateSchema
+	if err := yaml.Unmarshal(data, &schemas); err != nil {
+		return nil, fmt.Errorf("schema.yaml: %w", err)
+	}
+	for key, schema := range schemas {
+		if !entities.IsValidType(schema.Type) {
+			return nil, fmt.Errorf("schema.yaml: template %s has invalid type %q", key, schema.Type)
+		}
+		if schema.Priority == "" {
+			schema.Priority = entities.PriorityMedium
+			schemas[key] = schema
+		}
+		if !entities.IsValidPriority(schema.Priority) {
+			return nil, fmt.Errorf("schema.yaml: template %s has invalid priority %q", key, schema.Priority)
+		}
+		for name, variable := range schema.Variables {
+			switch variable.Type {
+			case typeString, typeNumber, typeBool, typeTime, typeDate, typeList:
+			default:
+				return nil, fmt.Errorf("schema.yaml: variable %s of %s has invalid type %q", name, key, variable.Type)
+			}
+		}
+	}
+
+	registry := &Registry{
+		schemas: schemas,
+		locales: make(map[string]*locale),
+	}
+
+	names, err := files.ReadDir("locales")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range names {
+		loc, err := registry.loadLocale(path.Join("locales", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		registry.locales[loc.tag] = loc
+	}
+
+	fallback, err := language.Parse(fallbackLocale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fallback locale %q", fallbackLocale)
+	}
+	registry.fallback = registry.locales[fallback.String()]
+	if registry.fallback == nil {
+		return nil, fmt.Errorf("no templates for fallback locale %q", fallbackLocale)
+	}
+	for key := range schemas {
+		if registry.fallback.templates[key] == nil {
+			return nil, fmt.Errorf("template %s is missing in fallback locale %s", key, registry.fallback.tag)
+		}
+	}
+
+	return registry, nil
+}
+
+// Locales lists the locales templates are available in
+func (r *Registry) Locales() []string {
+	tags := make([]string, 0, len(r.locales))
+	for tag := range r.locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Render validates the variables against the schema of the template and
+// renders it in the recipient's language, falling back through parent
+// languages (de-AT, de) to the fallback locale
+func (r *Registry) Render(key string, recipient *entities.Recipient, variables map[string]interface{}) (*entities.Rendered, error) {
+	schema, ok := r.schemas[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", entities.ErrUnknownTemplate, key)
+	}
+
+	normalized, err := normalize(schema, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, tmpl := r.resolve(key, recipient.Locale)
+	location := recipient.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	rendered, err := tmpl.execute(loc.renderData(schema, normalized, location))
+	if err != nil {
+		return nil, fmt.Errorf("render %s in %s: %w", key, loc.tag, err)
+	}
+	rendered.Type = schema.Type
+	rendered.Priority = schema.Priority
+	rendered.Locale = loc.tag
+	rendered.Variables = normalized
+	return rendered, nil
+}
+
+// resolve picks the most specific locale translating the template
+func (r *Registry) resolve(key, preferred string) (*locale, *compiledTemplate) {
+	if tag, err := language.Parse(preferred); err == nil {
+		for ; tag != language.Und; tag = tag.Parent() {
+			if loc := r.locales[tag.String()]; loc != nil && loc.templates[key] != nil {
+				return loc, loc.templates[key]
+			}
+		}
+	}
+	return r.fallback, r.fallback.templates[key]
+}
+
+func (r *Registry) loadLocale(file string) (*locale, error) {
+	data, err := files.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var parsed localeFile
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	tag, err := language.Parse(strings.TrimSuffix(path.Base(file), ".yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: file name is not a locale", file)
+	}
+	if parsed.Formats.Time == "" || parsed.Formats.Date == "" {
+		return nil, fmt.Errorf("%s: time and date formats are required", file)
+	}
+
+	loc := &locale{
+		tag:        tag.String(),
+		timeLayout: parsed.Formats.Time,
+		dateLayout: parsed.Formats.Date,
+		templates:  make(map[string]*compiledTemplate, len(parsed.Templates)),
+	}
+
+	for key, source := range parsed.Templates {
+		schema, ok := r.schemas[key]
+		if !ok {
+			return nil, fmt.Errorf("%s: template %s is not in schema.yaml", file, key)
+		}
+		if source.Title == "" || source.Message == "" {
+			return nil, fmt.Errorf("%s: template %s needs a title and a message", file, key)
+		}
+
+		tmpl := &compiledTemplate{}
+		if tmpl.title, err = texttemplate.New(key + ".title").Option("missingkey=error").Parse(source.Title); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if tmpl.message, err = texttemplate.New(key + ".message").Option("missingkey=error").Parse(source.Message); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if source.HTML != "" {
+			if tmpl.html, err = htmltemplate.New(key + ".html").Option("missingkey=error").Parse(source.HTML); err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+		}
+
+		// Render once with sample values so references to variables
+		// outside the schema fail at startup rather than at send time
+		if _, err := tmpl.execute(loc.renderData(schema, sampleVariables(schema), time.UTC)); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		loc.templates[key] = tmpl
+	}
+
+	return loc, nil
+}
+
+func (t *compiledTemplate) execute(data map[string]interface{}) (*entities.Rendered, error) {
+	var title, message, html bytes.Buffer
+	if err := t.title.Execute(&title, data); err != nil {
+		return nil, err
+	}
+	if err := t.message.Execute(&message, data); err != nil {
+		return nil, err
+	}
+	if t.html != nil {
+		if err := t.html.Execute(&html, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return &entities.Rendered{
+		Title:   strings.TrimSpace(title.String()),
+		Message: strings.TrimSpace(message.String()),
+		HTML:    strings.TrimSpace(html.String()),
+	}, nil
+}
+
+// renderData prepares validated variables for the templates. Every schema
+// variable is present; times are formatted in the recipient's timezone.
+func (l *locale) renderData(schema templateSchema, variables map[string]interface{}, location *time.Location) map[string]interface{} {
+	data := make(map[string]interface{}, len(schema.Variables))
+	for name, variable := range schema.Variables {
+		value, ok := variables[name]
+		if !ok {
+			data[name] = nil
+			continue
+		}
+
+		switch variable.Type {
+		case typeTime:
+			parsed, _ := time.Parse(time.RFC3339, value.(string))
+			data[name] = parsed.In(location).Format(l.timeLayout)
+		case typeDate:
+			// Dates are calendar days, not instants, so they are not
+			// moved into the recipient's timezone
+			parsed, _ := time.Parse(time.RFC3339, value.(string))
+			data[name] = parsed.Format(l.dateLayout)
+		default:
+			data[name] = value
+		}
+	}
+	return data
+}
+
+// normalize checks variables against the schema. Times become RFC 3339
+// strings so they survive being stored as JSON.
+func normalize(schema templateSchema, variables map[string]interface{}) (map[string]interface{}, error) {
+	normalized := make(map[string]interface{}, len(variables))
+	for name, value := range variables {
+		variable, ok := schema.Variables[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown variable %s", entities.ErrInvalidTemplateVariables, name)
+		}
+		if value == nil {
+			continue
+		}
+
+		converted, ok := convert(variable.Type, value)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s must be a %s", entities.ErrInvalidTemplateVariables, name, variable.Type)
+		}
+		normalized[name] = converted
+	}
+
+	for name, variable := range schema.Variables {
+		if _, ok := normalized[name]; variable.Required && !ok {
+			return nil, fmt.Errorf("%w: %s is required", entities.ErrInvalidTemplateVariables, name)
+		}
+	}
+
+	return normalized, nil
+}
+
+func convert(variableType string, value interface{}) (interface{}, bool) {
+	switch variableType {
+	case typeString:
+		s, ok := value.(string)
+		return s, ok
+	case typeBool:
+		b, ok := value.(bool)
+		return b, ok
+	case typeNumber:
+		switch n := value.(type) {
+		case int, int32, int64, uint, uint32, uint64, float32, float64:
+			return n, true
+		}
+		return nil, false
+	case typeList:
+		switch list := value.(type) {
+		case []string:
+			return list, true
+		case []interface{}:
+			items := make([]string, len(list))
+			for i, item := range list {
+				s, ok := item.(string)
+				if !ok {
+					return nil, false
+				}
+				items[i] = s
+			}
+			return items, true
+		}
+		return nil, false
+	case typeTime, typeDate:
+		switch t := value.(type) {
+		case time.Time:
+			return t.UTC().Format(time.RFC3339), true
+		case *time.Time:
+			if t == nil {
+				return nil, false
+			}
+			return t.UTC().Format(time.RFC3339), true
+		case string:
+			if _, err := time.Parse(time.RFC3339, t); err != nil {
+				return nil, false
+			}
+			return t, true
+		}
+		return nil, false
+	}
+	return nil, false
+}
+
+func sampleVariables(schema templateSchema) map[string]interface{} {
+	sample := make(map[string]interface{}, len(schema.Variables))
+	for name, variable := range schema.Variables {
+		switch variable.Type {
+		case typeString:
+			sample[name] = "sample"
+		case typeNumber:
+			sample[name] = 1
+		case typeBool:
+			sample[name] = true
+		case typeList:
+			sample[name] = []string{"sample"}
+		case typeTime, typeDate:
+			sample[name] = time.Date(2025, 1, 2, 15, 4, 0, 0, time.UTC).Format(time.RFC3339)
+		}
+	}
+	return sample
+}
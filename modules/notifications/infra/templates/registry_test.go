@@ -0,0 +1,200 @@
+package templates
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry, err := NewRegistry("en")
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	return registry
+}
+
+func TestNewRegistry(t *testing.T) {
+	registry := newTestRegistry(t)
+	if got := registry.Locales(); !reflect.DeepEqual(got, []string{"de", "en"}) {
+		t.Fatalf("Locales = %v, want [de en]", got)
+	}
+
+	for _, fallback := range []string{"fr", "not a locale"} {
+		if _, err := NewRegistry(fallback); err == nil {
+			t.Fatalf("NewRegistry(%q) succeeded without templates for the locale", fallback)
+		}
+	}
+}
+
+func TestRegistryRenderLocale(t *testing.T) {
+	registry := newTestRegistry(t)
+	variables := map[string]interface{}{
+		"meeting_id":    "m-1",
+		"meeting_title": "Planning",
+		"minutes":       15,
+		"start_time":    time.Date(2025, time.January, 2, 15, 4, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		locale     string
+		wantLocale string
+		wantTitle  string
+	}{
+		{"de", "de", "Besprechungserinnerung"},
+		{"de-AT", "de", "Besprechungserinnerung"},
+		{"en-GB", "en", "Meeting reminder"},
+		{"fr", "en", "Meeting reminder"},
+		{"", "en", "Meeting reminder"},
+		{"not a locale", "en", "Meeting reminder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			rendered, err := registry.Render("meeting.reminder", &entities.Recipient{Locale: tt.locale}, variables)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if rendered.Locale != tt.wantLocale || rendered.Title != tt.wantTitle {
+				t.Fatalf("rendered %s %q, want %s %q", rendered.Locale, rendered.Title, tt.wantLocale, tt.wantTitle)
+			}
+			if rendered.Type != entities.TypeMeeting || rendered.Priority != entities.PriorityHigh {
+				t.Fatalf("type %s, priority %s, want the template defaults", rendered.Type, rendered.Priority)
+			}
+		})
+	}
+}
+
+func TestRegistryRenderFormatsVariables(t *testing.T) {
+	registry := newTestRegistry(t)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	start := time.Date(2025, time.January, 2, 15, 4, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		key         string
+		recipient   *entities.Recipient
+		variables   map[string]interface{}
+		wantMessage string
+		wantHTML    string
+	}{
+		{
+			name:        "time in the recipient's timezone",
+			key:         "meeting.reminder",
+			recipient:   &entities.Recipient{Locale: "de", Location: berlin},
+			variables:   map[string]interface{}{"meeting_id": "m-1", "meeting_title": "Planung", "minutes": 15, "start_time": start, "outside_working_hours": true},
+			wantMessage: "Planung beginnt in 15 Minuten (16:04 CET), außerhalb Ihrer Arbeitszeit",
+		},
+		{
+			name:        "time without a timezone",
+			key:         "meeting.reminder",
+			recipient:   &entities.Recipient{Locale: "en"},
+			variables:   map[string]interface{}{"meeting_id": "m-1", "meeting_title": "Planning", "minutes": 15, "start_time": "2025-01-02T15:04:00Z"},
+			wantMessage: "Planning starts in 15 minutes (15:04 UTC)",
+		},
+		{
+			name:        "dates stay on their day",
+			key:         "meeting.action_item_assigned",
+			recipient:   &entities.Recipient{Locale: "en", Location: kolkata},
+			variables:   map[string]interface{}{"meeting_id": "m-1", "meeting_title": "Planning", "action_item_id": "a-1", "action_item_title": "Send notes", "due_date": "2025-01-02T23:30:00Z"},
+			wantMessage: `You were assigned "Send notes" from "Planning", due Jan 2, 2025`,
+		},
+		{
+			name:        "optional variable left out",
+			key:         "meeting.action_item_assigned",
+			recipient:   &entities.Recipient{Locale: "de"},
+			variables:   map[string]interface{}{"meeting_id": "m-1", "meeting_title": "Planung", "action_item_id": "a-1", "action_item_title": "Notizen senden", "due_date": nil},
+			wantMessage: "Ihnen wurde „Notizen senden“ aus „Planung“ zugewiesen",
+		},
+		{
+			name:      "html is escaped",
+			key:       "meeting.reminder",
+			recipient: &entities.Recipient{Locale: "en"},
+			variables: map[string]interface{}{"meeting_id": "m-1", "meeting_title": "<script>x</script>", "minutes": 5, "start_time": start},
+			wantHTML:  "<p><strong>&lt;script&gt;x&lt;/script&gt;</strong> starts in 5 minutes (15:04 UTC).</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := registry.Render(tt.key, tt.recipient, tt.variables)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+			if tt.wantMessage != "" && rendered.Message != tt.wantMessage {
+				t.Fatalf("message = %q, want %q", rendered.Message, tt.wantMessage)
+			}
+			if tt.wantHTML != "" && !strings.Contains(rendered.HTML, tt.wantHTML) {
+				t.Fatalf("html = %q, want it to contain %q", rendered.HTML, tt.wantHTML)
+			}
+		})
+	}
+}
+
+func TestRegistryRenderValidatesVariables(t *testing.T) {
+	registry := newTestRegistry(t)
+	recipient := &entities.Recipient{Locale: "en"}
+
+	tests := []struct {
+		name      string
+		key       string
+		variables map[string]interface{}
+		want      error
+	}{
+		{"unknown template", "meeting.rescheduled", nil, entities.ErrUnknownTemplate},
+		{"unknown variable", "payroll.generated", map[string]interface{}{"payroll_id": "p-1", "period": "2025-01", "bonus": "yes"}, entities.ErrInvalidTemplateVariables},
+		{"missing required", "payroll.generated", map[string]interface{}{"payroll_id": "p-1"}, entities.ErrInvalidTemplateVariables},
+		{"required set to nil", "payroll.generated", map[string]interface{}{"payroll_id": "p-1", "period": nil}, entities.ErrInvalidTemplateVariables},
+		{"string given a number", "payroll.generated", map[string]interface{}{"payroll_id": 1, "period": "2025-01"}, entities.ErrInvalidTemplateVariables},
+		{"number given a string", "notification.digest", map[string]interface{}{"count": "3", "titles": []string{"a"}}, entities.ErrInvalidTemplateVariables},
+		{"list of numbers", "notification.digest", map[string]interface{}{"count": 1, "titles": []interface{}{1}}, entities.ErrInvalidTemplateVariables},
+		{"time that is not RFC 3339", "meeting.cancelled", map[string]interface{}{"meeting_id": "m-1", "meeting_title": "Planning", "start_time": "tomorrow"}, entities.ErrInvalidTemplateVariables},
+		{"nil time pointer", "meeting.cancelled", map[string]interface{}{"meeting_id": "m-1", "meeting_title": "Planning", "start_time": (*time.Time)(nil)}, entities.ErrInvalidTemplateVariables},
+		{"valid", "notification.digest", map[string]interface{}{"count": 2, "titles": []interface{}{"a", "b"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := registry.Render(tt.key, recipient, tt.variables); !errors.Is(err, tt.want) {
+				t.Fatalf("Render: err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistryRenderNormalizesVariables(t *testing.T) {
+	registry := newTestRegistry(t)
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	start := time.Date(2025, time.January, 2, 20, 34, 0, 0, kolkata)
+
+	rendered, err := registry.Render("meeting.cancelled", &entities.Recipient{}, map[string]interface{}{
+		"meeting_id":    "m-1",
+		"meeting_title": "Planning",
+		"start_time":    &start,
+	})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	// Variables are stored with the notification, so times are kept as
+	// UTC strings
+	want := map[string]interface{}{"meeting_id": "m-1", "meeting_title": "Planning", "start_time": "2025-01-02T15:04:00Z"}
+	if !reflect.DeepEqual(rendered.Variables, want) {
+		t.Fatalf("variables = %v, want %v", rendered.Variables, want)
+	}
+}
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	crmevents "github.com/manab-pr/evtaarpro/modules/crm/domain/events"
+	meetingevents "github.com/manab-pr/evtaarpro/modules/meetings/domain/events"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/usecases"
+	payrollevents "github.com/manab-pr/evtaarpro/modules/payroll/domain/events"
+)
+
+// subscriber names the notifications module in the record of handled
+// events
+const subscriber = "notifications"
+
+// Subscribers turns the domain events of other modules into notifications.
+// Events may arrive more than once; every notification is dispatched under
+// the event ID as key, so a redelivered event notifies nobody twice.
+type Subscribers struct {
+	dispatchUC    *usecases.DispatchNotificationUseCase
+	recipientRepo ports.RecipientRepository
+}
+
+// NewSubscribers creates new Subscribers
+func NewSubscribers(dispatchUC *usecases.DispatchNotificationUseCase, recipientRepo ports.RecipientRepository) *Subscribers {
+	return &Subscribers{
+		dispatchUC:    dispatchUC,
+		recipientRepo: recipientRepo,
+	}
+}
+
+// Register subscribes the handlers to the events they handle
+func (s *Subscribers) Register(bus *eventbus.Bus) {
+	bus.Subscribe(meetingevents.MeetingCancelledEvent, subscriber, s.meetingCancelled)
+	bus.Subscribe(payrollevents.PayrollApprovedEvent, subscriber, s.payrollApproved)
+	bus.Subscribe(crmevents.CustomerAssignedEvent, subscriber, s.customerAssigned)
+}
+
+// meetingCancelled notifies everyone in the meeting except whoever
+// cancelled it
+func (s *Subscribers) meetingCancelled(ctx context.Context, envelope *eventbus.Envelope) error {
+	var event meetingevents.MeetingCancelled
+	if err := envelope.Decode(&event); err != nil {
+		return err
+	}
+
+	for _, userID := range event.ParticipantIDs {
+		if userID == event.CancelledBy {
+			continue
+		}
+		err := s.dispatch(ctx, envelope, userID, "meeting.cancelled", map[string]interface{}{
+			"meeting_id":    event.MeetingID,
+			"meeting_title": event.Title,
+			"start_time":    event.StartTime,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// payrollApproved notifies the employee, if they have a user account
+func (s *Subscribers) payrollApproved(ctx context.Context, envelope *eventbus.Envelope) error {
+	var event payrollevents.PayrollApproved
+	if err := envelope.Decode(&event); err != nil {
+		return err
+	}
+	if event.EmployeeUserID == "" {
+		return nil
+	}
+
+	return s.dispatch(ctx, envelope, event.EmployeeUserID, "payroll.approved", map[string]interface{}{
+		"payroll_id": event.PayrollID,
+		"period":     fmt.Sprintf("%d-%02d", event.Year, event.Month),
+		"net_salary": fmt.Sprintf("%.2f", event.NetSalary),
+	})
+}
+
+// customerAssigned notifies the assignee, unless they assigned the customer
+// to themselves
+func (s *Subscribers) customerAssigned(ctx context.Context, envelope *eventbus.Envelope) error {
+	var event crmevents.CustomerAssigned
+	if err := envelope.Decode(&event); err != nil {
+		return err
+	}
+	if event.AssignedTo == event.AssignedBy {
+		return nil
+	}
+
+	variables := map[string]interface{}{
+		"customer_id":   event.CustomerID,
+		"customer_name": event.CustomerName,
+	}
+	if assigner, err := s.recipientRepo.GetRecipient(ctx, event.AssignedBy); err == nil && assigner.Name != "" {
+		variables["assigned_by"] = assigner.Name
+	}
+
+	return s.dispatch(ctx, envelope, event.AssignedTo, "crm.customer_assigned", variables)
+}
+
+// dispatch sends a templated notification keyed by the event. Users that
+// no longer exist are skipped rather than retried.
+func (s *Subscribers) dispatch(ctx context.Context, envelope *eventbus.Envelope, userID, template string, variables map[string]interface{}) error {
+	_, err := s.dispatchUC.Execute(ctx, usecases.DispatchInput{
+		UserID:    userID,
+		Key:       envelope.ID,
+		Template:  template,
+		Variables: variables,
+	})
+	if errors.Is(err, entities.ErrRecipientNotFound) {
+		logging.FromContext(ctx).Info("skipping notification for unknown user", "template", template, "event_id", envelope.ID, "user_id", userID)
+		return nil
+	}
+	return err
+}
@@ -1,25 +1,56 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+// NotificationContent is the content of a notification to send. With a
+// template, type, title and message come from the template.
+type NotificationContent struct {
+	Template  string                 `json:"template"`
+	Variables map[string]interface{} `json:"variables"`
+	Priority  string                 `json:"priority"` // low, medium, high or urgent; defaults to the template's priority or medium
+	Type      string                 `json:"type" binding:"required_without=Template"`
+	Title     string                 `json:"title" binding:"required_without=Template"`
+	Message   string                 `json:"message" binding:"required_without=Template"`
+	Data      json.RawMessage        `json:"data" swaggertype:"object"` // JSON object
+	ExpiresAt *time.Time             `json:"expires_at"`                // defaults to notifications.default_ttl from now
+}
 
 // CreateNotificationRequest represents a notification creation request
 type CreateNotificationRequest struct {
-	UserID  string `json:"user_id" binding:"required"`
-	Type    string `json:"type" binding:"required"`
-	Title   string `json:"title" binding:"required"`
-	Message string `json:"message" binding:"required"`
-	Data    string `json:"data"`
+	UserID string `json:"user_id" binding:"required"`
+	NotificationContent
+}
+
+// BroadcastNotificationRequest represents a notification sent to every
+// active user with one of the roles and in one of the departments
+type BroadcastNotificationRequest struct {
+	Roles       []string `json:"roles"`
+	Departments []string `json:"departments"`
+	NotificationContent
+}
+
+// BroadcastNotificationResponse represents the outcome of a broadcast
+type BroadcastNotificationResponse struct {
+	Recipients int `json:"recipients"`
+	Failed     int `json:"failed"`
 }
 
 // NotificationResponse represents a notification response
 type NotificationResponse struct {
-	ID        string     `json:"id"`
-	UserID    string     `json:"user_id"`
-	Type      string     `json:"type"`
-	Title     string     `json:"title"`
-	Message   string     `json:"message"`
-	Data      string     `json:"data"`
-	Read      bool       `json:"read"`
-	CreatedAt time.Time  `json:"created_at"`
-	ReadAt    *time.Time `json:"read_at,omitempty"`
+	ID        string          `json:"id"`
+	UserID    string          `json:"user_id"`
+	Type      string          `json:"type"`
+	Priority  string          `json:"priority"`
+	Template  string          `json:"template,omitempty"`
+	Locale    string          `json:"locale,omitempty"`
+	Title     string          `json:"title"`
+	Message   string          `json:"message"`
+	Data      json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	Read      bool            `json:"read"`
+	CreatedAt time.Time       `json:"created_at"`
+	ReadAt    *time.Time      `json:"read_at,omitempty"`
+	ExpiresAt *time.Time      `json:"expires_at,omitempty"`
 }
@@ -0,0 +1,50 @@
+package dto
+
+import "time"
+
+// PreferenceDTO enables or disables a channel for a notification type;
+// type "*" applies to every type
+type PreferenceDTO struct {
+	Type    string `json:"type" binding:"required"`
+	Channel string `json:"channel" binding:"required"`
+	Enabled bool   `json:"enabled"`
+}
+
+// QuietHoursDTO is a daily window in the user's timezone, HH:MM
+type QuietHoursDTO struct {
+	Start string `json:"start" binding:"required"`
+	End   string `json:"end" binding:"required"`
+}
+
+// UpdatePreferencesRequest represents a notification settings update.
+// Preferences are merged into the existing ones.
+type UpdatePreferencesRequest struct {
+	Preferences     []PreferenceDTO `json:"preferences" binding:"omitempty,dive"`
+	QuietHours      *QuietHoursDTO  `json:"quiet_hours"`
+	ClearQuietHours bool            `json:"clear_quiet_hours"`
+	WebhookURL      *string         `json:"webhook_url"`
+	Digest          *string         `json:"digest"` // immediate, hourly or daily
+}
+
+// PreferencesResponse represents a user's notification settings
+type PreferencesResponse struct {
+	Channels        []string        `json:"channels"`
+	DefaultChannels []string        `json:"default_channels"`
+	Preferences     []PreferenceDTO `json:"preferences"`
+	QuietHours      *QuietHoursDTO  `json:"quiet_hours,omitempty"`
+	WebhookURL      string          `json:"webhook_url,omitempty"`
+	Digest          string          `json:"digest"`
+}
+
+// DeliveryResponse represents the delivery of a notification over a channel
+type DeliveryResponse struct {
+	Channel       string     `json:"channel"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	Batched       bool       `json:"batched"`             // waits for the next digest
+	DigestID      *string    `json:"digest_id,omitempty"` // digest notification it was sent in
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -1,30 +1,49 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/manab-pr/evtaarpro/internal/response"
 	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
 	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/usecases"
 	"github.com/manab-pr/evtaarpro/modules/notifications/presentation/http/dto"
 )
 
 // NotificationHandlers contains notification-related HTTP handlers
 type NotificationHandlers struct {
 	notificationRepo ports.NotificationRepository
+	dispatchUC       *usecases.DispatchNotificationUseCase
+	broadcastUC      *usecases.BroadcastNotificationUseCase
 }
 
 // NewNotificationHandlers creates new NotificationHandlers
-func NewNotificationHandlers(notificationRepo ports.NotificationRepository) *NotificationHandlers {
+func NewNotificationHandlers(
+	notificationRepo ports.NotificationRepository,
+	dispatchUC *usecases.DispatchNotificationUseCase,
+	broadcastUC *usecases.BroadcastNotificationUseCase,
+) *NotificationHandlers {
 	return &NotificationHandlers{
 		notificationRepo: notificationRepo,
+		dispatchUC:       dispatchUC,
+		broadcastUC:      broadcastUC,
 	}
 }
 
-// CreateNotification creates a new notification
+// CreateNotification creates a notification for a user
+// @Summary Create a notification
+// @Description Send a notification to a user, from a template or free-form. Restricted to admins; other notifications come from domain events.
+// @Tags notifications
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateNotificationRequest true "Notification"
+// @Success 201 {object} response.Response{data=dto.NotificationResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /notifications [post]
 func (h *NotificationHandlers) CreateNotification(c *gin.Context) {
 	var req dto.CreateNotificationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -32,25 +51,53 @@ func (h *NotificationHandlers) CreateNotification(c *gin.Context) {
 		return
 	}
 
-	notification := &entities.Notification{
-		ID:        uuid.New().String(),
-		UserID:    req.UserID,
-		Type:      req.Type,
-		Title:     req.Title,
-		Message:   req.Message,
-		Data:      req.Data,
-		Read:      false,
-		CreatedAt: time.Now(),
-	}
+	input := mapContentToInput(req.NotificationContent)
+	input.UserID = req.UserID
 
-	if err := h.notificationRepo.Create(c.Request.Context(), notification); err != nil {
-		response.InternalServerError(c, "Failed to create notification")
+	notification, err := h.dispatchUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		handleNotificationError(c, err)
 		return
 	}
 
 	response.Created(c, "Notification created successfully", mapNotificationToResponse(notification))
 }
 
+// BroadcastNotification sends a notification to roles and departments
+// @Summary Broadcast a notification
+// @Description Send a notification to every active user with one of the roles and in one of the departments. Each user receives it in their language and according to their preferences.
+// @Tags notifications
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.BroadcastNotificationRequest true "Audience and notification"
+// @Success 201 {object} response.Response{data=dto.BroadcastNotificationResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /notifications/broadcast [post]
+func (h *NotificationHandlers) BroadcastNotification(c *gin.Context) {
+	var req dto.BroadcastNotificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	output, err := h.broadcastUC.Execute(c.Request.Context(), usecases.BroadcastInput{
+		Roles:        req.Roles,
+		Departments:  req.Departments,
+		Notification: mapContentToInput(req.NotificationContent),
+	})
+	if err != nil {
+		handleNotificationError(c, err)
+		return
+	}
+
+	response.Created(c, "Notification broadcast successfully", dto.BroadcastNotificationResponse{
+		Recipients: output.Recipients,
+		Failed:     output.Failed,
+	})
+}
+
 // ListNotifications lists notifications for the logged-in user
 func (h *NotificationHandlers) ListNotifications(c *gin.Context) {
 	userID, _ := c.Get("user_id")
@@ -125,11 +172,44 @@ func mapNotificationToResponse(notification *entities.Notification) dto.Notifica
 		ID:        notification.ID,
 		UserID:    notification.UserID,
 		Type:      notification.Type,
+		Priority:  notification.Priority,
+		Template:  notification.Template,
+		Locale:    notification.Locale,
 		Title:     notification.Title,
 		Message:   notification.Message,
 		Data:      notification.Data,
+		ExpiresAt: notification.ExpiresAt,
 		Read:      notification.Read,
 		CreatedAt: notification.CreatedAt,
 		ReadAt:    notification.ReadAt,
 	}
 }
+
+func mapContentToInput(content dto.NotificationContent) usecases.DispatchInput {
+	return usecases.DispatchInput{
+		Template:  content.Template,
+		Variables: content.Variables,
+		Priority:  content.Priority,
+		Type:      content.Type,
+		Title:     content.Title,
+		Message:   content.Message,
+		Data:      content.Data,
+		ExpiresAt: content.ExpiresAt,
+	}
+}
+
+func handleNotificationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entities.ErrRecipientNotFound),
+		errors.Is(err, entities.ErrEmptyAudience),
+		errors.Is(err, entities.ErrUnknownTemplate),
+		errors.Is(err, entities.ErrInvalidTemplateVariables),
+		errors.Is(err, entities.ErrInvalidPriority),
+		errors.Is(err, entities.ErrInvalidType),
+		errors.Is(err, entities.ErrInvalidData),
+		errors.Is(err, entities.ErrInvalidExpiry):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalServerError(c, "Failed to create notification")
+	}
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/notifications/presentation/http/dto"
+)
+
+// PreferenceHandlers contains notification delivery HTTP handlers
+type PreferenceHandlers struct {
+	getPreferencesUC    *usecases.GetPreferencesUseCase
+	updatePreferencesUC *usecases.UpdatePreferencesUseCase
+	listDeliveriesUC    *usecases.ListDeliveriesUseCase
+	channels            []string
+	defaultChannels     []string
+}
+
+// NewPreferenceHandlers creates new PreferenceHandlers. channels lists
+// every configured channel, including in-app.
+func NewPreferenceHandlers(
+	getPreferencesUC *usecases.GetPreferencesUseCase,
+	updatePreferencesUC *usecases.UpdatePreferencesUseCase,
+	listDeliveriesUC *usecases.ListDeliveriesUseCase,
+	channels []string,
+	defaultChannels []string,
+) *PreferenceHandlers {
+	return &PreferenceHandlers{
+		getPreferencesUC:    getPreferencesUC,
+		updatePreferencesUC: updatePreferencesUC,
+		listDeliveriesUC:    listDeliveriesUC,
+		channels:            channels,
+		defaultChannels:     defaultChannels,
+	}
+}
+
+// GetPreferences returns the user's notification settings
+// @Summary Get notification preferences
+// @Description Get the channels enabled per notification type, quiet hours, webhook URL and digest frequency. Types without a preference use the default channels.
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.Response{data=dto.PreferencesResponse}
+// @Router /notifications/preferences [get]
+func (h *PreferenceHandlers) GetPreferences(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	settings, err := h.getPreferencesUC.Execute(c.Request.Context(), userID.(string))
+	if err != nil {
+		handlePreferenceError(c, err)
+		return
+	}
+
+	response.OK(c, "Notification preferences retrieved successfully", h.mapSettingsToResponse(settings))
+}
+
+// UpdatePreferences changes the user's notification settings
+// @Summary Update notification preferences
+// @Description Enable or disable channels per notification type ("*" for every type), set or clear quiet hours (in the user's timezone), set the webhook URL and the digest frequency. Only in-app notifications are delivered during quiet hours; other channels follow when they end. With hourly or daily digests, low and medium priority notifications are grouped into one digest.
+// @Tags notifications
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdatePreferencesRequest true "Changes"
+// @Success 200 {object} response.Response{data=dto.PreferencesResponse}
+// @Failure 400 {object} response.Response
+// @Router /notifications/preferences [put]
+func (h *PreferenceHandlers) UpdatePreferences(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.UpdatePreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	input := usecases.UpdatePreferencesInput{
+		Preferences:     make([]entities.Preference, len(req.Preferences)),
+		ClearQuietHours: req.ClearQuietHours,
+		WebhookURL:      req.WebhookURL,
+		Digest:          req.Digest,
+	}
+	for i, preference := range req.Preferences {
+		input.Preferences[i] = entities.Preference{Type: preference.Type, Channel: preference.Channel, Enabled: preference.Enabled}
+	}
+	if req.QuietHours != nil {
+		input.QuietHours = &entities.QuietHours{Start: req.QuietHours.Start, End: req.QuietHours.End}
+	}
+
+	settings, err := h.updatePreferencesUC.Execute(c.Request.Context(), userID.(string), input)
+	if err != nil {
+		handlePreferenceError(c, err)
+		return
+	}
+
+	response.OK(c, "Notification preferences updated successfully", h.mapSettingsToResponse(settings))
+}
+
+// ListDeliveries returns the delivery status of a notification per channel
+// @Summary List notification deliveries
+// @Description Get the delivery status of one of the user's notifications per channel
+// @Tags notifications
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} response.Response{data=[]dto.DeliveryResponse}
+// @Failure 404 {object} response.Response
+// @Router /notifications/{id}/deliveries [get]
+func (h *PreferenceHandlers) ListDeliveries(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	deliveries, err := h.listDeliveriesUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		handlePreferenceError(c, err)
+		return
+	}
+
+	deliveryResponses := make([]dto.DeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		deliveryResponses[i] = dto.DeliveryResponse{
+			Channel:       delivery.Channel,
+			Status:        delivery.Status,
+			Attempts:      delivery.Attempts,
+			Batched:       delivery.Batched,
+			DigestID:      delivery.DigestID,
+			NextAttemptAt: delivery.NextAttemptAt,
+			LastError:     delivery.LastError,
+			DeliveredAt:   delivery.DeliveredAt,
+			UpdatedAt:     delivery.UpdatedAt,
+		}
+	}
+
+	response.OK(c, "Notification deliveries retrieved successfully", deliveryResponses)
+}
+
+func handlePreferenceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entities.ErrNotificationNotFound):
+		response.NotFound(c, err.Error())
+	case errors.Is(err, entities.ErrInvalidPreference),
+		errors.Is(err, entities.ErrInvalidQuietHours),
+		errors.Is(err, entities.ErrInvalidWebhookURL),
+		errors.Is(err, entities.ErrInvalidDigest):
+		response.BadRequest(c, err.Error())
+	default:
+		response.InternalServerError(c, "Failed to process notification preferences")
+	}
+}
+
+func (h *PreferenceHandlers) mapSettingsToResponse(settings *entities.Settings) dto.PreferencesResponse {
+	preferences := make([]dto.PreferenceDTO, len(settings.Preferences))
+	for i, preference := range settings.Preferences {
+		preferences[i] = dto.PreferenceDTO{Type: preference.Type, Channel: preference.Channel, Enabled: preference.Enabled}
+	}
+
+	resp := dto.PreferencesResponse{
+		Channels:        h.channels,
+		DefaultChannels: h.defaultChannels,
+		Preferences:     preferences,
+		WebhookURL:      settings.WebhookURL,
+		Digest:          settings.Digest,
+	}
+	if settings.QuietHours != nil {
+		resp.QuietHours = &dto.QuietHoursDTO{Start: settings.QuietHours.Start, End: settings.QuietHours.End}
+	}
+	return resp
+}
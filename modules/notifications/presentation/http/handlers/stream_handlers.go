@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/internal/realtime"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+)
+
+const (
+	// streamBacklogPageSize is how many missed notifications are loaded at
+	// a time when a stream resumes
+	streamBacklogPageSize = 100
+	// streamRetry is the reconnect delay suggested to clients
+	streamRetry = 3 * time.Second
+)
+
+// StreamHandlers serves the notification event stream
+type StreamHandlers struct {
+	notificationRepo ports.NotificationRepository
+	gateway          *realtime.Gateway
+	keepAlive        time.Duration
+}
+
+// NewStreamHandlers creates new StreamHandlers
+func NewStreamHandlers(notificationRepo ports.NotificationRepository, gateway *realtime.Gateway, keepAlive time.Duration) *StreamHandlers {
+	if keepAlive <= 0 {
+		keepAlive = 30 * time.Second
+	}
+
+	return &StreamHandlers{
+		notificationRepo: notificationRepo,
+		gateway:          gateway,
+		keepAlive:        keepAlive,
+	}
+}
+
+// StreamNotifications streams the user's notifications as Server-Sent Events
+// @Summary Notification stream
+// @Description Stream new notifications ("notification" events) and unread count changes ("unread_count" events) as Server-Sent Events. A reconnecting client sends Last-Event-ID and first receives every notification it missed.
+// @Tags notifications
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "ID of the last notification event received"
+// @Param last_event_id query string false "Same as Last-Event-ID, for clients that cannot set headers"
+// @Param access_token query string false "Access token for clients that cannot set headers"
+// @Success 200
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /notifications/stream [get]
+func (h *StreamHandlers) StreamNotifications(c *gin.Context) {
+	userID := c.GetString("user_id")
+	ctx := c.Request.Context()
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	var resumeFrom *entities.StreamPosition
+	if lastEventID != "" {
+		position, err := entities.ParseEventID(lastEventID)
+		if err != nil {
+			response.BadRequest(c, err.Error())
+			return
+		}
+		resumeFrom = &position
+	}
+
+	// Subscribe before loading the backlog so nothing created in between is
+	// lost; notifications seen in both are sent once
+	subscription := h.gateway.Subscribe(userID)
+	defer subscription.Close()
+
+	// Streams outlive the server write timeout
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		logging.FromContext(ctx).Warn("failed to clear write deadline of notification stream", "error", err)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+
+	sent := make(map[string]bool)
+	if resumeFrom != nil {
+		position := *resumeFrom
+		for {
+			notifications, err := h.notificationRepo.ListAfter(ctx, userID, position, streamBacklogPageSize)
+			if err != nil {
+				// The client reconnects and resumes from the last event it got
+				logging.FromContext(ctx).Warn("failed to load missed notifications", "error", err)
+				return
+			}
+
+			for _, notification := range notifications {
+				data, err := json.Marshal(mapNotificationToResponse(notification))
+				if err != nil {
+					continue
+				}
+				position = notification.Position()
+				writeStreamEvent(c.Writer, position.EventID(), "notification", data)
+				sent[notification.ID] = true
+			}
+
+			if len(notifications) < streamBacklogPageSize {
+				break
+			}
+		}
+	}
+
+	count, err := h.notificationRepo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to count unread notifications", "error", err)
+		return
+	}
+	data, _ := json.Marshal(gin.H{"count": count})
+	writeStreamEvent(c.Writer, "", "unread_count", data)
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(h.keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-subscription.Done():
+			return
+
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+			c.Writer.Flush()
+
+		case message := <-subscription.Events():
+			event, err := realtime.DecodeEvent(message)
+			if err != nil {
+				continue
+			}
+
+			switch event.Type {
+			case realtime.EventNotificationCreated:
+				var notification entities.Notification
+				if err := json.Unmarshal(event.Data, &notification); err != nil || sent[notification.ID] {
+					continue
+				}
+				data, err := json.Marshal(mapNotificationToResponse(&notification))
+				if err != nil {
+					continue
+				}
+				writeStreamEvent(c.Writer, notification.Position().EventID(), "notification", data)
+			case realtime.EventUnreadCountChanged:
+				writeStreamEvent(c.Writer, "", "unread_count", event.Data)
+			default:
+				continue
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// writeStreamEvent writes a Server-Sent Event. Events without an ID leave
+// the client's last event ID unchanged.
+func writeStreamEvent(w gin.ResponseWriter, id, event string, data []byte) {
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/realtime"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/notifications/domain/ports"
+	"github.com/redis/go-redis/v9"
+)
+
+// streamRepo serves a fixed, ordered list of notifications
+type streamRepo struct {
+	ports.NotificationRepository
+
+	notifications []*entities.Notification
+	unread        int
+	pages         int
+}
+
+func (r *streamRepo) ListAfter(ctx context.Context, userID string, after entities.StreamPosition, limit int) ([]*entities.Notification, error) {
+	r.pages++
+	page := make([]*entities.Notification, 0, limit)
+	for _, notification := range r.notifications {
+		position := notification.Position()
+		if position.CreatedAt.Before(after.CreatedAt) || (position.CreatedAt.Equal(after.CreatedAt) && position.ID <= after.ID) {
+			continue
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, notification)
+	}
+	return page, nil
+}
+
+func (r *streamRepo) GetUnreadCount(ctx context.Context, userID string) (int, error) {
+	return r.unread, nil
+}
+
+// streamEvent is a Server-Sent Event read from the stream
+type streamEvent struct {
+	id, event, data string
+}
+
+func TestStreamNotificationsResumes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	start := time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)
+
+	repo := &streamRepo{unread: 7}
+	for i := 0; i < streamBacklogPageSize+20; i++ {
+		repo.notifications = append(repo.notifications, &entities.Notification{
+			ID:        fmt.Sprintf("n-%03d", i),
+			UserID:    "alice",
+			Title:     "Reminder",
+			CreatedAt: start.Add(time.Duration(i) * time.Second),
+		})
+	}
+	last := repo.notifications[len(repo.notifications)-1].Position().EventID()
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		query       bool
+		wantStatus  int
+		wantFirst   string
+		wantCount   int
+		wantPages   int
+	}{
+		{"fresh stream", "", false, http.StatusOK, "", 0, 0},
+		{"resume", repo.notifications[9].Position().EventID(), false, http.StatusOK, "n-010", streamBacklogPageSize + 10, 2},
+		{"resume from query", repo.notifications[109].Position().EventID(), true, http.StatusOK, "n-110", 10, 1},
+		{"up to date", last, false, http.StatusOK, "", 0, 1},
+		{"bad event ID", "yesterday", false, http.StatusBadRequest, "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.pages = 0
+			live := config.NewLive(&config.Config{})
+			store := &datastore.RedisStore{Client: redis.NewClient(&redis.Options{Addr: "redis.invalid:6379"})}
+			h := NewStreamHandlers(repo, realtime.NewGateway(live, store), time.Hour)
+
+			router := gin.New()
+			router.GET("/notifications/stream", func(c *gin.Context) {
+				c.Set("user_id", "alice")
+				h.StreamNotifications(c)
+			})
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			url := server.URL + "/notifications/stream"
+			if tt.query {
+				url += "?last_event_id=" + tt.lastEventID
+			}
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if tt.lastEventID != "" && !tt.query {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+			if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+				t.Fatalf("content type = %q", got)
+			}
+
+			// The stream stays open; read up to the unread count that
+			// follows the backlog
+			var events []streamEvent
+			var current streamEvent
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				line := scanner.Text()
+				if line == "" {
+					if current.event != "" {
+						events = append(events, current)
+					}
+					if current.event == "unread_count" {
+						break
+					}
+					current = streamEvent{}
+					continue
+				}
+				field, value, _ := strings.Cut(line, ": ")
+				switch field {
+				case "id":
+					current.id = value
+				case "event":
+					current.event = value
+				case "data":
+					current.data = value
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				t.Fatalf("read stream: %v", err)
+			}
+
+			if len(events) != tt.wantCount+1 {
+				t.Fatalf("got %d events, want %d notifications and the unread count", len(events), tt.wantCount)
+			}
+			if unread := events[len(events)-1]; unread.id != "" || unread.data != `{"count":7}` {
+				t.Fatalf("unread count event = %+v", unread)
+			}
+			if tt.wantCount > 0 {
+				first := events[0]
+				if first.event != "notification" || !strings.Contains(first.data, `"id":"`+tt.wantFirst+`"`) {
+					t.Fatalf("first event = %+v, want notification %s", first, tt.wantFirst)
+				}
+				if got := events[len(events)-2].id; got != last {
+					t.Fatalf("last notification event ID = %s, want %s", got, last)
+				}
+			}
+			if repo.pages != tt.wantPages {
+				t.Fatalf("loaded %d backlog pages, want %d", repo.pages, tt.wantPages)
+			}
+		})
+	}
+}
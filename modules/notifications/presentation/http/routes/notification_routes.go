@@ -7,16 +7,27 @@ import (
 )
 
 // RegisterRoutes registers notification routes
-func RegisterRoutes(rg *gin.RouterGroup, notificationHandlers *handlers.NotificationHandlers, jwtSecret string) {
+func RegisterRoutes(rg *gin.RouterGroup, notificationHandlers *handlers.NotificationHandlers, streamHandlers *handlers.StreamHandlers, preferenceHandlers *handlers.PreferenceHandlers, jwtSecret string, broadcastRoles []string) {
+	// EventSource cannot set headers, so the stream also accepts the token
+	// as a query parameter
+	rg.GET("/notifications/stream", middleware.StreamAuth(jwtSecret), streamHandlers.StreamNotifications)
+
 	notifications := rg.Group("/notifications")
 	notifications.Use(middleware.AuthMiddleware(jwtSecret))
 	{
-		// Notification routes
-		notifications.POST("", notificationHandlers.CreateNotification)
+		// Notification routes. Users only receive notifications; they are
+		// created by domain events, admins and broadcasts
+		notifications.POST("", middleware.RequireRole("admin"), notificationHandlers.CreateNotification)
+		notifications.POST("/broadcast", middleware.RequireRole(broadcastRoles...), notificationHandlers.BroadcastNotification)
 		notifications.GET("", notificationHandlers.ListNotifications)
 		notifications.GET("/unread-count", notificationHandlers.GetUnreadCount)
 		notifications.PUT("/:id/read", notificationHandlers.MarkAsRead)
 		notifications.POST("/read-all", notificationHandlers.MarkAllAsRead)
 		notifications.DELETE("/:id", notificationHandlers.DeleteNotification)
+
+		// Delivery routes
+		notifications.GET("/preferences", preferenceHandlers.GetPreferences)
+		notifications.PUT("/preferences", preferenceHandlers.UpdatePreferences)
+		notifications.GET("/:id/deliveries", preferenceHandlers.ListDeliveries)
 	}
 }
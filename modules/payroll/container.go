@@ -2,8 +2,13 @@ package payroll
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/audit"
 	"github.com/manab-pr/evtaarpro/internal/config"
 	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/internal/idempotency"
+	"github.com/manab-pr/evtaarpro/internal/middleware"
+	"github.com/manab-pr/evtaarpro/modules/payroll/domain/usecases"
 	"github.com/manab-pr/evtaarpro/modules/payroll/infra/postgresql"
 	"github.com/manab-pr/evtaarpro/modules/payroll/presentation/http/handlers"
 	"github.com/manab-pr/evtaarpro/modules/payroll/presentation/http/routes"
@@ -13,10 +18,15 @@ import (
 func RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
 	// Infrastructure
 	payrollRepo := postgresql.NewPayrollRepository(pgStore.DB)
+	auditLog := audit.NewLog(pgStore.DB)
+
+	// Use cases
+	approvePayrollUC := usecases.NewApprovePayrollUseCase(payrollRepo, pgStore, eventbus.NewOutbox(), auditLog)
+	updateSalaryUC := usecases.NewUpdateSalaryUseCase(payrollRepo, pgStore, auditLog)
 
 	// Handlers
-	payrollHandlers := handlers.NewPayrollHandlers(payrollRepo)
+	payrollHandlers := handlers.NewPayrollHandlers(payrollRepo, approvePayrollUC, updateSalaryUC)
 
 	// Register routes
-	routes.RegisterRoutes(rg, payrollHandlers, cfg.JWT.Secret)
+	routes.RegisterRoutes(rg, payrollHandlers, cfg.JWT.Secret, middleware.Idempotency(idempotency.NewStore(redisStore)))
 }
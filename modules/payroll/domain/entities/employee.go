@@ -1,6 +1,24 @@
 package entities
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmployeeNotFound      = errors.New("employee not found")
+	ErrInvalidSalary         = errors.New("salary must be positive")
+	ErrPayrollRecordNotFound = errors.New("payroll record not found")
+	ErrPayrollNotPending     = errors.New("only pending payroll records can be approved")
+)
+
+// Payment statuses of payroll records
+const (
+	PaymentPending  = "pending"
+	PaymentApproved = "approved"
+	PaymentPaid     = "paid"
+	PaymentFailed   = "failed"
+)
 
 // Employee represents an employee entity
 type Employee struct {
@@ -16,6 +34,17 @@ type Employee struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// SetSalary changes the monthly salary of the employee
+func (e *Employee) SetSalary(amount float64, now time.Time) error {
+	if amount <= 0 {
+		return ErrInvalidSalary
+	}
+
+	e.SalaryAmount = amount
+	e.UpdatedAt = now
+	return nil
+}
+
 // Attendance represents attendance record
 type Attendance struct {
 	ID          string     `json:"id"`
@@ -39,9 +68,20 @@ type PayrollRecord struct {
 	Allowances    float64    `json:"allowances"`
 	Deductions    float64    `json:"deductions"`
 	NetSalary     float64    `json:"net_salary"`
-	PaymentStatus string     `json:"payment_status"` // pending, paid, failed
+	PaymentStatus string     `json:"payment_status"` // pending, approved, paid, failed
 	PaymentDate   *time.Time `json:"payment_date,omitempty"`
 	Notes         string     `json:"notes"`
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
 }
+
+// Approve approves a pending payroll record for payment
+func (r *PayrollRecord) Approve(now time.Time) error {
+	if r.PaymentStatus != PaymentPending {
+		return ErrPayrollNotPending
+	}
+
+	r.PaymentStatus = PaymentApproved
+	r.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,24 @@
+package events
+
+import "time"
+
+// PayrollApprovedEvent is the name of PayrollApproved events
+const PayrollApprovedEvent = "payroll.payroll_approved"
+
+// PayrollApproved is published when a payroll record is approved for
+// payment
+type PayrollApproved struct {
+	PayrollID      string    `json:"payroll_id"`
+	EmployeeID     string    `json:"employee_id"`
+	EmployeeUserID string    `json:"employee_user_id,omitempty"` // empty for employees without a user account
+	Month          int       `json:"month"`
+	Year           int       `json:"year"`
+	NetSalary      float64   `json:"net_salary"`
+	ApprovedBy     string    `json:"approved_by"`
+	ApprovedAt     time.Time `json:"approved_at"`
+}
+
+// EventName implements eventbus.Event
+func (PayrollApproved) EventName() string {
+	return PayrollApprovedEvent
+}
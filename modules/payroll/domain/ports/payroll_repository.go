@@ -10,6 +10,9 @@ type PayrollRepository interface {
 	// Employee operations
 	CreateEmployee(ctx context.Context, employee *entities.Employee) error
 	GetEmployee(ctx context.Context, id string) (*entities.Employee, error)
+	// LockEmployee retrieves an employee and locks it until the transaction
+	// of ctx ends
+	LockEmployee(ctx context.Context, id string) (*entities.Employee, error)
 	ListEmployees(ctx context.Context, limit, offset int) ([]*entities.Employee, int, error)
 	UpdateEmployee(ctx context.Context, employee *entities.Employee) error
 
@@ -20,6 +23,9 @@ type PayrollRepository interface {
 	// Payroll operations
 	CreatePayrollRecord(ctx context.Context, record *entities.PayrollRecord) error
 	GetPayrollRecord(ctx context.Context, id string) (*entities.PayrollRecord, error)
+	// LockPayrollRecord retrieves a payroll record and locks it until the
+	// transaction of ctx ends
+	LockPayrollRecord(ctx context.Context, id string) (*entities.PayrollRecord, error)
 	ListPayrollRecords(ctx context.Context, limit, offset int) ([]*entities.PayrollRecord, int, error)
 	UpdatePayrollRecord(ctx context.Context, record *entities.PayrollRecord) error
 }
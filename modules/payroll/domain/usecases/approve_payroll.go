@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/audit"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/modules/payroll/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/payroll/domain/events"
+	"github.com/manab-pr/evtaarpro/modules/payroll/domain/ports"
+)
+
+// ApprovePayrollUseCase handles approving payroll records for payment
+type ApprovePayrollUseCase struct {
+	payrollRepo ports.PayrollRepository
+	transactor  datastore.Transactor
+	outbox      eventbus.Publisher
+	auditLog    audit.Recorder
+}
+
+// NewApprovePayrollUseCase creates a new ApprovePayrollUseCase
+func NewApprovePayrollUseCase(
+	payrollRepo ports.PayrollRepository,
+	transactor datastore.Transactor,
+	outbox eventbus.Publisher,
+	auditLog audit.Recorder,
+) *ApprovePayrollUseCase {
+	return &ApprovePayrollUseCase{
+		payrollRepo: payrollRepo,
+		transactor:  transactor,
+		outbox:      outbox,
+		auditLog:    auditLog,
+	}
+}
+
+// Execute approves a pending payroll record. The approval and its
+// PayrollApproved event and audit entry are stored together; the record
+// stays locked in between so it cannot be approved twice.
+func (uc *ApprovePayrollUseCase) Execute(ctx context.Context, recordID, approvedBy string) (*entities.PayrollRecord, error) {
+	var record *entities.PayrollRecord
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		record, err = uc.payrollRepo.LockPayrollRecord(ctx, recordID)
+		if err != nil {
+			return err
+		}
+		previousStatus := record.PaymentStatus
+		if err := record.Approve(time.Now()); err != nil {
+			return err
+		}
+
+		employee, err := uc.payrollRepo.GetEmployee(ctx, record.EmployeeID)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.payrollRepo.UpdatePayrollRecord(ctx, record); err != nil {
+			return err
+		}
+
+		err = uc.auditLog.Record(ctx, audit.Record{
+			ActorID:      approvedBy,
+			Action:       "payroll.approve",
+			ResourceType: "payroll_record",
+			ResourceID:   record.ID,
+			Before:       map[string]interface{}{"payment_status": previousStatus},
+			After: map[string]interface{}{
+				"payment_status": record.PaymentStatus,
+				"net_salary":     record.NetSalary,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		event := events.PayrollApproved{
+			PayrollID:  record.ID,
+			EmployeeID: record.EmployeeID,
+			Month:      record.Month,
+			Year:       record.Year,
+			NetSalary:  record.NetSalary,
+			ApprovedBy: approvedBy,
+			ApprovedAt: record.UpdatedAt,
+		}
+		if employee.UserID != nil {
+			event.EmployeeUserID = *employee.UserID
+		}
+		return uc.outbox.Publish(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/audit"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/payroll/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/payroll/domain/ports"
+)
+
+// UpdateSalaryUseCase handles changing the salary of employees
+type UpdateSalaryUseCase struct {
+	payrollRepo ports.PayrollRepository
+	transactor  datastore.Transactor
+	auditLog    audit.Recorder
+}
+
+// NewUpdateSalaryUseCase creates a new UpdateSalaryUseCase
+func NewUpdateSalaryUseCase(payrollRepo ports.PayrollRepository, transactor datastore.Transactor, auditLog audit.Recorder) *UpdateSalaryUseCase {
+	return &UpdateSalaryUseCase{
+		payrollRepo: payrollRepo,
+		transactor:  transactor,
+		auditLog:    auditLog,
+	}
+}
+
+// Execute changes the monthly salary of an employee. The change and its
+// audit entry are stored together. Payroll records generated before keep
+// their amounts.
+func (uc *UpdateSalaryUseCase) Execute(ctx context.Context, employeeID string, amount float64, changedBy string) (*entities.Employee, error) {
+	var employee *entities.Employee
+	err := uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		employee, err = uc.payrollRepo.LockEmployee(ctx, employeeID)
+		if err != nil {
+			return err
+		}
+
+		previousSalary := employee.SalaryAmount
+		if err := employee.SetSalary(amount, time.Now()); err != nil {
+			return err
+		}
+		if err := uc.payrollRepo.UpdateEmployee(ctx, employee); err != nil {
+			return err
+		}
+
+		return uc.auditLog.Record(ctx, audit.Record{
+			ActorID:      changedBy,
+			Action:       "payroll.salary_change",
+			ResourceType: "employee",
+			ResourceID:   employee.ID,
+			Before:       map[string]interface{}{"salary_amount": previousSalary},
+			After:        map[string]interface{}{"salary_amount": employee.SalaryAmount},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return employee, nil
+}
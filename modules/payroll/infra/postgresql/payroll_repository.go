@@ -3,7 +3,9 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"errors"
 
+	"github.com/manab-pr/evtaarpro/internal/datastore"
 	"github.com/manab-pr/evtaarpro/modules/payroll/domain/entities"
 )
 
@@ -49,6 +51,29 @@ func (r *PayrollRepository) GetEmployee(ctx context.Context, id string) (*entiti
 	return employee, nil
 }
 
+// LockEmployee retrieves an employee with a row lock held until the
+// transaction of ctx ends
+func (r *PayrollRepository) LockEmployee(ctx context.Context, id string) (*entities.Employee, error) {
+	query := `
+		SELECT id, user_id, employee_code, department, designation, joining_date, salary_amount, is_active, created_at, updated_at
+		FROM employees WHERE id = $1
+		FOR UPDATE
+	`
+	employee := &entities.Employee{}
+	err := datastore.Conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&employee.ID, &employee.UserID, &employee.EmployeeCode, &employee.Department,
+		&employee.Designation, &employee.JoiningDate, &employee.SalaryAmount,
+		&employee.IsActive, &employee.CreatedAt, &employee.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrEmployeeNotFound
+		}
+		return nil, err
+	}
+	return employee, nil
+}
+
 // ListEmployees retrieves employees with pagination
 func (r *PayrollRepository) ListEmployees(ctx context.Context, limit, offset int) ([]*entities.Employee, int, error) {
 	query := `
@@ -96,7 +121,7 @@ func (r *PayrollRepository) UpdateEmployee(ctx context.Context, employee *entiti
 		SET department = $2, designation = $3, salary_amount = $4, is_active = $5, updated_at = $6
 		WHERE id = $1
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := datastore.Conn(ctx, r.db).ExecContext(ctx, query,
 		employee.ID, employee.Department, employee.Designation,
 		employee.SalaryAmount, employee.IsActive, employee.UpdatedAt,
 	)
@@ -168,13 +193,31 @@ func (r *PayrollRepository) GetPayrollRecord(ctx context.Context, id string) (*e
 		SELECT id, employee_id, month, year, basic_salary, allowances, deductions, net_salary, payment_status, payment_date, notes, created_at, updated_at
 		FROM payroll_records WHERE id = $1
 	`
+	return scanPayrollRecord(r.db.QueryRowContext(ctx, query, id))
+}
+
+// LockPayrollRecord retrieves a payroll record with a row lock held until
+// the transaction of ctx ends
+func (r *PayrollRepository) LockPayrollRecord(ctx context.Context, id string) (*entities.PayrollRecord, error) {
+	query := `
+		SELECT id, employee_id, month, year, basic_salary, allowances, deductions, net_salary, payment_status, payment_date, notes, created_at, updated_at
+		FROM payroll_records WHERE id = $1
+		FOR UPDATE
+	`
+	return scanPayrollRecord(datastore.Conn(ctx, r.db).QueryRowContext(ctx, query, id))
+}
+
+func scanPayrollRecord(row *sql.Row) (*entities.PayrollRecord, error) {
 	record := &entities.PayrollRecord{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := row.Scan(
 		&record.ID, &record.EmployeeID, &record.Month, &record.Year, &record.BasicSalary,
 		&record.Allowances, &record.Deductions, &record.NetSalary, &record.PaymentStatus,
 		&record.PaymentDate, &record.Notes, &record.CreatedAt, &record.UpdatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrPayrollRecordNotFound
+		}
 		return nil, err
 	}
 	return record, nil
@@ -219,14 +262,15 @@ func (r *PayrollRepository) ListPayrollRecords(ctx context.Context, limit, offse
 	return records, total, nil
 }
 
-// UpdatePayrollRecord updates a payroll record
+// UpdatePayrollRecord updates a payroll record, inside the transaction of
+// ctx if there is one
 func (r *PayrollRepository) UpdatePayrollRecord(ctx context.Context, record *entities.PayrollRecord) error {
 	query := `
 		UPDATE payroll_records
 		SET payment_status = $2, payment_date = $3, notes = $4, updated_at = $5
 		WHERE id = $1
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := datastore.Conn(ctx, r.db).ExecContext(ctx, query,
 		record.ID, record.PaymentStatus, record.PaymentDate, record.Notes, record.UpdatedAt,
 	)
 	return err
@@ -26,6 +26,11 @@ type EmployeeResponse struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+// UpdateSalaryRequest represents a salary change
+type UpdateSalaryRequest struct {
+	SalaryAmount float64 `json:"salary_amount" binding:"required"`
+}
+
 // MarkAttendanceRequest represents an attendance request
 type MarkAttendanceRequest struct {
 	EmployeeID string     `json:"employee_id" binding:"required"`
@@ -1,26 +1,37 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/metrics"
 	"github.com/manab-pr/evtaarpro/internal/response"
 	"github.com/manab-pr/evtaarpro/modules/payroll/domain/entities"
 	"github.com/manab-pr/evtaarpro/modules/payroll/domain/ports"
+	"github.com/manab-pr/evtaarpro/modules/payroll/domain/usecases"
 	"github.com/manab-pr/evtaarpro/modules/payroll/presentation/http/dto"
 )
 
 // PayrollHandlers contains payroll-related HTTP handlers
 type PayrollHandlers struct {
-	payrollRepo ports.PayrollRepository
+	payrollRepo      ports.PayrollRepository
+	approvePayrollUC *usecases.ApprovePayrollUseCase
+	updateSalaryUC   *usecases.UpdateSalaryUseCase
 }
 
 // NewPayrollHandlers creates new PayrollHandlers
-func NewPayrollHandlers(payrollRepo ports.PayrollRepository) *PayrollHandlers {
+func NewPayrollHandlers(
+	payrollRepo ports.PayrollRepository,
+	approvePayrollUC *usecases.ApprovePayrollUseCase,
+	updateSalaryUC *usecases.UpdateSalaryUseCase,
+) *PayrollHandlers {
 	return &PayrollHandlers{
-		payrollRepo: payrollRepo,
+		payrollRepo:      payrollRepo,
+		approvePayrollUC: approvePayrollUC,
+		updateSalaryUC:   updateSalaryUC,
 	}
 }
 
@@ -85,6 +96,32 @@ func (h *PayrollHandlers) GetEmployee(c *gin.Context) {
 	response.OK(c, "Employee retrieved successfully", mapEmployeeToResponse(employee))
 }
 
+// UpdateSalary changes the monthly salary of an employee
+func (h *PayrollHandlers) UpdateSalary(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.UpdateSalaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	employee, err := h.updateSalaryUC.Execute(c.Request.Context(), c.Param("id"), req.SalaryAmount, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, entities.ErrEmployeeNotFound):
+			response.NotFound(c, "Employee not found")
+		case errors.Is(err, entities.ErrInvalidSalary):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalServerError(c, "Failed to update salary")
+		}
+		return
+	}
+
+	response.OK(c, "Salary updated successfully", mapEmployeeToResponse(employee))
+}
+
 // MarkAttendance marks attendance for an employee
 func (h *PayrollHandlers) MarkAttendance(c *gin.Context) {
 	var req dto.MarkAttendanceRequest
@@ -172,7 +209,7 @@ func (h *PayrollHandlers) GeneratePayroll(c *gin.Context) {
 		Allowances:    req.Allowances,
 		Deductions:    req.Deductions,
 		NetSalary:     netSalary,
-		PaymentStatus: "pending",
+		PaymentStatus: entities.PaymentPending,
 		Notes:         req.Notes,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
@@ -182,6 +219,7 @@ func (h *PayrollHandlers) GeneratePayroll(c *gin.Context) {
 		response.InternalServerError(c, "Failed to generate payroll")
 		return
 	}
+	metrics.PayrollRecordsGenerated.Inc()
 
 	response.Created(c, "Payroll generated successfully", mapPayrollRecordToResponse(payrollRecord))
 }
@@ -218,6 +256,26 @@ func (h *PayrollHandlers) GetPayrollRecord(c *gin.Context) {
 	response.OK(c, "Payroll record retrieved successfully", mapPayrollRecordToResponse(record))
 }
 
+// ApprovePayroll approves a pending payroll record for payment
+func (h *PayrollHandlers) ApprovePayroll(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	record, err := h.approvePayrollUC.Execute(c.Request.Context(), c.Param("id"), userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, entities.ErrPayrollRecordNotFound):
+			response.NotFound(c, "Payroll record not found")
+		case errors.Is(err, entities.ErrPayrollNotPending):
+			response.Conflict(c, err.Error())
+		default:
+			response.InternalServerError(c, "Failed to approve payroll")
+		}
+		return
+	}
+
+	response.OK(c, "Payroll approved successfully", mapPayrollRecordToResponse(record))
+}
+
 func mapEmployeeToResponse(employee *entities.Employee) dto.EmployeeResponse {
 	return dto.EmployeeResponse{
 		ID:           employee.ID,
@@ -7,14 +7,15 @@ import (
 )
 
 // RegisterRoutes registers payroll routes
-func RegisterRoutes(rg *gin.RouterGroup, payrollHandlers *handlers.PayrollHandlers, jwtSecret string) {
+func RegisterRoutes(rg *gin.RouterGroup, payrollHandlers *handlers.PayrollHandlers, jwtSecret string, idempotent gin.HandlerFunc) {
 	payroll := rg.Group("/payroll")
-	payroll.Use(middleware.AuthMiddleware(jwtSecret))
+	payroll.Use(middleware.AuthMiddleware(jwtSecret), idempotent)
 	{
 		// Employee routes
 		payroll.POST("/employees", payrollHandlers.CreateEmployee)
 		payroll.GET("/employees", payrollHandlers.ListEmployees)
 		payroll.GET("/employees/:id", payrollHandlers.GetEmployee)
+		payroll.PUT("/employees/:id/salary", middleware.RequireRole("admin", "hr"), payrollHandlers.UpdateSalary)
 
 		// Attendance routes
 		payroll.POST("/attendance", payrollHandlers.MarkAttendance)
@@ -24,5 +25,6 @@ func RegisterRoutes(rg *gin.RouterGroup, payrollHandlers *handlers.PayrollHandle
 		payroll.POST("/records", payrollHandlers.GeneratePayroll)
 		payroll.GET("/records", payrollHandlers.ListPayrollRecords)
 		payroll.GET("/records/:id", payrollHandlers.GetPayrollRecord)
+		payroll.POST("/records/:id/approve", middleware.RequireRole("admin", "hr"), payrollHandlers.ApprovePayroll)
 	}
 }
@@ -2,8 +2,11 @@ package users
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/audit"
+	"github.com/manab-pr/evtaarpro/internal/cache"
 	"github.com/manab-pr/evtaarpro/internal/config"
 	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/users/data/cached"
 	"github.com/manab-pr/evtaarpro/modules/users/data/postgresql/repository"
 	"github.com/manab-pr/evtaarpro/modules/users/domain/usecases"
 	"github.com/manab-pr/evtaarpro/modules/users/presentation/http/handlers"
@@ -13,15 +16,16 @@ import (
 // RegisterRoutes registers users module routes
 func RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore, redisStore *datastore.RedisStore) {
 	// Infrastructure
-	userRepo := repository.NewUserRepository(pgStore.DB)
+	userRepo := cached.NewUserRepository(repository.NewUserRepository(pgStore.DB), cache.New(redisStore, "users"))
 
 	// Use cases
 	getUserUC := usecases.NewGetUserUseCase(userRepo)
 	listUsersUC := usecases.NewListUsersUseCase(userRepo)
 	updateUserUC := usecases.NewUpdateUserUseCase(userRepo)
+	changeRoleUC := usecases.NewChangeRoleUseCase(userRepo, pgStore, audit.NewLog(pgStore.DB))
 
 	// Handlers
-	userHandlers := handlers.NewUserHandlers(getUserUC, listUsersUC, updateUserUC)
+	userHandlers := handlers.NewUserHandlers(getUserUC, listUsersUC, updateUserUC, changeRoleUC)
 
 	// Register routes
 	routes.RegisterRoutes(rg, userHandlers, cfg.JWT.Secret)
@@ -0,0 +1,96 @@
+package cached
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/manab-pr/evtaarpro/internal/cache"
+	"github.com/manab-pr/evtaarpro/modules/users/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/users/domain/repository"
+)
+
+// ListTag is carried by every cached list of users
+const ListTag = "users"
+
+// UserTag is carried by the cached reads of the user with the given ID
+func UserTag(id string) string {
+	return "user:" + id
+}
+
+// userList is a cached page of users
+type userList struct {
+	Users []*entities.User `json:"users"`
+	Total int64            `json:"total"`
+}
+
+// UserRepository caches user reads of a repository.UserRepository and
+// invalidates them on writes. Other methods go straight to the repository.
+type UserRepository struct {
+	repository.UserRepository
+	cache *cache.Cache
+}
+
+// NewUserRepository creates a UserRepository caching the reads of repo
+func NewUserRepository(repo repository.UserRepository, cache *cache.Cache) *UserRepository {
+	return &UserRepository{UserRepository: repo, cache: cache}
+}
+
+// Create creates a new user
+func (r *UserRepository) Create(ctx context.Context, user *entities.User) error {
+	if err := r.UserRepository.Create(ctx, user); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, ListTag)
+	return nil
+}
+
+// GetByID retrieves a user by ID
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*entities.User, error) {
+	return cache.Fetch(ctx, r.cache, "id:"+id, []string{UserTag(id)}, func(ctx context.Context) (*entities.User, error) {
+		return r.UserRepository.GetByID(ctx, id)
+	})
+}
+
+// List retrieves a list of users with pagination
+func (r *UserRepository) List(ctx context.Context, page, pageSize int) ([]*entities.User, int64, error) {
+	key := fmt.Sprintf("list:%d:%d", page, pageSize)
+	list, err := cache.Fetch(ctx, r.cache, key, []string{ListTag}, func(ctx context.Context) (*userList, error) {
+		users, total, err := r.UserRepository.List(ctx, page, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		return &userList{Users: users, Total: total}, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return list.Users, list.Total, nil
+}
+
+// Update updates a user
+func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, UserTag(user.ID), ListTag)
+	return nil
+}
+
+// UpdateRole sets the role of a user and returns the role it replaced
+func (r *UserRepository) UpdateRole(ctx context.Context, user *entities.User) (string, error) {
+	previousRole, err := r.UserRepository.UpdateRole(ctx, user)
+	if err != nil {
+		return previousRole, err
+	}
+	r.cache.Invalidate(ctx, UserTag(user.ID), ListTag)
+	return previousRole, nil
+}
+
+// Delete deletes a user
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	if err := r.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, UserTag(id), ListTag)
+	return nil
+}
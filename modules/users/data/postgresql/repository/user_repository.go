@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/lib/pq"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
 	"github.com/manab-pr/evtaarpro/modules/users/domain/entities"
 )
 
@@ -22,10 +24,12 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 // Create creates a new user
 func (r *UserRepository) Create(ctx context.Context, user *entities.User) error {
 	query := `
-		INSERT INTO users (id, email, first_name, last_name, phone, avatar, role, department, is_active, email_verified, created_at, updated_at, password_hash)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, '')
+		INSERT INTO users (id, email, first_name, last_name, phone, avatar, role, department, timezone, locale,
+			working_hours_start, working_hours_end, working_days, is_active, email_verified, created_at, updated_at, password_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, '')
 	`
 
+	workStart, workEnd, workDays := workingHoursParams(user.WorkingHours)
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID,
 		user.Email,
@@ -35,6 +39,11 @@ func (r *UserRepository) Create(ctx context.Context, user *entities.User) error
 		user.Avatar,
 		user.Role,
 		user.Department,
+		user.Timezone,
+		user.Locale,
+		workStart,
+		workEnd,
+		workDays,
 		user.IsActive,
 		user.EmailVerified,
 		user.CreatedAt,
@@ -56,6 +65,11 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*entities.User
 			COALESCE(avatar, '') AS avatar,
 			role,
 			COALESCE(department, '') AS department,
+			timezone,
+			locale,
+			COALESCE(to_char(working_hours_start, 'HH24:MI'), '') AS working_hours_start,
+			COALESCE(to_char(working_hours_end, 'HH24:MI'), '') AS working_hours_end,
+			working_days,
 			is_active,
 			email_verified,
 			created_at,
@@ -64,22 +78,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*entities.User
 		WHERE id = $1
 	`
 
-	user := &entities.User{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID,
-		&user.Email,
-		&user.FirstName,
-		&user.LastName,
-		&user.Phone,
-		&user.Avatar,
-		&user.Role,
-		&user.Department,
-		&user.IsActive,
-		&user.EmailVerified,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("user not found")
@@ -102,6 +101,11 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entitie
 			COALESCE(avatar, '') AS avatar,
 			role,
 			COALESCE(department, '') AS department,
+			timezone,
+			locale,
+			COALESCE(to_char(working_hours_start, 'HH24:MI'), '') AS working_hours_start,
+			COALESCE(to_char(working_hours_end, 'HH24:MI'), '') AS working_hours_end,
+			working_days,
 			is_active,
 			email_verified,
 			created_at,
@@ -110,22 +114,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entitie
 		WHERE email = $1
 	`
 
-	user := &entities.User{}
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
-		&user.ID,
-		&user.Email,
-		&user.FirstName,
-		&user.LastName,
-		&user.Phone,
-		&user.Avatar,
-		&user.Role,
-		&user.Department,
-		&user.IsActive,
-		&user.EmailVerified,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
-
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, email))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("user not found")
@@ -158,6 +147,11 @@ func (r *UserRepository) List(ctx context.Context, page, pageSize int) ([]*entit
 			COALESCE(avatar, '') AS avatar,
 			role,
 			COALESCE(department, '') AS department,
+			timezone,
+			locale,
+			COALESCE(to_char(working_hours_start, 'HH24:MI'), '') AS working_hours_start,
+			COALESCE(to_char(working_hours_end, 'HH24:MI'), '') AS working_hours_end,
+			working_days,
 			is_active,
 			email_verified,
 			created_at,
@@ -175,21 +169,8 @@ func (r *UserRepository) List(ctx context.Context, page, pageSize int) ([]*entit
 
 	users := make([]*entities.User, 0)
 	for rows.Next() {
-		user := &entities.User{}
-		if err := rows.Scan(
-			&user.ID,
-			&user.Email,
-			&user.FirstName,
-			&user.LastName,
-			&user.Phone,
-			&user.Avatar,
-			&user.Role,
-			&user.Department,
-			&user.IsActive,
-			&user.EmailVerified,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		); err != nil {
+		user, err := scanUser(rows)
+		if err != nil {
 			return nil, 0, err
 		}
 		users = append(users, user)
@@ -202,10 +183,12 @@ func (r *UserRepository) List(ctx context.Context, page, pageSize int) ([]*entit
 func (r *UserRepository) Update(ctx context.Context, user *entities.User) error {
 	query := `
 		UPDATE users
-		SET first_name = $2, last_name = $3, phone = $4, avatar = $5, department = $6, updated_at = $7
+		SET first_name = $2, last_name = $3, phone = $4, avatar = $5, department = $6, timezone = $7, locale = $8,
+			working_hours_start = $9, working_hours_end = $10, working_days = $11, updated_at = $12
 		WHERE id = $1
 	`
 
+	workStart, workEnd, workDays := workingHoursParams(user.WorkingHours)
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID,
 		user.FirstName,
@@ -213,12 +196,32 @@ func (r *UserRepository) Update(ctx context.Context, user *entities.User) error
 		user.Phone,
 		user.Avatar,
 		user.Department,
+		user.Timezone,
+		user.Locale,
+		workStart,
+		workEnd,
+		workDays,
 		user.UpdatedAt,
 	)
 
 	return err
 }
 
+// UpdateRole sets the role of a user and returns the role it replaced
+func (r *UserRepository) UpdateRole(ctx context.Context, user *entities.User) (string, error) {
+	query := `
+		UPDATE users u
+		SET role = $2, updated_at = $3
+		FROM (SELECT id, role FROM users WHERE id = $1 FOR UPDATE) previous
+		WHERE u.id = previous.id
+		RETURNING previous.role
+	`
+
+	var previousRole string
+	err := datastore.Conn(ctx, r.db).QueryRowContext(ctx, query, user.ID, user.Role, user.UpdatedAt).Scan(&previousRole)
+	return previousRole, err
+}
+
 // Delete deletes a user
 func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM users WHERE id = $1`
@@ -252,6 +255,11 @@ func (r *UserRepository) Search(ctx context.Context, query string, page, pageSiz
 			COALESCE(avatar, '') AS avatar,
 			role,
 			COALESCE(department, '') AS department,
+			timezone,
+			locale,
+			COALESCE(to_char(working_hours_start, 'HH24:MI'), '') AS working_hours_start,
+			COALESCE(to_char(working_hours_end, 'HH24:MI'), '') AS working_hours_end,
+			working_days,
 			is_active,
 			email_verified,
 			created_at,
@@ -270,21 +278,8 @@ func (r *UserRepository) Search(ctx context.Context, query string, page, pageSiz
 
 	users := make([]*entities.User, 0)
 	for rows.Next() {
-		user := &entities.User{}
-		if err := rows.Scan(
-			&user.ID,
-			&user.Email,
-			&user.FirstName,
-			&user.LastName,
-			&user.Phone,
-			&user.Avatar,
-			&user.Role,
-			&user.Department,
-			&user.IsActive,
-			&user.EmailVerified,
-			&user.CreatedAt,
-			&user.UpdatedAt,
-		); err != nil {
+		user, err := scanUser(rows)
+		if err != nil {
 			return nil, 0, err
 		}
 		users = append(users, user)
@@ -292,3 +287,49 @@ func (r *UserRepository) Search(ctx context.Context, query string, page, pageSiz
 
 	return users, total, nil
 }
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row rowScanner) (*entities.User, error) {
+	user := &entities.User{}
+	var workStart, workEnd string
+	var workDays []string
+
+	if err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.FirstName,
+		&user.LastName,
+		&user.Phone,
+		&user.Avatar,
+		&user.Role,
+		&user.Department,
+		&user.Timezone,
+		&user.Locale,
+		&workStart,
+		&workEnd,
+		pq.Array(&workDays),
+		&user.IsActive,
+		&user.EmailVerified,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if workStart != "" {
+		user.WorkingHours = &entities.WorkingHours{Start: workStart, End: workEnd, Days: workDays}
+	}
+
+	return user, nil
+}
+
+// workingHoursParams returns the working hour columns, NULL for the defaults
+func workingHoursParams(hours *entities.WorkingHours) (sql.NullString, sql.NullString, interface{}) {
+	if hours == nil {
+		return sql.NullString{}, sql.NullString{}, nil
+	}
+	return sql.NullString{String: hours.Start, Valid: true}, sql.NullString{String: hours.End, Valid: true}, pq.Array(hours.Days)
+}
@@ -2,15 +2,38 @@ package entities
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/text/language"
 )
 
 var (
-	ErrInvalidUserData = errors.New("invalid user data")
+	ErrInvalidUserData     = errors.New("invalid user data")
+	ErrInvalidTimezone     = errors.New("invalid timezone")
+	ErrInvalidLocale       = errors.New("invalid locale")
+	ErrInvalidWorkingHours = errors.New("invalid working hours")
+	ErrInvalidRole         = errors.New("role must be admin, employee, client or hr")
 )
 
+var roleNames = map[string]bool{
+	"admin":    true,
+	"employee": true,
+	"client":   true,
+	"hr":       true,
+}
+
+var weekdayNames = map[string]bool{
+	"sunday":    true,
+	"monday":    true,
+	"tuesday":   true,
+	"wednesday": true,
+	"thursday":  true,
+	"friday":    true,
+	"saturday":  true,
+}
+
 // User represents a user profile entity
 type User struct {
 	ID            string
@@ -21,12 +44,23 @@ type User struct {
 	Avatar        string
 	Role          string
 	Department    string
+	Timezone      string // IANA name, e.g. "Europe/Berlin"
+	Locale        string // BCP 47 tag, e.g. "de-AT"
+	WorkingHours  *WorkingHours
 	IsActive      bool
 	EmailVerified bool
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
 
+// WorkingHours holds a user's personal working hours in their timezone.
+// Users without working hours get the organisation defaults.
+type WorkingHours struct {
+	Start string // HH:MM
+	End   string // HH:MM
+	Days  []string
+}
+
 // NewUser creates a new user entity
 func NewUser(email, firstName, lastName, role string) (*User, error) {
 	if email == "" || firstName == "" || lastName == "" {
@@ -40,6 +74,8 @@ func NewUser(email, firstName, lastName, role string) (*User, error) {
 		FirstName:     firstName,
 		LastName:      lastName,
 		Role:          role,
+		Timezone:      "UTC",
+		Locale:        "en",
 		IsActive:      true,
 		EmailVerified: false,
 		CreatedAt:     now,
@@ -69,8 +105,78 @@ func (u *User) Update(firstName, lastName, phone, department string) {
 	u.UpdatedAt = time.Now()
 }
 
+// SetRole changes the user's role
+func (u *User) SetRole(role string) error {
+	if !roleNames[role] {
+		return ErrInvalidRole
+	}
+
+	u.Role = role
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
 // SetAvatar sets the user's avatar URL
 func (u *User) SetAvatar(avatarURL string) {
 	u.Avatar = avatarURL
 	u.UpdatedAt = time.Now()
 }
+
+// SetTimezone sets the user's IANA timezone
+func (u *User) SetTimezone(name string) error {
+	if name == "" || name == "Local" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return ErrInvalidTimezone
+	}
+
+	u.Timezone = name
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetLocale sets the user's preferred language as a BCP 47 tag
+func (u *User) SetLocale(tag string) error {
+	parsed, err := language.Parse(tag)
+	if err != nil || parsed == language.Und {
+		return ErrInvalidLocale
+	}
+
+	u.Locale = parsed.String()
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// SetWorkingHours sets the user's working hours; nil restores the defaults
+func (u *User) SetWorkingHours(hours *WorkingHours) error {
+	if hours != nil {
+		start, err := time.Parse("15:04", hours.Start)
+		if err != nil {
+			return ErrInvalidWorkingHours
+		}
+		end, err := time.Parse("15:04", hours.End)
+		if err != nil || !start.Before(end) || len(hours.Days) == 0 {
+			return ErrInvalidWorkingHours
+		}
+
+		days := make([]string, 0, len(hours.Days))
+		seen := make(map[string]bool, len(hours.Days))
+		for _, day := range hours.Days {
+			day = strings.ToLower(strings.TrimSpace(day))
+			if !weekdayNames[day] {
+				return ErrInvalidWorkingHours
+			}
+			if !seen[day] {
+				seen[day] = true
+				days = append(days, day)
+			}
+		}
+
+		hours = &WorkingHours{Start: start.Format("15:04"), End: end.Format("15:04"), Days: days}
+	}
+
+	u.WorkingHours = hours
+	u.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUserSetTimezone(t *testing.T) {
+	tests := []struct {
+		name string
+		want error
+	}{
+		{"Asia/Kolkata", nil},
+		{"UTC", nil},
+		{"", ErrInvalidTimezone},
+		{"Local", ErrInvalidTimezone},
+		{"Mars/Olympus_Mons", ErrInvalidTimezone},
+		{"+05:30", ErrInvalidTimezone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser("ada@example.com", "Ada", "Lovelace", "employee")
+			if err != nil {
+				t.Fatalf("NewUser: %v", err)
+			}
+
+			err = user.SetTimezone(tt.name)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("SetTimezone(%q): err = %v, want %v", tt.name, err, tt.want)
+			}
+			wantTimezone := tt.name
+			if err != nil {
+				wantTimezone = "UTC"
+			}
+			if user.Timezone != wantTimezone {
+				t.Fatalf("timezone = %q, want %q", user.Timezone, wantTimezone)
+			}
+		})
+	}
+}
+
+func TestUserSetWorkingHours(t *testing.T) {
+	tests := []struct {
+		name  string
+		hours *WorkingHours
+		want  *WorkingHours
+		err   error
+	}{
+		{
+			"normalized",
+			&WorkingHours{Start: "08:30", End: "16:00", Days: []string{" Monday", "TUESDAY", "monday"}},
+			&WorkingHours{Start: "08:30", End: "16:00", Days: []string{"monday", "tuesday"}},
+			nil,
+		},
+		{"defaults", nil, nil, nil},
+		{"bad start", &WorkingHours{Start: "8am", End: "16:00", Days: []string{"monday"}}, nil, ErrInvalidWorkingHours},
+		{"bad end", &WorkingHours{Start: "08:00", End: "24:00", Days: []string{"monday"}}, nil, ErrInvalidWorkingHours},
+		{"ends before it starts", &WorkingHours{Start: "16:00", End: "08:00", Days: []string{"monday"}}, nil, ErrInvalidWorkingHours},
+		{"empty", &WorkingHours{Start: "09:00", End: "09:00", Days: []string{"monday"}}, nil, ErrInvalidWorkingHours},
+		{"no days", &WorkingHours{Start: "09:00", End: "17:00"}, nil, ErrInvalidWorkingHours},
+		{"unknown day", &WorkingHours{Start: "09:00", End: "17:00", Days: []string{"funday"}}, nil, ErrInvalidWorkingHours},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser("ada@example.com", "Ada", "Lovelace", "employee")
+			if err != nil {
+				t.Fatalf("NewUser: %v", err)
+			}
+
+			if err := user.SetWorkingHours(tt.hours); !errors.Is(err, tt.err) {
+				t.Fatalf("SetWorkingHours: err = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(user.WorkingHours, tt.want) {
+				t.Fatalf("working hours = %+v, want %+v", user.WorkingHours, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,10 @@ type UserRepository interface {
 	// Update updates a user
 	Update(ctx context.Context, user *entities.User) error
 
+	// UpdateRole sets the role of a user and returns the role it replaced,
+	// locking the user until the transaction of ctx ends
+	UpdateRole(ctx context.Context, user *entities.User) (string, error)
+
 	// Delete deletes a user
 	Delete(ctx context.Context, id string) error
 
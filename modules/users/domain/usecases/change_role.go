@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/manab-pr/evtaarpro/internal/audit"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/modules/users/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/users/domain/repository"
+)
+
+var ErrCannotChangeOwnRole = errors.New("you cannot change your own role")
+
+// ChangeRoleUseCase handles changing the role of users
+type ChangeRoleUseCase struct {
+	userRepo   repository.UserRepository
+	transactor datastore.Transactor
+	auditLog   audit.Recorder
+}
+
+// NewChangeRoleUseCase creates a new ChangeRoleUseCase
+func NewChangeRoleUseCase(userRepo repository.UserRepository, transactor datastore.Transactor, auditLog audit.Recorder) *ChangeRoleUseCase {
+	return &ChangeRoleUseCase{
+		userRepo:   userRepo,
+		transactor: transactor,
+		auditLog:   auditLog,
+	}
+}
+
+// Execute changes the role of a user. The change and its audit entry are
+// stored together. Admins cannot change their own role, so there is always
+// an admin left. The user's tokens keep the old role until they expire.
+func (uc *ChangeRoleUseCase) Execute(ctx context.Context, userID, role, changedBy string) (*entities.User, error) {
+	if userID == changedBy {
+		return nil, ErrCannotChangeOwnRole
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if err := user.SetRole(role); err != nil {
+		return nil, err
+	}
+
+	err = uc.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		previousRole, err := uc.userRepo.UpdateRole(ctx, user)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrUserNotFound
+			}
+			return err
+		}
+
+		return uc.auditLog.Record(ctx, audit.Record{
+			ActorID:      changedBy,
+			Action:       "users.role_change",
+			ResourceType: "user",
+			ResourceID:   user.ID,
+			Before:       map[string]interface{}{"role": previousRole},
+			After:        map[string]interface{}{"role": user.Role},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/manab-pr/evtaarpro/modules/users/domain/entities"
 	"github.com/manab-pr/evtaarpro/modules/users/domain/repository"
 )
 
@@ -19,13 +20,18 @@ func NewUpdateUserUseCase(userRepo repository.UserRepository) *UpdateUserUseCase
 	return &UpdateUserUseCase{userRepo: userRepo}
 }
 
-// UpdateInput represents update input
+// UpdateInput represents update input. Empty fields are left unchanged;
+// ResetWorkingHours restores the default working hours.
 type UpdateInput struct {
-	UserID     string
-	FirstName  string
-	LastName   string
-	Phone      string
-	Department string
+	UserID            string
+	FirstName         string
+	LastName          string
+	Phone             string
+	Department        string
+	Timezone          string
+	Locale            string
+	WorkingHours      *entities.WorkingHours
+	ResetWorkingHours bool
 }
 
 // Execute updates user information
@@ -37,5 +43,21 @@ func (uc *UpdateUserUseCase) Execute(ctx context.Context, input UpdateInput) err
 
 	user.Update(input.FirstName, input.LastName, input.Phone, input.Department)
 
+	if input.Timezone != "" {
+		if err := user.SetTimezone(input.Timezone); err != nil {
+			return err
+		}
+	}
+	if input.Locale != "" {
+		if err := user.SetLocale(input.Locale); err != nil {
+			return err
+		}
+	}
+	if input.WorkingHours != nil || input.ResetWorkingHours {
+		if err := user.SetWorkingHours(input.WorkingHours); err != nil {
+			return err
+		}
+	}
+
 	return uc.userRepo.Update(ctx, user)
 }
@@ -4,27 +4,53 @@ import "time"
 
 // UserResponse represents a user response
 type UserResponse struct {
-	ID            string    `json:"id"`
-	Email         string    `json:"email"`
-	FirstName     string    `json:"first_name"`
-	LastName      string    `json:"last_name"`
-	FullName      string    `json:"full_name"`
-	Phone         string    `json:"phone,omitempty"`
-	Avatar        string    `json:"avatar,omitempty"`
-	Role          string    `json:"role"`
-	Department    string    `json:"department,omitempty"`
-	IsActive      bool      `json:"is_active"`
-	EmailVerified bool      `json:"email_verified"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            string                `json:"id"`
+	Email         string                `json:"email"`
+	FirstName     string                `json:"first_name"`
+	LastName      string                `json:"last_name"`
+	FullName      string                `json:"full_name"`
+	Phone         string                `json:"phone,omitempty"`
+	Avatar        string                `json:"avatar,omitempty"`
+	Role          string                `json:"role"`
+	Department    string                `json:"department,omitempty"`
+	Timezone      string                `json:"timezone"`
+	Locale        string                `json:"locale"`
+	WorkingHours  *WorkingHoursResponse `json:"working_hours,omitempty"`
+	IsActive      bool                  `json:"is_active"`
+	EmailVerified bool                  `json:"email_verified"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+// WorkingHoursResponse represents a user's personal working hours
+type WorkingHoursResponse struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Days  []string `json:"days"`
+}
+
+// ChangeRoleRequest represents a role change
+type ChangeRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin employee client hr"`
 }
 
 // UpdateUserRequest represents a user update request
 type UpdateUserRequest struct {
-	FirstName  string `json:"first_name"`
-	LastName   string `json:"last_name"`
-	Phone      string `json:"phone"`
-	Department string `json:"department"`
+	FirstName         string               `json:"first_name"`
+	LastName          string               `json:"last_name"`
+	Phone             string               `json:"phone"`
+	Department        string               `json:"department"`
+	Timezone          string               `json:"timezone"`
+	Locale            string               `json:"locale"`
+	WorkingHours      *WorkingHoursRequest `json:"working_hours"`
+	ResetWorkingHours bool                 `json:"reset_working_hours"`
+}
+
+// WorkingHoursRequest represents personal working hours in the user's timezone
+type WorkingHoursRequest struct {
+	Start string   `json:"start" binding:"required"`
+	End   string   `json:"end" binding:"required"`
+	Days  []string `json:"days" binding:"required,min=1"`
 }
 
 // ListUsersQuery represents list users query parameters
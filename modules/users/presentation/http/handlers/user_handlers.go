@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +16,7 @@ type UserHandlers struct {
 	getUserUC   *usecases.GetUserUseCase
 	listUsersUC *usecases.ListUsersUseCase
 	updateUserUC *usecases.UpdateUserUseCase
+	changeRoleUC *usecases.ChangeRoleUseCase
 }
 
 // NewUserHandlers creates new UserHandlers
@@ -22,11 +24,13 @@ func NewUserHandlers(
 	getUserUC *usecases.GetUserUseCase,
 	listUsersUC *usecases.ListUsersUseCase,
 	updateUserUC *usecases.UpdateUserUseCase,
+	changeRoleUC *usecases.ChangeRoleUseCase,
 ) *UserHandlers {
 	return &UserHandlers{
 		getUserUC:   getUserUC,
 		listUsersUC: listUsersUC,
 		updateUserUC: updateUserUC,
+		changeRoleUC: changeRoleUC,
 	}
 }
 
@@ -113,7 +117,7 @@ func (h *UserHandlers) ListUsers(c *gin.Context) {
 
 // UpdateUser handles updating user profile
 // @Summary Update user profile
-// @Description Update the current user's profile
+// @Description Update the current user's profile, including timezone, language and working hours
 // @Tags users
 // @Security BearerAuth
 // @Accept json
@@ -137,14 +141,32 @@ func (h *UserHandlers) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	var workingHours *entities.WorkingHours
+	if req.WorkingHours != nil {
+		workingHours = &entities.WorkingHours{
+			Start: req.WorkingHours.Start,
+			End:   req.WorkingHours.End,
+			Days:  req.WorkingHours.Days,
+		}
+	}
+
 	if err := h.updateUserUC.Execute(c.Request.Context(), usecases.UpdateInput{
-		UserID:     userID.(string),
-		FirstName:  req.FirstName,
-		LastName:   req.LastName,
-		Phone:      req.Phone,
-		Department: req.Department,
+		UserID:            userID.(string),
+		FirstName:         req.FirstName,
+		LastName:          req.LastName,
+		Phone:             req.Phone,
+		Department:        req.Department,
+		Timezone:          req.Timezone,
+		Locale:            req.Locale,
+		WorkingHours:      workingHours,
+		ResetWorkingHours: req.ResetWorkingHours,
 	}); err != nil {
-		response.InternalServerError(c, "Failed to update user")
+		switch {
+		case errors.Is(err, entities.ErrInvalidTimezone), errors.Is(err, entities.ErrInvalidLocale), errors.Is(err, entities.ErrInvalidWorkingHours):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalServerError(c, "Failed to update user")
+		}
 		return
 	}
 
@@ -154,7 +176,57 @@ func (h *UserHandlers) UpdateUser(c *gin.Context) {
 	response.OK(c, "User updated successfully", mapUserToResponse(user))
 }
 
+// ChangeRole handles changing a user's role
+// @Summary Change user role
+// @Description Change the role of another user (admin only). The change is recorded in the audit log and takes effect when the user next signs in.
+// @Tags users
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.ChangeRoleRequest true "New role"
+// @Success 200 {object} response.Response{data=dto.UserResponse}
+// @Failure 400 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /users/{id}/role [put]
+func (h *UserHandlers) ChangeRole(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.ChangeRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	user, err := h.changeRoleUC.Execute(c.Request.Context(), c.Param("id"), req.Role, userID.(string))
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrUserNotFound):
+			response.NotFound(c, "User not found")
+		case errors.Is(err, usecases.ErrCannotChangeOwnRole):
+			response.Forbidden(c, err.Error())
+		case errors.Is(err, entities.ErrInvalidRole):
+			response.BadRequest(c, err.Error())
+		default:
+			response.InternalServerError(c, "Failed to change role")
+		}
+		return
+	}
+
+	response.OK(c, "Role changed successfully", mapUserToResponse(user))
+}
+
 func mapUserToResponse(user *entities.User) dto.UserResponse {
+	var workingHours *dto.WorkingHoursResponse
+	if user.WorkingHours != nil {
+		workingHours = &dto.WorkingHoursResponse{
+			Start: user.WorkingHours.Start,
+			End:   user.WorkingHours.End,
+			Days:  user.WorkingHours.Days,
+		}
+	}
+
 	return dto.UserResponse{
 		ID:            user.ID,
 		Email:         user.Email,
@@ -165,6 +237,9 @@ func mapUserToResponse(user *entities.User) dto.UserResponse {
 		Avatar:        user.Avatar,
 		Role:          user.Role,
 		Department:    user.Department,
+		Timezone:      user.Timezone,
+		Locale:        user.Locale,
+		WorkingHours:  workingHours,
 		IsActive:      user.IsActive,
 		EmailVerified: user.EmailVerified,
 		CreatedAt:     user.CreatedAt,
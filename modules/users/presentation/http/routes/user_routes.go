@@ -15,5 +15,6 @@ func RegisterRoutes(rg *gin.RouterGroup, handlers *handlers.UserHandlers, jwtSec
 		users.PUT("/me", handlers.UpdateUser)
 		users.GET("", handlers.ListUsers)
 		users.GET("/:id", handlers.GetUser)
+		users.PUT("/:id/role", middleware.RequireRole("admin"), handlers.ChangeRole)
 	}
 }
@@ -0,0 +1,100 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/config"
+	"github.com/manab-pr/evtaarpro/internal/datastore"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/internal/jobs"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/infra/postgresql"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/infra/sender"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/presentation/events"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/presentation/http/handlers"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/presentation/http/routes"
+)
+
+// RegisterRoutes registers webhooks module routes
+func RegisterRoutes(rg *gin.RouterGroup, cfg *config.Config, pgStore *datastore.PostgresStore) {
+	// Infrastructure
+	endpointRepo := postgresql.NewEndpointRepository(pgStore.DB)
+	deliveryRepo := postgresql.NewDeliveryRepository(pgStore.DB)
+	httpSender := sender.NewHTTPSender(cfg.Webhooks.Timeout)
+
+	// Use cases
+	createEndpointUC := usecases.NewCreateEndpointUseCase(endpointRepo, events.EventTypes, cfg.Webhooks.RequireHTTPS)
+	listEndpointsUC := usecases.NewListEndpointsUseCase(endpointRepo)
+	getEndpointUC := usecases.NewGetEndpointUseCase(endpointRepo)
+	updateEndpointUC := usecases.NewUpdateEndpointUseCase(endpointRepo, events.EventTypes, cfg.Webhooks.RequireHTTPS)
+	deleteEndpointUC := usecases.NewDeleteEndpointUseCase(endpointRepo)
+	rotateSecretUC := usecases.NewRotateSecretUseCase(endpointRepo)
+	sendTestEventUC := usecases.NewSendTestEventUseCase(endpointRepo, deliveryRepo, httpSender, retryPolicy(cfg))
+	listDeliveriesUC := usecases.NewListDeliveriesUseCase(endpointRepo, deliveryRepo)
+	getDeliveryUC := usecases.NewGetDeliveryUseCase(deliveryRepo)
+	redeliverUC := usecases.NewRedeliverUseCase(deliveryRepo)
+
+	// Handlers
+	webhookHandlers := handlers.NewWebhookHandlers(
+		createEndpointUC,
+		listEndpointsUC,
+		getEndpointUC,
+		updateEndpointUC,
+		deleteEndpointUC,
+		rotateSecretUC,
+		sendTestEventUC,
+		listDeliveriesUC,
+		getDeliveryUC,
+		redeliverUC,
+		events.EventTypes,
+	)
+
+	// Register routes
+	routes.RegisterRoutes(rg, webhookHandlers, cfg.JWT.Secret)
+}
+
+// RegisterSubscribers subscribes the webhooks module to the domain events
+// endpoints may subscribe to
+func RegisterSubscribers(bus *eventbus.Bus, pgStore *datastore.PostgresStore) {
+	subscriber := events.NewSubscriber(usecases.NewEnqueueEventUseCase(
+		postgresql.NewEndpointRepository(pgStore.DB),
+		postgresql.NewDeliveryRepository(pgStore.DB),
+	))
+	subscriber.Register(bus)
+}
+
+// RegisterJobs registers webhooks module background jobs
+func RegisterJobs(scheduler *jobs.Scheduler, cfg *config.Config, pgStore *datastore.PostgresStore) {
+	processDeliveriesUC := usecases.NewProcessDeliveriesUseCase(
+		postgresql.NewEndpointRepository(pgStore.DB),
+		postgresql.NewDeliveryRepository(pgStore.DB),
+		sender.NewHTTPSender(cfg.Webhooks.Timeout),
+		retryPolicy(cfg),
+		cfg.Webhooks.BatchSize,
+	)
+
+	scheduler.Register(jobs.Job{
+		Name:     "webhooks.deliver",
+		Interval: cfg.Webhooks.JobInterval,
+		Run: func(ctx context.Context) error {
+			output, err := processDeliveriesUC.Execute(ctx)
+			if err != nil {
+				return err
+			}
+			if output.Succeeded+output.Retrying+output.Dead > 0 {
+				logging.FromContext(ctx).Info("processed webhook deliveries", "succeeded", output.Succeeded, "retrying", output.Retrying, "dead", output.Dead)
+			}
+			return nil
+		},
+	})
+}
+
+func retryPolicy(cfg *config.Config) eventbus.RetryPolicy {
+	return eventbus.RetryPolicy{
+		MaxAttempts: cfg.Webhooks.MaxAttempts,
+		Backoff:     cfg.Webhooks.RetryBackoff,
+		MaxBackoff:  cfg.Webhooks.MaxBackoff,
+	}
+}
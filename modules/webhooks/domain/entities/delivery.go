@@ -0,0 +1,126 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+)
+
+// Delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead" // ran out of attempts; can be redelivered
+)
+
+// TestEventType is the type of the events sent by the "send test event"
+// action. Endpoints cannot subscribe to it.
+const TestEventType = "webhook.test"
+
+var (
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrDeliveryNotDead  = errors.New("only dead deliveries can be redelivered")
+)
+
+// Payload is the JSON body posted to endpoints
+type Payload struct {
+	ID         string          `json:"id"` // event ID; the same for every endpoint and attempt
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Delivery records the delivery of one event to one endpoint
+type Delivery struct {
+	ID             string
+	EndpointID     string
+	EventID        string
+	EventType      string
+	Payload        json.RawMessage
+	Status         string
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewDelivery creates a pending delivery of the payload to the endpoint,
+// due now
+func NewDelivery(endpointID string, payload *Payload) (*Delivery, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Delivery{
+		ID:            uuid.New().String(),
+		EndpointID:    endpointID,
+		EventID:       payload.ID,
+		EventType:     payload.Type,
+		Payload:       body,
+		Status:        DeliveryPending,
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
+// Record records an attempt. Failed deliveries are retried after the
+// backoff of the policy and dead-lettered once they run out of attempts.
+func (d *Delivery) Record(attempt *Attempt, policy eventbus.RetryPolicy) {
+	d.Attempts++
+	d.LastStatusCode = attempt.StatusCode
+	d.LastError = attempt.Error
+	d.UpdatedAt = time.Now()
+
+	if attempt.Succeeded() {
+		d.Status = DeliverySucceeded
+		d.NextAttemptAt = nil
+		d.DeliveredAt = &attempt.AttemptedAt
+		return
+	}
+
+	if d.Attempts >= policy.MaxAttempts {
+		d.Status = DeliveryDead
+		d.NextAttemptAt = nil
+		return
+	}
+
+	retryAt := attempt.AttemptedAt.Add(policy.Delay(d.Attempts))
+	d.NextAttemptAt = &retryAt
+}
+
+// Redeliver queues a dead delivery again with a fresh set of attempts
+func (d *Delivery) Redeliver(now time.Time) error {
+	if d.Status != DeliveryDead {
+		return ErrDeliveryNotDead
+	}
+
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = &now
+	d.UpdatedAt = now
+	return nil
+}
+
+// Attempt is one try to post a delivery
+type Attempt struct {
+	DeliveryID  string
+	StatusCode  int    // 0 when no response arrived
+	Error       string // empty for 2xx responses
+	Response    string // start of the response body
+	Duration    time.Duration
+	AttemptedAt time.Time
+}
+
+// Succeeded reports whether the endpoint accepted the delivery
+func (a *Attempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
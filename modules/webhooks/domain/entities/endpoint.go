@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/netguard"
+)
+
+var (
+	ErrEndpointNotFound  = errors.New("webhook endpoint not found")
+	ErrInvalidURL        = errors.New("invalid webhook URL")
+	ErrInsecureURL       = errors.New("webhook URL must use https")
+	ErrInternalURL       = errors.New("webhook URL must not point at a private or local address")
+	ErrInvalidEventTypes = errors.New("invalid webhook event types")
+)
+
+// AllEvents subscribes an endpoint to every event type, including types
+// added later
+const AllEvents = "*"
+
+// secretPrefix marks signing secrets so they are recognizable in configs
+const secretPrefix = "whsec_"
+
+// Endpoint is a URL that receives the events it subscribed to
+type Endpoint struct {
+	ID          string
+	URL         string
+	Description string
+	EventTypes  []string
+	Secret      string // signs payloads; only shown when created or rotated
+	Active      bool
+	CreatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewEndpoint creates an active endpoint with a new signing secret
+func NewEndpoint(rawURL, description string, eventTypes []string, createdBy string) (*Endpoint, error) {
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Endpoint{
+		ID:          uuid.New().String(),
+		URL:         rawURL,
+		Description: description,
+		EventTypes:  eventTypes,
+		Secret:      secret,
+		Active:      true,
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Subscribes reports whether the endpoint receives events of eventType
+func (e *Endpoint) Subscribes(eventType string) bool {
+	for _, subscribed := range e.EventTypes {
+		if subscribed == eventType || subscribed == AllEvents {
+			return true
+		}
+	}
+	return false
+}
+
+// RotateSecret replaces the signing secret
+func (e *Endpoint) RotateSecret() error {
+	secret, err := newSecret()
+	if err != nil {
+		return err
+	}
+
+	e.Secret = secret
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// ValidateURL checks that rawURL is an absolute http(s) URL outside the
+// server's network, rejecting localhost and private IP literals; with
+// requireHTTPS only https is accepted. Names resolving to private addresses
+// are refused by the sender when it connects.
+func ValidateURL(rawURL string, requireHTTPS bool) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Hostname() == "" {
+		return ErrInvalidURL
+	}
+	if requireHTTPS && parsed.Scheme != "https" {
+		return ErrInsecureURL
+	}
+	if netguard.CheckHost(parsed.Hostname()) != nil {
+		return ErrInternalURL
+	}
+	return nil
+}
+
+// ValidateEventTypes checks that eventTypes is non-empty and lists only
+// known types or AllEvents
+func ValidateEventTypes(eventTypes, known []string) error {
+	if len(eventTypes) == 0 {
+		return ErrInvalidEventTypes
+	}
+
+	for _, eventType := range eventTypes {
+		if eventType == AllEvents {
+			continue
+		}
+		found := false
+		for _, k := range known {
+			if k == eventType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ErrInvalidEventTypes
+		}
+	}
+	return nil
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(buf), nil
+}
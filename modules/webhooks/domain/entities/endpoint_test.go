@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		requireHTTPS bool
+		want         error
+	}{
+		{"https", "https://hooks.example.com/evtaarpro", true, nil},
+		{"http allowed", "http://hooks.example.com/evtaarpro", false, nil},
+		{"public IP", "https://93.184.216.34/hooks", true, nil},
+		{"http required https", "http://hooks.example.com/evtaarpro", true, ErrInsecureURL},
+		{"relative", "/hooks", false, ErrInvalidURL},
+		{"other scheme", "ftp://hooks.example.com", false, ErrInvalidURL},
+		{"no host", "https://:443/hooks", false, ErrInvalidURL},
+		{"localhost", "http://localhost:6379", false, ErrInternalURL},
+		{"loopback", "http://127.0.0.1:8080/admin", false, ErrInternalURL},
+		{"metadata service", "http://169.254.169.254/latest/meta-data/", false, ErrInternalURL},
+		{"private network", "https://10.0.0.12/hooks", true, ErrInternalURL},
+		{"IPv6 loopback", "http://[::1]:9000/hooks", false, ErrInternalURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateURL(tt.url, tt.requireHTTPS); !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateURL(%q): err = %v, want %v", tt.url, err, tt.want)
+			}
+		})
+	}
+}
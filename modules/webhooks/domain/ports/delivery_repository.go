@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+)
+
+// DeliveryRepository defines webhook delivery persistence operations
+type DeliveryRepository interface {
+	// CreateBatch stores deliveries, skipping those whose endpoint already
+	// has a delivery of the event
+	CreateBatch(ctx context.Context, deliveries []*entities.Delivery) error
+	GetByID(ctx context.Context, endpointID, id string) (*entities.Delivery, error)
+	// ListByEndpoint retrieves the deliveries of an endpoint, newest first,
+	// optionally only those with the given status
+	ListByEndpoint(ctx context.Context, endpointID, status string, limit, offset int) ([]*entities.Delivery, int, error)
+	// ClaimDue retrieves pending deliveries of active endpoints due at now,
+	// oldest first, and hides them from other workers until now+lease
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.Delivery, error)
+	Update(ctx context.Context, delivery *entities.Delivery) error
+
+	AddAttempt(ctx context.Context, attempt *entities.Attempt) error
+	ListAttempts(ctx context.Context, deliveryID string) ([]*entities.Attempt, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+)
+
+// EndpointRepository defines webhook endpoint persistence operations
+type EndpointRepository interface {
+	Create(ctx context.Context, endpoint *entities.Endpoint) error
+	GetByID(ctx context.Context, id string) (*entities.Endpoint, error)
+	List(ctx context.Context) ([]*entities.Endpoint, error)
+	// ListSubscribed retrieves the active endpoints subscribed to eventType
+	ListSubscribed(ctx context.Context, eventType string) ([]*entities.Endpoint, error)
+	Update(ctx context.Context, endpoint *entities.Endpoint) error
+	Delete(ctx context.Context, id string) error
+}
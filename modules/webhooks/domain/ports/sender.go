@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+)
+
+// Sender posts deliveries to endpoints. Failures are reported in the
+// attempt rather than as errors.
+type Sender interface {
+	Send(ctx context.Context, endpoint *entities.Endpoint, delivery *entities.Delivery) *entities.Attempt
+}
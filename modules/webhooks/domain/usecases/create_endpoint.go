@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// CreateEndpointInput represents a webhook endpoint to register
+type CreateEndpointInput struct {
+	URL         string
+	Description string
+	EventTypes  []string
+	CreatedBy   string
+}
+
+// CreateEndpointUseCase handles registering webhook endpoints
+type CreateEndpointUseCase struct {
+	endpointRepo ports.EndpointRepository
+	eventTypes   []string
+	requireHTTPS bool
+}
+
+// NewCreateEndpointUseCase creates a new CreateEndpointUseCase. eventTypes
+// lists the event types endpoints may subscribe to.
+func NewCreateEndpointUseCase(endpointRepo ports.EndpointRepository, eventTypes []string, requireHTTPS bool) *CreateEndpointUseCase {
+	return &CreateEndpointUseCase{
+		endpointRepo: endpointRepo,
+		eventTypes:   eventTypes,
+		requireHTTPS: requireHTTPS,
+	}
+}
+
+// Execute registers an endpoint. The returned endpoint carries its signing
+// secret, which is not shown again.
+func (uc *CreateEndpointUseCase) Execute(ctx context.Context, input CreateEndpointInput) (*entities.Endpoint, error) {
+	if err := entities.ValidateURL(input.URL, uc.requireHTTPS); err != nil {
+		return nil, err
+	}
+	if err := entities.ValidateEventTypes(input.EventTypes, uc.eventTypes); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := entities.NewEndpoint(input.URL, input.Description, input.EventTypes, input.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.endpointRepo.Create(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// DeleteEndpointUseCase handles removing webhook endpoints
+type DeleteEndpointUseCase struct {
+	endpointRepo ports.EndpointRepository
+}
+
+// NewDeleteEndpointUseCase creates a new DeleteEndpointUseCase
+func NewDeleteEndpointUseCase(endpointRepo ports.EndpointRepository) *DeleteEndpointUseCase {
+	return &DeleteEndpointUseCase{
+		endpointRepo: endpointRepo,
+	}
+}
+
+// Execute removes an endpoint together with its delivery log
+func (uc *DeleteEndpointUseCase) Execute(ctx context.Context, endpointID string) error {
+	if _, err := uc.endpointRepo.GetByID(ctx, endpointID); err != nil {
+		return err
+	}
+	return uc.endpointRepo.Delete(ctx, endpointID)
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// deliver makes one attempt to post the delivery and records its outcome
+func deliver(
+	ctx context.Context,
+	deliveryRepo ports.DeliveryRepository,
+	sender ports.Sender,
+	retry eventbus.RetryPolicy,
+	endpoint *entities.Endpoint,
+	delivery *entities.Delivery,
+) (*entities.Attempt, error) {
+	attempt := sender.Send(ctx, endpoint, delivery)
+	attempt.DeliveryID = delivery.ID
+
+	if err := deliveryRepo.AddAttempt(ctx, attempt); err != nil {
+		return nil, err
+	}
+	delivery.Record(attempt, retry)
+	if err := deliveryRepo.Update(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return attempt, nil
+}
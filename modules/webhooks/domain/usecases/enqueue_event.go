@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// EnqueueEventUseCase handles queueing domain events for the endpoints
+// subscribed to them
+type EnqueueEventUseCase struct {
+	endpointRepo ports.EndpointRepository
+	deliveryRepo ports.DeliveryRepository
+}
+
+// NewEnqueueEventUseCase creates a new EnqueueEventUseCase
+func NewEnqueueEventUseCase(endpointRepo ports.EndpointRepository, deliveryRepo ports.DeliveryRepository) *EnqueueEventUseCase {
+	return &EnqueueEventUseCase{
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// Execute creates a pending delivery of the event for every subscribed
+// endpoint and returns how many endpoints are subscribed. Enqueueing the
+// same event again creates no duplicates.
+func (uc *EnqueueEventUseCase) Execute(ctx context.Context, envelope *eventbus.Envelope) (int, error) {
+	endpoints, err := uc.endpointRepo.ListSubscribed(ctx, envelope.Name)
+	if err != nil {
+		return 0, err
+	}
+	if len(endpoints) == 0 {
+		return 0, nil
+	}
+
+	payload := &entities.Payload{
+		ID:         envelope.ID,
+		Type:       envelope.Name,
+		OccurredAt: envelope.OccurredAt,
+		Data:       envelope.Payload,
+	}
+
+	deliveries := make([]*entities.Delivery, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		delivery, err := entities.NewDelivery(endpoint.ID, payload)
+		if err != nil {
+			return 0, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := uc.deliveryRepo.CreateBatch(ctx, deliveries); err != nil {
+		return 0, err
+	}
+
+	return len(deliveries), nil
+}
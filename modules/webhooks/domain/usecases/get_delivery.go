@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// GetDeliveryOutput represents a delivery with its attempts
+type GetDeliveryOutput struct {
+	Delivery *entities.Delivery
+	Attempts []*entities.Attempt
+}
+
+// GetDeliveryUseCase handles retrieving a delivery and its attempts
+type GetDeliveryUseCase struct {
+	deliveryRepo ports.DeliveryRepository
+}
+
+// NewGetDeliveryUseCase creates a new GetDeliveryUseCase
+func NewGetDeliveryUseCase(deliveryRepo ports.DeliveryRepository) *GetDeliveryUseCase {
+	return &GetDeliveryUseCase{
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// Execute retrieves a delivery of an endpoint with its attempts, oldest
+// first
+func (uc *GetDeliveryUseCase) Execute(ctx context.Context, endpointID, deliveryID string) (*GetDeliveryOutput, error) {
+	delivery, err := uc.deliveryRepo.GetByID(ctx, endpointID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := uc.deliveryRepo.ListAttempts(ctx, delivery.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetDeliveryOutput{
+		Delivery: delivery,
+		Attempts: attempts,
+	}, nil
+}
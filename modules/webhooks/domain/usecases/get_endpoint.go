@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// GetEndpointUseCase handles retrieving a webhook endpoint
+type GetEndpointUseCase struct {
+	endpointRepo ports.EndpointRepository
+}
+
+// NewGetEndpointUseCase creates a new GetEndpointUseCase
+func NewGetEndpointUseCase(endpointRepo ports.EndpointRepository) *GetEndpointUseCase {
+	return &GetEndpointUseCase{
+		endpointRepo: endpointRepo,
+	}
+}
+
+// Execute retrieves a webhook endpoint
+func (uc *GetEndpointUseCase) Execute(ctx context.Context, endpointID string) (*entities.Endpoint, error) {
+	return uc.endpointRepo.GetByID(ctx, endpointID)
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// ListDeliveriesInput represents the filter for listing deliveries
+type ListDeliveriesInput struct {
+	EndpointID string
+	Status     string
+	Page       int
+	PageSize   int
+}
+
+// ListDeliveriesOutput represents a page of deliveries
+type ListDeliveriesOutput struct {
+	Deliveries []*entities.Delivery
+	Total      int
+	Page       int
+	PageSize   int
+}
+
+// ListDeliveriesUseCase handles listing the delivery log of an endpoint
+type ListDeliveriesUseCase struct {
+	endpointRepo ports.EndpointRepository
+	deliveryRepo ports.DeliveryRepository
+}
+
+// NewListDeliveriesUseCase creates a new ListDeliveriesUseCase
+func NewListDeliveriesUseCase(endpointRepo ports.EndpointRepository, deliveryRepo ports.DeliveryRepository) *ListDeliveriesUseCase {
+	return &ListDeliveriesUseCase{
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// Execute lists the deliveries of an endpoint, newest first
+func (uc *ListDeliveriesUseCase) Execute(ctx context.Context, input ListDeliveriesInput) (*ListDeliveriesOutput, error) {
+	if _, err := uc.endpointRepo.GetByID(ctx, input.EndpointID); err != nil {
+		return nil, err
+	}
+
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.PageSize < 1 || input.PageSize > 100 {
+		input.PageSize = 20
+	}
+
+	offset := (input.Page - 1) * input.PageSize
+	deliveries, total, err := uc.deliveryRepo.ListByEndpoint(ctx, input.EndpointID, input.Status, input.PageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListDeliveriesOutput{
+		Deliveries: deliveries,
+		Total:      total,
+		Page:       input.Page,
+		PageSize:   input.PageSize,
+	}, nil
+}
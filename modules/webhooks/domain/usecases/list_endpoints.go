@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// ListEndpointsUseCase handles listing webhook endpoints
+type ListEndpointsUseCase struct {
+	endpointRepo ports.EndpointRepository
+}
+
+// NewListEndpointsUseCase creates a new ListEndpointsUseCase
+func NewListEndpointsUseCase(endpointRepo ports.EndpointRepository) *ListEndpointsUseCase {
+	return &ListEndpointsUseCase{
+		endpointRepo: endpointRepo,
+	}
+}
+
+// Execute lists every webhook endpoint
+func (uc *ListEndpointsUseCase) Execute(ctx context.Context) ([]*entities.Endpoint, error) {
+	return uc.endpointRepo.List(ctx)
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/internal/logging"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// claimLease is how long claimed deliveries stay hidden from other workers.
+// It outlasts a batch of timed out requests.
+const claimLease = 30 * time.Minute
+
+// ProcessDeliveriesUseCase handles posting due deliveries
+type ProcessDeliveriesUseCase struct {
+	endpointRepo ports.EndpointRepository
+	deliveryRepo ports.DeliveryRepository
+	sender       ports.Sender
+	retry        eventbus.RetryPolicy
+	batchSize    int
+}
+
+// NewProcessDeliveriesUseCase creates a new ProcessDeliveriesUseCase
+func NewProcessDeliveriesUseCase(
+	endpointRepo ports.EndpointRepository,
+	deliveryRepo ports.DeliveryRepository,
+	sender ports.Sender,
+	retry eventbus.RetryPolicy,
+	batchSize int,
+) *ProcessDeliveriesUseCase {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = 1
+	}
+
+	return &ProcessDeliveriesUseCase{
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		retry:        retry,
+		batchSize:    batchSize,
+	}
+}
+
+// ProcessDeliveriesOutput represents the outcome of a run
+type ProcessDeliveriesOutput struct {
+	Succeeded int
+	Retrying  int
+	Dead      int
+}
+
+// Execute posts due deliveries of active endpoints, oldest first. Failed
+// deliveries are retried with exponential backoff and dead-lettered after
+// the last attempt.
+func (uc *ProcessDeliveriesUseCase) Execute(ctx context.Context) (*ProcessDeliveriesOutput, error) {
+	deliveries, err := uc.deliveryRepo.ClaimDue(ctx, time.Now(), claimLease, uc.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ProcessDeliveriesOutput{}
+	endpoints := make(map[string]*entities.Endpoint)
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			break
+		}
+
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			endpoint, err = uc.endpointRepo.GetByID(ctx, delivery.EndpointID)
+			if errors.Is(err, entities.ErrEndpointNotFound) {
+				continue // deleted along with its deliveries
+			}
+			if err != nil {
+				return output, err
+			}
+			endpoints[delivery.EndpointID] = endpoint
+		}
+
+		if _, err := deliver(ctx, uc.deliveryRepo, uc.sender, uc.retry, endpoint, delivery); err != nil {
+			logging.FromContext(ctx).Error("failed to record webhook delivery", "delivery_id", delivery.ID, "error", err)
+			continue
+		}
+
+		switch delivery.Status {
+		case entities.DeliverySucceeded:
+			output.Succeeded++
+		case entities.DeliveryDead:
+			output.Dead++
+			logging.FromContext(ctx).Warn("webhook delivery is dead", "delivery_id", delivery.ID, "url", endpoint.URL, "attempts", delivery.Attempts, "last_error", delivery.LastError)
+		default:
+			output.Retrying++
+		}
+	}
+
+	return output, nil
+}
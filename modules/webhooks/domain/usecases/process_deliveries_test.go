@@ -0,0 +1,302 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/infra/sender"
+)
+
+// fakeEndpointRepo keeps endpoints in memory. Methods the tests do not need
+// panic through the embedded nil interface.
+type fakeEndpointRepo struct {
+	ports.EndpointRepository
+	endpoints map[string]*entities.Endpoint
+}
+
+func (r *fakeEndpointRepo) GetByID(ctx context.Context, id string) (*entities.Endpoint, error) {
+	endpoint, ok := r.endpoints[id]
+	if !ok {
+		return nil, entities.ErrEndpointNotFound
+	}
+	copied := *endpoint
+	return &copied, nil
+}
+
+// fakeDeliveryRepo keeps deliveries and their attempts in memory
+type fakeDeliveryRepo struct {
+	ports.DeliveryRepository
+	deliveries map[string]*entities.Delivery
+	attempts   map[string][]*entities.Attempt
+}
+
+func newFakeDeliveryRepo(deliveries ...*entities.Delivery) *fakeDeliveryRepo {
+	r := &fakeDeliveryRepo{
+		deliveries: make(map[string]*entities.Delivery),
+		attempts:   make(map[string][]*entities.Attempt),
+	}
+	for _, delivery := range deliveries {
+		copied := *delivery
+		r.deliveries[delivery.ID] = &copied
+	}
+	return r
+}
+
+func (r *fakeDeliveryRepo) GetByID(ctx context.Context, endpointID, id string) (*entities.Delivery, error) {
+	delivery, ok := r.deliveries[id]
+	if !ok || delivery.EndpointID != endpointID {
+		return nil, entities.ErrDeliveryNotFound
+	}
+	copied := *delivery
+	return &copied, nil
+}
+
+func (r *fakeDeliveryRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.Delivery, error) {
+	deliveries := make([]*entities.Delivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.Status != entities.DeliveryPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+		leased := now.Add(lease)
+		delivery.NextAttemptAt = &leased
+		copied := *delivery
+		deliveries = append(deliveries, &copied)
+	}
+	return deliveries, nil
+}
+
+func (r *fakeDeliveryRepo) Update(ctx context.Context, delivery *entities.Delivery) error {
+	if _, ok := r.deliveries[delivery.ID]; !ok {
+		return entities.ErrDeliveryNotFound
+	}
+	copied := *delivery
+	r.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+func (r *fakeDeliveryRepo) AddAttempt(ctx context.Context, attempt *entities.Attempt) error {
+	r.attempts[attempt.DeliveryID] = append(r.attempts[attempt.DeliveryID], attempt)
+	return nil
+}
+
+// makeDue moves the next attempt of a delivery into the past, as if its
+// backoff had elapsed
+func (r *fakeDeliveryRepo) makeDue(id string) {
+	past := time.Now().Add(-time.Second)
+	r.deliveries[id].NextAttemptAt = &past
+}
+
+// receiver is a local webhook endpoint answering with the queued status
+// codes, then 204
+type receiver struct {
+	t      *testing.T
+	secret string
+
+	mu       sync.Mutex
+	statuses []int
+	requests int
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rc.t.Errorf("read body: %v", err)
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get("X-Webhook-Timestamp"), 10, 64)
+	if err != nil {
+		rc.t.Errorf("X-Webhook-Timestamp %q: %v", r.Header.Get("X-Webhook-Timestamp"), err)
+	}
+	if want := "sha256=" + sender.Sign(rc.secret, timestamp, body); r.Header.Get("X-Webhook-Signature") != want {
+		rc.t.Errorf("X-Webhook-Signature = %q, want %q", r.Header.Get("X-Webhook-Signature"), want)
+	}
+	if r.Header.Get("Content-Type") != "application/json" || r.Header.Get("X-Webhook-ID") == "" {
+		rc.t.Errorf("unexpected headers %v", r.Header)
+	}
+
+	var payload entities.Payload
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Type != r.Header.Get("X-Webhook-Event") {
+		rc.t.Errorf("payload %s does not match event %q: %v", body, r.Header.Get("X-Webhook-Event"), err)
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests++
+	status := http.StatusNoContent
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte("received"))
+}
+
+// webhookDelivery wires ProcessDeliveriesUseCase to a local receiver
+type webhookDelivery struct {
+	receiver   *receiver
+	endpoint   *entities.Endpoint
+	delivery   *entities.Delivery
+	deliveries *fakeDeliveryRepo
+	retry      eventbus.RetryPolicy
+	process    *ProcessDeliveriesUseCase
+}
+
+func newWebhookDelivery(t *testing.T, retry eventbus.RetryPolicy, statuses ...int) *webhookDelivery {
+	t.Helper()
+	rc := &receiver{t: t, statuses: statuses}
+	server := httptest.NewServer(rc)
+	t.Cleanup(server.Close)
+
+	endpoint, err := entities.NewEndpoint(server.URL, "", []string{entities.AllEvents}, "admin")
+	if err != nil {
+		t.Fatalf("NewEndpoint: %v", err)
+	}
+	rc.secret = endpoint.Secret
+
+	delivery, err := entities.NewDelivery(endpoint.ID, &entities.Payload{
+		ID:         "event-1",
+		Type:       "meeting.created",
+		OccurredAt: time.Now(),
+		Data:       json.RawMessage(`{"meeting_id":"m-1"}`),
+	})
+	if err != nil {
+		t.Fatalf("NewDelivery: %v", err)
+	}
+
+	endpoints := &fakeEndpointRepo{endpoints: map[string]*entities.Endpoint{endpoint.ID: endpoint}}
+	deliveries := newFakeDeliveryRepo(delivery)
+
+	return &webhookDelivery{
+		receiver:   rc,
+		endpoint:   endpoint,
+		delivery:   delivery,
+		deliveries: deliveries,
+		retry:      retry,
+		process:    NewProcessDeliveriesUseCase(endpoints, deliveries, sender.NewHTTPSender(time.Second).WithTransport(server.Client().Transport), retry, 10),
+	}
+}
+
+// run processes due deliveries and returns the stored delivery
+func (w *webhookDelivery) run(t *testing.T) (*ProcessDeliveriesOutput, *entities.Delivery) {
+	t.Helper()
+	output, err := w.process.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("process deliveries: %v", err)
+	}
+	return output, w.deliveries.deliveries[w.delivery.ID]
+}
+
+func TestProcessDeliveriesSignsAndSucceeds(t *testing.T) {
+	w := newWebhookDelivery(t, eventbus.RetryPolicy{MaxAttempts: 3, Backoff: time.Minute})
+
+	output, delivery := w.run(t)
+	if output.Succeeded != 1 || output.Retrying != 0 || output.Dead != 0 {
+		t.Fatalf("output = %+v, want one success", output)
+	}
+	if delivery.Status != entities.DeliverySucceeded || delivery.DeliveredAt == nil || delivery.NextAttemptAt != nil {
+		t.Fatalf("delivery = %+v, want succeeded", delivery)
+	}
+	attempts := w.deliveries.attempts[delivery.ID]
+	if len(attempts) != 1 || attempts[0].StatusCode != http.StatusNoContent {
+		t.Fatalf("attempts = %+v, want one 204", attempts)
+	}
+
+	// Delivered deliveries are not claimed again
+	if output, _ := w.run(t); *output != (ProcessDeliveriesOutput{}) {
+		t.Fatalf("second run = %+v, want nothing processed", output)
+	}
+	if w.receiver.requests != 1 {
+		t.Fatalf("receiver got %d requests, want 1", w.receiver.requests)
+	}
+}
+
+func TestProcessDeliveriesRetriesWithBackoff(t *testing.T) {
+	retry := eventbus.RetryPolicy{MaxAttempts: 5, Backoff: time.Minute, MaxBackoff: 3 * time.Minute}
+	w := newWebhookDelivery(t, retry, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable)
+
+	for i, backoff := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		output, delivery := w.run(t)
+		if output.Retrying != 1 {
+			t.Fatalf("attempt %d: output = %+v, want a retry", i+1, output)
+		}
+		if delivery.Status != entities.DeliveryPending || delivery.Attempts != i+1 {
+			t.Fatalf("attempt %d: delivery = %+v, want pending", i+1, delivery)
+		}
+		attempts := w.deliveries.attempts[delivery.ID]
+		last := attempts[len(attempts)-1]
+		if last.StatusCode < 500 || last.Error == "" || last.Response != "received" {
+			t.Fatalf("attempt %d = %+v, want a recorded 5xx", i+1, last)
+		}
+		if got := delivery.NextAttemptAt.Sub(last.AttemptedAt); got != backoff {
+			t.Fatalf("attempt %d: retry after %v, want %v", i+1, got, backoff)
+		}
+
+		// Not due until the backoff elapsed
+		if output, _ := w.run(t); output.Retrying+output.Succeeded != 0 {
+			t.Fatalf("attempt %d: delivery retried before its backoff", i+1)
+		}
+		w.deliveries.makeDue(delivery.ID)
+	}
+
+	output, delivery := w.run(t)
+	if output.Succeeded != 1 || delivery.Status != entities.DeliverySucceeded || delivery.Attempts != 4 {
+		t.Fatalf("output = %+v, delivery = %+v, want success on the fourth attempt", output, delivery)
+	}
+	if w.receiver.requests != 4 {
+		t.Fatalf("receiver got %d requests, want 4", w.receiver.requests)
+	}
+}
+
+func TestProcessDeliveriesDeadLettersAndRedelivers(t *testing.T) {
+	w := newWebhookDelivery(t, eventbus.RetryPolicy{MaxAttempts: 2, Backoff: time.Minute},
+		http.StatusInternalServerError, http.StatusInternalServerError)
+
+	w.run(t)
+	w.deliveries.makeDue(w.delivery.ID)
+	output, delivery := w.run(t)
+	if output.Dead != 1 {
+		t.Fatalf("output = %+v, want a dead delivery", output)
+	}
+	if delivery.Status != entities.DeliveryDead || delivery.Attempts != 2 || delivery.NextAttemptAt != nil {
+		t.Fatalf("delivery = %+v, want dead after 2 attempts", delivery)
+	}
+	if delivery.LastStatusCode != http.StatusInternalServerError || delivery.LastError == "" {
+		t.Fatalf("delivery = %+v, want the last failure recorded", delivery)
+	}
+
+	// Dead deliveries stay put until redelivered by hand
+	if output, _ := w.run(t); *output != (ProcessDeliveriesOutput{}) {
+		t.Fatalf("run after dead-lettering = %+v, want nothing processed", output)
+	}
+
+	redeliver := NewRedeliverUseCase(w.deliveries)
+	redelivered, err := redeliver.Execute(context.Background(), w.endpoint.ID, delivery.ID)
+	if err != nil {
+		t.Fatalf("redeliver: %v", err)
+	}
+	if redelivered.Status != entities.DeliveryPending || redelivered.Attempts != 0 {
+		t.Fatalf("redelivered = %+v, want pending with fresh attempts", redelivered)
+	}
+
+	output, delivery = w.run(t)
+	if output.Succeeded != 1 || delivery.Status != entities.DeliverySucceeded {
+		t.Fatalf("output = %+v, delivery = %+v, want the redelivery to succeed", output, delivery)
+	}
+	if w.receiver.requests != 3 {
+		t.Fatalf("receiver got %d requests, want 3", w.receiver.requests)
+	}
+
+	if _, err := redeliver.Execute(context.Background(), w.endpoint.ID, delivery.ID); !errors.Is(err, entities.ErrDeliveryNotDead) {
+		t.Fatalf("redeliver a delivered delivery: err = %v, want ErrDeliveryNotDead", err)
+	}
+}
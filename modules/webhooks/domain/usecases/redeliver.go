@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// RedeliverUseCase handles queueing dead deliveries again
+type RedeliverUseCase struct {
+	deliveryRepo ports.DeliveryRepository
+}
+
+// NewRedeliverUseCase creates a new RedeliverUseCase
+func NewRedeliverUseCase(deliveryRepo ports.DeliveryRepository) *RedeliverUseCase {
+	return &RedeliverUseCase{
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// Execute queues a dead delivery for immediate delivery with a fresh set of
+// attempts
+func (uc *RedeliverUseCase) Execute(ctx context.Context, endpointID, deliveryID string) (*entities.Delivery, error) {
+	delivery, err := uc.deliveryRepo.GetByID(ctx, endpointID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if err := delivery.Redeliver(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.deliveryRepo.Update(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
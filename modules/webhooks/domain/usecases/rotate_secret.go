@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// RotateSecretUseCase handles replacing the signing secret of an endpoint
+type RotateSecretUseCase struct {
+	endpointRepo ports.EndpointRepository
+}
+
+// NewRotateSecretUseCase creates a new RotateSecretUseCase
+func NewRotateSecretUseCase(endpointRepo ports.EndpointRepository) *RotateSecretUseCase {
+	return &RotateSecretUseCase{
+		endpointRepo: endpointRepo,
+	}
+}
+
+// Execute replaces the signing secret. Pending retries are signed with the
+// new secret.
+func (uc *RotateSecretUseCase) Execute(ctx context.Context, endpointID string) (*entities.Endpoint, error) {
+	endpoint, err := uc.endpointRepo.GetByID(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if err := endpoint.RotateSecret(); err != nil {
+		return nil, err
+	}
+	if err := uc.endpointRepo.Update(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
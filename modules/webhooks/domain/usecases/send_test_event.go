@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// SendTestEventOutput represents a test delivery and its first attempt
+type SendTestEventOutput struct {
+	Delivery *entities.Delivery
+	Attempt  *entities.Attempt
+}
+
+// SendTestEventUseCase handles sending test events to endpoints
+type SendTestEventUseCase struct {
+	endpointRepo ports.EndpointRepository
+	deliveryRepo ports.DeliveryRepository
+	sender       ports.Sender
+	retry        eventbus.RetryPolicy
+}
+
+// NewSendTestEventUseCase creates a new SendTestEventUseCase
+func NewSendTestEventUseCase(
+	endpointRepo ports.EndpointRepository,
+	deliveryRepo ports.DeliveryRepository,
+	sender ports.Sender,
+	retry eventbus.RetryPolicy,
+) *SendTestEventUseCase {
+	if retry.MaxAttempts <= 0 {
+		retry.MaxAttempts = 1
+	}
+
+	return &SendTestEventUseCase{
+		endpointRepo: endpointRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		retry:        retry,
+	}
+}
+
+// Execute posts a webhook.test event to the endpoint right away, even if it
+// is inactive, and returns the outcome. A failed test is retried like any
+// other delivery.
+func (uc *SendTestEventUseCase) Execute(ctx context.Context, endpointID string) (*SendTestEventOutput, error) {
+	endpoint, err := uc.endpointRepo.GetByID(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(map[string]string{
+		"endpoint_id": endpoint.ID,
+		"message":     "This is a test event.",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := entities.NewDelivery(endpoint.ID, &entities.Payload{
+		ID:         uuid.New().String(),
+		Type:       entities.TestEventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.deliveryRepo.CreateBatch(ctx, []*entities.Delivery{delivery}); err != nil {
+		return nil, err
+	}
+
+	attempt, err := deliver(ctx, uc.deliveryRepo, uc.sender, uc.retry, endpoint, delivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendTestEventOutput{
+		Delivery: delivery,
+		Attempt:  attempt,
+	}, nil
+}
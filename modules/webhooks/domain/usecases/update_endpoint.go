@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/ports"
+)
+
+// UpdateEndpointInput represents changes to a webhook endpoint. Nil fields
+// are left unchanged.
+type UpdateEndpointInput struct {
+	URL         *string
+	Description *string
+	EventTypes  []string
+	Active      *bool
+}
+
+// UpdateEndpointUseCase handles changing webhook endpoints
+type UpdateEndpointUseCase struct {
+	endpointRepo ports.EndpointRepository
+	eventTypes   []string
+	requireHTTPS bool
+}
+
+// NewUpdateEndpointUseCase creates a new UpdateEndpointUseCase
+func NewUpdateEndpointUseCase(endpointRepo ports.EndpointRepository, eventTypes []string, requireHTTPS bool) *UpdateEndpointUseCase {
+	return &UpdateEndpointUseCase{
+		endpointRepo: endpointRepo,
+		eventTypes:   eventTypes,
+		requireHTTPS: requireHTTPS,
+	}
+}
+
+// Execute updates an endpoint. Deliveries of a deactivated endpoint wait
+// until it is activated again.
+func (uc *UpdateEndpointUseCase) Execute(ctx context.Context, endpointID string, input UpdateEndpointInput) (*entities.Endpoint, error) {
+	endpoint, err := uc.endpointRepo.GetByID(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		if err := entities.ValidateURL(*input.URL, uc.requireHTTPS); err != nil {
+			return nil, err
+		}
+		endpoint.URL = *input.URL
+	}
+	if input.Description != nil {
+		endpoint.Description = *input.Description
+	}
+	if input.EventTypes != nil {
+		if err := entities.ValidateEventTypes(input.EventTypes, uc.eventTypes); err != nil {
+			return nil, err
+		}
+		endpoint.EventTypes = input.EventTypes
+	}
+	if input.Active != nil {
+		endpoint.Active = *input.Active
+	}
+	endpoint.UpdatedAt = time.Now()
+
+	if err := uc.endpointRepo.Update(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return endpoint, nil
+}
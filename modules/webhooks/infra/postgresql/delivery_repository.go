@@ -0,0 +1,214 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+)
+
+const deliveryColumns = `id, endpoint_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+	last_status_code, last_error, delivered_at, created_at, updated_at`
+
+// DeliveryRepository implements webhook delivery persistence
+type DeliveryRepository struct {
+	db *sql.DB
+}
+
+// NewDeliveryRepository creates a new repository
+func NewDeliveryRepository(db *sql.DB) *DeliveryRepository {
+	return &DeliveryRepository{db: db}
+}
+
+// CreateBatch stores deliveries, skipping those whose endpoint already has a
+// delivery of the event
+func (r *DeliveryRepository) CreateBatch(ctx context.Context, deliveries []*entities.Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (id, endpoint_id, event_id, event_type, payload, status, attempts,
+			next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (endpoint_id, event_id) DO NOTHING
+	`
+	for _, delivery := range deliveries {
+		_, err := r.db.ExecContext(ctx, query,
+			delivery.ID, delivery.EndpointID, delivery.EventID, delivery.EventType, string(delivery.Payload),
+			delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.CreatedAt, delivery.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByID retrieves a delivery of an endpoint by ID
+func (r *DeliveryRepository) GetByID(ctx context.Context, endpointID, id string) (*entities.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1 AND endpoint_id = $2`
+	delivery, err := scanDelivery(r.db.QueryRowContext(ctx, query, id, endpointID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// ListByEndpoint retrieves the deliveries of an endpoint, newest first
+func (r *DeliveryRepository) ListByEndpoint(ctx context.Context, endpointID, status string, limit, offset int) ([]*entities.Delivery, int, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries
+		WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	deliveries, err := r.query(ctx, query, endpointID, status, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM webhook_deliveries WHERE endpoint_id = $1 AND ($2 = '' OR status = $2)`
+	if err := r.db.QueryRowContext(ctx, countQuery, endpointID, status).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+// ClaimDue retrieves pending deliveries of active endpoints due at now and
+// pushes them back by lease, skipping deliveries other workers are claiming
+// at the same time
+func (r *DeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entities.Delivery, error) {
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT d.id FROM webhook_deliveries d
+			JOIN webhook_endpoints e ON e.id = d.endpoint_id
+			WHERE d.status = 'pending' AND d.next_attempt_at <= $1 AND e.active
+			ORDER BY d.next_attempt_at
+			LIMIT $3
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+	deliveries, err := r.query(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// RETURNING gives no order guarantee
+	sortByCreation(deliveries)
+	return deliveries, nil
+}
+
+// Update updates the state of a delivery
+func (r *DeliveryRepository) Update(ctx context.Context, delivery *entities.Delivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6,
+			delivered_at = $7, updated_at = $8
+		WHERE id = $1
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		sql.NullInt64{Int64: int64(delivery.LastStatusCode), Valid: delivery.LastStatusCode != 0},
+		nullString(delivery.LastError), delivery.DeliveredAt, delivery.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return requireRow(result, entities.ErrDeliveryNotFound)
+}
+
+// AddAttempt records an attempt to post a delivery
+func (r *DeliveryRepository) AddAttempt(ctx context.Context, attempt *entities.Attempt) error {
+	query := `
+		INSERT INTO webhook_attempts (delivery_id, status_code, error, response, duration_ms, attempted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		attempt.DeliveryID, sql.NullInt64{Int64: int64(attempt.StatusCode), Valid: attempt.StatusCode != 0},
+		nullString(attempt.Error), nullString(attempt.Response), attempt.Duration.Milliseconds(), attempt.AttemptedAt,
+	)
+	return err
+}
+
+// ListAttempts retrieves the attempts of a delivery, oldest first
+func (r *DeliveryRepository) ListAttempts(ctx context.Context, deliveryID string) ([]*entities.Attempt, error) {
+	query := `
+		SELECT delivery_id, status_code, error, response, duration_ms, attempted_at
+		FROM webhook_attempts
+		WHERE delivery_id = $1
+		ORDER BY attempted_at, id
+	`
+	rows, err := r.db.QueryContext(ctx, query, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*entities.Attempt
+	for rows.Next() {
+		attempt := &entities.Attempt{}
+		var statusCode sql.NullInt64
+		var attemptErr, response sql.NullString
+		var durationMS int64
+		if err := rows.Scan(&attempt.DeliveryID, &statusCode, &attemptErr, &response, &durationMS, &attempt.AttemptedAt); err != nil {
+			return nil, err
+		}
+		attempt.StatusCode = int(statusCode.Int64)
+		attempt.Error = attemptErr.String
+		attempt.Response = response.String
+		attempt.Duration = time.Duration(durationMS) * time.Millisecond
+		attempts = append(attempts, attempt)
+	}
+	return attempts, rows.Err()
+}
+
+func (r *DeliveryRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*entities.Delivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanDelivery(row rowScanner) (*entities.Delivery, error) {
+	delivery := &entities.Delivery{}
+	var payload []byte
+	var statusCode sql.NullInt64
+	var lastError sql.NullString
+	err := row.Scan(
+		&delivery.ID, &delivery.EndpointID, &delivery.EventID, &delivery.EventType, &payload, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &statusCode, &lastError, &delivery.DeliveredAt,
+		&delivery.CreatedAt, &delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = payload
+	delivery.LastStatusCode = int(statusCode.Int64)
+	delivery.LastError = lastError.String
+	return delivery, nil
+}
+
+func sortByCreation(deliveries []*entities.Delivery) {
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+	})
+}
@@ -0,0 +1,144 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+)
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const endpointColumns = `id, url, description, event_types, secret, active, created_by, created_at, updated_at`
+
+// EndpointRepository implements webhook endpoint persistence
+type EndpointRepository struct {
+	db *sql.DB
+}
+
+// NewEndpointRepository creates a new repository
+func NewEndpointRepository(db *sql.DB) *EndpointRepository {
+	return &EndpointRepository{db: db}
+}
+
+// Create creates a new endpoint
+func (r *EndpointRepository) Create(ctx context.Context, endpoint *entities.Endpoint) error {
+	query := `
+		INSERT INTO webhook_endpoints (` + endpointColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		endpoint.ID, endpoint.URL, endpoint.Description, pq.Array(endpoint.EventTypes), endpoint.Secret,
+		endpoint.Active, nullString(endpoint.CreatedBy), endpoint.CreatedAt, endpoint.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID retrieves an endpoint by ID
+func (r *EndpointRepository) GetByID(ctx context.Context, id string) (*entities.Endpoint, error) {
+	query := `SELECT ` + endpointColumns + ` FROM webhook_endpoints WHERE id = $1`
+	endpoint, err := scanEndpoint(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entities.ErrEndpointNotFound
+		}
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// List retrieves all endpoints, newest first
+func (r *EndpointRepository) List(ctx context.Context) ([]*entities.Endpoint, error) {
+	query := `SELECT ` + endpointColumns + ` FROM webhook_endpoints ORDER BY created_at DESC`
+	return r.query(ctx, query)
+}
+
+// ListSubscribed retrieves the active endpoints subscribed to eventType
+func (r *EndpointRepository) ListSubscribed(ctx context.Context, eventType string) ([]*entities.Endpoint, error) {
+	query := `
+		SELECT ` + endpointColumns + `
+		FROM webhook_endpoints
+		WHERE active AND ($1 = ANY(event_types) OR $2 = ANY(event_types))
+		ORDER BY created_at
+	`
+	return r.query(ctx, query, eventType, entities.AllEvents)
+}
+
+// Update updates an endpoint
+func (r *EndpointRepository) Update(ctx context.Context, endpoint *entities.Endpoint) error {
+	query := `
+		UPDATE webhook_endpoints
+		SET url = $2, description = $3, event_types = $4, secret = $5, active = $6, updated_at = $7
+		WHERE id = $1
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		endpoint.ID, endpoint.URL, endpoint.Description, pq.Array(endpoint.EventTypes), endpoint.Secret,
+		endpoint.Active, endpoint.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return requireRow(result, entities.ErrEndpointNotFound)
+}
+
+// Delete deletes an endpoint; its deliveries go with it
+func (r *EndpointRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result, entities.ErrEndpointNotFound)
+}
+
+func (r *EndpointRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entities.Endpoint, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var endpoints []*entities.Endpoint
+	for rows.Next() {
+		endpoint, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, rows.Err()
+}
+
+func scanEndpoint(row rowScanner) (*entities.Endpoint, error) {
+	endpoint := &entities.Endpoint{}
+	var createdBy sql.NullString
+	err := row.Scan(
+		&endpoint.ID, &endpoint.URL, &endpoint.Description, pq.Array(&endpoint.EventTypes), &endpoint.Secret,
+		&endpoint.Active, &createdBy, &endpoint.CreatedAt, &endpoint.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.CreatedBy = createdBy.String
+	return endpoint, nil
+}
+
+// requireRow returns notFound when the statement changed no row
+func requireRow(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,116 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/internal/netguard"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+)
+
+// responseLimit is how much of a response body is kept in the delivery log
+const responseLimit = 2 << 10
+
+// HTTPSender posts deliveries as signed JSON requests
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender creates a new HTTPSender. Since tenants choose the URLs,
+// only public addresses are dialed, and redirects are not followed, so a
+// delivery only ever reaches the registered URL and never an internal
+// service.
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: netguard.NewTransport(timeout),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// WithTransport returns a copy of the sender that sends through transport
+// instead of the public-only default, e.g. to reach a test receiver on the
+// loopback interface
+func (s *HTTPSender) WithTransport(transport http.RoundTripper) *HTTPSender {
+	client := *s.client
+	client.Transport = transport
+	return &HTTPSender{client: &client}
+}
+
+// Sign returns the signature of a payload sent at timestamp (Unix seconds):
+// the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the endpoint
+// secret. Receivers recompute it to verify the X-Webhook-Signature header
+// and reject stale timestamps to stop replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts the delivery to the endpoint. Anything but a 2xx response is
+// a failed attempt.
+func (s *HTTPSender) Send(ctx context.Context, endpoint *entities.Endpoint, delivery *entities.Delivery) *entities.Attempt {
+	attempt := &entities.Attempt{
+		DeliveryID:  delivery.ID,
+		AttemptedAt: time.Now(),
+	}
+	defer func() {
+		attempt.Duration = time.Since(attempt.AttemptedAt)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	timestamp := attempt.AttemptedAt.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EvtaarPro-Webhooks/1.0")
+	req.Header.Set("X-Webhook-ID", delivery.ID)
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", "sha256="+Sign(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	attempt.StatusCode = resp.StatusCode
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, responseLimit))
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	attempt.Response = printable(body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("endpoint returned %s", resp.Status)
+	}
+	return attempt
+}
+
+// printable makes a response body storable in a TEXT column, which rejects
+// invalid UTF-8 (e.g. a rune cut in half by the limit) and NUL bytes
+func printable(body []byte) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(string(body), ""), "\x00", "")
+}
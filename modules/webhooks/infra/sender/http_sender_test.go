@@ -0,0 +1,134 @@
+package sender
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		// echo -n '1700000000.{"id":"1"}' | openssl dgst -sha256 -hmac whsec_test
+		{"reference", "whsec_test", 1700000000, `{"id":"1"}`, "11bf4466ea17c3df3fd743af0b435368e16b7a05eb8eced85e8c4670767bdec5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Fatalf("Sign = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	reference := Sign("whsec_test", 1700000000, []byte(`{"id":"1"}`))
+	for name, got := range map[string]string{
+		"secret":    Sign("whsec_other", 1700000000, []byte(`{"id":"1"}`)),
+		"timestamp": Sign("whsec_test", 1700000001, []byte(`{"id":"1"}`)),
+		"body":      Sign("whsec_test", 1700000000, []byte(`{"id":"2"}`)),
+	} {
+		if got == reference {
+			t.Fatalf("changing the %s kept the signature", name)
+		}
+	}
+}
+
+func TestHTTPSenderSend(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("down\x00 \xff" + strings.Repeat("x", 2*responseLimit)))
+	})
+	var redirected bool
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/elsewhere", func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Trust the loopback receiver, keeping the redirect policy of the sender
+	sender := NewHTTPSender(time.Second).WithTransport(server.Client().Transport)
+	delivery := &entities.Delivery{ID: "delivery-1", EventType: "meeting.created", Payload: []byte(`{}`)}
+
+	tests := []struct {
+		path      string
+		status    int
+		succeeded bool
+	}{
+		{"/ok", http.StatusAccepted, true},
+		{"/fail", http.StatusServiceUnavailable, false},
+		{"/redirect", http.StatusTemporaryRedirect, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			endpoint := &entities.Endpoint{URL: server.URL + tt.path, Secret: "whsec_test"}
+			attempt := sender.Send(context.Background(), endpoint, delivery)
+			if attempt.StatusCode != tt.status || attempt.Succeeded() != tt.succeeded {
+				t.Fatalf("attempt = %+v, want status %d", attempt, tt.status)
+			}
+			if attempt.DeliveryID != delivery.ID || attempt.AttemptedAt.IsZero() {
+				t.Fatalf("attempt = %+v, want it tied to the delivery", attempt)
+			}
+			if len(attempt.Response) > responseLimit || strings.ContainsAny(attempt.Response, "\x00�") {
+				t.Fatalf("stored response of %d bytes is not printable or too long", len(attempt.Response))
+			}
+		})
+	}
+	if redirected {
+		t.Fatal("the redirect was followed")
+	}
+
+	// Connection failures are reported in the attempt
+	server.Close()
+	attempt := sender.Send(context.Background(), &entities.Endpoint{URL: server.URL + "/ok"}, delivery)
+	if attempt.Succeeded() || attempt.StatusCode != 0 || attempt.Error == "" {
+		t.Fatalf("attempt against a closed server = %+v", attempt)
+	}
+}
+
+func TestHTTPSenderRefusesInternalAddresses(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(time.Second)
+	delivery := &entities.Delivery{ID: "delivery-1", EventType: "meeting.created", Payload: []byte(`{}`)}
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"loopback server", server.URL},
+		{"loopback by name", strings.Replace(server.URL, "127.0.0.1", "localhost", 1)},
+		{"metadata service", "http://169.254.169.254/latest/meta-data/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempt := sender.Send(context.Background(), &entities.Endpoint{URL: tt.url, Secret: "whsec_test"}, delivery)
+			if attempt.Succeeded() || attempt.StatusCode != 0 || !strings.Contains(attempt.Error, "not publicly routable") {
+				t.Fatalf("attempt = %+v, want the connection refused", attempt)
+			}
+		})
+	}
+	if called {
+		t.Fatal("the loopback server was reached")
+	}
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"context"
+
+	"github.com/manab-pr/evtaarpro/internal/eventbus"
+	crmevents "github.com/manab-pr/evtaarpro/modules/crm/domain/events"
+	meetingevents "github.com/manab-pr/evtaarpro/modules/meetings/domain/events"
+	payrollevents "github.com/manab-pr/evtaarpro/modules/payroll/domain/events"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/usecases"
+)
+
+// subscriber names the webhooks module in the record of handled events
+const subscriber = "webhooks"
+
+// EventTypes lists the domain events endpoints may subscribe to. The event
+// payloads are sent as is, so their JSON fields are part of the webhook API.
+var EventTypes = []string{
+	meetingevents.MeetingCancelledEvent,
+	payrollevents.PayrollApprovedEvent,
+	crmevents.CustomerAssignedEvent,
+}
+
+// Subscriber queues domain events for the webhook endpoints subscribed to
+// them
+type Subscriber struct {
+	enqueueEventUC *usecases.EnqueueEventUseCase
+}
+
+// NewSubscriber creates a new Subscriber
+func NewSubscriber(enqueueEventUC *usecases.EnqueueEventUseCase) *Subscriber {
+	return &Subscriber{
+		enqueueEventUC: enqueueEventUC,
+	}
+}
+
+// Register subscribes the subscriber to every event type in EventTypes
+func (s *Subscriber) Register(bus *eventbus.Bus) {
+	for _, eventType := range EventTypes {
+		bus.Subscribe(eventType, subscriber, s.enqueue)
+	}
+}
+
+func (s *Subscriber) enqueue(ctx context.Context, envelope *eventbus.Envelope) error {
+	_, err := s.enqueueEventUC.Execute(ctx, envelope)
+	return err
+}
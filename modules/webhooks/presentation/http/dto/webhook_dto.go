@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateEndpointRequest represents a webhook endpoint registration
+type CreateEndpointRequest struct {
+	URL         string   `json:"url" binding:"required"`
+	Description string   `json:"description"`
+	EventTypes  []string `json:"event_types" binding:"required,min=1"` // "*" subscribes to every type
+}
+
+// UpdateEndpointRequest represents changes to a webhook endpoint; omitted
+// fields are left unchanged
+type UpdateEndpointRequest struct {
+	URL         *string  `json:"url"`
+	Description *string  `json:"description"`
+	EventTypes  []string `json:"event_types" binding:"omitempty,min=1"`
+	Active      *bool    `json:"active"`
+}
+
+// EndpointResponse represents a webhook endpoint
+type EndpointResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Description string    `json:"description"`
+	EventTypes  []string  `json:"event_types"`
+	Secret      string    `json:"secret,omitempty"` // only when created or rotated
+	Active      bool      `json:"active"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DeliveryResponse represents the delivery of an event to an endpoint
+type DeliveryResponse struct {
+	ID             string          `json:"id"`
+	EndpointID     string          `json:"endpoint_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"` // pending, succeeded or dead
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	Payload        json.RawMessage `json:"payload,omitempty"` // only on the delivery detail
+}
+
+// AttemptResponse represents one try to post a delivery
+type AttemptResponse struct {
+	StatusCode  int       `json:"status_code,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Response    string    `json:"response,omitempty"` // start of the response body
+	DurationMS  int64     `json:"duration_ms"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+// DeliveryDetailResponse represents a delivery with its attempts
+type DeliveryDetailResponse struct {
+	DeliveryResponse
+	AttemptLog []AttemptResponse `json:"attempt_log"`
+}
+
+// TestEventResponse represents the outcome of sending a test event
+type TestEventResponse struct {
+	Delivery DeliveryResponse `json:"delivery"`
+	Attempt  AttemptResponse  `json:"attempt"`
+}
@@ -0,0 +1,406 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/response"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/entities"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/domain/usecases"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/presentation/http/dto"
+)
+
+// WebhookHandlers contains webhook HTTP handlers
+type WebhookHandlers struct {
+	createEndpointUC *usecases.CreateEndpointUseCase
+	listEndpointsUC  *usecases.ListEndpointsUseCase
+	getEndpointUC    *usecases.GetEndpointUseCase
+	updateEndpointUC *usecases.UpdateEndpointUseCase
+	deleteEndpointUC *usecases.DeleteEndpointUseCase
+	rotateSecretUC   *usecases.RotateSecretUseCase
+	sendTestEventUC  *usecases.SendTestEventUseCase
+	listDeliveriesUC *usecases.ListDeliveriesUseCase
+	getDeliveryUC    *usecases.GetDeliveryUseCase
+	redeliverUC      *usecases.RedeliverUseCase
+	eventTypes       []string
+}
+
+// NewWebhookHandlers creates new WebhookHandlers. eventTypes lists the
+// event types endpoints may subscribe to.
+func NewWebhookHandlers(
+	createEndpointUC *usecases.CreateEndpointUseCase,
+	listEndpointsUC *usecases.ListEndpointsUseCase,
+	getEndpointUC *usecases.GetEndpointUseCase,
+	updateEndpointUC *usecases.UpdateEndpointUseCase,
+	deleteEndpointUC *usecases.DeleteEndpointUseCase,
+	rotateSecretUC *usecases.RotateSecretUseCase,
+	sendTestEventUC *usecases.SendTestEventUseCase,
+	listDeliveriesUC *usecases.ListDeliveriesUseCase,
+	getDeliveryUC *usecases.GetDeliveryUseCase,
+	redeliverUC *usecases.RedeliverUseCase,
+	eventTypes []string,
+) *WebhookHandlers {
+	return &WebhookHandlers{
+		createEndpointUC: createEndpointUC,
+		listEndpointsUC:  listEndpointsUC,
+		getEndpointUC:    getEndpointUC,
+		updateEndpointUC: updateEndpointUC,
+		deleteEndpointUC: deleteEndpointUC,
+		rotateSecretUC:   rotateSecretUC,
+		sendTestEventUC:  sendTestEventUC,
+		listDeliveriesUC: listDeliveriesUC,
+		getDeliveryUC:    getDeliveryUC,
+		redeliverUC:      redeliverUC,
+		eventTypes:       eventTypes,
+	}
+}
+
+// ListEventTypes returns the event types endpoints may subscribe to
+// @Summary List webhook event types
+// @Description Get the event types endpoints may subscribe to. "*" subscribes to every type, including types added later.
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.Response{data=[]string}
+// @Router /webhooks/event-types [get]
+func (h *WebhookHandlers) ListEventTypes(c *gin.Context) {
+	response.OK(c, "Webhook event types retrieved successfully", h.eventTypes)
+}
+
+// CreateEndpoint registers a webhook endpoint
+// @Summary Create webhook endpoint
+// @Description Register a URL that receives the given event types as signed JSON POST requests. The response contains the signing secret, which is not shown again.
+// @Tags webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateEndpointRequest true "Endpoint"
+// @Success 201 {object} response.Response{data=dto.EndpointResponse}
+// @Failure 400 {object} response.Response
+// @Router /webhooks [post]
+func (h *WebhookHandlers) CreateEndpoint(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+
+	var req dto.CreateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	endpoint, err := h.createEndpointUC.Execute(c.Request.Context(), usecases.CreateEndpointInput{
+		URL:         req.URL,
+		Description: req.Description,
+		EventTypes:  req.EventTypes,
+		CreatedBy:   userID.(string),
+	})
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	response.Created(c, "Webhook endpoint created successfully", mapEndpointToResponse(endpoint, true))
+}
+
+// ListEndpoints returns every webhook endpoint
+// @Summary List webhook endpoints
+// @Description Get every registered webhook endpoint, newest first
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.Response{data=[]dto.EndpointResponse}
+// @Router /webhooks [get]
+func (h *WebhookHandlers) ListEndpoints(c *gin.Context) {
+	endpoints, err := h.listEndpointsUC.Execute(c.Request.Context())
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	endpointResponses := make([]dto.EndpointResponse, len(endpoints))
+	for i, endpoint := range endpoints {
+		endpointResponses[i] = mapEndpointToResponse(endpoint, false)
+	}
+
+	response.OK(c, "Webhook endpoints retrieved successfully", endpointResponses)
+}
+
+// GetEndpoint returns a webhook endpoint
+// @Summary Get webhook endpoint
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Success 200 {object} response.Response{data=dto.EndpointResponse}
+// @Failure 404 {object} response.Response
+// @Router /webhooks/{id} [get]
+func (h *WebhookHandlers) GetEndpoint(c *gin.Context) {
+	endpoint, err := h.getEndpointUC.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	response.OK(c, "Webhook endpoint retrieved successfully", mapEndpointToResponse(endpoint, false))
+}
+
+// UpdateEndpoint changes a webhook endpoint
+// @Summary Update webhook endpoint
+// @Description Change the URL, description or event types of an endpoint, or deactivate it. Deliveries to an inactive endpoint wait until it is activated again.
+// @Tags webhooks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Param request body dto.UpdateEndpointRequest true "Changes"
+// @Success 200 {object} response.Response{data=dto.EndpointResponse}
+// @Failure 400 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /webhooks/{id} [patch]
+func (h *WebhookHandlers) UpdateEndpoint(c *gin.Context) {
+	var req dto.UpdateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	endpoint, err := h.updateEndpointUC.Execute(c.Request.Context(), c.Param("id"), usecases.UpdateEndpointInput{
+		URL:         req.URL,
+		Description: req.Description,
+		EventTypes:  req.EventTypes,
+		Active:      req.Active,
+	})
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	response.OK(c, "Webhook endpoint updated successfully", mapEndpointToResponse(endpoint, false))
+}
+
+// DeleteEndpoint removes a webhook endpoint
+// @Summary Delete webhook endpoint
+// @Description Remove an endpoint together with its delivery log
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Success 200 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandlers) DeleteEndpoint(c *gin.Context) {
+	if err := h.deleteEndpointUC.Execute(c.Request.Context(), c.Param("id")); err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	response.OK(c, "Webhook endpoint deleted successfully", nil)
+}
+
+// RotateSecret replaces the signing secret of a webhook endpoint
+// @Summary Rotate webhook secret
+// @Description Replace the signing secret of an endpoint. The response contains the new secret; pending retries are signed with it.
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Success 200 {object} response.Response{data=dto.EndpointResponse}
+// @Failure 404 {object} response.Response
+// @Router /webhooks/{id}/rotate-secret [post]
+func (h *WebhookHandlers) RotateSecret(c *gin.Context) {
+	endpoint, err := h.rotateSecretUC.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	response.OK(c, "Webhook secret rotated successfully", mapEndpointToResponse(endpoint, true))
+}
+
+// SendTestEvent posts a test event to a webhook endpoint
+// @Summary Send test event
+// @Description Post a webhook.test event to the endpoint right away, even if it is inactive, and return the outcome of the attempt. A failed test is retried like any other delivery.
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Success 200 {object} response.Response{data=dto.TestEventResponse}
+// @Failure 404 {object} response.Response
+// @Router /webhooks/{id}/test [post]
+func (h *WebhookHandlers) SendTestEvent(c *gin.Context) {
+	output, err := h.sendTestEventUC.Execute(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	message := "Test event delivered successfully"
+	if !output.Attempt.Succeeded() {
+		message = "Test event delivery failed"
+	}
+
+	response.OK(c, message, dto.TestEventResponse{
+		Delivery: mapDeliveryToResponse(output.Delivery, false),
+		Attempt:  mapAttemptToResponse(output.Attempt),
+	})
+}
+
+// ListDeliveries returns the delivery log of a webhook endpoint
+// @Summary List webhook deliveries
+// @Description Get the deliveries of an endpoint, newest first, optionally filtered by status
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Param status query string false "pending, succeeded or dead"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Success 200 {object} response.PaginatedResponse{data=[]dto.DeliveryResponse}
+// @Failure 404 {object} response.Response
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandlers) ListDeliveries(c *gin.Context) {
+	status := c.Query("status")
+	switch status {
+	case "", entities.DeliveryPending, entities.DeliverySucceeded, entities.DeliveryDead:
+	default:
+		response.BadRequest(c, "status must be pending, succeeded or dead")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	output, err := h.listDeliveriesUC.Execute(c.Request.Context(), usecases.ListDeliveriesInput{
+		EndpointID: c.Param("id"),
+		Status:     status,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	deliveryResponses := make([]dto.DeliveryResponse, len(output.Deliveries))
+	for i, delivery := range output.Deliveries {
+		deliveryResponses[i] = mapDeliveryToResponse(delivery, false)
+	}
+
+	response.Paginated(c, deliveryResponses, output.Page, output.PageSize, int64(output.Total))
+}
+
+// GetDelivery returns a webhook delivery with its attempts
+// @Summary Get webhook delivery
+// @Description Get a delivery with its payload and every attempt to post it, including status codes, errors, response bodies and durations
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} response.Response{data=dto.DeliveryDetailResponse}
+// @Failure 404 {object} response.Response
+// @Router /webhooks/{id}/deliveries/{deliveryId} [get]
+func (h *WebhookHandlers) GetDelivery(c *gin.Context) {
+	output, err := h.getDeliveryUC.Execute(c.Request.Context(), c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	attempts := make([]dto.AttemptResponse, len(output.Attempts))
+	for i, attempt := range output.Attempts {
+		attempts[i] = mapAttemptToResponse(attempt)
+	}
+
+	response.OK(c, "Webhook delivery retrieved successfully", dto.DeliveryDetailResponse{
+		DeliveryResponse: mapDeliveryToResponse(output.Delivery, true),
+		AttemptLog:       attempts,
+	})
+}
+
+// Redeliver queues a dead webhook delivery again
+// @Summary Redeliver webhook
+// @Description Queue a dead delivery for immediate delivery with a fresh set of attempts
+// @Tags webhooks
+// @Security BearerAuth
+// @Produce json
+// @Param id path string true "Endpoint ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 200 {object} response.Response{data=dto.DeliveryResponse}
+// @Failure 404 {object} response.Response
+// @Failure 409 {object} response.Response
+// @Router /webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandlers) Redeliver(c *gin.Context) {
+	delivery, err := h.redeliverUC.Execute(c.Request.Context(), c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+
+	response.OK(c, "Webhook delivery queued successfully", mapDeliveryToResponse(delivery, false))
+}
+
+func handleWebhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entities.ErrEndpointNotFound),
+		errors.Is(err, entities.ErrDeliveryNotFound):
+		response.NotFound(c, err.Error())
+	case errors.Is(err, entities.ErrInvalidURL),
+		errors.Is(err, entities.ErrInsecureURL),
+		errors.Is(err, entities.ErrInternalURL),
+		errors.Is(err, entities.ErrInvalidEventTypes):
+		response.BadRequest(c, err.Error())
+	case errors.Is(err, entities.ErrDeliveryNotDead):
+		response.Conflict(c, err.Error())
+	default:
+		response.InternalServerError(c, "Failed to process webhook")
+	}
+}
+
+func mapEndpointToResponse(endpoint *entities.Endpoint, withSecret bool) dto.EndpointResponse {
+	resp := dto.EndpointResponse{
+		ID:          endpoint.ID,
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		EventTypes:  endpoint.EventTypes,
+		Active:      endpoint.Active,
+		CreatedBy:   endpoint.CreatedBy,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}
+	if withSecret {
+		resp.Secret = endpoint.Secret
+	}
+	return resp
+}
+
+func mapDeliveryToResponse(delivery *entities.Delivery, withPayload bool) dto.DeliveryResponse {
+	resp := dto.DeliveryResponse{
+		ID:             delivery.ID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+	if withPayload {
+		resp.Payload = delivery.Payload
+	}
+	return resp
+}
+
+func mapAttemptToResponse(attempt *entities.Attempt) dto.AttemptResponse {
+	return dto.AttemptResponse{
+		StatusCode:  attempt.StatusCode,
+		Error:       attempt.Error,
+		Response:    attempt.Response,
+		DurationMS:  attempt.Duration.Milliseconds(),
+		AttemptedAt: attempt.AttemptedAt,
+	}
+}
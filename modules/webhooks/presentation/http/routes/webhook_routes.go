@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/manab-pr/evtaarpro/internal/middleware"
+	"github.com/manab-pr/evtaarpro/modules/webhooks/presentation/http/handlers"
+)
+
+// RegisterRoutes registers webhook routes
+func RegisterRoutes(rg *gin.RouterGroup, webhookHandlers *handlers.WebhookHandlers, jwtSecret string) {
+	webhooks := rg.Group("/webhooks")
+	webhooks.Use(middleware.AuthMiddleware(jwtSecret), middleware.RequireRole("admin"))
+	{
+		// Endpoint routes
+		webhooks.GET("/event-types", webhookHandlers.ListEventTypes)
+		webhooks.POST("", webhookHandlers.CreateEndpoint)
+		webhooks.GET("", webhookHandlers.ListEndpoints)
+		webhooks.GET("/:id", webhookHandlers.GetEndpoint)
+		webhooks.PATCH("/:id", webhookHandlers.UpdateEndpoint)
+		webhooks.DELETE("/:id", webhookHandlers.DeleteEndpoint)
+		webhooks.POST("/:id/rotate-secret", webhookHandlers.RotateSecret)
+
+		// Delivery routes
+		webhooks.POST("/:id/test", webhookHandlers.SendTestEvent)
+		webhooks.GET("/:id/deliveries", webhookHandlers.ListDeliveries)
+		webhooks.GET("/:id/deliveries/:deliveryId", webhookHandlers.GetDelivery)
+		webhooks.POST("/:id/deliveries/:deliveryId/redeliver", webhookHandlers.Redeliver)
+	}
+}
@@ -2,6 +2,9 @@ package jitsi
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -23,8 +26,9 @@ func NewClient(domain, appID, appSecret string) *Client {
 	}
 }
 
-// CreateRoomToken generates a JWT token for a Jitsi room
-func (c *Client) CreateRoomToken(roomName, userID, userName, userEmail string, moderator bool) (string, error) {
+// CreateRoomToken generates a JWT token for a Jitsi room. Features such as
+// "recording" or "livestreaming" are added to the token context when given.
+func (c *Client) CreateRoomToken(roomName, userID, userName, userEmail string, moderator bool, features map[string]bool) (string, error) {
 	now := time.Now()
 	claims := jwt.MapClaims{
 		"iss":   c.appID,
@@ -43,6 +47,43 @@ func (c *Client) CreateRoomToken(roomName, userID, userName, userEmail string, m
 			},
 		},
 	}
+	if len(features) > 0 {
+		claims["context"].(map[string]interface{})["features"] = features
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(c.appSecret))
+}
+
+// CreateGuestToken generates a restricted JWT token for an unauthenticated
+// guest. The token is never a moderator, expires after ttl and disables
+// recording, live streaming and outbound calls.
+func (c *Client) CreateGuestToken(roomName, guestID, guestName, guestEmail string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":  c.appID,
+		"sub":  c.domain,
+		"aud":  c.appID,
+		"room": roomName,
+		"exp":  now.Add(ttl).Unix(),
+		"nbf":  now.Unix(),
+		"iat":  now.Unix(),
+		"context": map[string]interface{}{
+			"user": map[string]interface{}{
+				"id":          guestID,
+				"name":        guestName,
+				"email":       guestEmail,
+				"moderator":   false,
+				"affiliation": "guest",
+			},
+			"features": map[string]interface{}{
+				"recording":     false,
+				"livestreaming": false,
+				"transcription": false,
+				"outbound-call": false,
+			},
+		},
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(c.appSecret))
@@ -53,6 +94,29 @@ func (c *Client) GetRoomURL(roomName string) string {
 	return fmt.Sprintf("https://%s/%s", c.domain, roomName)
 }
 
+// GetRoomURLWithConfig returns the URL for a Jitsi room with config
+// overrides in the URL fragment, e.g. #config.startWithAudioMuted=true.
+// Jitsi reads the values as JSON, so only booleans and numbers are supported.
+func (c *Client) GetRoomURLWithConfig(roomName string, config map[string]interface{}) string {
+	roomURL := c.GetRoomURL(roomName)
+	if len(config) == 0 {
+		return roomURL
+	}
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, len(keys))
+	for i, key := range keys {
+		params[i] = "config." + key + "=" + url.QueryEscape(fmt.Sprint(config[key]))
+	}
+
+	return roomURL + "#" + strings.Join(params, "&")
+}
+
 // ValidateRoomName checks if a room name is valid
 func (c *Client) ValidateRoomName(roomName string) bool {
 	return len(roomName) > 0 && len(roomName) <= 100
@@ -0,0 +1,71 @@
+package jitsi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestGetRoomURLWithConfig(t *testing.T) {
+	client := NewClient("meet.example.com", "app", "secret")
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		want   string
+	}{
+		{"no config", nil, "https://meet.example.com/room-1"},
+		{
+			"sorted keys",
+			map[string]interface{}{"startWithAudioMuted": true, "fileRecordingsEnabled": false, "channelLastN": 20},
+			"https://meet.example.com/room-1#config.channelLastN=20&config.fileRecordingsEnabled=false&config.startWithAudioMuted=true",
+		},
+		{"escaped value", map[string]interface{}{"subject": "a&b"}, "https://meet.example.com/room-1#config.subject=a%26b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.GetRoomURLWithConfig("room-1", tt.config); got != tt.want {
+				t.Fatalf("GetRoomURLWithConfig = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateRoomTokenFeatures(t *testing.T) {
+	client := NewClient("meet.example.com", "app", "secret")
+
+	tests := []struct {
+		name     string
+		features map[string]bool
+		want     interface{}
+	}{
+		{"no features", nil, nil},
+		{"recording", map[string]bool{"recording": true, "livestreaming": false}, map[string]interface{}{"recording": true, "livestreaming": false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signed, err := client.CreateRoomToken("room-1", "user-1", "Alice", "alice@example.com", true, tt.features)
+			if err != nil {
+				t.Fatalf("CreateRoomToken: %v", err)
+			}
+
+			claims := jwt.MapClaims{}
+			if _, err := jwt.ParseWithClaims(signed, claims, func(*jwt.Token) (interface{}, error) {
+				return []byte("secret"), nil
+			}); err != nil {
+				t.Fatalf("ParseWithClaims: %v", err)
+			}
+
+			tokenContext := claims["context"].(map[string]interface{})
+			if got := tokenContext["features"]; !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("features = %v, want %v", got, tt.want)
+			}
+			if user := tokenContext["user"].(map[string]interface{}); claims["room"] != "room-1" || user["moderator"] != true {
+				t.Fatalf("claims = %v", claims)
+			}
+		})
+	}
+}